@@ -14,7 +14,11 @@ type AccountClient struct {
 }
 
 func NewAccountClient(url string) (*AccountClient, error) {
-	connection, err := grpc.Dial(url, grpc.WithInsecure())
+	connection, err := grpc.Dial(
+		url,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(util.AccessTokenClientInterceptor()),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -122,3 +126,57 @@ func (client *AccountClient) RefreshToken(ctx context.Context, refreshToken, dev
 	}
 	return response, nil
 }
+
+// SetTaxExempt needs an admin access token in ctx.
+func (client *AccountClient) SetTaxExempt(ctx context.Context, accountID string, taxExempt bool) (*pb.SetTaxExemptResponse, error) {
+	response, err := client.client.SetTaxExempt(ctx, &pb.SetTaxExemptRequest{
+		AccountId: accountID,
+		TaxExempt: taxExempt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CreateOrUpdateAddress needs the account's own or an admin access token in
+// ctx, as do the other address book calls.
+func (client *AccountClient) CreateOrUpdateAddress(ctx context.Context, address *pb.Address) (*pb.CreateOrUpdateAddressResponse, error) {
+	response, err := client.client.CreateOrUpdateAddress(ctx, &pb.CreateOrUpdateAddressRequest{
+		Address: address,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (client *AccountClient) GetAddress(ctx context.Context, id string) (*pb.GetAddressResponse, error) {
+	response, err := client.client.GetAddress(ctx, &pb.GetAddressRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (client *AccountClient) ListAddresses(ctx context.Context, accountID string) (*pb.ListAddressesResponse, error) {
+	response, err := client.client.ListAddresses(ctx, &pb.ListAddressesRequest{
+		AccountId: accountID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (client *AccountClient) DeleteAddress(ctx context.Context, id string) (*pb.DeleteAddressResponse, error) {
+	response, err := client.client.DeleteAddress(ctx, &pb.DeleteAddressRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -51,7 +51,7 @@ func main() {
 	// Start gRPC server (blocks)
 	logger.Service().Info().Int("port", config.Port).Msg("starting account service")
 
-	if err := account.ListenGrpcServer(service, logger, config.Port); err != nil {
+	if err := account.ListenGrpcServer(service, config.JwtSecret, logger, config.Port); err != nil {
 		logger.Service().Fatal().Err(err).Msg("failed to start gRPC server")
 	}
 }
@@ -0,0 +1,3 @@
+-- Adds tax exemption to an existing account database. Existing accounts are
+-- not exempt. Safe to run more than once.
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS tax_exempt BOOLEAN NOT NULL DEFAULT FALSE;
@@ -10,6 +10,29 @@ type Account struct {
 	UserType string `json:"user_type" validate:"required,oneof=super_admin admin merchant customer"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"-" validate:"required,min=8,max=50"`
+	// TaxExempt accounts are not charged tax on their orders. Only admins
+	// change it, with SetTaxExempt.
+	TaxExempt bool `json:"tax_exempt"`
+}
+
+// Address is an entry in an account's address book. An account has at most
+// one default shipping and one default billing address.
+type Address struct {
+	ID        string `json:"id"`
+	AccountID string `json:"account_id"`
+	// Name is who the address is for.
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+	// Country is an ISO 3166-1 alpha-2 code such as "DE" or "US".
+	Country         string    `json:"country"`
+	Phone           string    `json:"phone"`
+	DefaultShipping bool      `json:"default_shipping"`
+	DefaultBilling  bool      `json:"default_billing"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 type AuthenticatedResponse struct {
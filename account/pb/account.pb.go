@@ -11,6 +11,7 @@ package __
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,12 +25,14 @@ const (
 )
 
 type Account struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Usertype      string                 `protobuf:"bytes,3,opt,name=usertype,proto3" json:"usertype,omitempty"`
-	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
-	Password      string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Usertype string                 `protobuf:"bytes,3,opt,name=usertype,proto3" json:"usertype,omitempty"`
+	Email    string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Password string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	// tax_exempt accounts are not charged tax on their orders.
+	TaxExempt     bool `protobuf:"varint,6,opt,name=tax_exempt,json=taxExempt,proto3" json:"tax_exempt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -99,6 +102,13 @@ func (x *Account) GetPassword() string {
 	return ""
 }
 
+func (x *Account) GetTaxExempt() bool {
+	if x != nil {
+		return x.TaxExempt
+	}
+	return false
+}
+
 type CreateOrUpdateAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -911,17 +921,613 @@ func (x *RefreshTokenResponse) GetRefreshToken() string {
 	return ""
 }
 
+// SetTaxExemptRequest is only accepted from admins.
+type SetTaxExemptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TaxExempt     bool                   `protobuf:"varint,2,opt,name=tax_exempt,json=taxExempt,proto3" json:"tax_exempt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTaxExemptRequest) Reset() {
+	*x = SetTaxExemptRequest{}
+	mi := &file_account_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTaxExemptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTaxExemptRequest) ProtoMessage() {}
+
+func (x *SetTaxExemptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTaxExemptRequest.ProtoReflect.Descriptor instead.
+func (*SetTaxExemptRequest) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetTaxExemptRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SetTaxExemptRequest) GetTaxExempt() bool {
+	if x != nil {
+		return x.TaxExempt
+	}
+	return false
+}
+
+type SetTaxExemptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTaxExemptResponse) Reset() {
+	*x = SetTaxExemptResponse{}
+	mi := &file_account_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTaxExemptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTaxExemptResponse) ProtoMessage() {}
+
+func (x *SetTaxExemptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTaxExemptResponse.ProtoReflect.Descriptor instead.
+func (*SetTaxExemptResponse) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SetTaxExemptResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+// Address is an entry in an account's address book. country is an ISO 3166-1
+// alpha-2 code. An account has at most one default shipping and one default
+// billing address.
+type Address struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId       string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Line1           string                 `protobuf:"bytes,4,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2           string                 `protobuf:"bytes,5,opt,name=line2,proto3" json:"line2,omitempty"`
+	City            string                 `protobuf:"bytes,6,opt,name=city,proto3" json:"city,omitempty"`
+	Region          string                 `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode      string                 `protobuf:"bytes,8,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country         string                 `protobuf:"bytes,9,opt,name=country,proto3" json:"country,omitempty"`
+	Phone           string                 `protobuf:"bytes,10,opt,name=phone,proto3" json:"phone,omitempty"`
+	DefaultShipping bool                   `protobuf:"varint,11,opt,name=default_shipping,json=defaultShipping,proto3" json:"default_shipping,omitempty"`
+	DefaultBilling  bool                   `protobuf:"varint,12,opt,name=default_billing,json=defaultBilling,proto3" json:"default_billing,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	mi := &file_account_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Address) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Address) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Address) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Address) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *Address) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Address) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *Address) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Address) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Address) GetDefaultShipping() bool {
+	if x != nil {
+		return x.DefaultShipping
+	}
+	return false
+}
+
+func (x *Address) GetDefaultBilling() bool {
+	if x != nil {
+		return x.DefaultBilling
+	}
+	return false
+}
+
+func (x *Address) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Address book requests are only accepted from the account itself and admins.
+type CreateOrUpdateAddressRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// address.id is empty for new addresses; created_at is ignored.
+	Address       *Address `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrUpdateAddressRequest) Reset() {
+	*x = CreateOrUpdateAddressRequest{}
+	mi := &file_account_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrUpdateAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrUpdateAddressRequest) ProtoMessage() {}
+
+func (x *CreateOrUpdateAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrUpdateAddressRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrUpdateAddressRequest) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateOrUpdateAddressRequest) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type CreateOrUpdateAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrUpdateAddressResponse) Reset() {
+	*x = CreateOrUpdateAddressResponse{}
+	mi := &file_account_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrUpdateAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrUpdateAddressResponse) ProtoMessage() {}
+
+func (x *CreateOrUpdateAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrUpdateAddressResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrUpdateAddressResponse) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateOrUpdateAddressResponse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type GetAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressRequest) Reset() {
+	*x = GetAddressRequest{}
+	mi := &file_account_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressRequest) ProtoMessage() {}
+
+func (x *GetAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressRequest.ProtoReflect.Descriptor instead.
+func (*GetAddressRequest) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetAddressRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *Address               `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAddressResponse) Reset() {
+	*x = GetAddressResponse{}
+	mi := &file_account_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAddressResponse) ProtoMessage() {}
+
+func (x *GetAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAddressResponse.ProtoReflect.Descriptor instead.
+func (*GetAddressResponse) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetAddressResponse) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type ListAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressesRequest) Reset() {
+	*x = ListAddressesRequest{}
+	mi := &file_account_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressesRequest) ProtoMessage() {}
+
+func (x *ListAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ListAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListAddressesRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ListAddressesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addresses     []*Address             `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAddressesResponse) Reset() {
+	*x = ListAddressesResponse{}
+	mi := &file_account_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAddressesResponse) ProtoMessage() {}
+
+func (x *ListAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAddressesResponse.ProtoReflect.Descriptor instead.
+func (*ListAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListAddressesResponse) GetAddresses() []*Address {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type DeleteAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAddressRequest) Reset() {
+	*x = DeleteAddressRequest{}
+	mi := &file_account_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAddressRequest) ProtoMessage() {}
+
+func (x *DeleteAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAddressRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAddressRequest) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteAddressRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAddressResponse) Reset() {
+	*x = DeleteAddressResponse{}
+	mi := &file_account_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAddressResponse) ProtoMessage() {}
+
+func (x *DeleteAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAddressResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAddressResponse) Descriptor() ([]byte, []int) {
+	return file_account_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteAddressResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 var File_account_proto protoreflect.FileDescriptor
 
 const file_account_proto_rawDesc = "" +
 	"\n" +
-	"\raccount.proto\x12\x02pb\"{\n" +
+	"\raccount.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9a\x01\n" +
 	"\aAccount\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
 	"\busertype\x18\x03 \x01(\tR\busertype\x12\x14\n" +
 	"\x05email\x18\x04 \x01(\tR\x05email\x12\x1a\n" +
-	"\bpassword\x18\x05 \x01(\tR\bpassword\"\x90\x01\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12\x1d\n" +
+	"\n" +
+	"tax_exempt\x18\x06 \x01(\bR\ttaxExempt\"\x90\x01\n" +
 	"\x1cCreateOrUpdateAccountRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
@@ -975,7 +1581,49 @@ const file_account_proto_rawDesc = "" +
 	"\x14RefreshTokenResponse\x12%\n" +
 	"\aaccount\x18\x01 \x01(\v2\v.pb.AccountR\aaccount\x12!\n" +
 	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x12#\n" +
-	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken2\xeb\x03\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshToken\"S\n" +
+	"\x13SetTaxExemptRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1d\n" +
+	"\n" +
+	"tax_exempt\x18\x02 \x01(\bR\ttaxExempt\"=\n" +
+	"\x14SetTaxExemptResponse\x12%\n" +
+	"\aaccount\x18\x01 \x01(\v2\v.pb.AccountR\aaccount\"\x84\x03\n" +
+	"\aAddress\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x14\n" +
+	"\x05line1\x18\x04 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x05 \x01(\tR\x05line2\x12\x12\n" +
+	"\x04city\x18\x06 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\a \x01(\tR\x06region\x12\x1f\n" +
+	"\vpostal_code\x18\b \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\t \x01(\tR\acountry\x12\x14\n" +
+	"\x05phone\x18\n" +
+	" \x01(\tR\x05phone\x12)\n" +
+	"\x10default_shipping\x18\v \x01(\bR\x0fdefaultShipping\x12'\n" +
+	"\x0fdefault_billing\x18\f \x01(\bR\x0edefaultBilling\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"E\n" +
+	"\x1cCreateOrUpdateAddressRequest\x12%\n" +
+	"\aaddress\x18\x01 \x01(\v2\v.pb.AddressR\aaddress\"F\n" +
+	"\x1dCreateOrUpdateAddressResponse\x12%\n" +
+	"\aaddress\x18\x01 \x01(\v2\v.pb.AddressR\aaddress\"#\n" +
+	"\x11GetAddressRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\";\n" +
+	"\x12GetAddressResponse\x12%\n" +
+	"\aaddress\x18\x01 \x01(\v2\v.pb.AddressR\aaddress\"5\n" +
+	"\x14ListAddressesRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"B\n" +
+	"\x15ListAddressesResponse\x12)\n" +
+	"\taddresses\x18\x01 \x03(\v2\v.pb.AddressR\taddresses\"&\n" +
+	"\x14DeleteAddressRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"1\n" +
+	"\x15DeleteAddressResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xd5\x06\n" +
 	"\x0eAccountService\x12\\\n" +
 	"\x15CreateOrUpdateAccount\x12 .pb.CreateOrUpdateAccountRequest\x1a!.pb.CreateOrUpdateAccountResponse\x12G\n" +
 	"\x0eGetAccountByID\x12\x19.pb.GetAccountByIDRequest\x1a\x1a.pb.GetAccountByIDResponse\x12A\n" +
@@ -983,7 +1631,13 @@ const file_account_proto_rawDesc = "" +
 	"\x10CheckEmailExists\x12\x1b.pb.CheckEmailExistsRequest\x1a\x1c.pb.CheckEmailExistsResponse\x12,\n" +
 	"\x05Login\x12\x10.pb.LoginRequest\x1a\x11.pb.LoginResponse\x12/\n" +
 	"\x06Logout\x12\x11.pb.LogoutRequest\x1a\x12.pb.LogoutResponse\x12A\n" +
-	"\fRefreshToken\x12\x17.pb.RefreshTokenRequest\x1a\x18.pb.RefreshTokenResponseB\x04Z\x02./b\x06proto3"
+	"\fRefreshToken\x12\x17.pb.RefreshTokenRequest\x1a\x18.pb.RefreshTokenResponse\x12A\n" +
+	"\fSetTaxExempt\x12\x17.pb.SetTaxExemptRequest\x1a\x18.pb.SetTaxExemptResponse\x12\\\n" +
+	"\x15CreateOrUpdateAddress\x12 .pb.CreateOrUpdateAddressRequest\x1a!.pb.CreateOrUpdateAddressResponse\x12;\n" +
+	"\n" +
+	"GetAddress\x12\x15.pb.GetAddressRequest\x1a\x16.pb.GetAddressResponse\x12D\n" +
+	"\rListAddresses\x12\x18.pb.ListAddressesRequest\x1a\x19.pb.ListAddressesResponse\x12D\n" +
+	"\rDeleteAddress\x12\x18.pb.DeleteAddressRequest\x1a\x19.pb.DeleteAddressResponseB\x04Z\x02./b\x06proto3"
 
 var (
 	file_account_proto_rawDescOnce sync.Once
@@ -997,7 +1651,7 @@ func file_account_proto_rawDescGZIP() []byte {
 	return file_account_proto_rawDescData
 }
 
-var file_account_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_account_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_account_proto_goTypes = []any{
 	(*Account)(nil),                       // 0: pb.Account
 	(*CreateOrUpdateAccountRequest)(nil),  // 1: pb.CreateOrUpdateAccountRequest
@@ -1015,6 +1669,18 @@ var file_account_proto_goTypes = []any{
 	(*LogoutResponse)(nil),                // 13: pb.LogoutResponse
 	(*RefreshTokenRequest)(nil),           // 14: pb.RefreshTokenRequest
 	(*RefreshTokenResponse)(nil),          // 15: pb.RefreshTokenResponse
+	(*SetTaxExemptRequest)(nil),           // 16: pb.SetTaxExemptRequest
+	(*SetTaxExemptResponse)(nil),          // 17: pb.SetTaxExemptResponse
+	(*Address)(nil),                       // 18: pb.Address
+	(*CreateOrUpdateAddressRequest)(nil),  // 19: pb.CreateOrUpdateAddressRequest
+	(*CreateOrUpdateAddressResponse)(nil), // 20: pb.CreateOrUpdateAddressResponse
+	(*GetAddressRequest)(nil),             // 21: pb.GetAddressRequest
+	(*GetAddressResponse)(nil),            // 22: pb.GetAddressResponse
+	(*ListAddressesRequest)(nil),          // 23: pb.ListAddressesRequest
+	(*ListAddressesResponse)(nil),         // 24: pb.ListAddressesResponse
+	(*DeleteAddressRequest)(nil),          // 25: pb.DeleteAddressRequest
+	(*DeleteAddressResponse)(nil),         // 26: pb.DeleteAddressResponse
+	(*timestamppb.Timestamp)(nil),         // 27: google.protobuf.Timestamp
 }
 var file_account_proto_depIdxs = []int32{
 	0,  // 0: pb.CreateOrUpdateAccountResponse.account:type_name -> pb.Account
@@ -1023,25 +1689,41 @@ var file_account_proto_depIdxs = []int32{
 	9,  // 3: pb.LoginRequest.device_info:type_name -> pb.DeviceInfo
 	0,  // 4: pb.LoginResponse.account:type_name -> pb.Account
 	0,  // 5: pb.RefreshTokenResponse.account:type_name -> pb.Account
-	1,  // 6: pb.AccountService.CreateOrUpdateAccount:input_type -> pb.CreateOrUpdateAccountRequest
-	3,  // 7: pb.AccountService.GetAccountByID:input_type -> pb.GetAccountByIDRequest
-	5,  // 8: pb.AccountService.ListAccounts:input_type -> pb.ListAccountsRequest
-	7,  // 9: pb.AccountService.CheckEmailExists:input_type -> pb.CheckEmailExistsRequest
-	10, // 10: pb.AccountService.Login:input_type -> pb.LoginRequest
-	12, // 11: pb.AccountService.Logout:input_type -> pb.LogoutRequest
-	14, // 12: pb.AccountService.RefreshToken:input_type -> pb.RefreshTokenRequest
-	2,  // 13: pb.AccountService.CreateOrUpdateAccount:output_type -> pb.CreateOrUpdateAccountResponse
-	4,  // 14: pb.AccountService.GetAccountByID:output_type -> pb.GetAccountByIDResponse
-	6,  // 15: pb.AccountService.ListAccounts:output_type -> pb.ListAccountsResponse
-	8,  // 16: pb.AccountService.CheckEmailExists:output_type -> pb.CheckEmailExistsResponse
-	11, // 17: pb.AccountService.Login:output_type -> pb.LoginResponse
-	13, // 18: pb.AccountService.Logout:output_type -> pb.LogoutResponse
-	15, // 19: pb.AccountService.RefreshToken:output_type -> pb.RefreshTokenResponse
-	13, // [13:20] is the sub-list for method output_type
-	6,  // [6:13] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	0,  // 6: pb.SetTaxExemptResponse.account:type_name -> pb.Account
+	27, // 7: pb.Address.created_at:type_name -> google.protobuf.Timestamp
+	18, // 8: pb.CreateOrUpdateAddressRequest.address:type_name -> pb.Address
+	18, // 9: pb.CreateOrUpdateAddressResponse.address:type_name -> pb.Address
+	18, // 10: pb.GetAddressResponse.address:type_name -> pb.Address
+	18, // 11: pb.ListAddressesResponse.addresses:type_name -> pb.Address
+	1,  // 12: pb.AccountService.CreateOrUpdateAccount:input_type -> pb.CreateOrUpdateAccountRequest
+	3,  // 13: pb.AccountService.GetAccountByID:input_type -> pb.GetAccountByIDRequest
+	5,  // 14: pb.AccountService.ListAccounts:input_type -> pb.ListAccountsRequest
+	7,  // 15: pb.AccountService.CheckEmailExists:input_type -> pb.CheckEmailExistsRequest
+	10, // 16: pb.AccountService.Login:input_type -> pb.LoginRequest
+	12, // 17: pb.AccountService.Logout:input_type -> pb.LogoutRequest
+	14, // 18: pb.AccountService.RefreshToken:input_type -> pb.RefreshTokenRequest
+	16, // 19: pb.AccountService.SetTaxExempt:input_type -> pb.SetTaxExemptRequest
+	19, // 20: pb.AccountService.CreateOrUpdateAddress:input_type -> pb.CreateOrUpdateAddressRequest
+	21, // 21: pb.AccountService.GetAddress:input_type -> pb.GetAddressRequest
+	23, // 22: pb.AccountService.ListAddresses:input_type -> pb.ListAddressesRequest
+	25, // 23: pb.AccountService.DeleteAddress:input_type -> pb.DeleteAddressRequest
+	2,  // 24: pb.AccountService.CreateOrUpdateAccount:output_type -> pb.CreateOrUpdateAccountResponse
+	4,  // 25: pb.AccountService.GetAccountByID:output_type -> pb.GetAccountByIDResponse
+	6,  // 26: pb.AccountService.ListAccounts:output_type -> pb.ListAccountsResponse
+	8,  // 27: pb.AccountService.CheckEmailExists:output_type -> pb.CheckEmailExistsResponse
+	11, // 28: pb.AccountService.Login:output_type -> pb.LoginResponse
+	13, // 29: pb.AccountService.Logout:output_type -> pb.LogoutResponse
+	15, // 30: pb.AccountService.RefreshToken:output_type -> pb.RefreshTokenResponse
+	17, // 31: pb.AccountService.SetTaxExempt:output_type -> pb.SetTaxExemptResponse
+	20, // 32: pb.AccountService.CreateOrUpdateAddress:output_type -> pb.CreateOrUpdateAddressResponse
+	22, // 33: pb.AccountService.GetAddress:output_type -> pb.GetAddressResponse
+	24, // 34: pb.AccountService.ListAddresses:output_type -> pb.ListAddressesResponse
+	26, // 35: pb.AccountService.DeleteAddress:output_type -> pb.DeleteAddressResponse
+	24, // [24:36] is the sub-list for method output_type
+	12, // [12:24] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_account_proto_init() }
@@ -1055,7 +1737,7 @@ func file_account_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_account_proto_rawDesc), len(file_account_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
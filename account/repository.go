@@ -16,6 +16,16 @@ type Repository interface {
 	ListAccounts(ctx context.Context, skip uint, take uint) ([]*Account, error)
 	CheckEmailExists(ctx context.Context, email string) (bool, error)
 	GetAccountByEmail(ctx context.Context, email string) (*Account, error)
+	SetTaxExempt(ctx context.Context, id string, taxExempt bool) (*Account, error)
+
+	// Address Book
+	// CreateOrUpdateAddress stores address. If it is a default address, the
+	// account's other address of that kind stops being the default.
+	CreateOrUpdateAddress(ctx context.Context, address *Address) (*Address, error)
+	GetAddress(ctx context.Context, id string) (*Address, error)
+	// ListAddresses returns the addresses of an account, oldest first.
+	ListAddresses(ctx context.Context, accountID string) ([]*Address, error)
+	DeleteAddress(ctx context.Context, id string) error
 
 	// Session Management
 	CreateOrUpdateSession(ctx context.Context, session *Session) error
@@ -54,15 +64,15 @@ func (repository *PostgresRepository) Close() {
 
 func (repository *PostgresRepository) CreateOrUpdateAccount(ctx context.Context, account *Account) (*Account, error) {
 	start := time.Now()
-	query := "INSERT INTO accounts (id, name, user_type, email, password) VALUES ($1, NULLIF($2, ''), $3, $4, $5) ON CONFLICT (id) DO UPDATE SET name = NULLIF($2, ''), user_type = $3, email = $4, password = $5"
+	query := "INSERT INTO accounts (id, name, user_type, email, password) VALUES ($1, NULLIF($2, ''), $3, $4, $5) ON CONFLICT (id) DO UPDATE SET name = NULLIF($2, ''), user_type = $3, email = $4, password = $5 RETURNING tax_exempt"
 
-	_, err := repository.db.ExecContext(ctx, query, account.ID, account.Name, account.UserType, account.Email, account.Password)
+	err := repository.db.QueryRowContext(ctx, query, account.ID, account.Name, account.UserType, account.Email, account.Password).Scan(&account.TaxExempt)
 
 	repository.logger.Database().Debug().
 		Str("query", query).
 		Str("duration", time.Since(start).String()).
 		Bool("success", err == nil).
-		Msg("Execute Query")
+		Msg("Query Row")
 
 	if err != nil {
 		return nil, err
@@ -72,12 +82,12 @@ func (repository *PostgresRepository) CreateOrUpdateAccount(ctx context.Context,
 
 func (repository *PostgresRepository) GetAccountById(ctx context.Context, id string) (*Account, error) {
 	start := time.Now()
-	query := "SELECT id, name, user_type, email FROM accounts WHERE id = $1"
+	query := "SELECT id, name, user_type, email, tax_exempt FROM accounts WHERE id = $1"
 
 	row := repository.db.QueryRowContext(ctx, query, id)
 	account := &Account{}
 	var name sql.NullString
-	err := row.Scan(&account.ID, &name, &account.UserType, &account.Email)
+	err := row.Scan(&account.ID, &name, &account.UserType, &account.Email, &account.TaxExempt)
 
 	repository.logger.Database().Debug().
 		Str("query", query).
@@ -94,7 +104,7 @@ func (repository *PostgresRepository) GetAccountById(ctx context.Context, id str
 
 func (repository *PostgresRepository) ListAccounts(ctx context.Context, skip uint, take uint) ([]*Account, error) {
 	start := time.Now()
-	query := "SELECT id, name, user_type, email FROM accounts ORDER by id DESC LIMIT $1 OFFSET $2"
+	query := "SELECT id, name, user_type, email, tax_exempt FROM accounts ORDER by id DESC LIMIT $1 OFFSET $2"
 
 	rows, err := repository.db.QueryContext(ctx, query, take, skip)
 
@@ -113,7 +123,7 @@ func (repository *PostgresRepository) ListAccounts(ctx context.Context, skip uin
 	for rows.Next() {
 		account := &Account{}
 		var name sql.NullString
-		if err := rows.Scan(&account.ID, &name, &account.UserType, &account.Email); err != nil {
+		if err := rows.Scan(&account.ID, &name, &account.UserType, &account.Email, &account.TaxExempt); err != nil {
 			return nil, err
 		}
 		account.Name = name.String
@@ -147,12 +157,12 @@ func (repository *PostgresRepository) CheckEmailExists(ctx context.Context, emai
 
 func (repository *PostgresRepository) GetAccountByEmail(ctx context.Context, email string) (*Account, error) {
 	start := time.Now()
-	query := "SELECT id, name, user_type, email, password FROM accounts WHERE email = $1"
+	query := "SELECT id, name, user_type, email, password, tax_exempt FROM accounts WHERE email = $1"
 
 	row := repository.db.QueryRowContext(ctx, query, email)
 	account := &Account{}
 	var name sql.NullString
-	err := row.Scan(&account.ID, &name, &account.UserType, &account.Email, &account.Password)
+	err := row.Scan(&account.ID, &name, &account.UserType, &account.Email, &account.Password, &account.TaxExempt)
 
 	repository.logger.Database().Debug().
 		Str("query", query).
@@ -167,6 +177,174 @@ func (repository *PostgresRepository) GetAccountByEmail(ctx context.Context, ema
 	return account, nil
 }
 
+func (repository *PostgresRepository) SetTaxExempt(ctx context.Context, id string, taxExempt bool) (*Account, error) {
+	start := time.Now()
+	query := "UPDATE accounts SET tax_exempt = $2 WHERE id = $1 RETURNING id, name, user_type, email, tax_exempt"
+
+	row := repository.db.QueryRowContext(ctx, query, id, taxExempt)
+	account := &Account{}
+	var name sql.NullString
+	err := row.Scan(&account.ID, &name, &account.UserType, &account.Email, &account.TaxExempt)
+
+	repository.logger.Database().Debug().
+		Str("query", query).
+		Str("duration", time.Since(start).String()).
+		Bool("success", err == nil).
+		Msg("Query Row")
+
+	if err != nil {
+		return nil, err
+	}
+	account.Name = name.String
+	return account, nil
+}
+
+const addressColumns = "id, account_id, name, line1, line2, city, region, postal_code, country, phone, default_shipping, default_billing, created_at"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAddress(row rowScanner) (*Address, error) {
+	address := &Address{}
+	err := row.Scan(&address.ID, &address.AccountID, &address.Name, &address.Line1, &address.Line2, &address.City, &address.Region, &address.PostalCode, &address.Country, &address.Phone, &address.DefaultShipping, &address.DefaultBilling, &address.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+func (repository *PostgresRepository) CreateOrUpdateAddress(ctx context.Context, address *Address) (result *Address, err error) {
+	start := time.Now()
+	query := `
+		INSERT INTO addresses (` + addressColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (id)
+		DO UPDATE SET
+			name = EXCLUDED.name,
+			line1 = EXCLUDED.line1,
+			line2 = EXCLUDED.line2,
+			city = EXCLUDED.city,
+			region = EXCLUDED.region,
+			postal_code = EXCLUDED.postal_code,
+			country = EXCLUDED.country,
+			phone = EXCLUDED.phone,
+			default_shipping = EXCLUDED.default_shipping,
+			default_billing = EXCLUDED.default_billing
+		RETURNING ` + addressColumns
+
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	// Clear the old defaults first, as only one address of each kind may be
+	// the default.
+	if address.DefaultShipping {
+		if _, err = tx.ExecContext(ctx, "UPDATE addresses SET default_shipping = FALSE WHERE account_id = $1 AND id <> $2 AND default_shipping", address.AccountID, address.ID); err != nil {
+			return nil, err
+		}
+	}
+	if address.DefaultBilling {
+		if _, err = tx.ExecContext(ctx, "UPDATE addresses SET default_billing = FALSE WHERE account_id = $1 AND id <> $2 AND default_billing", address.AccountID, address.ID); err != nil {
+			return nil, err
+		}
+	}
+	result, err = scanAddress(tx.QueryRowContext(ctx, query,
+		address.ID,
+		address.AccountID,
+		address.Name,
+		address.Line1,
+		address.Line2,
+		address.City,
+		address.Region,
+		address.PostalCode,
+		address.Country,
+		address.Phone,
+		address.DefaultShipping,
+		address.DefaultBilling,
+		address.CreatedAt,
+	))
+
+	repository.logger.Database().Debug().
+		Str("query", query).
+		Str("duration", time.Since(start).String()).
+		Bool("success", err == nil).
+		Msg("Query Row")
+
+	return result, err
+}
+
+func (repository *PostgresRepository) GetAddress(ctx context.Context, id string) (*Address, error) {
+	start := time.Now()
+	query := "SELECT " + addressColumns + " FROM addresses WHERE id = $1"
+
+	address, err := scanAddress(repository.db.QueryRowContext(ctx, query, id))
+
+	repository.logger.Database().Debug().
+		Str("query", query).
+		Str("duration", time.Since(start).String()).
+		Bool("success", err == nil).
+		Msg("Query Row")
+
+	if err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+func (repository *PostgresRepository) ListAddresses(ctx context.Context, accountID string) ([]*Address, error) {
+	start := time.Now()
+	query := "SELECT " + addressColumns + " FROM addresses WHERE account_id = $1 ORDER BY created_at, id"
+
+	rows, err := repository.db.QueryContext(ctx, query, accountID)
+
+	repository.logger.Database().Debug().
+		Str("query", query).
+		Str("duration", time.Since(start).String()).
+		Bool("success", err == nil).
+		Msg("Query Context")
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addresses := []*Address{}
+	for rows.Next() {
+		address, err := scanAddress(rows)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+func (repository *PostgresRepository) DeleteAddress(ctx context.Context, id string) error {
+	start := time.Now()
+	query := "DELETE FROM addresses WHERE id = $1"
+
+	_, err := repository.db.ExecContext(ctx, query, id)
+
+	repository.logger.Database().Debug().
+		Str("query", query).
+		Str("duration", time.Since(start).String()).
+		Bool("success", err == nil).
+		Msg("Execute Query")
+
+	return err
+}
+
 func (repository *PostgresRepository) CreateOrUpdateSession(ctx context.Context, session *Session) error {
 	start := time.Now()
 	query := `
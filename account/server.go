@@ -8,6 +8,7 @@ import (
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/Asif-Faizal/Minimum-Viable-Shop/account/pb"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
@@ -19,7 +20,7 @@ type GrpcServer struct {
 	pb.UnimplementedAccountServiceServer
 }
 
-func ListenGrpcServer(service Service, logger util.Logger, port int) error {
+func ListenGrpcServer(service Service, jwtSecret string, logger util.Logger, port int) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return err
@@ -28,6 +29,7 @@ func ListenGrpcServer(service Service, logger util.Logger, port int) error {
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			util.UnaryServerInterceptor(logger),
+			util.AuthUnaryServerInterceptor(jwtSecret),
 		)),
 	)
 
@@ -61,12 +63,7 @@ func (server *GrpcServer) CreateOrUpdateAccount(ctx context.Context, request *pb
 		return nil, err
 	}
 	return &pb.CreateOrUpdateAccountResponse{
-		Account: &pb.Account{
-			Id:       account.ID,
-			Name:     account.Name,
-			Usertype: account.UserType,
-			Email:    account.Email,
-		},
+		Account: toProtoAccount(account),
 	}, nil
 }
 
@@ -76,12 +73,7 @@ func (server *GrpcServer) GetAccountByID(ctx context.Context, request *pb.GetAcc
 		return nil, err
 	}
 	return &pb.GetAccountByIDResponse{
-		Account: &pb.Account{
-			Id:       account.ID,
-			Name:     account.Name,
-			Usertype: account.UserType,
-			Email:    account.Email,
-		},
+		Account: toProtoAccount(account),
 	}, nil
 }
 
@@ -92,12 +84,7 @@ func (server *GrpcServer) ListAccounts(ctx context.Context, request *pb.ListAcco
 	}
 	accounts := []*pb.Account{}
 	for _, account := range domainAccounts {
-		accounts = append(accounts, &pb.Account{
-			Id:       account.ID,
-			Name:     account.Name,
-			Usertype: account.UserType,
-			Email:    account.Email,
-		})
+		accounts = append(accounts, toProtoAccount(account))
 	}
 	return &pb.ListAccountsResponse{Accounts: accounts}, nil
 }
@@ -130,12 +117,7 @@ func (server *GrpcServer) Login(ctx context.Context, request *pb.LoginRequest) (
 
 	var account *pb.Account
 	if resp.Account != nil {
-		account = &pb.Account{
-			Id:       resp.Account.ID,
-			Name:     resp.Account.Name,
-			Usertype: resp.Account.UserType,
-			Email:    resp.Account.Email,
-		}
+		account = toProtoAccount(resp.Account)
 	}
 
 	return &pb.LoginResponse{
@@ -160,12 +142,7 @@ func (server *GrpcServer) RefreshToken(ctx context.Context, request *pb.RefreshT
 
 	var account *pb.Account
 	if resp.Account != nil {
-		account = &pb.Account{
-			Id:       resp.Account.ID,
-			Name:     resp.Account.Name,
-			Usertype: resp.Account.UserType,
-			Email:    resp.Account.Email,
-		}
+		account = toProtoAccount(resp.Account)
 	}
 
 	return &pb.RefreshTokenResponse{
@@ -174,3 +151,90 @@ func (server *GrpcServer) RefreshToken(ctx context.Context, request *pb.RefreshT
 		RefreshToken: resp.RefreshToken,
 	}, nil
 }
+
+func (server *GrpcServer) SetTaxExempt(ctx context.Context, request *pb.SetTaxExemptRequest) (*pb.SetTaxExemptResponse, error) {
+	account, err := server.accountService.SetTaxExempt(ctx, request.AccountId, request.TaxExempt)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SetTaxExemptResponse{Account: toProtoAccount(account)}, nil
+}
+
+func (server *GrpcServer) CreateOrUpdateAddress(ctx context.Context, request *pb.CreateOrUpdateAddressRequest) (*pb.CreateOrUpdateAddressResponse, error) {
+	if request.Address == nil {
+		return nil, fmt.Errorf("address is required")
+	}
+	address, err := server.accountService.CreateOrUpdateAddress(ctx, &Address{
+		ID:              request.Address.Id,
+		AccountID:       request.Address.AccountId,
+		Name:            request.Address.Name,
+		Line1:           request.Address.Line1,
+		Line2:           request.Address.Line2,
+		City:            request.Address.City,
+		Region:          request.Address.Region,
+		PostalCode:      request.Address.PostalCode,
+		Country:         request.Address.Country,
+		Phone:           request.Address.Phone,
+		DefaultShipping: request.Address.DefaultShipping,
+		DefaultBilling:  request.Address.DefaultBilling,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateOrUpdateAddressResponse{Address: toProtoAddress(address)}, nil
+}
+
+func (server *GrpcServer) GetAddress(ctx context.Context, request *pb.GetAddressRequest) (*pb.GetAddressResponse, error) {
+	address, err := server.accountService.GetAddress(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetAddressResponse{Address: toProtoAddress(address)}, nil
+}
+
+func (server *GrpcServer) ListAddresses(ctx context.Context, request *pb.ListAddressesRequest) (*pb.ListAddressesResponse, error) {
+	domainAddresses, err := server.accountService.ListAddresses(ctx, request.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	addresses := []*pb.Address{}
+	for _, address := range domainAddresses {
+		addresses = append(addresses, toProtoAddress(address))
+	}
+	return &pb.ListAddressesResponse{Addresses: addresses}, nil
+}
+
+func (server *GrpcServer) DeleteAddress(ctx context.Context, request *pb.DeleteAddressRequest) (*pb.DeleteAddressResponse, error) {
+	if err := server.accountService.DeleteAddress(ctx, request.Id); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteAddressResponse{Success: true}, nil
+}
+
+func toProtoAccount(account *Account) *pb.Account {
+	return &pb.Account{
+		Id:        account.ID,
+		Name:      account.Name,
+		Usertype:  account.UserType,
+		Email:     account.Email,
+		TaxExempt: account.TaxExempt,
+	}
+}
+
+func toProtoAddress(address *Address) *pb.Address {
+	return &pb.Address{
+		Id:              address.ID,
+		AccountId:       address.AccountID,
+		Name:            address.Name,
+		Line1:           address.Line1,
+		Line2:           address.Line2,
+		City:            address.City,
+		Region:          address.Region,
+		PostalCode:      address.PostalCode,
+		Country:         address.Country,
+		Phone:           address.Phone,
+		DefaultShipping: address.DefaultShipping,
+		DefaultBilling:  address.DefaultBilling,
+		CreatedAt:       timestamppb.New(address.CreatedAt),
+	}
+}
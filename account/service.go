@@ -3,6 +3,8 @@ package account
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
@@ -17,8 +19,18 @@ type Service interface {
 	Login(ctx context.Context, email string, password string, deviceID string, deviceInfo *DeviceInfo) (*AuthenticatedResponse, error)
 	Logout(ctx context.Context, accessToken string, deviceID string) error
 	RefreshToken(ctx context.Context, refreshToken string, deviceID string) (*AuthenticatedResponse, error)
+	SetTaxExempt(ctx context.Context, id string, taxExempt bool) (*Account, error)
+	CreateOrUpdateAddress(ctx context.Context, address *Address) (*Address, error)
+	GetAddress(ctx context.Context, id string) (*Address, error)
+	ListAddresses(ctx context.Context, accountID string) ([]*Address, error)
+	DeleteAddress(ctx context.Context, id string) error
 }
 
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("permission denied")
+)
+
 type AccountService struct {
 	repository         Repository
 	jwtSecret          string
@@ -67,6 +79,7 @@ func (service *AccountService) CreateOrUpdateAccount(ctx context.Context, accoun
 		Email:    account.Email,
 		Password: hashed,
 	}
+	// The repository fills in TaxExempt, which is kept on update.
 	if _, err := service.repository.CreateOrUpdateAccount(ctx, newAccount); err != nil {
 		return nil, err
 	}
@@ -223,3 +236,114 @@ func (service *AccountService) RefreshToken(ctx context.Context, refreshToken st
 		RefreshToken: newRefreshToken,
 	}, nil
 }
+
+// SetTaxExempt exempts an account from tax on the orders it places from now
+// on, or ends its exemption. Only admins change exemptions.
+func (service *AccountService) SetTaxExempt(ctx context.Context, id string, taxExempt bool) (*Account, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, ErrForbidden
+	}
+	if id == "" {
+		return nil, errors.New("account id is required")
+	}
+	return service.repository.SetTaxExempt(ctx, id, taxExempt)
+}
+
+// CreateOrUpdateAddress adds an address to an account's address book, or
+// updates one of its addresses. Only the account itself and admins manage an
+// address book.
+func (service *AccountService) CreateOrUpdateAddress(ctx context.Context, address *Address) (*Address, error) {
+	newAddress := &Address{
+		ID:              address.ID,
+		AccountID:       address.AccountID,
+		Name:            strings.TrimSpace(address.Name),
+		Line1:           strings.TrimSpace(address.Line1),
+		Line2:           strings.TrimSpace(address.Line2),
+		City:            strings.TrimSpace(address.City),
+		Region:          strings.TrimSpace(address.Region),
+		PostalCode:      strings.TrimSpace(address.PostalCode),
+		Country:         strings.ToUpper(strings.TrimSpace(address.Country)),
+		Phone:           strings.TrimSpace(address.Phone),
+		DefaultShipping: address.DefaultShipping,
+		DefaultBilling:  address.DefaultBilling,
+		CreatedAt:       time.Now().UTC(),
+	}
+	if newAddress.ID == "" {
+		newAddress.ID = ksuid.New().String()
+	} else {
+		existing, err := service.repository.GetAddress(ctx, newAddress.ID)
+		if err != nil {
+			return nil, err
+		}
+		if newAddress.AccountID != "" && newAddress.AccountID != existing.AccountID {
+			return nil, errors.New("addresses cannot move to another account")
+		}
+		newAddress.AccountID = existing.AccountID
+		newAddress.CreatedAt = existing.CreatedAt
+	}
+	if newAddress.AccountID == "" {
+		return nil, errors.New("account id is required")
+	}
+	if err := authorizeAccount(ctx, newAddress.AccountID); err != nil {
+		return nil, err
+	}
+	switch {
+	case newAddress.Name == "":
+		return nil, errors.New("name is required")
+	case newAddress.Line1 == "":
+		return nil, errors.New("line1 is required")
+	case newAddress.City == "":
+		return nil, errors.New("city is required")
+	case len(newAddress.Country) != 2 || strings.Trim(newAddress.Country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "":
+		return nil, fmt.Errorf("country %q is not a two letter code", address.Country)
+	}
+	return service.repository.CreateOrUpdateAddress(ctx, newAddress)
+}
+
+func (service *AccountService) GetAddress(ctx context.Context, id string) (*Address, error) {
+	if id == "" {
+		return nil, errors.New("address id is required")
+	}
+	address, err := service.repository.GetAddress(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeAccount(ctx, address.AccountID); err != nil {
+		return nil, err
+	}
+	return address, nil
+}
+
+func (service *AccountService) ListAddresses(ctx context.Context, accountID string) ([]*Address, error) {
+	if accountID == "" {
+		return nil, errors.New("account id is required")
+	}
+	if err := authorizeAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+	return service.repository.ListAddresses(ctx, accountID)
+}
+
+func (service *AccountService) DeleteAddress(ctx context.Context, id string) error {
+	address, err := service.GetAddress(ctx, id)
+	if err != nil {
+		return err
+	}
+	return service.repository.DeleteAddress(ctx, address.ID)
+}
+
+// authorizeAccount lets the account itself and admins through.
+func authorizeAccount(ctx context.Context, accountID string) error {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if !claims.IsAdmin() && claims.AccountID != accountID {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -9,7 +9,8 @@ CREATE TABLE IF NOT EXISTS accounts (
     name VARCHAR(24),
     user_type user_type_enum NOT NULL,
     email VARCHAR(255) NOT NULL UNIQUE,
-    password VARCHAR(255) NOT NULL
+    password VARCHAR(255) NOT NULL,
+    tax_exempt BOOLEAN NOT NULL DEFAULT FALSE
 );
 
 CREATE TABLE IF NOT EXISTS sessions (
@@ -49,3 +50,25 @@ CREATE INDEX IF NOT EXISTS idx_sessions_refresh_token_active ON sessions (refres
 
 -- Access Token lookup (for Logout)
 CREATE INDEX IF NOT EXISTS idx_sessions_access_token ON sessions (access_token);
+
+-- Address books. An account has at most one default shipping and one default
+-- billing address.
+CREATE TABLE IF NOT EXISTS addresses (
+    id CHAR(27) PRIMARY KEY,
+    account_id CHAR(27) NOT NULL REFERENCES accounts(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    line1 VARCHAR(255) NOT NULL,
+    line2 VARCHAR(255) NOT NULL DEFAULT '',
+    city VARCHAR(255) NOT NULL,
+    region VARCHAR(64) NOT NULL DEFAULT '',
+    postal_code VARCHAR(32) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL,
+    phone VARCHAR(32) NOT NULL DEFAULT '',
+    default_shipping BOOLEAN NOT NULL DEFAULT FALSE,
+    default_billing BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_addresses_account ON addresses (account_id, created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_addresses_default_shipping ON addresses (account_id) WHERE default_shipping;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_addresses_default_billing ON addresses (account_id) WHERE default_billing;
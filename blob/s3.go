@@ -0,0 +1,216 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+type S3Config struct {
+	// Endpoint is the S3 API base URL, e.g. https://s3.eu-west-1.amazonaws.com
+	// or http://minio:9000 for an S3-compatible server.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PublicURL is the base URL objects are served from. It defaults to the
+	// bucket URL on Endpoint.
+	PublicURL string
+}
+
+// S3Store keeps objects in an S3 bucket using path-style requests signed with
+// AWS Signature Version 4, which S3-compatible servers such as MinIO accept.
+type S3Store struct {
+	config    S3Config
+	bucketURL *url.URL
+	client    *http.Client
+}
+
+// emptyPayloadHash is the SHA-256 of an empty request body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+func NewS3Store(config S3Config) (*S3Store, error) {
+	if config.Endpoint == "" || config.Bucket == "" {
+		return nil, fmt.Errorf("s3 endpoint and bucket are required")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	bucketURL, err := url.Parse(strings.TrimSuffix(config.Endpoint, "/") + "/" + config.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint: %w", err)
+	}
+	if config.PublicURL == "" {
+		config.PublicURL = bucketURL.String()
+	}
+	config.PublicURL = strings.TrimSuffix(config.PublicURL, "/")
+	return &S3Store{
+		config:    config,
+		bucketURL: bucketURL,
+		client:    &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+func (store *S3Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	request, err := store.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	request.ContentLength = size
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+	// The body is streamed, so it is not part of the signature.
+	response, err := store.do(request, "UNSIGNED-PAYLOAD")
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+func (store *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	request, err := store.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := store.do(request, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+func (store *S3Store) Delete(ctx context.Context, key string) error {
+	request, err := store.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	response, err := store.do(request, emptyPayloadHash)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	return nil
+}
+
+func (store *S3Store) URL(key string) string {
+	return store.config.PublicURL + "/" + (&url.URL{Path: key}).EscapedPath()
+}
+
+func (store *S3Store) newRequest(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	if key == "" {
+		return nil, fmt.Errorf("invalid blob key %q", key)
+	}
+	objectURL := *store.bucketURL
+	objectURL.Path = store.bucketURL.Path + "/" + key
+	objectURL.RawPath = ""
+	return http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+}
+
+// do signs and sends the request, turning error responses into errors.
+func (store *S3Store) do(request *http.Request, payloadHash string) (*http.Response, error) {
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	signV4(request, payloadHash, store.config.AccessKeyID, store.config.SecretAccessKey, store.config.Region, "s3", time.Now())
+	response, err := store.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNotFound {
+		response.Body.Close()
+		return nil, ErrNotFound
+	}
+	if response.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		response.Body.Close()
+		return nil, fmt.Errorf("s3 %s %s: %s: %s", request.Method, request.URL.Path, response.Status, strings.TrimSpace(string(message)))
+	}
+	return response, nil
+}
+
+// signV4 adds an AWS Signature Version 4 Authorization header covering the
+// host and every header already set on the request.
+func signV4(request *http.Request, payloadHash string, accessKeyID string, secretAccessKey string, region string, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	request.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": request.URL.Host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	canonicalHeaders := ""
+	for _, name := range names {
+		canonicalHeaders += name + ":" + headers[name] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		canonicalQuery(request.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := []string{}
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, escape(key)+"="+escape(value))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// escape percent-encodes everything but RFC 3986 unreserved characters.
+func escape(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
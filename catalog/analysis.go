@@ -0,0 +1,128 @@
+package catalog
+
+// Product text is indexed with a language analyzer per locale: the default
+// locale's text in the "localized" subfield of name and description, and
+// translations under translations.<locale>. Each analyzer has a search-time
+// twin that also expands synonyms from the locale's Elasticsearch synonym
+// set, which can be updated without reindexing; see UpdateSynonyms.
+
+// synonymsFilter marks where the synonym filter goes in a search analyzer.
+// Synonyms are expanded after lowercasing but before stemming.
+const synonymsFilter = "synonyms"
+
+// localeFilters lists the token filters of each locale's analyzer.
+var localeFilters = map[string][]string{
+	"en": {"english_possessive", "lowercase", synonymsFilter, "english_stop", "english_stemmer"},
+	"de": {"lowercase", synonymsFilter, "german_stop", "german_normalization", "german_stemmer"},
+	"fr": {"french_elision", "lowercase", synonymsFilter, "french_stop", "french_stemmer"},
+	"es": {"lowercase", synonymsFilter, "spanish_stop", "spanish_stemmer"},
+}
+
+var languageFilters = map[string]interface{}{
+	"english_possessive": map[string]interface{}{"type": "stemmer", "language": "possessive_english"},
+	"english_stop":       map[string]interface{}{"type": "stop", "stopwords": "_english_"},
+	"english_stemmer":    map[string]interface{}{"type": "stemmer", "language": "english"},
+	"german_stop":        map[string]interface{}{"type": "stop", "stopwords": "_german_"},
+	"german_stemmer":     map[string]interface{}{"type": "stemmer", "language": "light_german"},
+	"french_elision": map[string]interface{}{
+		"type":          "elision",
+		"articles_case": true,
+		"articles":      []string{"l", "m", "t", "qu", "n", "s", "j", "d", "c", "jusqu", "quoiqu", "lorsqu", "puisqu"},
+	},
+	"french_stop":     map[string]interface{}{"type": "stop", "stopwords": "_french_"},
+	"french_stemmer":  map[string]interface{}{"type": "stemmer", "language": "light_french"},
+	"spanish_stop":    map[string]interface{}{"type": "stop", "stopwords": "_spanish_"},
+	"spanish_stemmer": map[string]interface{}{"type": "stemmer", "language": "light_spanish"},
+}
+
+// SynonymSetID returns the name of the Elasticsearch synonym set of a locale.
+func SynonymSetID(locale string) string {
+	return "catalog-synonyms-" + locale
+}
+
+func textAnalyzer(locale string) string {
+	return "text_" + locale
+}
+
+func searchAnalyzer(locale string) string {
+	return "text_" + locale + "_search"
+}
+
+// analysisSettings returns the catalog index's analyzers and their filters.
+func analysisSettings() map[string]interface{} {
+	filters := map[string]interface{}{}
+	for name, filter := range languageFilters {
+		filters[name] = filter
+	}
+	analyzers := map[string]interface{}{}
+	for _, locale := range Locales {
+		synonyms := "synonyms_" + locale
+		filters[synonyms] = map[string]interface{}{
+			"type":         "synonym_graph",
+			"synonyms_set": SynonymSetID(locale),
+			"updateable":   true,
+		}
+		indexChain := []string{}
+		searchChain := []string{}
+		for _, filter := range localeFilters[locale] {
+			if filter == synonymsFilter {
+				searchChain = append(searchChain, synonyms)
+				continue
+			}
+			indexChain = append(indexChain, filter)
+			searchChain = append(searchChain, filter)
+		}
+		analyzers[textAnalyzer(locale)] = map[string]interface{}{
+			"type":      "custom",
+			"tokenizer": "standard",
+			"filter":    indexChain,
+		}
+		analyzers[searchAnalyzer(locale)] = map[string]interface{}{
+			"type":      "custom",
+			"tokenizer": "standard",
+			"filter":    searchChain,
+		}
+	}
+	return map[string]interface{}{
+		"filter":   filters,
+		"analyzer": analyzers,
+	}
+}
+
+func localizedText(locale string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":            "text",
+		"analyzer":        textAnalyzer(locale),
+		"search_analyzer": searchAnalyzer(locale),
+	}
+}
+
+// translationsMapping maps translations.<locale>.name and description of
+// every locale but the default.
+func translationsMapping() map[string]interface{} {
+	locales := map[string]interface{}{}
+	for _, locale := range Locales {
+		if locale == DefaultLocale {
+			continue
+		}
+		locales[locale] = map[string]interface{}{
+			"properties": map[string]interface{}{
+				"name":        localizedText(locale),
+				"description": localizedText(locale),
+			},
+		}
+	}
+	return map[string]interface{}{"properties": locales}
+}
+
+// searchFields returns the fields a query in locale is matched against.
+func searchFields(locale string) []string {
+	fields := []string{"name", "description", "name.localized", "description.localized"}
+	if locale != "" && locale != DefaultLocale {
+		fields = append(fields, "translations."+locale+".name", "translations."+locale+".description")
+	}
+	return fields
+}
+
+// maxSynonymRules is the most rules Elasticsearch keeps in a synonym set.
+const maxSynonymRules = 10000
@@ -0,0 +1,127 @@
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func (attributeType AttributeType) Valid() bool {
+	switch attributeType {
+	case AttributeTypeString, AttributeTypeNumber, AttributeTypeEnum, AttributeTypeBoolean:
+		return true
+	}
+	return false
+}
+
+// Attribute returns the definition of the named attribute, if the category has one.
+func (category *Category) Attribute(name string) (*AttributeDefinition, bool) {
+	for _, definition := range category.Attributes {
+		if definition.Name == name {
+			return definition, true
+		}
+	}
+	return nil, false
+}
+
+func validateCategory(category *Category) error {
+	if category.Name == "" {
+		return fmt.Errorf("category name is required")
+	}
+	seen := map[string]bool{}
+	for _, definition := range category.Attributes {
+		if definition.Name == "" {
+			return fmt.Errorf("attribute name is required")
+		}
+		if seen[definition.Name] {
+			return fmt.Errorf("attribute %q is defined more than once", definition.Name)
+		}
+		seen[definition.Name] = true
+		if !definition.Type.Valid() {
+			return fmt.Errorf("attribute %q has invalid type %q", definition.Name, definition.Type)
+		}
+		if definition.Unit != "" && definition.Type != AttributeTypeNumber {
+			return fmt.Errorf("attribute %q: only number attributes can have a unit", definition.Name)
+		}
+		if definition.Type == AttributeTypeEnum && len(definition.Values) == 0 {
+			return fmt.Errorf("attribute %q: enum attributes need at least one value", definition.Name)
+		}
+		if definition.Type != AttributeTypeEnum && len(definition.Values) > 0 {
+			return fmt.Errorf("attribute %q: only enum attributes can list values", definition.Name)
+		}
+	}
+	return nil
+}
+
+// validateAttributes checks product attributes against the category's schema
+// and returns them in canonical form, typed from their definitions. Without a
+// category, attributes are free-form strings.
+func validateAttributes(category *Category, attributes []*ProductAttribute) ([]*ProductAttribute, error) {
+	validated := []*ProductAttribute{}
+	seen := map[string]bool{}
+	for _, attribute := range attributes {
+		name := strings.TrimSpace(attribute.Name)
+		if name == "" {
+			return nil, fmt.Errorf("attribute name is required")
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("attribute %q is set more than once", name)
+		}
+		seen[name] = true
+		if category == nil {
+			validated = append(validated, &ProductAttribute{Name: name, Value: attribute.Value, Type: AttributeTypeString})
+			continue
+		}
+		definition, ok := category.Attribute(name)
+		if !ok {
+			return nil, fmt.Errorf("category %s has no attribute %q", category.Name, name)
+		}
+		value, err := normalizeAttributeValue(definition, attribute.Value)
+		if err != nil {
+			return nil, err
+		}
+		validated = append(validated, &ProductAttribute{
+			Name:  name,
+			Value: value,
+			Type:  definition.Type,
+			Unit:  definition.Unit,
+		})
+	}
+	if category != nil {
+		for _, definition := range category.Attributes {
+			if definition.Required && !seen[definition.Name] {
+				return nil, fmt.Errorf("attribute %q is required in category %s", definition.Name, category.Name)
+			}
+		}
+	}
+	return validated, nil
+}
+
+func normalizeAttributeValue(definition *AttributeDefinition, value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch definition.Type {
+	case AttributeTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("attribute %q must be a number, got %q", definition.Name, value)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case AttributeTypeBoolean:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("attribute %q must be true or false, got %q", definition.Name, value)
+		}
+		return strconv.FormatBool(boolean), nil
+	case AttributeTypeEnum:
+		for _, allowed := range definition.Values {
+			if strings.EqualFold(allowed, value) {
+				return allowed, nil
+			}
+		}
+		return "", fmt.Errorf("attribute %q must be one of %s, got %q", definition.Name, strings.Join(definition.Values, ", "), value)
+	}
+	if value == "" {
+		return "", fmt.Errorf("attribute %q must not be empty", definition.Name)
+	}
+	return value, nil
+}
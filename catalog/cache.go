@@ -0,0 +1,470 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/redis/go-redis/v9"
+)
+
+// CacheConfig configures the tiers of a CachedRepository.
+type CacheConfig struct {
+	// Size is the number of products and categories kept in the local LRU.
+	Size     int
+	LocalTTL time.Duration
+	// RedisURL, if set, adds a Redis tier shared by all catalog replicas.
+	// Writes drop their entries from it and tell the other replicas to drop
+	// them from their local tiers.
+	RedisURL string
+	RedisTTL time.Duration
+}
+
+// CachedRepository caches product and category lookups by id in front of
+// another repository. Writes through it drop the entries they change. Other
+// methods, including listings and search, go to the repository.
+type CachedRepository struct {
+	Repository
+	local    *expirable.LRU[string, []byte]
+	redis    *redis.Client
+	pubsub   *redis.PubSub
+	redisTTL time.Duration
+	logger   util.Logger
+
+	// fills holds the keys being loaded into the cache, so that an entry
+	// read before an invalidation of its key is not cached after it.
+	mutex sync.Mutex
+	fills map[string]*cacheFill
+
+	localHits     atomic.Uint64
+	redisHits     atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+// cacheFill counts the invalidations of a key since the first of the loads
+// of it in progress began.
+type cacheFill struct {
+	generation uint64
+	loads      int
+}
+
+// CacheStats counts the lookups each tier of a CachedRepository served, and
+// the entries dropped by writes, since it was created.
+type CacheStats struct {
+	LocalHits     uint64 `json:"local_hits"`
+	RedisHits     uint64 `json:"redis_hits"`
+	Misses        uint64 `json:"misses"`
+	Invalidations uint64 `json:"invalidations"`
+}
+
+// cachedProduct is the cache entry of a product.
+type cachedProduct struct {
+	Version  string          `json:"version"`
+	Document ProductDocument `json:"document"`
+}
+
+// invalidationChannel carries the keys dropped by writes to every replica.
+const invalidationChannel = "catalog:invalidations"
+
+// storeScript sets an entry in Redis unless its generation key has changed
+// since the entry was read, which means it was invalidated meanwhile.
+var storeScript = redis.NewScript(`
+local generation = redis.call('GET', KEYS[2])
+if (generation or '') ~= ARGV[2] then
+	return 0
+end
+if ARGV[3] == '0' then
+	redis.call('SET', KEYS[1], ARGV[1])
+else
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[3])
+end
+return 1
+`)
+
+func NewCachedRepository(repository Repository, config CacheConfig, logger util.Logger) (*CachedRepository, error) {
+	cached := &CachedRepository{
+		Repository: repository,
+		local:      expirable.NewLRU[string, []byte](config.Size, nil, config.LocalTTL),
+		redisTTL:   config.RedisTTL,
+		logger:     logger,
+		fills:      map[string]*cacheFill{},
+	}
+	if config.RedisURL != "" {
+		options, err := redis.ParseURL(config.RedisURL)
+		if err != nil {
+			return nil, err
+		}
+		cached.redis = redis.NewClient(options)
+		if err := cached.redis.Ping(context.Background()).Err(); err != nil {
+			cached.redis.Close()
+			return nil, err
+		}
+		cached.pubsub = cached.redis.Subscribe(context.Background(), invalidationChannel)
+		if _, err := cached.pubsub.Receive(context.Background()); err != nil {
+			cached.pubsub.Close()
+			cached.redis.Close()
+			return nil, err
+		}
+		go cached.receiveInvalidations()
+	}
+	return cached, nil
+}
+
+func (repository *CachedRepository) Close() {
+	if repository.redis != nil {
+		repository.pubsub.Close()
+		repository.redis.Close()
+	}
+	repository.Repository.Close()
+}
+
+// Stats returns the cache's hit and miss counts.
+func (repository *CachedRepository) Stats() CacheStats {
+	return CacheStats{
+		LocalHits:     repository.localHits.Load(),
+		RedisHits:     repository.redisHits.Load(),
+		Misses:        repository.misses.Load(),
+		Invalidations: repository.invalidations.Load(),
+	}
+}
+
+// PublishStats exports the cache's hit and miss counts as the expvar
+// variable name, served at /debug/vars by expvar.Handler.
+func (repository *CachedRepository) PublishStats(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		return repository.Stats()
+	}))
+}
+
+// CreateOrUpdateProduct drops the cached product even if the write fails, as
+// a version conflict may come from a stale entry.
+func (repository *CachedRepository) CreateOrUpdateProduct(ctx context.Context, product *Product) (*Product, error) {
+	defer repository.invalidate(ctx, productCacheKey(product.ID))
+	return repository.Repository.CreateOrUpdateProduct(ctx, product)
+}
+
+func (repository *CachedRepository) BulkCreateOrUpdateProducts(ctx context.Context, products []*Product) ([]error, error) {
+	keys := []string{}
+	for _, product := range products {
+		keys = append(keys, productCacheKey(product.ID))
+	}
+	defer repository.invalidate(ctx, keys...)
+	return repository.Repository.BulkCreateOrUpdateProducts(ctx, products)
+}
+
+func (repository *CachedRepository) UpdateProductRating(ctx context.Context, id string, rating ProductRating) error {
+	defer repository.invalidate(ctx, productCacheKey(id))
+	return repository.Repository.UpdateProductRating(ctx, id, rating)
+}
+
+func (repository *CachedRepository) GetProductById(ctx context.Context, id string) (*Product, error) {
+	products, err := repository.ListProductsWithIds(ctx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, ErrProductNotFound
+	}
+	return products[0], nil
+}
+
+// ListProductsWithIds looks each product up in the local tier, then in
+// Redis, and loads the rest from the repository in one request.
+func (repository *CachedRepository) ListProductsWithIds(ctx context.Context, ids []string) ([]*Product, error) {
+	keys := []string{}
+	for _, id := range ids {
+		keys = append(keys, productCacheKey(id))
+	}
+	entries, err := repository.lookup(ctx, keys, func(missing []string) (map[string][]byte, error) {
+		missingIds := []string{}
+		for _, key := range missing {
+			missingIds = append(missingIds, strings.TrimPrefix(key, productCacheKey("")))
+		}
+		loaded, err := repository.Repository.ListProductsWithIds(ctx, missingIds)
+		if err != nil {
+			return nil, err
+		}
+		entries := map[string][]byte{}
+		for _, product := range loaded {
+			entry, err := json.Marshal(cachedProduct{Version: product.Version, Document: toProductDocument(product)})
+			if err != nil {
+				return nil, err
+			}
+			entries[productCacheKey(product.ID)] = entry
+		}
+		return entries, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	products := []*Product{}
+	for _, id := range ids {
+		entry := entries[productCacheKey(id)]
+		if entry == nil {
+			continue
+		}
+		cached := cachedProduct{}
+		if err := json.Unmarshal(entry, &cached); err != nil {
+			return nil, err
+		}
+		product, err := toProduct(id, cached.Document)
+		if err != nil {
+			return nil, err
+		}
+		product.Version = cached.Version
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+func (repository *CachedRepository) CreateOrUpdateCategory(ctx context.Context, category *Category) (*Category, error) {
+	defer repository.invalidate(ctx, categoryCacheKey(category.ID))
+	return repository.Repository.CreateOrUpdateCategory(ctx, category)
+}
+
+func (repository *CachedRepository) GetCategoryById(ctx context.Context, id string) (*Category, error) {
+	key := categoryCacheKey(id)
+	entries, err := repository.lookup(ctx, []string{key}, func([]string) (map[string][]byte, error) {
+		category, err := repository.Repository.GetCategoryById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		entry, err := json.Marshal(CategoryDocument{Name: category.Name, Attributes: category.Attributes})
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{key: entry}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return decodeCategory(id, entries[key])
+}
+
+// lookup returns the entries of keys, looking each up in the local tier,
+// then in Redis, and loading the rest with load, which returns the entries
+// it found by key. Keys found nowhere have nil entries. Entries read from
+// Redis or loaded are cached unless their key is invalidated while they are
+// read.
+func (repository *CachedRepository) lookup(ctx context.Context, keys []string, load func(missing []string) (map[string][]byte, error)) (map[string][]byte, error) {
+	entries := map[string][]byte{}
+	missing := []string{}
+	for _, key := range keys {
+		if _, ok := entries[key]; ok {
+			continue
+		}
+		if entry, ok := repository.local.Get(key); ok {
+			repository.localHits.Add(1)
+			entries[key] = entry
+			continue
+		}
+		entries[key] = nil
+		missing = append(missing, key)
+	}
+	if len(missing) == 0 {
+		return entries, nil
+	}
+	generations := repository.beginFills(missing)
+	defer repository.endFills(missing)
+	missing, redisGenerations := repository.lookupRedis(ctx, missing, generations, entries)
+	if len(missing) == 0 {
+		return entries, nil
+	}
+	repository.misses.Add(uint64(len(missing)))
+	loaded, err := load(missing)
+	if err != nil {
+		return nil, err
+	}
+	for key, entry := range loaded {
+		entries[key] = entry
+		repository.storeLocal(key, generations[key], entry)
+		if generation, ok := redisGenerations[key]; ok {
+			repository.storeRedis(ctx, key, generation, entry)
+		}
+	}
+	return entries, nil
+}
+
+// lookupRedis fills entries with the keys found in Redis, keeping them in
+// the local tier too, and returns the keys it did not find with the
+// generations they had in Redis. Redis errors are logged and treated as
+// misses, without generations, so that the entries loaded for them are
+// only cached locally.
+func (repository *CachedRepository) lookupRedis(ctx context.Context, keys []string, generations map[string]uint64, entries map[string][]byte) ([]string, map[string]string) {
+	if repository.redis == nil {
+		return keys, nil
+	}
+	lookups := append([]string{}, keys...)
+	for _, key := range keys {
+		lookups = append(lookups, generationCacheKey(key))
+	}
+	values, err := repository.redis.MGet(ctx, lookups...).Result()
+	if err != nil {
+		repository.logger.Database().Warn().Err(err).Msg("failed to read catalog cache")
+		return keys, nil
+	}
+	missing := []string{}
+	redisGenerations := map[string]string{}
+	for i, key := range keys {
+		entry, ok := values[i].(string)
+		if !ok {
+			missing = append(missing, key)
+			generation, _ := values[len(keys)+i].(string)
+			redisGenerations[key] = generation
+			continue
+		}
+		repository.redisHits.Add(1)
+		entries[key] = []byte(entry)
+		repository.storeLocal(key, generations[key], []byte(entry))
+	}
+	return missing, redisGenerations
+}
+
+// beginFills records that keys are being loaded and returns the generation
+// of each, which storeLocal checks.
+func (repository *CachedRepository) beginFills(keys []string) map[string]uint64 {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	generations := map[string]uint64{}
+	for _, key := range keys {
+		fill, ok := repository.fills[key]
+		if !ok {
+			fill = &cacheFill{}
+			repository.fills[key] = fill
+		}
+		fill.loads++
+		generations[key] = fill.generation
+	}
+	return generations
+}
+
+func (repository *CachedRepository) endFills(keys []string) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	for _, key := range keys {
+		fill := repository.fills[key]
+		fill.loads--
+		if fill.loads == 0 {
+			delete(repository.fills, key)
+		}
+	}
+}
+
+// storeLocal keeps entry in the local tier unless key was invalidated since
+// its fill began with generation.
+func (repository *CachedRepository) storeLocal(key string, generation uint64, entry []byte) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	if repository.fills[key].generation == generation {
+		repository.local.Add(key, entry)
+	}
+}
+
+// storeRedis sets entry in Redis unless key was invalidated since Redis was
+// found to hold generation for it.
+func (repository *CachedRepository) storeRedis(ctx context.Context, key string, generation string, entry []byte) {
+	err := storeScript.Run(ctx, repository.redis, []string{key, generationCacheKey(key)}, entry, generation, repository.redisTTL.Milliseconds()).Err()
+	if err != nil {
+		repository.logger.Database().Warn().Err(err).Msg("failed to write catalog cache")
+	}
+}
+
+// invalidate drops keys from the local tier and from Redis, and tells the
+// other replicas to drop them from their local tiers.
+func (repository *CachedRepository) invalidate(ctx context.Context, keys ...string) {
+	repository.invalidateLocal(keys)
+	repository.invalidations.Add(uint64(len(keys)))
+	if repository.redis == nil || len(keys) == 0 {
+		return
+	}
+	message, err := json.Marshal(keys)
+	if err != nil {
+		repository.logger.Database().Warn().Err(err).Strs("keys", keys).Msg("failed to invalidate catalog cache")
+		return
+	}
+	// The entries must go even if the request that wrote them was cancelled.
+	ctx = context.WithoutCancel(ctx)
+	_, err = repository.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys...)
+		for _, key := range keys {
+			// Generations outlive the entries a fill may set, so that a
+			// fill that read an old one always sees it change.
+			pipe.Incr(ctx, generationCacheKey(key))
+			if repository.redisTTL > 0 {
+				pipe.Expire(ctx, generationCacheKey(key), 2*repository.redisTTL)
+			}
+		}
+		pipe.Publish(ctx, invalidationChannel, message)
+		return nil
+	})
+	if err != nil {
+		repository.logger.Database().Warn().Err(err).Strs("keys", keys).Msg("failed to invalidate catalog cache")
+	}
+}
+
+func (repository *CachedRepository) invalidateLocal(keys []string) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	for _, key := range keys {
+		repository.local.Remove(key)
+		if fill, ok := repository.fills[key]; ok {
+			fill.generation++
+		}
+	}
+}
+
+// receiveInvalidations drops the keys invalidated by any replica from the
+// local tier until the subscription is closed. Invalidations sent while the
+// subscription reconnects are lost, and their entries kept until they
+// expire.
+func (repository *CachedRepository) receiveInvalidations() {
+	for message := range repository.pubsub.Channel() {
+		keys := []string{}
+		if err := json.Unmarshal([]byte(message.Payload), &keys); err != nil {
+			repository.logger.Database().Warn().Err(err).Msg("failed to read catalog cache invalidation")
+			continue
+		}
+		repository.invalidateLocal(keys)
+	}
+}
+
+func productCacheKey(id string) string {
+	return "catalog:product:" + id
+}
+
+func categoryCacheKey(id string) string {
+	return "catalog:category:" + id
+}
+
+// generationCacheKey counts the invalidations of the entry at key in Redis.
+func generationCacheKey(key string) string {
+	return key + ":generation"
+}
+
+// RunCacheStatsLogger logs the cache's hit and miss counts every interval
+// until ctx is cancelled.
+func RunCacheStatsLogger(ctx context.Context, repository *CachedRepository, interval time.Duration, logger util.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		stats := repository.Stats()
+		logger.Service().Info().
+			Uint64("local_hits", stats.LocalHits).
+			Uint64("redis_hits", stats.RedisHits).
+			Uint64("misses", stats.Misses).
+			Uint64("invalidations", stats.Invalidations).
+			Msg("catalog cache stats")
+	}
+}
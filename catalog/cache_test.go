@@ -0,0 +1,97 @@
+package catalog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	"github.com/segmentio/ksuid"
+)
+
+// racingRepository calls during once, after the first products it reads
+// have been read and before they are returned.
+type racingRepository struct {
+	Repository
+	during func()
+}
+
+func (repository *racingRepository) ListProductsWithIds(ctx context.Context, ids []string) ([]*Product, error) {
+	products, err := repository.Repository.ListProductsWithIds(ctx, ids)
+	if during := repository.during; during != nil {
+		repository.during = nil
+		during()
+	}
+	return products, err
+}
+
+func newCacheTestProduct(name string) *Product {
+	return &Product{
+		ID:           ksuid.New().String(),
+		Name:         name,
+		Price:        money.New(1999, "USD"),
+		Prices:       []money.Money{},
+		Status:       ProductStatusPublished,
+		Media:        []*ProductMedia{},
+		Attributes:   []*ProductAttribute{},
+		Translations: map[string]*ProductTranslation{},
+	}
+}
+
+func TestCachedRepositoryServesRepeatLookupsLocally(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewCachedRepository(NewMemoryRepository(), CacheConfig{Size: 10}, util.NewLogger("error"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	product, err := cache.CreateOrUpdateProduct(ctx, newCacheTestProduct("lamp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if _, err := cache.GetProductById(ctx, product.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats := cache.Stats()
+	if stats.Misses != 1 || stats.LocalHits != 1 || stats.Invalidations != 1 {
+		t.Errorf("stats = %+v, want 1 miss, 1 local hit and 1 invalidation", stats)
+	}
+}
+
+func TestCachedRepositoryDoesNotCacheEntriesInvalidatedWhileRead(t *testing.T) {
+	ctx := context.Background()
+	racing := &racingRepository{Repository: NewMemoryRepository()}
+	cache, err := NewCachedRepository(racing, CacheConfig{Size: 10}, util.NewLogger("error"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	product, err := cache.CreateOrUpdateProduct(ctx, newCacheTestProduct("lamp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	racing.during = func() {
+		updated := *product
+		updated.Name = "desk lamp"
+		if _, err := cache.CreateOrUpdateProduct(ctx, &updated); err != nil {
+			t.Error(err)
+		}
+	}
+	read, err := cache.GetProductById(ctx, product.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Name != "lamp" {
+		t.Fatalf("read during the update = %q, want the product as it was read", read.Name)
+	}
+	read, err = cache.GetProductById(ctx, product.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Name != "desk lamp" {
+		t.Errorf("read after the update = %q, want %q", read.Name, "desk lamp")
+	}
+	if len(cache.fills) != 0 {
+		t.Errorf("%d fills left in progress", len(cache.fills))
+	}
+}
@@ -2,9 +2,12 @@ package catalog
 
 import (
 	"context"
+	"io"
 
 	pb "github.com/Asif-Faizal/Minimum-Viable-Shop/catalog/pb"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type CatalogClient struct {
@@ -13,7 +16,12 @@ type CatalogClient struct {
 }
 
 func NewCatalogClient(url string) (*CatalogClient, error) {
-	connection, err := grpc.Dial(url, grpc.WithInsecure())
+	connection, err := grpc.Dial(
+		url,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(util.AccessTokenClientInterceptor()),
+		grpc.WithStreamInterceptor(util.AccessTokenStreamClientInterceptor()),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -28,12 +36,21 @@ func (client *CatalogClient) Close() {
 }
 
 // CreateOrUpdate Product
-func (client *CatalogClient) CreateOrUpdateProduct(ctx context.Context, id, name, description string, price float32) (*pb.CreateOrUpdateProductResponse, error) {
-	response, err := client.client.CreateOrUpdateProduct(ctx, &pb.CreateOrUpdateProductRequest{
-		Id:          id,
-		Name:        name,
-		Description: description,
-		Price:       price,
+func (client *CatalogClient) CreateOrUpdateProduct(ctx context.Context, product *Product) (*pb.CreateOrUpdateProductResponse, error) {
+	response, err := client.client.CreateOrUpdateProduct(ctx, toProductRequest(product))
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Patch Product updates the named fields of a product at the given version
+func (client *CatalogClient) PatchProduct(ctx context.Context, id string, version string, patch *Product, fields []string) (*pb.PatchProductResponse, error) {
+	response, err := client.client.PatchProduct(ctx, &pb.PatchProductRequest{
+		Id:         id,
+		Version:    version,
+		Product:    toProductRequest(patch),
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: fields},
 	})
 	if err != nil {
 		return nil, err
@@ -42,9 +59,10 @@ func (client *CatalogClient) CreateOrUpdateProduct(ctx context.Context, id, name
 }
 
 // Get Product by ID
-func (client *CatalogClient) GetProductByID(ctx context.Context, id string) (*pb.GetProductByIDResponse, error) {
+func (client *CatalogClient) GetProductByID(ctx context.Context, id string, currency string) (*pb.GetProductByIDResponse, error) {
 	response, err := client.client.GetProductByID(ctx, &pb.GetProductByIDRequest{
-		Id: id,
+		Id:       id,
+		Currency: currency,
 	})
 	if err != nil {
 		return nil, err
@@ -53,10 +71,16 @@ func (client *CatalogClient) GetProductByID(ctx context.Context, id string) (*pb
 }
 
 // List Products
-func (client *CatalogClient) ListProducts(ctx context.Context, skip uint64, take uint64) (*pb.ListProductsResponse, error) {
+func (client *CatalogClient) ListProducts(ctx context.Context, skip uint64, take uint64, cursor string, currency string, includeUnpublished bool, filter ProductFilter) (*pb.ListProductsResponse, error) {
 	response, err := client.client.ListProducts(ctx, &pb.ListProductsRequest{
-		Skip: skip,
-		Take: take,
+		Skip:               skip,
+		Take:               take,
+		Cursor:             cursor,
+		Currency:           currency,
+		IncludeUnpublished: includeUnpublished,
+		CategoryId:         filter.CategoryID,
+		Attributes:         filterToProto(filter),
+		Sort:               string(filter.Sort),
 	})
 	if err != nil {
 		return nil, err
@@ -65,9 +89,10 @@ func (client *CatalogClient) ListProducts(ctx context.Context, skip uint64, take
 }
 
 // List Products With Ids
-func (client *CatalogClient) ListProductsWithIDs(ctx context.Context, ids []string) (*pb.ListProductsWithIdsResponse, error) {
+func (client *CatalogClient) ListProductsWithIDs(ctx context.Context, ids []string, currency string) (*pb.ListProductsWithIdsResponse, error) {
 	response, err := client.client.ListProductsWithIds(ctx, &pb.ListProductsWithIdsRequest{
-		Ids: ids,
+		Ids:      ids,
+		Currency: currency,
 	})
 	if err != nil {
 		return nil, err
@@ -76,12 +101,271 @@ func (client *CatalogClient) ListProductsWithIDs(ctx context.Context, ids []stri
 }
 
 // Search products
-func (client *CatalogClient) SearchProducts(ctx context.Context, query string) (*pb.SearchProductsResponse, error) {
+func (client *CatalogClient) SearchProducts(ctx context.Context, query string, skip uint64, take uint64, cursor string, currency string, includeUnpublished bool, filter ProductFilter) (*pb.SearchProductsResponse, error) {
 	response, err := client.client.SearchProducts(ctx, &pb.SearchProductsRequest{
-		Query: query,
+		Query:              query,
+		Skip:               skip,
+		Take:               take,
+		Cursor:             cursor,
+		Currency:           currency,
+		IncludeUnpublished: includeUnpublished,
+		CategoryId:         filter.CategoryID,
+		Attributes:         filterToProto(filter),
+		Sort:               string(filter.Sort),
+		Locale:             filter.Locale,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List Products by Merchant
+func (client *CatalogClient) ListProductsByMerchant(ctx context.Context, merchantID string, skip uint64, take uint64, currency string, includeUnpublished bool) (*pb.ListProductsByMerchantResponse, error) {
+	response, err := client.client.ListProductsByMerchant(ctx, &pb.ListProductsByMerchantRequest{
+		MerchantId:         merchantID,
+		Skip:               skip,
+		Take:               take,
+		Currency:           currency,
+		IncludeUnpublished: includeUnpublished,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List Related Products
+func (client *CatalogClient) ListRelatedProducts(ctx context.Context, productID string, take uint64, currency string) (*pb.ListRelatedProductsResponse, error) {
+	response, err := client.client.ListRelatedProducts(ctx, &pb.ListRelatedProductsRequest{
+		ProductId: productID,
+		Take:      take,
+		Currency:  currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Transfer Product Ownership
+func (client *CatalogClient) TransferProductOwnership(ctx context.Context, productID string, merchantID string) (*pb.TransferProductOwnershipResponse, error) {
+	response, err := client.client.TransferProductOwnership(ctx, &pb.TransferProductOwnershipRequest{
+		ProductId:  productID,
+		MerchantId: merchantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Delete Product
+func (client *CatalogClient) DeleteProduct(ctx context.Context, id string) (*pb.DeleteProductResponse, error) {
+	response, err := client.client.DeleteProduct(ctx, &pb.DeleteProductRequest{
+		Id: id,
 	})
 	if err != nil {
 		return nil, err
 	}
 	return response, nil
 }
+
+// Import Products streams rows from next until it returns io.EOF
+func (client *CatalogClient) ImportProducts(ctx context.Context, next func() (*ImportRow, error)) (*pb.ImportProductsResponse, error) {
+	stream, err := client.client.ImportProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		row, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			stream.CloseSend()
+			return nil, err
+		}
+		if err := stream.Send(&pb.ImportProductsRequest{
+			Product: toProductRequest(row.Product),
+			Row:     row.Row,
+		}); err != nil {
+			// The server's error is reported by CloseAndRecv.
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// Export Products calls fn for each exported product
+func (client *CatalogClient) ExportProducts(ctx context.Context, merchantID string, includeUnpublished bool, fn func(*Product) error) error {
+	stream, err := client.client.ExportProducts(ctx, &pb.ExportProductsRequest{
+		MerchantId:         merchantID,
+		IncludeUnpublished: includeUnpublished,
+	})
+	if err != nil {
+		return err
+	}
+	for {
+		product, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(fromProtoProduct(product)); err != nil {
+			return err
+		}
+	}
+}
+
+func toProductRequest(product *Product) *pb.CreateOrUpdateProductRequest {
+	return &pb.CreateOrUpdateProductRequest{
+		Id:              product.ID,
+		Name:            product.Name,
+		Description:     product.Description,
+		Price:           product.Price.ToProto(),
+		Prices:          pricesToProto(product.Prices),
+		CompareAtPrice:  optionalPriceToProto(product.CompareAtPrice),
+		ScheduledPrices: scheduledPricesToProto(product.ScheduledPrices),
+		Status:          string(product.Status),
+		PublishAt:       timeToProto(product.PublishAt),
+		UnpublishAt:     timeToProto(product.UnpublishAt),
+		CategoryId:      product.CategoryID,
+		Attributes:      attributesToProto(product.Attributes),
+		TaxCategory:     product.TaxCategory,
+		Weight:          product.Weight,
+		Translations:    translationsToProto(product.Translations),
+		Version:         product.Version,
+	}
+}
+
+// Add Product Media
+func (client *CatalogClient) AddProductMedia(ctx context.Context, productID string, media *ProductMedia) (*pb.AddProductMediaResponse, error) {
+	response, err := client.client.AddProductMedia(ctx, &pb.AddProductMediaRequest{
+		ProductId: productID,
+		Media:     toProtoMedia(media),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Update Product Media
+func (client *CatalogClient) UpdateProductMedia(ctx context.Context, productID string, mediaID string, altText string) (*pb.UpdateProductMediaResponse, error) {
+	response, err := client.client.UpdateProductMedia(ctx, &pb.UpdateProductMediaRequest{
+		ProductId: productID,
+		MediaId:   mediaID,
+		AltText:   altText,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Remove Product Media
+func (client *CatalogClient) RemoveProductMedia(ctx context.Context, productID string, mediaID string) (*pb.RemoveProductMediaResponse, error) {
+	response, err := client.client.RemoveProductMedia(ctx, &pb.RemoveProductMediaRequest{
+		ProductId: productID,
+		MediaId:   mediaID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Reorder Product Media
+func (client *CatalogClient) ReorderProductMedia(ctx context.Context, productID string, mediaIDs []string) (*pb.ReorderProductMediaResponse, error) {
+	response, err := client.client.ReorderProductMedia(ctx, &pb.ReorderProductMediaRequest{
+		ProductId: productID,
+		MediaIds:  mediaIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Update Product Rating is called by the review service with a service token
+func (client *CatalogClient) UpdateProductRating(ctx context.Context, productID string, average float64, count uint64) error {
+	_, err := client.client.UpdateProductRating(ctx, &pb.UpdateProductRatingRequest{
+		ProductId: productID,
+		Rating: &pb.ProductRating{
+			Average: average,
+			Count:   count,
+		},
+	})
+	return err
+}
+
+// Get Price History of a product, latest change first
+func (client *CatalogClient) GetPriceHistory(ctx context.Context, productID string, skip uint64, take uint64) (*pb.GetPriceHistoryResponse, error) {
+	response, err := client.client.GetPriceHistory(ctx, &pb.GetPriceHistoryRequest{
+		ProductId: productID,
+		Skip:      skip,
+		Take:      take,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Create or Update Category
+func (client *CatalogClient) CreateOrUpdateCategory(ctx context.Context, category *Category) (*pb.CreateOrUpdateCategoryResponse, error) {
+	response, err := client.client.CreateOrUpdateCategory(ctx, &pb.CreateOrUpdateCategoryRequest{
+		Category: toProtoCategory(category),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Get Category
+func (client *CatalogClient) GetCategory(ctx context.Context, id string) (*pb.GetCategoryResponse, error) {
+	response, err := client.client.GetCategory(ctx, &pb.GetCategoryRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List Categories
+func (client *CatalogClient) ListCategories(ctx context.Context, skip uint64, take uint64) (*pb.ListCategoriesResponse, error) {
+	response, err := client.client.ListCategories(ctx, &pb.ListCategoriesRequest{
+		Skip: skip,
+		Take: take,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Get Synonyms
+func (client *CatalogClient) GetSynonyms(ctx context.Context, locale string) ([]string, error) {
+	response, err := client.client.GetSynonyms(ctx, &pb.GetSynonymsRequest{Locale: locale})
+	if err != nil {
+		return nil, err
+	}
+	return response.Rules, nil
+}
+
+// Update Synonyms
+func (client *CatalogClient) UpdateSynonyms(ctx context.Context, locale string, rules []string) error {
+	_, err := client.client.UpdateSynonyms(ctx, &pb.UpdateSynonymsRequest{
+		Locale: locale,
+		Rules:  rules,
+	})
+	return err
+}
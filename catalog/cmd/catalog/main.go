@@ -1,19 +1,41 @@
 package main
 
 import (
+	"context"
+	"expvar"
+	"fmt"
 	"log"
+	"net/http"
 	"time"
 
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/pricing"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/tinrab/retry"
 )
 
 type Config struct {
-	DatabaseUrl string `envconfig:"ELASTICSEARCH_URL"`
-	Port        int    `envconfig:"GRPC_PORT" default:"8080"`
-	LogLevel    string `envconfig:"LOG_LEVEL" default:"info"`
+	// Repository selects where the catalog is stored: elasticsearch,
+	// postgres or memory.
+	Repository       string        `envconfig:"CATALOG_REPOSITORY" default:"elasticsearch"`
+	DatabaseUrl      string        `envconfig:"ELASTICSEARCH_URL"`
+	PostgresUrl      string        `envconfig:"DATABASE_URL"`
+	AccountUrl       string        `envconfig:"ACCOUNT_SERVICE_URL"`
+	Port             int           `envconfig:"GRPC_PORT" default:"8080"`
+	LogLevel         string        `envconfig:"LOG_LEVEL" default:"info"`
+	RatesFile        string        `envconfig:"EXCHANGE_RATES_FILE"`
+	JwtSecret        string        `envconfig:"JWT_SECRET" default:"my-secret-key"`
+	ScheduleInterval time.Duration `envconfig:"PRODUCT_SCHEDULE_INTERVAL" default:"1m"`
+	// CacheSize is the number of products kept in the local cache; 0
+	// disables caching.
+	CacheSize          int           `envconfig:"CATALOG_CACHE_SIZE" default:"10000"`
+	CacheTTL           time.Duration `envconfig:"CATALOG_CACHE_TTL" default:"30s"`
+	RedisUrl           string        `envconfig:"CATALOG_REDIS_URL"`
+	RedisCacheTTL      time.Duration `envconfig:"CATALOG_REDIS_CACHE_TTL" default:"5m"`
+	CacheStatsInterval time.Duration `envconfig:"CATALOG_CACHE_STATS_INTERVAL" default:"5m"`
+	// MetricsPort serves the cache counters at /debug/vars; 0 turns it off.
+	MetricsPort int `envconfig:"CATALOG_METRICS_PORT" default:"9091"`
 }
 
 func main() {
@@ -23,18 +45,73 @@ func main() {
 		log.Fatal(err)
 	}
 	logger := util.NewLogger(config.LogLevel)
+	rates, err := pricing.LoadRateTable(config.RatesFile)
+	if err != nil {
+		logger.Service().Fatal().Err(err).Msg("failed to load exchange rates")
+	}
+	switch config.Repository {
+	case "elasticsearch", "postgres", "memory":
+	default:
+		logger.Service().Fatal().Str("repository", config.Repository).Msg("unknown catalog repository")
+	}
 	var repository catalog.Repository
+	var cache *catalog.CachedRepository
 	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
-		repository, err = catalog.NewElasticRepository(config.DatabaseUrl, logger)
+		repository, err = newRepository(config, logger)
 		if err != nil {
 			logger.Service().Error().Err(err).Msg("failed to connect to database")
 			return err
 		}
+		if config.CacheSize > 0 {
+			cache, err = catalog.NewCachedRepository(repository, catalog.CacheConfig{
+				Size:     config.CacheSize,
+				LocalTTL: config.CacheTTL,
+				RedisURL: config.RedisUrl,
+				RedisTTL: config.RedisCacheTTL,
+			}, logger)
+			if err != nil {
+				logger.Service().Error().Err(err).Msg("failed to connect to cache")
+				repository.Close()
+				return err
+			}
+			repository = cache
+		}
 		return nil
 	})
 	defer repository.Close()
 	logger.Service().Info().Msg("connected to database")
-	service := catalog.NewCatalogService(repository)
+	service := catalog.NewCatalogService(repository, pricing.NewPricingService(rates))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go catalog.RunProductScheduler(ctx, service, config.ScheduleInterval, logger)
+	if cache != nil {
+		go catalog.RunCacheStatsLogger(ctx, cache, config.CacheStatsInterval, logger)
+		cache.PublishStats("catalog_cache")
+	}
+	if config.MetricsPort > 0 {
+		go serveMetrics(config.MetricsPort, logger)
+	}
 	logger.Service().Info().Int("port", config.Port).Msg("starting catalog service")
-	logger.Service().Fatal().Err(catalog.ListenGrpcServer(service, logger, config.Port)).Msg("failed to start gRPC server")
+	logger.Service().Fatal().Err(catalog.ListenGrpcServer(service, config.AccountUrl, config.JwtSecret, logger, config.Port)).Msg("failed to start gRPC server")
+}
+
+func serveMetrics(port int, logger util.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/vars", expvar.Handler())
+	logger.Service().Info().Int("port", port).Msg("serving metrics")
+	if err := http.ListenAndServe(fmt.Sprintf(":%d", port), mux); err != nil {
+		logger.Service().Error().Err(err).Msg("failed to serve metrics")
+	}
+}
+
+func newRepository(config Config, logger util.Logger) (catalog.Repository, error) {
+	switch config.Repository {
+	case "elasticsearch":
+		return catalog.NewElasticRepository(config.DatabaseUrl, logger)
+	case "postgres":
+		return catalog.NewPostgresRepository(config.PostgresUrl, logger)
+	case "memory":
+		return catalog.NewMemoryRepository(), nil
+	}
+	return nil, fmt.Errorf("unknown catalog repository %q", config.Repository)
 }
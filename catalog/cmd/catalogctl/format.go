@@ -0,0 +1,367 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+// csvHeader lists the CSV columns. Prices are decimal amounts in major units;
+// the prices column holds other-currency prices as "17.99 EUR;15.00 GBP".
+// The attributes column holds "material=steel;weight=1.5". merchant_id is
+// exported for reference and ignored on import. weight is in grams. Rows with a version only
+// update the product if it has not changed since it was exported.
+// compare_at_price is in the price's currency, as are scheduled_prices,
+// written "2026-11-27T00:00:00Z=79.99/99.99;2026-12-01T00:00:00Z=99.99" with
+// an optional compare-at price after the slash. Scheduled prices in CSV have
+// no other-currency prices. Translations follow in name_LOCALE and
+// description_LOCALE columns.
+var csvHeader = append([]string{
+	"id", "name", "description", "price", "currency", "prices",
+	"compare_at_price", "scheduled_prices",
+	"status", "publish_at", "unpublish_at", "merchant_id",
+	"category_id", "attributes", "tax_category", "weight", "version",
+}, translationColumns()...)
+
+func translationColumns() []string {
+	columns := []string{}
+	for _, locale := range translationLocales() {
+		columns = append(columns, "name_"+locale, "description_"+locale)
+	}
+	return columns
+}
+
+func translationLocales() []string {
+	locales := []string{}
+	for _, locale := range catalog.Locales {
+		if locale != catalog.DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+	line    uint64
+}
+
+func newCSVReader(r io.Reader) *csvReader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	return &csvReader{reader: reader}
+}
+
+func (r *csvReader) Read() (*catalog.ImportRow, error) {
+	if r.columns == nil {
+		header, err := r.reader.Read()
+		if err != nil {
+			return nil, err
+		}
+		r.line++
+		r.columns = map[string]int{}
+		for i, name := range header {
+			r.columns[strings.TrimSpace(name)] = i
+		}
+		for _, required := range []string{"name", "price", "currency"} {
+			if _, ok := r.columns[required]; !ok {
+				return nil, fmt.Errorf("csv header is missing the %q column", required)
+			}
+		}
+	}
+	record, err := r.reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	r.line++
+	field := func(name string) string {
+		if i, ok := r.columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	product, err := parseCSVProduct(field)
+	if err != nil {
+		return nil, fmt.Errorf("line %d: %w", r.line, err)
+	}
+	return &catalog.ImportRow{Row: r.line, Product: product}, nil
+}
+
+func parseCSVProduct(field func(string) string) (*catalog.Product, error) {
+	price, err := money.Parse(field("price"), field("currency"))
+	if err != nil {
+		return nil, err
+	}
+	prices := []money.Money{}
+	for _, listed := range strings.Split(field("prices"), ";") {
+		listed = strings.TrimSpace(listed)
+		if listed == "" {
+			continue
+		}
+		amount, currency, ok := strings.Cut(listed, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid price %q, expected e.g. \"17.99 EUR\"", listed)
+		}
+		parsed, err := money.Parse(amount, strings.TrimSpace(currency))
+		if err != nil {
+			return nil, err
+		}
+		prices = append(prices, parsed)
+	}
+	compareAtPrice, err := parseOptionalPrice(field("compare_at_price"), price.Currency)
+	if err != nil {
+		return nil, err
+	}
+	scheduledPrices := []*catalog.ScheduledPrice{}
+	for _, scheduled := range strings.Split(field("scheduled_prices"), ";") {
+		scheduled = strings.TrimSpace(scheduled)
+		if scheduled == "" {
+			continue
+		}
+		at, amounts, ok := strings.Cut(scheduled, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid scheduled price %q, expected e.g. \"2026-11-27T00:00:00Z=79.99/99.99\"", scheduled)
+		}
+		effectiveFrom, err := parseTime(strings.TrimSpace(at))
+		if err != nil || effectiveFrom == nil {
+			return nil, fmt.Errorf("invalid scheduled price %q, expected e.g. \"2026-11-27T00:00:00Z=79.99/99.99\"", scheduled)
+		}
+		amount, compareAt, _ := strings.Cut(amounts, "/")
+		scheduledPrice, err := money.Parse(strings.TrimSpace(amount), price.Currency)
+		if err != nil {
+			return nil, err
+		}
+		scheduledCompareAt, err := parseOptionalPrice(compareAt, price.Currency)
+		if err != nil {
+			return nil, err
+		}
+		scheduledPrices = append(scheduledPrices, &catalog.ScheduledPrice{
+			EffectiveFrom:  *effectiveFrom,
+			Price:          scheduledPrice,
+			CompareAtPrice: scheduledCompareAt,
+		})
+	}
+	publishAt, err := parseTime(field("publish_at"))
+	if err != nil {
+		return nil, err
+	}
+	unpublishAt, err := parseTime(field("unpublish_at"))
+	if err != nil {
+		return nil, err
+	}
+	attributes := []*catalog.ProductAttribute{}
+	for _, attribute := range strings.Split(field("attributes"), ";") {
+		attribute = strings.TrimSpace(attribute)
+		if attribute == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(attribute, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid attribute %q, expected e.g. \"material=steel\"", attribute)
+		}
+		attributes = append(attributes, &catalog.ProductAttribute{
+			Name:  strings.TrimSpace(name),
+			Value: strings.TrimSpace(value),
+		})
+	}
+	var weight uint64
+	if field("weight") != "" {
+		weight, err = strconv.ParseUint(field("weight"), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q, expected grams", field("weight"))
+		}
+	}
+	translations := map[string]*catalog.ProductTranslation{}
+	for _, locale := range translationLocales() {
+		name, description := field("name_"+locale), field("description_"+locale)
+		if name != "" || description != "" {
+			translations[locale] = &catalog.ProductTranslation{Name: name, Description: description}
+		}
+	}
+	return &catalog.Product{
+		ID:              field("id"),
+		Name:            field("name"),
+		Description:     field("description"),
+		Price:           price,
+		Prices:          prices,
+		CompareAtPrice:  compareAtPrice,
+		ScheduledPrices: scheduledPrices,
+		Status:          catalog.ProductStatus(field("status")),
+		PublishAt:       publishAt,
+		UnpublishAt:     unpublishAt,
+		CategoryID:      field("category_id"),
+		Attributes:      attributes,
+		TaxCategory:     field("tax_category"),
+		Weight:          uint32(weight),
+		Translations:    translations,
+		Version:         field("version"),
+	}, nil
+}
+
+func parseOptionalPrice(amount string, currency string) (*money.Money, error) {
+	amount = strings.TrimSpace(amount)
+	if amount == "" {
+		return nil, nil
+	}
+	price, err := money.Parse(amount, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %q, expected RFC 3339", value)
+	}
+	return &t, nil
+}
+
+type csvWriter struct {
+	writer      *csv.Writer
+	wroteHeader bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{writer: csv.NewWriter(w)}
+}
+
+func (w *csvWriter) Write(product *catalog.Product) error {
+	if !w.wroteHeader {
+		if err := w.writer.Write(csvHeader); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	prices := []string{}
+	for _, listed := range product.Prices {
+		prices = append(prices, listed.String())
+	}
+	compareAtPrice := ""
+	if product.CompareAtPrice != nil {
+		compareAtPrice = product.CompareAtPrice.Decimal()
+	}
+	scheduledPrices := []string{}
+	for _, scheduled := range product.ScheduledPrices {
+		entry := formatTime(&scheduled.EffectiveFrom) + "=" + scheduled.Price.Decimal()
+		if scheduled.CompareAtPrice != nil {
+			entry += "/" + scheduled.CompareAtPrice.Decimal()
+		}
+		scheduledPrices = append(scheduledPrices, entry)
+	}
+	attributes := []string{}
+	for _, attribute := range product.Attributes {
+		attributes = append(attributes, attribute.Name+"="+attribute.Value)
+	}
+	weight := ""
+	if product.Weight > 0 {
+		weight = strconv.FormatUint(uint64(product.Weight), 10)
+	}
+	record := []string{
+		product.ID,
+		product.Name,
+		product.Description,
+		product.Price.Decimal(),
+		product.Price.Currency,
+		strings.Join(prices, ";"),
+		compareAtPrice,
+		strings.Join(scheduledPrices, ";"),
+		string(product.Status),
+		formatTime(product.PublishAt),
+		formatTime(product.UnpublishAt),
+		product.MerchantID,
+		product.CategoryID,
+		strings.Join(attributes, ";"),
+		product.TaxCategory,
+		weight,
+		product.Version,
+	}
+	for _, locale := range translationLocales() {
+		translation, ok := product.Translations[locale]
+		if !ok {
+			translation = &catalog.ProductTranslation{}
+		}
+		record = append(record, translation.Name, translation.Description)
+	}
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Flush() error {
+	if !w.wroteHeader {
+		if err := w.writer.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// ndjsonReader reads one catalog.Product JSON object per line.
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    uint64
+}
+
+func newNDJSONReader(r io.Reader) *ndjsonReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	return &ndjsonReader{scanner: scanner}
+}
+
+func (r *ndjsonReader) Read() (*catalog.ImportRow, error) {
+	for r.scanner.Scan() {
+		r.line++
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+		product := &catalog.Product{}
+		if err := json.Unmarshal([]byte(line), product); err != nil {
+			return nil, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		return &catalog.ImportRow{Row: r.line, Product: product}, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+type ndjsonWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	writer := bufio.NewWriter(w)
+	return &ndjsonWriter{writer: writer, encoder: json.NewEncoder(writer)}
+}
+
+func (w *ndjsonWriter) Write(product *catalog.Product) error {
+	return w.encoder.Encode(product)
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return w.writer.Flush()
+}
@@ -0,0 +1,195 @@
+// Command catalogctl imports and exports catalog products as CSV or NDJSON,
+// and manages search synonyms.
+//
+//	catalogctl import [-format csv|ndjson] FILE
+//	catalogctl export [-format csv|ndjson] [-merchant ID] [-include-unpublished] [FILE]
+//	catalogctl synonyms [-locale LOCALE] [FILE]
+//
+// FILE may be "-" for standard input or output, and the format defaults to
+// the file extension. Imports need a merchant or admin access token in
+// CATALOG_ACCESS_TOKEN, and synonyms an admin access token.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	"github.com/kelseyhightower/envconfig"
+)
+
+type Config struct {
+	CatalogURL  string `envconfig:"CATALOG_SERVICE_URL" default:"localhost:50052"`
+	AccessToken string `envconfig:"CATALOG_ACCESS_TOKEN"`
+}
+
+func main() {
+	log.SetFlags(0)
+	var config Config
+	if err := envconfig.Process("", &config); err != nil {
+		log.Fatal(err)
+	}
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	client, err := catalog.NewCatalogClient(config.CatalogURL)
+	if err != nil {
+		log.Fatalf("failed to connect to catalog service: %v", err)
+	}
+	defer client.Close()
+	ctx := context.Background()
+	if config.AccessToken != "" {
+		ctx = util.ContextWithAccessToken(ctx, config.AccessToken)
+	}
+
+	switch os.Args[1] {
+	case "import":
+		err = runImport(ctx, client, os.Args[2:])
+	case "export":
+		err = runExport(ctx, client, os.Args[2:])
+	case "synonyms":
+		err = runSynonyms(ctx, client, os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: catalogctl import [-format csv|ndjson] FILE")
+	fmt.Fprintln(os.Stderr, "       catalogctl export [-format csv|ndjson] [-merchant ID] [-include-unpublished] [FILE]")
+	fmt.Fprintln(os.Stderr, "       catalogctl synonyms [-locale LOCALE] [FILE]")
+	os.Exit(2)
+}
+
+func runImport(ctx context.Context, client *catalog.CatalogClient, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	format := flags.String("format", "", "file format: csv or ndjson")
+	flags.Parse(args)
+	if flags.NArg() != 1 {
+		usage()
+	}
+	path := flags.Arg(0)
+
+	input := os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	reader, err := newReader(formatFor(*format, path), input)
+	if err != nil {
+		return err
+	}
+
+	response, err := client.ImportProducts(ctx, reader.Read)
+	if err != nil {
+		return fmt.Errorf("import failed: %w", err)
+	}
+	for _, importError := range response.Errors {
+		fmt.Fprintf(os.Stderr, "row %d: %s\n", importError.Row, importError.Message)
+	}
+	fmt.Fprintf(os.Stderr, "imported %d products, %d failed\n", response.Imported, len(response.Errors))
+	if len(response.Errors) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func runExport(ctx context.Context, client *catalog.CatalogClient, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	format := flags.String("format", "", "file format: csv or ndjson")
+	merchantID := flags.String("merchant", "", "only export products owned by this merchant")
+	includeUnpublished := flags.Bool("include-unpublished", false, "also export drafts and archived products")
+	flags.Parse(args)
+	if flags.NArg() > 1 {
+		usage()
+	}
+	path := "-"
+	if flags.NArg() == 1 {
+		path = flags.Arg(0)
+	}
+
+	output := os.Stdout
+	if path != "-" {
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+	writer, err := newWriter(formatFor(*format, path), output)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	err = client.ExportProducts(ctx, *merchantID, *includeUnpublished, func(product *catalog.Product) error {
+		count++
+		return writer.Write(product)
+	})
+	if err != nil {
+		return fmt.Errorf("export failed: %w", err)
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "exported %d products\n", count)
+	return nil
+}
+
+// formatFor returns the explicit format, or the one implied by the file
+// extension, defaulting to NDJSON.
+func formatFor(format string, path string) string {
+	if format != "" {
+		return format
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return "csv"
+	}
+	return "ndjson"
+}
+
+type productReader interface {
+	// Read returns the next product and its row, or io.EOF.
+	Read() (*catalog.ImportRow, error)
+}
+
+type productWriter interface {
+	Write(product *catalog.Product) error
+	Flush() error
+}
+
+func newReader(format string, r io.Reader) (productReader, error) {
+	switch format {
+	case "csv":
+		return newCSVReader(r), nil
+	case "ndjson":
+		return newNDJSONReader(r), nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func newWriter(format string, w io.Writer) (productWriter, error) {
+	switch format {
+	case "csv":
+		return newCSVWriter(w), nil
+	case "ndjson":
+		return newNDJSONWriter(w), nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+)
+
+// runSynonyms prints the synonym rules of a locale or, given a file of rules,
+// one per line, replaces them. Blank lines and lines starting with # are
+// skipped.
+func runSynonyms(ctx context.Context, client *catalog.CatalogClient, args []string) error {
+	flags := flag.NewFlagSet("synonyms", flag.ExitOnError)
+	locale := flags.String("locale", catalog.DefaultLocale, "locale of the synonyms")
+	flags.Parse(args)
+	if flags.NArg() > 1 {
+		usage()
+	}
+
+	if flags.NArg() == 0 {
+		rules, err := client.GetSynonyms(ctx, *locale)
+		if err != nil {
+			return fmt.Errorf("failed to get synonyms: %w", err)
+		}
+		for _, rule := range rules {
+			fmt.Println(rule)
+		}
+		return nil
+	}
+
+	input := os.Stdin
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	rules, err := readSynonyms(input)
+	if err != nil {
+		return err
+	}
+	if err := client.UpdateSynonyms(ctx, *locale, rules); err != nil {
+		return fmt.Errorf("failed to update synonyms: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "updated %d %s synonym rules\n", len(rules), *locale)
+	return nil
+}
+
+func readSynonyms(r io.Reader) ([]string, error) {
+	rules := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rules = append(rules, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
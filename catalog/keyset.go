@@ -0,0 +1,41 @@
+package catalog
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+// keysetCursor is the state behind the cursors of repositories that page by
+// sort key rather than through a point in time: the listing it belongs to,
+// the sort key of the product the cursor points at and its position in the
+// listing. Listings are sorted by rating when asked to, then by relevance
+// when searching, then by id.
+type keysetCursor struct {
+	Listing       string  `json:"listing,omitempty"`
+	RatingAverage float64 `json:"rating_average,omitempty"`
+	RatingCount   uint64  `json:"rating_count,omitempty"`
+	Score         float64 `json:"score,omitempty"`
+	ID            string  `json:"id"`
+	Offset        uint64  `json:"offset"`
+}
+
+func encodeKeysetCursor(cursor *keysetCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeKeysetCursor returns the state behind a cursor of listing.
+func decodeKeysetCursor(cursor string, listing string) (*keysetCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	state := &keysetCursor{}
+	if err := json.Unmarshal(data, state); err != nil || state.Listing != listing || state.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return state, nil
+}
@@ -0,0 +1,564 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+// MemoryRepository keeps the catalog in memory, for tests and small
+// deployments. Search matches query words as substrings of product text,
+// without stemming, and ranks products by the number of words they contain.
+type MemoryRepository struct {
+	mutex        sync.RWMutex
+	products     map[string]*memoryProduct
+	categories   map[string][]byte
+	priceChanges []*PriceChange
+	synonyms     map[string][]string
+}
+
+// memoryProduct holds a product as its encoded document, so that callers
+// never share data with the repository, and decoded for filtering.
+type memoryProduct struct {
+	source   []byte
+	document ProductDocument
+	version  uint64
+}
+
+func NewMemoryRepository() Repository {
+	return &MemoryRepository{
+		products:   map[string]*memoryProduct{},
+		categories: map[string][]byte{},
+		synonyms:   map[string][]string{},
+	}
+}
+
+func (repository *MemoryRepository) Close() {
+}
+
+func (repository *MemoryRepository) CreateOrUpdateProduct(ctx context.Context, product *Product) (*Product, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	if err := repository.write(product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// write stores product if its version matches, like ElasticRepository, and
+// sets its new version. The caller holds the write lock.
+func (repository *MemoryRepository) write(product *Product) error {
+	stored, exists := repository.products[product.ID]
+	var version uint64
+	if product.Version != "" {
+		var err error
+		version, err = strconv.ParseUint(product.Version, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid product version %q", product.Version)
+		}
+	}
+	if exists != (product.Version != "") || exists && stored.version != version {
+		return fmt.Errorf("%w: product %s", ErrVersionConflict, product.ID)
+	}
+	document := toProductDocument(product)
+	source, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	repository.products[product.ID] = &memoryProduct{source: source, document: document, version: version + 1}
+	product.Version = strconv.FormatUint(version+1, 10)
+	return nil
+}
+
+func (repository *MemoryRepository) GetProductById(ctx context.Context, id string) (*Product, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	stored, ok := repository.products[id]
+	if !ok {
+		return nil, ErrProductNotFound
+	}
+	return stored.decode(id)
+}
+
+func (repository *MemoryRepository) ListProducts(ctx context.Context, skip uint64, take uint64, cursor string, visibility Visibility, filter ProductFilter) (*ProductPage, error) {
+	listing, err := listingKey("list", visibility, filter)
+	if err != nil {
+		return nil, err
+	}
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	return repository.page(listing, skip, take, cursor, filter.Sort, func(stored *memoryProduct) (float64, bool) {
+		return 0, stored.visible(visibility) && stored.matches(filter)
+	})
+}
+
+func (repository *MemoryRepository) ListProductsWithIds(ctx context.Context, ids []string) ([]*Product, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	products := []*Product{}
+	for _, id := range ids {
+		stored, ok := repository.products[id]
+		if !ok {
+			continue
+		}
+		product, err := stored.decode(id)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+func (repository *MemoryRepository) SearchProducts(ctx context.Context, query string, skip uint64, take uint64, cursor string, visibility Visibility, filter ProductFilter) (*ProductPage, error) {
+	listing, err := listingKey("search", query, visibility, filter)
+	if err != nil {
+		return nil, err
+	}
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	rules := repository.synonyms[DefaultLocale]
+	if filter.Locale != "" && filter.Locale != DefaultLocale {
+		rules = append(append([]string{}, rules...), repository.synonyms[filter.Locale]...)
+	}
+	words := queryWords(expandSynonyms(query, rules))
+	return repository.page(listing, skip, take, cursor, filter.Sort, func(stored *memoryProduct) (float64, bool) {
+		if !stored.visible(visibility) || !stored.matches(filter) {
+			return 0, false
+		}
+		score := countContained(stored.text(filter.Locale), words)
+		return float64(score), score > 0
+	})
+}
+
+func (repository *MemoryRepository) ListProductsByMerchant(ctx context.Context, merchantID string, skip uint64, take uint64, visibility Visibility) ([]*Product, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	return repository.list(skip, take, func(stored *memoryProduct) bool {
+		return stored.visible(visibility) && stored.document.MerchantID == merchantID
+	})
+}
+
+// ListRelatedProducts scores products by the words of at least three letters
+// they share with the product's name and description, adding two for the
+// same category.
+func (repository *MemoryRepository) ListRelatedProducts(ctx context.Context, product *Product, take uint64, visibility Visibility) ([]*Product, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	words := []string{}
+	for _, word := range queryWords([]string{product.Name + " " + product.Description}) {
+		if len([]rune(word)) >= 3 {
+			words = append(words, word)
+		}
+	}
+	keys := []*keysetCursor{}
+	for id, stored := range repository.products {
+		if id == product.ID || !stored.visible(visibility) {
+			continue
+		}
+		score := countContained(stored.text(""), words)
+		if score == 0 {
+			continue
+		}
+		if product.CategoryID != "" && stored.document.CategoryID == product.CategoryID {
+			score += 2
+		}
+		keys = append(keys, &keysetCursor{ID: id, Score: float64(score)})
+	}
+	sortKeys(keys, ProductSortRelevance)
+	if uint64(len(keys)) > take {
+		keys = keys[:take]
+	}
+	return repository.decodeAll(keys)
+}
+
+func (repository *MemoryRepository) ListDueProducts(ctx context.Context, at time.Time, take uint64) ([]*Product, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	return repository.list(0, take, func(stored *memoryProduct) bool {
+		document := stored.document
+		if document.DeletedAt != nil {
+			return false
+		}
+		if document.Status == string(ProductStatusDraft) && document.PublishAt != nil && !document.PublishAt.After(at) {
+			return true
+		}
+		if document.Status == string(ProductStatusPublished) && document.UnpublishAt != nil && !document.UnpublishAt.After(at) {
+			return true
+		}
+		for _, scheduled := range document.ScheduledPrices {
+			if !scheduled.EffectiveFrom.After(at) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (repository *MemoryRepository) BulkCreateOrUpdateProducts(ctx context.Context, products []*Product) ([]error, error) {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	errs := make([]error, len(products))
+	for i, product := range products {
+		errs[i] = repository.write(product)
+	}
+	return errs, nil
+}
+
+func (repository *MemoryRepository) ExportProducts(ctx context.Context, merchantID string, visibility Visibility, fn func(*Product) error) error {
+	repository.mutex.RLock()
+	products, err := repository.list(0, uint64(len(repository.products)), func(stored *memoryProduct) bool {
+		return stored.visible(visibility) && (merchantID == "" || stored.document.MerchantID == merchantID)
+	})
+	repository.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	for _, product := range products {
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (repository *MemoryRepository) UpdateProductRating(ctx context.Context, id string, rating ProductRating) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	stored, ok := repository.products[id]
+	if !ok {
+		return ErrProductNotFound
+	}
+	document := stored.document
+	document.RatingAverage = rating.Average
+	document.RatingCount = rating.Count
+	source, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	repository.products[id] = &memoryProduct{source: source, document: document, version: stored.version + 1}
+	return nil
+}
+
+func (repository *MemoryRepository) AddPriceChanges(ctx context.Context, changes []*PriceChange) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	for _, change := range changes {
+		stored := *change
+		stored.Prices = append([]money.Money{}, change.Prices...)
+		stored.CompareAtPrice = copyPrice(change.CompareAtPrice)
+		repository.priceChanges = append(repository.priceChanges, &stored)
+	}
+	return nil
+}
+
+func (repository *MemoryRepository) ListPriceChanges(ctx context.Context, productID string, skip uint64, take uint64) ([]*PriceChange, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	changes := []*PriceChange{}
+	for _, change := range repository.priceChanges {
+		if change.ProductID == productID {
+			changes = append(changes, change)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].EffectiveFrom.After(changes[j].EffectiveFrom)
+	})
+	changes = pageOf(changes, skip, take)
+	for i, change := range changes {
+		copied := *change
+		copied.Prices = append([]money.Money{}, change.Prices...)
+		copied.CompareAtPrice = copyPrice(change.CompareAtPrice)
+		changes[i] = &copied
+	}
+	return changes, nil
+}
+
+func (repository *MemoryRepository) CreateOrUpdateCategory(ctx context.Context, category *Category) (*Category, error) {
+	source, err := json.Marshal(CategoryDocument{Name: category.Name, Attributes: category.Attributes})
+	if err != nil {
+		return nil, err
+	}
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	repository.categories[category.ID] = source
+	return category, nil
+}
+
+func (repository *MemoryRepository) GetCategoryById(ctx context.Context, id string) (*Category, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	source, ok := repository.categories[id]
+	if !ok {
+		return nil, ErrCategoryNotFound
+	}
+	return decodeCategory(id, source)
+}
+
+func (repository *MemoryRepository) ListCategories(ctx context.Context, skip uint64, take uint64) ([]*Category, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	categories := []*Category{}
+	for id, source := range repository.categories {
+		category, err := decodeCategory(id, source)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Name != categories[j].Name {
+			return categories[i].Name < categories[j].Name
+		}
+		return categories[i].ID < categories[j].ID
+	})
+	return pageOf(categories, skip, take), nil
+}
+
+func (repository *MemoryRepository) GetSynonyms(ctx context.Context, locale string) ([]string, error) {
+	repository.mutex.RLock()
+	defer repository.mutex.RUnlock()
+	return append([]string{}, repository.synonyms[locale]...), nil
+}
+
+func (repository *MemoryRepository) UpdateSynonyms(ctx context.Context, locale string, rules []string) error {
+	repository.mutex.Lock()
+	defer repository.mutex.Unlock()
+	repository.synonyms[locale] = append([]string{}, rules...)
+	return nil
+}
+
+// page lists the products match accepts, with the score it gives them, in
+// the given order, rejecting cursors of other listings than listing. The
+// caller holds the read lock.
+func (repository *MemoryRepository) page(listing string, skip uint64, take uint64, cursor string, order ProductSort, match func(*memoryProduct) (float64, bool)) (*ProductPage, error) {
+	state := &keysetCursor{Offset: skip}
+	if cursor != "" {
+		var err error
+		state, err = decodeKeysetCursor(cursor, listing)
+		if err != nil {
+			return nil, err
+		}
+	}
+	keys := []*keysetCursor{}
+	for id, stored := range repository.products {
+		score, ok := match(stored)
+		if !ok {
+			continue
+		}
+		keys = append(keys, &keysetCursor{
+			RatingAverage: stored.document.RatingAverage,
+			RatingCount:   stored.document.RatingCount,
+			Score:         score,
+			ID:            id,
+		})
+	}
+	sortKeys(keys, order)
+	start := skip
+	if cursor != "" {
+		start = uint64(len(keys))
+		for i, key := range keys {
+			if compareKeys(key, state, order) > 0 {
+				start = uint64(i)
+				break
+			}
+		}
+	}
+	page := &ProductPage{
+		Products:   []*Product{},
+		Cursors:    []string{},
+		TotalCount: uint64(len(keys)),
+	}
+	offset := state.Offset
+	for _, key := range pageOf(keys, start, take) {
+		product, err := repository.products[key.ID].decode(key.ID)
+		if err != nil {
+			return nil, err
+		}
+		offset++
+		key.Listing = listing
+		key.Offset = offset
+		keyCursor, err := encodeKeysetCursor(key)
+		if err != nil {
+			return nil, err
+		}
+		page.Products = append(page.Products, product)
+		page.Cursors = append(page.Cursors, keyCursor)
+	}
+	if len(page.Cursors) > 0 && offset < page.TotalCount {
+		page.NextCursor = page.Cursors[len(page.Cursors)-1]
+	}
+	return page, nil
+}
+
+// list returns the products match accepts in id order. The caller holds the
+// read lock.
+func (repository *MemoryRepository) list(skip uint64, take uint64, match func(*memoryProduct) bool) ([]*Product, error) {
+	keys := []*keysetCursor{}
+	for id, stored := range repository.products {
+		if match(stored) {
+			keys = append(keys, &keysetCursor{ID: id})
+		}
+	}
+	sortKeys(keys, ProductSortRelevance)
+	return repository.decodeAll(pageOf(keys, skip, take))
+}
+
+func (repository *MemoryRepository) decodeAll(keys []*keysetCursor) ([]*Product, error) {
+	products := []*Product{}
+	for _, key := range keys {
+		product, err := repository.products[key.ID].decode(key.ID)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+func (stored *memoryProduct) decode(id string) (*Product, error) {
+	product, err := decodeProduct(id, stored.source, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	product.Version = strconv.FormatUint(stored.version, 10)
+	return product, nil
+}
+
+func (stored *memoryProduct) visible(visibility Visibility) bool {
+	if stored.document.DeletedAt != nil {
+		return false
+	}
+	status := stored.document.Status
+	return visibility == VisibilityAll || status == "" || status == string(ProductStatusPublished)
+}
+
+// matches applies the category and attribute filters. Each attribute filter
+// must match a single attribute of the product.
+func (stored *memoryProduct) matches(filter ProductFilter) bool {
+	if filter.CategoryID != "" && stored.document.CategoryID != filter.CategoryID {
+		return false
+	}
+	for _, attributeFilter := range filter.Attributes {
+		matched := false
+		for _, attribute := range stored.document.Attributes {
+			if attribute.Name != attributeFilter.Name || attributeFilter.Value != "" && attribute.Value != attributeFilter.Value {
+				continue
+			}
+			if attributeFilter.Min != nil || attributeFilter.Max != nil {
+				if attribute.Number == nil ||
+					attributeFilter.Min != nil && *attribute.Number < *attributeFilter.Min ||
+					attributeFilter.Max != nil && *attribute.Number > *attributeFilter.Max {
+					continue
+				}
+			}
+			matched = true
+			break
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// text returns the lowercased text search matches: the name and description
+// and, for another locale, their translations into it.
+func (stored *memoryProduct) text(locale string) string {
+	text := stored.document.Name + " " + stored.document.Description
+	if translation, ok := stored.document.Translations[locale]; ok && locale != DefaultLocale {
+		text += " " + translation.Name + " " + translation.Description
+	}
+	return strings.ToLower(text)
+}
+
+// queryWords returns the distinct lowercased words of queries, without
+// surrounding punctuation.
+func queryWords(queries []string) []string {
+	words := []string{}
+	seen := map[string]bool{}
+	for _, query := range queries {
+		for _, word := range strings.Fields(strings.ToLower(query)) {
+			word = strings.TrimFunc(word, func(r rune) bool {
+				return strings.ContainsRune(`.,;:!?"'()[]{}`, r)
+			})
+			if word != "" && !seen[word] {
+				seen[word] = true
+				words = append(words, word)
+			}
+		}
+	}
+	return words
+}
+
+func countContained(text string, words []string) int {
+	count := 0
+	for _, word := range words {
+		if strings.Contains(text, word) {
+			count++
+		}
+	}
+	return count
+}
+
+func sortKeys(keys []*keysetCursor, order ProductSort) {
+	sort.Slice(keys, func(i, j int) bool { return compareKeys(keys[i], keys[j], order) < 0 })
+}
+
+// compareKeys orders listings like ElasticRepository: by rating when sorting
+// by it, then by score, then by id.
+func compareKeys(a *keysetCursor, b *keysetCursor, order ProductSort) int {
+	if order == ProductSortRating {
+		if a.RatingAverage != b.RatingAverage {
+			return descending(a.RatingAverage > b.RatingAverage)
+		}
+		if a.RatingCount != b.RatingCount {
+			return descending(a.RatingCount > b.RatingCount)
+		}
+	}
+	if a.Score != b.Score {
+		return descending(a.Score > b.Score)
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+func descending(greater bool) int {
+	if greater {
+		return -1
+	}
+	return 1
+}
+
+func pageOf[T any](values []T, skip uint64, take uint64) []T {
+	if skip >= uint64(len(values)) {
+		return []T{}
+	}
+	values = values[skip:]
+	if take < uint64(len(values)) {
+		values = values[:take]
+	}
+	return values
+}
+
+func copyPrice(price *money.Money) *money.Money {
+	if price == nil {
+		return nil
+	}
+	copied := *price
+	return &copied
+}
+
+func decodeCategory(id string, source []byte) (*Category, error) {
+	document := CategoryDocument{}
+	if err := json.Unmarshal(source, &document); err != nil {
+		return nil, err
+	}
+	return toCategory(id, document), nil
+}
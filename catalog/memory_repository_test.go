@@ -0,0 +1,46 @@
+package catalog_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog/repositorytest"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	repository := catalog.NewMemoryRepository()
+	defer repository.Close()
+	repositorytest.TestRepository(t, repository)
+}
+
+// TestPostgresRepository needs DATABASE_URL to point at a database with the
+// schema in catalog/up.sql, set aside for the checks.
+func TestPostgresRepository(t *testing.T) {
+	url := os.Getenv("DATABASE_URL")
+	if url == "" {
+		t.Skip("DATABASE_URL is not set")
+	}
+	repository, err := catalog.NewPostgresRepository(url, util.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	defer repository.Close()
+	repositorytest.TestRepository(t, repository)
+}
+
+// TestElasticRepository needs ELASTICSEARCH_URL to point at a cluster set
+// aside for the checks.
+func TestElasticRepository(t *testing.T) {
+	url := os.Getenv("ELASTICSEARCH_URL")
+	if url == "" {
+		t.Skip("ELASTICSEARCH_URL is not set")
+	}
+	repository, err := catalog.NewElasticRepository(url, util.NewLogger("error"))
+	if err != nil {
+		t.Fatalf("failed to connect to Elasticsearch: %v", err)
+	}
+	defer repository.Close()
+	repositorytest.TestRepository(t, repository)
+}
@@ -1,14 +1,324 @@
 package catalog
 
+import (
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/pricing"
+)
+
+type ProductStatus string
+
+const (
+	ProductStatusDraft     ProductStatus = "draft"
+	ProductStatusPublished ProductStatus = "published"
+	ProductStatusArchived  ProductStatus = "archived"
+)
+
+func (status ProductStatus) Valid() bool {
+	switch status {
+	case ProductStatusDraft, ProductStatusPublished, ProductStatusArchived:
+		return true
+	}
+	return false
+}
+
 type Product struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float32 `json:"price"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	// Prices lists explicit prices in other currencies, taking precedence over conversion.
+	Prices []money.Money `json:"prices"`
+	// CompareAtPrice is the was-price shown next to Price while the product
+	// is on sale. It is in Price's currency and higher than Price.
+	CompareAtPrice *money.Money `json:"compareAtPrice,omitempty"`
+	// ScheduledPrices replace the prices above once their time has passed;
+	// see ApplyProductSchedules.
+	ScheduledPrices []*ScheduledPrice `json:"scheduledPrices,omitempty"`
+	// MerchantID is the account that owns the product.
+	MerchantID string        `json:"merchantId"`
+	Status     ProductStatus `json:"status"`
+	// PublishAt publishes a draft and UnpublishAt archives a published
+	// product once the time has passed; see ApplyProductSchedules.
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+	// DeletedAt is set when the product is soft deleted.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// Media lists the product's images in display order.
+	Media []*ProductMedia `json:"media,omitempty"`
+	// Attributes must match the schema of the product's category, if it has
+	// one; products without a category may carry free-form string attributes.
+	CategoryID string              `json:"categoryId,omitempty"`
+	Attributes []*ProductAttribute `json:"attributes,omitempty"`
+	// TaxCategory selects the tax rates that apply to the product, such as
+	// "reduced" or "exempt". Empty is the standard rate.
+	TaxCategory string `json:"taxCategory,omitempty"`
+	// Weight is the shipping weight in grams; 0 is unknown and ships free of
+	// weight based fees.
+	Weight uint32 `json:"weight,omitempty"`
+	// Name and Description are in DefaultLocale; Translations holds them in
+	// other supported locales, keyed by locale.
+	Translations map[string]*ProductTranslation `json:"translations,omitempty"`
+	// Version identifies the stored revision of the product. Updates must carry
+	// the version they were based on and fail with ErrVersionConflict if the
+	// product has changed since.
+	Version string `json:"version,omitempty"`
+	// Rating aggregates the product's approved reviews. It is maintained by
+	// the review service and never changed by product updates.
+	Rating ProductRating `json:"-"`
+	// DisplayPrice, DisplayCompareAtPrice and ExchangeRate are resolved per
+	// request and never stored.
+	DisplayPrice          money.Money   `json:"-"`
+	DisplayCompareAtPrice *money.Money  `json:"-"`
+	ExchangeRate          *pricing.Rate `json:"-"`
+}
+
+// ScheduledPrice holds the prices a product takes on from EffectiveFrom.
+type ScheduledPrice struct {
+	EffectiveFrom  time.Time     `json:"effectiveFrom"`
+	Price          money.Money   `json:"price"`
+	Prices         []money.Money `json:"prices"`
+	CompareAtPrice *money.Money  `json:"compareAtPrice,omitempty"`
+}
+
+// PriceChange records the prices a product had from EffectiveFrom until the
+// next change.
+type PriceChange struct {
+	ID             string
+	ProductID      string
+	EffectiveFrom  time.Time
+	Price          money.Money
+	Prices         []money.Money
+	CompareAtPrice *money.Money
+}
+
+type ProductRating struct {
+	Average float64
+	Count   uint64
+}
+
+// ProductTranslation is a product's text in one locale. Empty fields fall
+// back to the default locale.
+type ProductTranslation struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// DefaultLocale is the language of a product's Name and Description.
+const DefaultLocale = "en"
+
+// Locales lists the languages product text is analyzed in, with stemming and
+// synonyms, starting with the default.
+var Locales = []string{DefaultLocale, "de", "fr", "es"}
+
+// ValidLocale reports whether locale is one of Locales.
+func ValidLocale(locale string) bool {
+	for _, supported := range Locales {
+		if locale == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// ProductMedia is an image of a product. The image and its thumbnail are kept
+// in a blob store under Key and ThumbnailKey.
+type ProductMedia struct {
+	ID           string `json:"id"`
+	Key          string `json:"key"`
+	URL          string `json:"url"`
+	ThumbnailKey string `json:"thumbnailKey"`
+	ThumbnailURL string `json:"thumbnailUrl"`
+	ContentType  string `json:"contentType"`
+	AltText      string `json:"altText"`
+	Width        uint32 `json:"width"`
+	Height       uint32 `json:"height"`
+}
+
+// ProductAttribute is a product spec such as material or weight. Value holds
+// the canonical text form of the value; Type and Unit come from the category's
+// attribute definition.
+type ProductAttribute struct {
+	Name  string        `json:"name"`
+	Value string        `json:"value"`
+	Type  AttributeType `json:"type,omitempty"`
+	Unit  string        `json:"unit,omitempty"`
+}
+
+type AttributeType string
+
+const (
+	AttributeTypeString  AttributeType = "string"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeEnum    AttributeType = "enum"
+	AttributeTypeBoolean AttributeType = "boolean"
+)
+
+// Category groups products and defines the attributes they may have.
+type Category struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Attributes []*AttributeDefinition `json:"attributes"`
+}
+
+type AttributeDefinition struct {
+	Name string        `json:"name"`
+	Type AttributeType `json:"type"`
+	// Unit, e.g. "kg", applies to number attributes.
+	Unit string `json:"unit,omitempty"`
+	// Values lists the allowed values of enum attributes.
+	Values   []string `json:"values,omitempty"`
+	Required bool     `json:"required"`
+}
+
+// AttributeFilter matches products with the named attribute equal to Value
+// or, for number attributes, within Min and Max.
+type AttributeFilter struct {
+	Name  string
+	Value string
+	Min   *float64
+	Max   *float64
+}
+
+type ProductSort string
+
+const (
+	// ProductSortRelevance orders search results by relevance and listings
+	// in index order.
+	ProductSortRelevance ProductSort = ""
+	// ProductSortRating puts the best rated products first.
+	ProductSortRating ProductSort = "rating"
+)
+
+func (sort ProductSort) Valid() bool {
+	return sort == ProductSortRelevance || sort == ProductSortRating
+}
+
+// ProductFilter narrows and orders product listings and search.
+type ProductFilter struct {
+	CategoryID string
+	Attributes []AttributeFilter
+	Sort       ProductSort
+	// Locale is the language search queries are written in. Text in that
+	// locale is searched along with the default locale's.
+	Locale string
+}
+
+// ProductPage is one page of a product listing.
+type ProductPage struct {
+	Products []*Product
+	// Cursors[i] continues the listing after Products[i].
+	Cursors []string
+	// NextCursor continues the listing after this page; it is empty on the
+	// last page.
+	NextCursor string
+	TotalCount uint64
+}
+
+// IsPublic reports whether the product is shown in public listings and search.
+func (product *Product) IsPublic() bool {
+	return product.Status == ProductStatusPublished && product.DeletedAt == nil
 }
 
 type ProductDocument struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float32 `json:"price"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	PriceAmount int64           `json:"price_amount"`
+	Currency    string          `json:"currency"`
+	Prices      []PriceDocument `json:"prices,omitempty"`
+	// CompareAtPrice and ScheduledPrices are in the same form as the price.
+	CompareAtPrice  *PriceDocument           `json:"compare_at_price,omitempty"`
+	ScheduledPrices []ScheduledPriceDocument `json:"scheduled_prices,omitempty"`
+	MerchantID      string                   `json:"merchant_id,omitempty"`
+	Status          string                   `json:"status,omitempty"`
+	PublishAt       *time.Time               `json:"publish_at,omitempty"`
+	UnpublishAt     *time.Time               `json:"unpublish_at,omitempty"`
+	DeletedAt       *time.Time               `json:"deleted_at,omitempty"`
+	Media           []MediaDocument          `json:"media,omitempty"`
+	CategoryID      string                   `json:"category_id,omitempty"`
+	Attributes      []AttributeDocument      `json:"attributes,omitempty"`
+	TaxCategory     string                   `json:"tax_category,omitempty"`
+	Weight          uint32                   `json:"weight,omitempty"`
+	// Translations is keyed by locale, each indexed with that locale's analyzer.
+	Translations map[string]TranslationDocument `json:"translations,omitempty"`
+	// RatingAverage and RatingCount are kept in the document so products
+	// can be sorted by rating.
+	RatingAverage float64 `json:"rating_average,omitempty"`
+	RatingCount   uint64  `json:"rating_count,omitempty"`
+	// LegacyPrice holds the float price of documents indexed before prices
+	// were stored in minor units. It is dropped the next time the product is written.
+	LegacyPrice *float64 `json:"price,omitempty"`
+}
+
+type MediaDocument struct {
+	ID           string `json:"id"`
+	Key          string `json:"key"`
+	URL          string `json:"url"`
+	ThumbnailKey string `json:"thumbnail_key"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	ContentType  string `json:"content_type"`
+	AltText      string `json:"alt_text"`
+	Width        uint32 `json:"width"`
+	Height       uint32 `json:"height"`
+}
+
+// AttributeDocument indexes the value both as text and, for number and
+// boolean attributes, as a typed field for filtering.
+type AttributeDocument struct {
+	Name    string   `json:"name"`
+	Value   string   `json:"value"`
+	Type    string   `json:"type,omitempty"`
+	Unit    string   `json:"unit,omitempty"`
+	Number  *float64 `json:"number,omitempty"`
+	Boolean *bool    `json:"boolean,omitempty"`
+}
+
+type TranslationDocument struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type CategoryDocument struct {
+	Name       string                 `json:"name"`
+	Attributes []*AttributeDefinition `json:"attributes"`
+}
+
+type PriceDocument struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+type ScheduledPriceDocument struct {
+	EffectiveFrom  time.Time       `json:"effective_from"`
+	PriceAmount    int64           `json:"price_amount"`
+	Currency       string          `json:"currency"`
+	Prices         []PriceDocument `json:"prices,omitempty"`
+	CompareAtPrice *PriceDocument  `json:"compare_at_price,omitempty"`
+}
+
+type PriceChangeDocument struct {
+	ProductID      string          `json:"product_id"`
+	EffectiveFrom  time.Time       `json:"effective_from"`
+	PriceAmount    int64           `json:"price_amount"`
+	Currency       string          `json:"currency"`
+	Prices         []PriceDocument `json:"prices,omitempty"`
+	CompareAtPrice *PriceDocument  `json:"compare_at_price,omitempty"`
+}
+
+// ImportRow is a product to import and the row it came from, used to report errors.
+type ImportRow struct {
+	Row     uint64
+	Product *Product
+}
+
+type ImportError struct {
+	Row       uint64 `json:"row"`
+	ProductID string `json:"productId,omitempty"`
+	Message   string `json:"message"`
+}
+
+type ImportResult struct {
+	Imported uint64        `json:"imported"`
+	Errors   []ImportError `json:"errors"`
 }
@@ -1,4 +1,4 @@
-// protoc --go_out=./pb --go-grpc_out=./pb catalog.proto
+// protoc -I. -I.. --go_out=./pb --go-grpc_out=./pb catalog.proto
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
@@ -9,8 +9,11 @@
 package __
 
 import (
+	pb "github.com/Asif-Faizal/Minimum-Viable-Shop/money/pb"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,11 +27,47 @@ const (
 )
 
 type Product struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float32                `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       *pb.Money              `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Prices      []*pb.Money            `protobuf:"bytes,5,rep,name=prices,proto3" json:"prices,omitempty"`
+	// display_price is the price resolved into the requested currency.
+	DisplayPrice *pb.Money `protobuf:"bytes,6,opt,name=display_price,json=displayPrice,proto3" json:"display_price,omitempty"`
+	// exchange_rate is set when display_price was converted from price.
+	ExchangeRate *pb.ExchangeRate `protobuf:"bytes,7,opt,name=exchange_rate,json=exchangeRate,proto3" json:"exchange_rate,omitempty"`
+	// merchant_id is the account that owns the product.
+	MerchantId string `protobuf:"bytes,8,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	// status is one of draft, published or archived.
+	Status      string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	PublishAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	UnpublishAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=unpublish_at,json=unpublishAt,proto3" json:"unpublish_at,omitempty"`
+	// deleted_at is set once the product has been soft deleted.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	// media lists the product's images in display order.
+	Media      []*ProductMedia     `protobuf:"bytes,13,rep,name=media,proto3" json:"media,omitempty"`
+	CategoryId string              `protobuf:"bytes,14,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Attributes []*ProductAttribute `protobuf:"bytes,15,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// version changes on every write; send it back to update the product.
+	Version string `protobuf:"bytes,16,opt,name=version,proto3" json:"version,omitempty"`
+	// rating aggregates the product's approved reviews.
+	Rating *ProductRating `protobuf:"bytes,17,opt,name=rating,proto3" json:"rating,omitempty"`
+	// translations holds name and description in other locales, keyed by locale.
+	Translations map[string]*ProductTranslation `protobuf:"bytes,18,rep,name=translations,proto3" json:"translations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// compare_at_price is the was-price shown next to price during a sale.
+	CompareAtPrice *pb.Money `protobuf:"bytes,19,opt,name=compare_at_price,json=compareAtPrice,proto3" json:"compare_at_price,omitempty"`
+	// display_compare_at_price is compare_at_price in the currency of
+	// display_price; it is not set when display_price comes from prices.
+	DisplayCompareAtPrice *pb.Money `protobuf:"bytes,20,opt,name=display_compare_at_price,json=displayCompareAtPrice,proto3" json:"display_compare_at_price,omitempty"`
+	// scheduled_prices replace price, prices and compare_at_price once their
+	// effective_from has passed, in order of effective_from.
+	ScheduledPrices []*ScheduledPrice `protobuf:"bytes,21,rep,name=scheduled_prices,json=scheduledPrices,proto3" json:"scheduled_prices,omitempty"`
+	// tax_category selects the tax rates that apply to the product; empty is
+	// the standard rate.
+	TaxCategory string `protobuf:"bytes,22,opt,name=tax_category,json=taxCategory,proto3" json:"tax_category,omitempty"`
+	// weight is the shipping weight in grams.
+	Weight        uint32 `protobuf:"varint,23,opt,name=weight,proto3" json:"weight,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -84,38 +123,2869 @@ func (x *Product) GetDescription() string {
 	return ""
 }
 
-func (x *Product) GetPrice() float32 {
+func (x *Product) GetPrice() *pb.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *Product) GetPrices() []*pb.Money {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *Product) GetDisplayPrice() *pb.Money {
+	if x != nil {
+		return x.DisplayPrice
+	}
+	return nil
+}
+
+func (x *Product) GetExchangeRate() *pb.ExchangeRate {
+	if x != nil {
+		return x.ExchangeRate
+	}
+	return nil
+}
+
+func (x *Product) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *Product) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Product) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *Product) GetUnpublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+func (x *Product) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Product) GetMedia() []*ProductMedia {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+func (x *Product) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *Product) GetAttributes() []*ProductAttribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Product) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Product) GetRating() *ProductRating {
+	if x != nil {
+		return x.Rating
+	}
+	return nil
+}
+
+func (x *Product) GetTranslations() map[string]*ProductTranslation {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *Product) GetCompareAtPrice() *pb.Money {
+	if x != nil {
+		return x.CompareAtPrice
+	}
+	return nil
+}
+
+func (x *Product) GetDisplayCompareAtPrice() *pb.Money {
+	if x != nil {
+		return x.DisplayCompareAtPrice
+	}
+	return nil
+}
+
+func (x *Product) GetScheduledPrices() []*ScheduledPrice {
+	if x != nil {
+		return x.ScheduledPrices
+	}
+	return nil
+}
+
+func (x *Product) GetTaxCategory() string {
+	if x != nil {
+		return x.TaxCategory
+	}
+	return ""
+}
+
+func (x *Product) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type ScheduledPrice struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	EffectiveFrom  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	Price          *pb.Money              `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Prices         []*pb.Money            `protobuf:"bytes,3,rep,name=prices,proto3" json:"prices,omitempty"`
+	CompareAtPrice *pb.Money              `protobuf:"bytes,4,opt,name=compare_at_price,json=compareAtPrice,proto3" json:"compare_at_price,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ScheduledPrice) Reset() {
+	*x = ScheduledPrice{}
+	mi := &file_catalog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledPrice) ProtoMessage() {}
+
+func (x *ScheduledPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledPrice.ProtoReflect.Descriptor instead.
+func (*ScheduledPrice) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScheduledPrice) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *ScheduledPrice) GetPrice() *pb.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ScheduledPrice) GetPrices() []*pb.Money {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *ScheduledPrice) GetCompareAtPrice() *pb.Money {
+	if x != nil {
+		return x.CompareAtPrice
+	}
+	return nil
+}
+
+// PriceChange records the prices a product had from effective_from until the
+// next change.
+type PriceChange struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId      string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	EffectiveFrom  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
+	Price          *pb.Money              `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Prices         []*pb.Money            `protobuf:"bytes,5,rep,name=prices,proto3" json:"prices,omitempty"`
+	CompareAtPrice *pb.Money              `protobuf:"bytes,6,opt,name=compare_at_price,json=compareAtPrice,proto3" json:"compare_at_price,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PriceChange) Reset() {
+	*x = PriceChange{}
+	mi := &file_catalog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceChange) ProtoMessage() {}
+
+func (x *PriceChange) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceChange.ProtoReflect.Descriptor instead.
+func (*PriceChange) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PriceChange) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PriceChange) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *PriceChange) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *PriceChange) GetPrice() *pb.Money {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return nil
+}
+
+func (x *PriceChange) GetPrices() []*pb.Money {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *PriceChange) GetCompareAtPrice() *pb.Money {
+	if x != nil {
+		return x.CompareAtPrice
+	}
+	return nil
+}
+
+type ProductTranslation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductTranslation) Reset() {
+	*x = ProductTranslation{}
+	mi := &file_catalog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductTranslation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductTranslation) ProtoMessage() {}
+
+func (x *ProductTranslation) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductTranslation.ProtoReflect.Descriptor instead.
+func (*ProductTranslation) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProductTranslation) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductTranslation) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type ProductRating struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Average       float64                `protobuf:"fixed64,1,opt,name=average,proto3" json:"average,omitempty"`
+	Count         uint64                 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductRating) Reset() {
+	*x = ProductRating{}
+	mi := &file_catalog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductRating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductRating) ProtoMessage() {}
+
+func (x *ProductRating) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductRating.ProtoReflect.Descriptor instead.
+func (*ProductRating) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProductRating) GetAverage() float64 {
+	if x != nil {
+		return x.Average
+	}
+	return 0
+}
+
+func (x *ProductRating) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ProductAttribute struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// value is the canonical text form, e.g. "1.5" or "true".
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// type and unit are copied from the category's attribute definition.
+	Type          string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Unit          string `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductAttribute) Reset() {
+	*x = ProductAttribute{}
+	mi := &file_catalog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductAttribute) ProtoMessage() {}
+
+func (x *ProductAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductAttribute.ProtoReflect.Descriptor instead.
+func (*ProductAttribute) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProductAttribute) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProductAttribute) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+type AttributeDefinition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// type is one of string, number, enum or boolean.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Unit string `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	// values lists the allowed values of enum attributes.
+	Values        []string `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty"`
+	Required      bool     `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeDefinition) Reset() {
+	*x = AttributeDefinition{}
+	mi := &file_catalog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeDefinition) ProtoMessage() {}
+
+func (x *AttributeDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeDefinition.ProtoReflect.Descriptor instead.
+func (*AttributeDefinition) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AttributeDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AttributeDefinition) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AttributeDefinition) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *AttributeDefinition) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *AttributeDefinition) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Attributes    []*AttributeDefinition `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_catalog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Category) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetAttributes() []*AttributeDefinition {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type AttributeFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// min and max bound number attributes.
+	Min           *float64 `protobuf:"fixed64,3,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max           *float64 `protobuf:"fixed64,4,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeFilter) Reset() {
+	*x = AttributeFilter{}
+	mi := &file_catalog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeFilter) ProtoMessage() {}
+
+func (x *AttributeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeFilter.ProtoReflect.Descriptor instead.
+func (*AttributeFilter) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AttributeFilter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AttributeFilter) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *AttributeFilter) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *AttributeFilter) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+type ProductMedia struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// key and thumbnail_key locate the image and its thumbnail in the blob store.
+	Key           string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Url           string `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	ThumbnailKey  string `protobuf:"bytes,4,opt,name=thumbnail_key,json=thumbnailKey,proto3" json:"thumbnail_key,omitempty"`
+	ThumbnailUrl  string `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	ContentType   string `protobuf:"bytes,6,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	AltText       string `protobuf:"bytes,7,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	Width         uint32 `protobuf:"varint,8,opt,name=width,proto3" json:"width,omitempty"`
+	Height        uint32 `protobuf:"varint,9,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductMedia) Reset() {
+	*x = ProductMedia{}
+	mi := &file_catalog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductMedia) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductMedia) ProtoMessage() {}
+
+func (x *ProductMedia) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductMedia.ProtoReflect.Descriptor instead.
+func (*ProductMedia) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProductMedia) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ProductMedia) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ProductMedia) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ProductMedia) GetThumbnailKey() string {
+	if x != nil {
+		return x.ThumbnailKey
+	}
+	return ""
+}
+
+func (x *ProductMedia) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *ProductMedia) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ProductMedia) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+func (x *ProductMedia) GetWidth() uint32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ProductMedia) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+type CreateOrUpdateProductRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Price       *pb.Money              `protobuf:"bytes,4,opt,name=price,proto3" json:"price,omitempty"`
+	Prices      []*pb.Money            `protobuf:"bytes,5,rep,name=prices,proto3" json:"prices,omitempty"`
+	// status defaults to draft when publish_at is in the future, published
+	// otherwise, and is left unchanged on update.
+	Status      string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	PublishAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	UnpublishAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=unpublish_at,json=unpublishAt,proto3" json:"unpublish_at,omitempty"`
+	// attributes are validated against the schema of category_id, if set.
+	CategoryId string              `protobuf:"bytes,9,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Attributes []*ProductAttribute `protobuf:"bytes,10,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// version is the version of the product being updated and is required for
+	// updates. The update fails if the product has changed since.
+	Version      string                         `protobuf:"bytes,11,opt,name=version,proto3" json:"version,omitempty"`
+	Translations map[string]*ProductTranslation `protobuf:"bytes,12,rep,name=translations,proto3" json:"translations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// compare_at_price must be in the currency of price and higher than it.
+	CompareAtPrice  *pb.Money         `protobuf:"bytes,13,opt,name=compare_at_price,json=compareAtPrice,proto3" json:"compare_at_price,omitempty"`
+	ScheduledPrices []*ScheduledPrice `protobuf:"bytes,14,rep,name=scheduled_prices,json=scheduledPrices,proto3" json:"scheduled_prices,omitempty"`
+	TaxCategory     string            `protobuf:"bytes,15,opt,name=tax_category,json=taxCategory,proto3" json:"tax_category,omitempty"`
+	Weight          uint32            `protobuf:"varint,16,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateOrUpdateProductRequest) Reset() {
+	*x = CreateOrUpdateProductRequest{}
+	mi := &file_catalog_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrUpdateProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrUpdateProductRequest) ProtoMessage() {}
+
+func (x *CreateOrUpdateProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrUpdateProductRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrUpdateProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateOrUpdateProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateOrUpdateProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateOrUpdateProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateOrUpdateProductRequest) GetPrice() *pb.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *CreateOrUpdateProductRequest) GetPrices() []*pb.Money {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *CreateOrUpdateProductRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateOrUpdateProductRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *CreateOrUpdateProductRequest) GetUnpublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UnpublishAt
+	}
+	return nil
+}
+
+func (x *CreateOrUpdateProductRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *CreateOrUpdateProductRequest) GetAttributes() []*ProductAttribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *CreateOrUpdateProductRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *CreateOrUpdateProductRequest) GetTranslations() map[string]*ProductTranslation {
+	if x != nil {
+		return x.Translations
+	}
+	return nil
+}
+
+func (x *CreateOrUpdateProductRequest) GetCompareAtPrice() *pb.Money {
+	if x != nil {
+		return x.CompareAtPrice
+	}
+	return nil
+}
+
+func (x *CreateOrUpdateProductRequest) GetScheduledPrices() []*ScheduledPrice {
+	if x != nil {
+		return x.ScheduledPrices
+	}
+	return nil
+}
+
+func (x *CreateOrUpdateProductRequest) GetTaxCategory() string {
+	if x != nil {
+		return x.TaxCategory
+	}
+	return ""
+}
+
+func (x *CreateOrUpdateProductRequest) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+type CreateOrUpdateProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrUpdateProductResponse) Reset() {
+	*x = CreateOrUpdateProductResponse{}
+	mi := &file_catalog_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrUpdateProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrUpdateProductResponse) ProtoMessage() {}
+
+func (x *CreateOrUpdateProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrUpdateProductResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrUpdateProductResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateOrUpdateProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type PatchProductRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// version must be the current version of the product.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// product holds the new values of the fields named in update_mask, e.g.
+	// "name", "price" or "publish_at"; its id and version are ignored.
+	Product       *CreateOrUpdateProductRequest `protobuf:"bytes,3,opt,name=product,proto3" json:"product,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask        `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchProductRequest) Reset() {
+	*x = PatchProductRequest{}
+	mi := &file_catalog_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchProductRequest) ProtoMessage() {}
+
+func (x *PatchProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchProductRequest.ProtoReflect.Descriptor instead.
+func (*PatchProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PatchProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PatchProductRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PatchProductRequest) GetProduct() *CreateOrUpdateProductRequest {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *PatchProductRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type PatchProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchProductResponse) Reset() {
+	*x = PatchProductResponse{}
+	mi := &file_catalog_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchProductResponse) ProtoMessage() {}
+
+func (x *PatchProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchProductResponse.ProtoReflect.Descriptor instead.
+func (*PatchProductResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PatchProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type GetProductByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByIDRequest) Reset() {
+	*x = GetProductByIDRequest{}
+	mi := &file_catalog_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByIDRequest) ProtoMessage() {}
+
+func (x *GetProductByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetProductByIDRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetProductByIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetProductByIDRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetProductByIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProductByIDResponse) Reset() {
+	*x = GetProductByIDResponse{}
+	mi := &file_catalog_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProductByIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProductByIDResponse) ProtoMessage() {}
+
+func (x *GetProductByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProductByIDResponse.ProtoReflect.Descriptor instead.
+func (*GetProductByIDResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetProductByIDResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type ListProductsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Skip     uint64                 `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
+	Take     uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	Currency string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	// include_unpublished lists drafts and archived products; admins only.
+	IncludeUnpublished bool               `protobuf:"varint,4,opt,name=include_unpublished,json=includeUnpublished,proto3" json:"include_unpublished,omitempty"`
+	CategoryId         string             `protobuf:"bytes,5,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Attributes         []*AttributeFilter `protobuf:"bytes,6,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// sort is empty for index order or "rating" for the best rated first.
+	Sort string `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`
+	// cursor continues a listing from a previous response; skip is ignored.
+	Cursor        string `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_catalog_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListProductsRequest) GetSkip() uint64 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetIncludeUnpublished() bool {
+	if x != nil {
+		return x.IncludeUnpublished
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetAttributes() []*AttributeFilter {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *ListProductsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListProductsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	// next_cursor continues the listing after this page; empty on the last page.
+	NextCursor string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalCount uint64 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// cursors[i] continues the listing after products[i].
+	Cursors       []string `protobuf:"bytes,4,rep,name=cursors,proto3" json:"cursors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_catalog_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListProductsResponse) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetCursors() []string {
+	if x != nil {
+		return x.Cursors
+	}
+	return nil
+}
+
+type ListProductsWithIdsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsWithIdsRequest) Reset() {
+	*x = ListProductsWithIdsRequest{}
+	mi := &file_catalog_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsWithIdsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsWithIdsRequest) ProtoMessage() {}
+
+func (x *ListProductsWithIdsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsWithIdsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsWithIdsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListProductsWithIdsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *ListProductsWithIdsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListProductsWithIdsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsWithIdsResponse) Reset() {
+	*x = ListProductsWithIdsResponse{}
+	mi := &file_catalog_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsWithIdsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsWithIdsResponse) ProtoMessage() {}
+
+func (x *ListProductsWithIdsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsWithIdsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsWithIdsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListProductsWithIdsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type SearchProductsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Query    string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Skip     uint64                 `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`
+	Take     uint64                 `protobuf:"varint,3,opt,name=take,proto3" json:"take,omitempty"`
+	Currency string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	// include_unpublished searches drafts and archived products; admins only.
+	IncludeUnpublished bool               `protobuf:"varint,5,opt,name=include_unpublished,json=includeUnpublished,proto3" json:"include_unpublished,omitempty"`
+	CategoryId         string             `protobuf:"bytes,6,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Attributes         []*AttributeFilter `protobuf:"bytes,7,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	// sort is empty for relevance or "rating" for the best rated first.
+	Sort string `protobuf:"bytes,8,opt,name=sort,proto3" json:"sort,omitempty"`
+	// locale is the language of query; defaults to en.
+	Locale string `protobuf:"bytes,9,opt,name=locale,proto3" json:"locale,omitempty"`
+	// cursor continues a search from a previous response; skip is ignored.
+	Cursor        string `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsRequest) Reset() {
+	*x = SearchProductsRequest{}
+	mi := &file_catalog_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsRequest) ProtoMessage() {}
+
+func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SearchProductsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetSkip() uint64 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *SearchProductsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetIncludeUnpublished() bool {
+	if x != nil {
+		return x.IncludeUnpublished
+	}
+	return false
+}
+
+func (x *SearchProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetAttributes() []*AttributeFilter {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *SearchProductsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type SearchProductsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	// next_cursor continues the search after this page; empty on the last page.
+	NextCursor string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalCount uint64 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// cursors[i] continues the search after products[i].
+	Cursors       []string `protobuf:"bytes,4,rep,name=cursors,proto3" json:"cursors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchProductsResponse) Reset() {
+	*x = SearchProductsResponse{}
+	mi := &file_catalog_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchProductsResponse) ProtoMessage() {}
+
+func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
+func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *SearchProductsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *SearchProductsResponse) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *SearchProductsResponse) GetCursors() []string {
+	if x != nil {
+		return x.Cursors
+	}
+	return nil
+}
+
+type ListRelatedProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Take          uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelatedProductsRequest) Reset() {
+	*x = ListRelatedProductsRequest{}
+	mi := &file_catalog_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelatedProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelatedProductsRequest) ProtoMessage() {}
+
+func (x *ListRelatedProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelatedProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListRelatedProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListRelatedProductsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListRelatedProductsRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *ListRelatedProductsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListRelatedProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRelatedProductsResponse) Reset() {
+	*x = ListRelatedProductsResponse{}
+	mi := &file_catalog_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRelatedProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRelatedProductsResponse) ProtoMessage() {}
+
+func (x *ListRelatedProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRelatedProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListRelatedProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListRelatedProductsResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type ListProductsByMerchantRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Skip       uint64                 `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`
+	Take       uint64                 `protobuf:"varint,3,opt,name=take,proto3" json:"take,omitempty"`
+	Currency   string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	// include_unpublished lists drafts and archived products; admins and the
+	// merchant only.
+	IncludeUnpublished bool `protobuf:"varint,5,opt,name=include_unpublished,json=includeUnpublished,proto3" json:"include_unpublished,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListProductsByMerchantRequest) Reset() {
+	*x = ListProductsByMerchantRequest{}
+	mi := &file_catalog_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsByMerchantRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsByMerchantRequest) ProtoMessage() {}
+
+func (x *ListProductsByMerchantRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsByMerchantRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsByMerchantRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListProductsByMerchantRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *ListProductsByMerchantRequest) GetSkip() uint64 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *ListProductsByMerchantRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *ListProductsByMerchantRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ListProductsByMerchantRequest) GetIncludeUnpublished() bool {
+	if x != nil {
+		return x.IncludeUnpublished
+	}
+	return false
+}
+
+type ListProductsByMerchantResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsByMerchantResponse) Reset() {
+	*x = ListProductsByMerchantResponse{}
+	mi := &file_catalog_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsByMerchantResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsByMerchantResponse) ProtoMessage() {}
+
+func (x *ListProductsByMerchantResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsByMerchantResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsByMerchantResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListProductsByMerchantResponse) GetProducts() []*Product {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type TransferProductOwnershipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	MerchantId    string                 `protobuf:"bytes,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferProductOwnershipRequest) Reset() {
+	*x = TransferProductOwnershipRequest{}
+	mi := &file_catalog_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferProductOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferProductOwnershipRequest) ProtoMessage() {}
+
+func (x *TransferProductOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferProductOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*TransferProductOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *TransferProductOwnershipRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *TransferProductOwnershipRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+type TransferProductOwnershipResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferProductOwnershipResponse) Reset() {
+	*x = TransferProductOwnershipResponse{}
+	mi := &file_catalog_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferProductOwnershipResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferProductOwnershipResponse) ProtoMessage() {}
+
+func (x *TransferProductOwnershipResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferProductOwnershipResponse.ProtoReflect.Descriptor instead.
+func (*TransferProductOwnershipResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *TransferProductOwnershipResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_catalog_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_catalog_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteProductResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type ImportProductsRequest struct {
+	state   protoimpl.MessageState        `protogen:"open.v1"`
+	Product *CreateOrUpdateProductRequest `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	// row identifies the product in errors, e.g. its line in the source file.
+	// Defaults to the 1-based position of the message in the stream.
+	Row           uint64 `protobuf:"varint,2,opt,name=row,proto3" json:"row,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductsRequest) Reset() {
+	*x = ImportProductsRequest{}
+	mi := &file_catalog_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductsRequest) ProtoMessage() {}
+
+func (x *ImportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ImportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ImportProductsRequest) GetProduct() *CreateOrUpdateProductRequest {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *ImportProductsRequest) GetRow() uint64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+type ImportError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           uint64                 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_catalog_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ImportError) GetRow() uint64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportError) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ImportError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ImportProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Imported      uint64                 `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Errors        []*ImportError         `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportProductsResponse) Reset() {
+	*x = ImportProductsResponse{}
+	mi := &file_catalog_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportProductsResponse) ProtoMessage() {}
+
+func (x *ImportProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportProductsResponse.ProtoReflect.Descriptor instead.
+func (*ImportProductsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ImportProductsResponse) GetImported() uint64 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportProductsResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ExportProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// merchant_id limits the export to one merchant's products.
+	MerchantId string `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	// include_unpublished exports drafts and archived products; admins and the
+	// merchant only.
+	IncludeUnpublished bool `protobuf:"varint,2,opt,name=include_unpublished,json=includeUnpublished,proto3" json:"include_unpublished,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ExportProductsRequest) Reset() {
+	*x = ExportProductsRequest{}
+	mi := &file_catalog_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportProductsRequest) ProtoMessage() {}
+
+func (x *ExportProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportProductsRequest.ProtoReflect.Descriptor instead.
+func (*ExportProductsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExportProductsRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *ExportProductsRequest) GetIncludeUnpublished() bool {
+	if x != nil {
+		return x.IncludeUnpublished
+	}
+	return false
+}
+
+type AddProductMediaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Media         *ProductMedia          `protobuf:"bytes,2,opt,name=media,proto3" json:"media,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductMediaRequest) Reset() {
+	*x = AddProductMediaRequest{}
+	mi := &file_catalog_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductMediaRequest) ProtoMessage() {}
+
+func (x *AddProductMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductMediaRequest.ProtoReflect.Descriptor instead.
+func (*AddProductMediaRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AddProductMediaRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *AddProductMediaRequest) GetMedia() *ProductMedia {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type AddProductMediaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Media         *ProductMedia          `protobuf:"bytes,2,opt,name=media,proto3" json:"media,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProductMediaResponse) Reset() {
+	*x = AddProductMediaResponse{}
+	mi := &file_catalog_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProductMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProductMediaResponse) ProtoMessage() {}
+
+func (x *AddProductMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProductMediaResponse.ProtoReflect.Descriptor instead.
+func (*AddProductMediaResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AddProductMediaResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *AddProductMediaResponse) GetMedia() *ProductMedia {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type UpdateProductMediaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	MediaId       string                 `protobuf:"bytes,2,opt,name=media_id,json=mediaId,proto3" json:"media_id,omitempty"`
+	AltText       string                 `protobuf:"bytes,3,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductMediaRequest) Reset() {
+	*x = UpdateProductMediaRequest{}
+	mi := &file_catalog_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductMediaRequest) ProtoMessage() {}
+
+func (x *UpdateProductMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductMediaRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductMediaRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateProductMediaRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *UpdateProductMediaRequest) GetMediaId() string {
+	if x != nil {
+		return x.MediaId
+	}
+	return ""
+}
+
+func (x *UpdateProductMediaRequest) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+type UpdateProductMediaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProductMediaResponse) Reset() {
+	*x = UpdateProductMediaResponse{}
+	mi := &file_catalog_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProductMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProductMediaResponse) ProtoMessage() {}
+
+func (x *UpdateProductMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProductMediaResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductMediaResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdateProductMediaResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type RemoveProductMediaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	MediaId       string                 `protobuf:"bytes,2,opt,name=media_id,json=mediaId,proto3" json:"media_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveProductMediaRequest) Reset() {
+	*x = RemoveProductMediaRequest{}
+	mi := &file_catalog_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveProductMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveProductMediaRequest) ProtoMessage() {}
+
+func (x *RemoveProductMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveProductMediaRequest.ProtoReflect.Descriptor instead.
+func (*RemoveProductMediaRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RemoveProductMediaRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *RemoveProductMediaRequest) GetMediaId() string {
+	if x != nil {
+		return x.MediaId
+	}
+	return ""
+}
+
+type RemoveProductMediaResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	// media is the removed record, so its blobs can be deleted.
+	Media         *ProductMedia `protobuf:"bytes,2,opt,name=media,proto3" json:"media,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveProductMediaResponse) Reset() {
+	*x = RemoveProductMediaResponse{}
+	mi := &file_catalog_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveProductMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveProductMediaResponse) ProtoMessage() {}
+
+func (x *RemoveProductMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveProductMediaResponse.ProtoReflect.Descriptor instead.
+func (*RemoveProductMediaResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RemoveProductMediaResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+func (x *RemoveProductMediaResponse) GetMedia() *ProductMedia {
+	if x != nil {
+		return x.Media
+	}
+	return nil
+}
+
+type ReorderProductMediaRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// media_ids lists every media id of the product in the new order.
+	MediaIds      []string `protobuf:"bytes,2,rep,name=media_ids,json=mediaIds,proto3" json:"media_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderProductMediaRequest) Reset() {
+	*x = ReorderProductMediaRequest{}
+	mi := &file_catalog_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderProductMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderProductMediaRequest) ProtoMessage() {}
+
+func (x *ReorderProductMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderProductMediaRequest.ProtoReflect.Descriptor instead.
+func (*ReorderProductMediaRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ReorderProductMediaRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReorderProductMediaRequest) GetMediaIds() []string {
+	if x != nil {
+		return x.MediaIds
+	}
+	return nil
+}
+
+type ReorderProductMediaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderProductMediaResponse) Reset() {
+	*x = ReorderProductMediaResponse{}
+	mi := &file_catalog_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderProductMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderProductMediaResponse) ProtoMessage() {}
+
+func (x *ReorderProductMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderProductMediaResponse.ProtoReflect.Descriptor instead.
+func (*ReorderProductMediaResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ReorderProductMediaResponse) GetProduct() *Product {
+	if x != nil {
+		return x.Product
+	}
+	return nil
+}
+
+type CreateOrUpdateCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrUpdateCategoryRequest) Reset() {
+	*x = CreateOrUpdateCategoryRequest{}
+	mi := &file_catalog_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrUpdateCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrUpdateCategoryRequest) ProtoMessage() {}
+
+func (x *CreateOrUpdateCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrUpdateCategoryRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrUpdateCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CreateOrUpdateCategoryRequest) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type CreateOrUpdateCategoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrUpdateCategoryResponse) Reset() {
+	*x = CreateOrUpdateCategoryResponse{}
+	mi := &file_catalog_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrUpdateCategoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrUpdateCategoryResponse) ProtoMessage() {}
+
+func (x *CreateOrUpdateCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrUpdateCategoryResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrUpdateCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateOrUpdateCategoryResponse) GetCategory() *Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+type GetCategoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoryRequest) Reset() {
+	*x = GetCategoryRequest{}
+	mi := &file_catalog_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoryRequest) ProtoMessage() {}
+
+func (x *GetCategoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCategoryRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetCategoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
 }
 
-type CreateOrUpdateProductRequest struct {
+type GetCategoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float32                `protobuf:"fixed32,4,opt,name=price,proto3" json:"price,omitempty"`
+	Category      *Category              `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrUpdateProductRequest) Reset() {
-	*x = CreateOrUpdateProductRequest{}
-	mi := &file_catalog_proto_msgTypes[1]
+func (x *GetCategoryResponse) Reset() {
+	*x = GetCategoryResponse{}
+	mi := &file_catalog_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrUpdateProductRequest) String() string {
+func (x *GetCategoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrUpdateProductRequest) ProtoMessage() {}
+func (*GetCategoryResponse) ProtoMessage() {}
 
-func (x *CreateOrUpdateProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[1]
+func (x *GetCategoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -126,61 +2996,92 @@ func (x *CreateOrUpdateProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrUpdateProductRequest.ProtoReflect.Descriptor instead.
-func (*CreateOrUpdateProductRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use GetCategoryResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoryResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *CreateOrUpdateProductRequest) GetId() string {
+func (x *GetCategoryResponse) GetCategory() *Category {
 	if x != nil {
-		return x.Id
+		return x.Category
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateOrUpdateProductRequest) GetName() string {
+type ListCategoriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skip          uint64                 `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
+	Take          uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCategoriesRequest) Reset() {
+	*x = ListCategoriesRequest{}
+	mi := &file_catalog_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCategoriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCategoriesRequest) ProtoMessage() {}
+
+func (x *ListCategoriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[46]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *CreateOrUpdateProductRequest) GetDescription() string {
+// Deprecated: Use ListCategoriesRequest.ProtoReflect.Descriptor instead.
+func (*ListCategoriesRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListCategoriesRequest) GetSkip() uint64 {
 	if x != nil {
-		return x.Description
+		return x.Skip
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateOrUpdateProductRequest) GetPrice() float32 {
+func (x *ListCategoriesRequest) GetTake() uint64 {
 	if x != nil {
-		return x.Price
+		return x.Take
 	}
 	return 0
 }
 
-type CreateOrUpdateProductResponse struct {
+type ListCategoriesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrUpdateProductResponse) Reset() {
-	*x = CreateOrUpdateProductResponse{}
-	mi := &file_catalog_proto_msgTypes[2]
+func (x *ListCategoriesResponse) Reset() {
+	*x = ListCategoriesResponse{}
+	mi := &file_catalog_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrUpdateProductResponse) String() string {
+func (x *ListCategoriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrUpdateProductResponse) ProtoMessage() {}
+func (*ListCategoriesResponse) ProtoMessage() {}
 
-func (x *CreateOrUpdateProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[2]
+func (x *ListCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -191,40 +3092,42 @@ func (x *CreateOrUpdateProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrUpdateProductResponse.ProtoReflect.Descriptor instead.
-func (*CreateOrUpdateProductResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ListCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*ListCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *CreateOrUpdateProductResponse) GetProduct() *Product {
+func (x *ListCategoriesResponse) GetCategories() []*Category {
 	if x != nil {
-		return x.Product
+		return x.Categories
 	}
 	return nil
 }
 
-type GetProductByIDRequest struct {
+type GetPriceHistoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Skip          uint64                 `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`
+	Take          uint64                 `protobuf:"varint,3,opt,name=take,proto3" json:"take,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductByIDRequest) Reset() {
-	*x = GetProductByIDRequest{}
-	mi := &file_catalog_proto_msgTypes[3]
+func (x *GetPriceHistoryRequest) Reset() {
+	*x = GetPriceHistoryRequest{}
+	mi := &file_catalog_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductByIDRequest) String() string {
+func (x *GetPriceHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductByIDRequest) ProtoMessage() {}
+func (*GetPriceHistoryRequest) ProtoMessage() {}
 
-func (x *GetProductByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[3]
+func (x *GetPriceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -235,40 +3138,55 @@ func (x *GetProductByIDRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductByIDRequest.ProtoReflect.Descriptor instead.
-func (*GetProductByIDRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetPriceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *GetProductByIDRequest) GetId() string {
+func (x *GetPriceHistoryRequest) GetProductId() string {
 	if x != nil {
-		return x.Id
+		return x.ProductId
 	}
 	return ""
 }
 
-type GetProductByIDResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Product       *Product               `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+func (x *GetPriceHistoryRequest) GetSkip() uint64 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *GetPriceHistoryRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+type GetPriceHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// changes are ordered latest first.
+	Changes       []*PriceChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProductByIDResponse) Reset() {
-	*x = GetProductByIDResponse{}
-	mi := &file_catalog_proto_msgTypes[4]
+func (x *GetPriceHistoryResponse) Reset() {
+	*x = GetPriceHistoryResponse{}
+	mi := &file_catalog_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProductByIDResponse) String() string {
+func (x *GetPriceHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProductByIDResponse) ProtoMessage() {}
+func (*GetPriceHistoryResponse) ProtoMessage() {}
 
-func (x *GetProductByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[4]
+func (x *GetPriceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -279,41 +3197,40 @@ func (x *GetProductByIDResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProductByIDResponse.ProtoReflect.Descriptor instead.
-func (*GetProductByIDResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetPriceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetPriceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *GetProductByIDResponse) GetProduct() *Product {
+func (x *GetPriceHistoryResponse) GetChanges() []*PriceChange {
 	if x != nil {
-		return x.Product
+		return x.Changes
 	}
 	return nil
 }
 
-type ListProductsRequest struct {
+type GetSynonymsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Skip          uint64                 `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
-	Take          uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	Locale        string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsRequest) Reset() {
-	*x = ListProductsRequest{}
-	mi := &file_catalog_proto_msgTypes[5]
+func (x *GetSynonymsRequest) Reset() {
+	*x = GetSynonymsRequest{}
+	mi := &file_catalog_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsRequest) String() string {
+func (x *GetSynonymsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsRequest) ProtoMessage() {}
+func (*GetSynonymsRequest) ProtoMessage() {}
 
-func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[5]
+func (x *GetSynonymsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -324,47 +3241,40 @@ func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
-func (*ListProductsRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *ListProductsRequest) GetSkip() uint64 {
-	if x != nil {
-		return x.Skip
-	}
-	return 0
+// Deprecated: Use GetSynonymsRequest.ProtoReflect.Descriptor instead.
+func (*GetSynonymsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *ListProductsRequest) GetTake() uint64 {
+func (x *GetSynonymsRequest) GetLocale() string {
 	if x != nil {
-		return x.Take
+		return x.Locale
 	}
-	return 0
+	return ""
 }
 
-type ListProductsResponse struct {
+type GetSynonymsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Rules         []string               `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsResponse) Reset() {
-	*x = ListProductsResponse{}
-	mi := &file_catalog_proto_msgTypes[6]
+func (x *GetSynonymsResponse) Reset() {
+	*x = GetSynonymsResponse{}
+	mi := &file_catalog_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsResponse) String() string {
+func (x *GetSynonymsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsResponse) ProtoMessage() {}
+func (*GetSynonymsResponse) ProtoMessage() {}
 
-func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[6]
+func (x *GetSynonymsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -375,40 +3285,43 @@ func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GetSynonymsResponse.ProtoReflect.Descriptor instead.
+func (*GetSynonymsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *ListProductsResponse) GetProducts() []*Product {
+func (x *GetSynonymsResponse) GetRules() []string {
 	if x != nil {
-		return x.Products
+		return x.Rules
 	}
 	return nil
 }
 
-type ListProductsWithIdsRequest struct {
+// UpdateSynonymsRequest replaces a locale's search synonyms. Rules are in Solr
+// format, e.g. "tee, t-shirt" or "tee => t-shirt". Admins only.
+type UpdateSynonymsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Locale        string                 `protobuf:"bytes,1,opt,name=locale,proto3" json:"locale,omitempty"`
+	Rules         []string               `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsWithIdsRequest) Reset() {
-	*x = ListProductsWithIdsRequest{}
-	mi := &file_catalog_proto_msgTypes[7]
+func (x *UpdateSynonymsRequest) Reset() {
+	*x = UpdateSynonymsRequest{}
+	mi := &file_catalog_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsWithIdsRequest) String() string {
+func (x *UpdateSynonymsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsWithIdsRequest) ProtoMessage() {}
+func (*UpdateSynonymsRequest) ProtoMessage() {}
 
-func (x *ListProductsWithIdsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[7]
+func (x *UpdateSynonymsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -419,40 +3332,46 @@ func (x *ListProductsWithIdsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsWithIdsRequest.ProtoReflect.Descriptor instead.
-func (*ListProductsWithIdsRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use UpdateSynonymsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSynonymsRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *ListProductsWithIdsRequest) GetIds() []string {
+func (x *UpdateSynonymsRequest) GetLocale() string {
 	if x != nil {
-		return x.Ids
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *UpdateSynonymsRequest) GetRules() []string {
+	if x != nil {
+		return x.Rules
 	}
 	return nil
 }
 
-type ListProductsWithIdsResponse struct {
+type UpdateSynonymsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProductsWithIdsResponse) Reset() {
-	*x = ListProductsWithIdsResponse{}
-	mi := &file_catalog_proto_msgTypes[8]
+func (x *UpdateSynonymsResponse) Reset() {
+	*x = UpdateSynonymsResponse{}
+	mi := &file_catalog_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProductsWithIdsResponse) String() string {
+func (x *UpdateSynonymsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProductsWithIdsResponse) ProtoMessage() {}
+func (*UpdateSynonymsResponse) ProtoMessage() {}
 
-func (x *ListProductsWithIdsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[8]
+func (x *UpdateSynonymsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -463,42 +3382,36 @@ func (x *ListProductsWithIdsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProductsWithIdsResponse.ProtoReflect.Descriptor instead.
-func (*ListProductsWithIdsResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *ListProductsWithIdsResponse) GetProducts() []*Product {
-	if x != nil {
-		return x.Products
-	}
-	return nil
+// Deprecated: Use UpdateSynonymsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSynonymsResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{53}
 }
 
-type SearchProductsRequest struct {
+// UpdateProductRatingRequest is sent by the review service; other callers are
+// rejected.
+type UpdateProductRatingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Skip          uint64                 `protobuf:"varint,2,opt,name=skip,proto3" json:"skip,omitempty"`
-	Take          uint64                 `protobuf:"varint,3,opt,name=take,proto3" json:"take,omitempty"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Rating        *ProductRating         `protobuf:"bytes,2,opt,name=rating,proto3" json:"rating,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchProductsRequest) Reset() {
-	*x = SearchProductsRequest{}
-	mi := &file_catalog_proto_msgTypes[9]
+func (x *UpdateProductRatingRequest) Reset() {
+	*x = UpdateProductRatingRequest{}
+	mi := &file_catalog_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchProductsRequest) String() string {
+func (x *UpdateProductRatingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchProductsRequest) ProtoMessage() {}
+func (*UpdateProductRatingRequest) ProtoMessage() {}
 
-func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[9]
+func (x *UpdateProductRatingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -509,54 +3422,46 @@ func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
-func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use UpdateProductRatingRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProductRatingRequest) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *SearchProductsRequest) GetQuery() string {
+func (x *UpdateProductRatingRequest) GetProductId() string {
 	if x != nil {
-		return x.Query
+		return x.ProductId
 	}
 	return ""
 }
 
-func (x *SearchProductsRequest) GetSkip() uint64 {
-	if x != nil {
-		return x.Skip
-	}
-	return 0
-}
-
-func (x *SearchProductsRequest) GetTake() uint64 {
+func (x *UpdateProductRatingRequest) GetRating() *ProductRating {
 	if x != nil {
-		return x.Take
+		return x.Rating
 	}
-	return 0
+	return nil
 }
 
-type SearchProductsResponse struct {
+type UpdateProductRatingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*Product             `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchProductsResponse) Reset() {
-	*x = SearchProductsResponse{}
-	mi := &file_catalog_proto_msgTypes[10]
+func (x *UpdateProductRatingResponse) Reset() {
+	*x = UpdateProductRatingResponse{}
+	mi := &file_catalog_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchProductsResponse) String() string {
+func (x *UpdateProductRatingResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchProductsResponse) ProtoMessage() {}
+func (*UpdateProductRatingResponse) ProtoMessage() {}
 
-func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_catalog_proto_msgTypes[10]
+func (x *UpdateProductRatingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_catalog_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,60 +3472,317 @@ func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
-func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
-	return file_catalog_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *SearchProductsResponse) GetProducts() []*Product {
-	if x != nil {
-		return x.Products
-	}
-	return nil
+// Deprecated: Use UpdateProductRatingResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProductRatingResponse) Descriptor() ([]byte, []int) {
+	return file_catalog_proto_rawDescGZIP(), []int{55}
 }
 
 var File_catalog_proto protoreflect.FileDescriptor
 
 const file_catalog_proto_rawDesc = "" +
 	"\n" +
-	"\rcatalog.proto\x12\x02pb\"e\n" +
+	"\rcatalog.proto\x12\x02pb\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x11money/money.proto\"\xcd\b\n" +
 	"\aProduct\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x02R\x05price\"z\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\"\n" +
+	"\x05price\x18\x04 \x01(\v2\f.money.MoneyR\x05price\x12$\n" +
+	"\x06prices\x18\x05 \x03(\v2\f.money.MoneyR\x06prices\x121\n" +
+	"\rdisplay_price\x18\x06 \x01(\v2\f.money.MoneyR\fdisplayPrice\x128\n" +
+	"\rexchange_rate\x18\a \x01(\v2\x13.money.ExchangeRateR\fexchangeRate\x12\x1f\n" +
+	"\vmerchant_id\x18\b \x01(\tR\n" +
+	"merchantId\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"publish_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12=\n" +
+	"\funpublish_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vunpublishAt\x129\n" +
+	"\n" +
+	"deleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12&\n" +
+	"\x05media\x18\r \x03(\v2\x10.pb.ProductMediaR\x05media\x12\x1f\n" +
+	"\vcategory_id\x18\x0e \x01(\tR\n" +
+	"categoryId\x124\n" +
+	"\n" +
+	"attributes\x18\x0f \x03(\v2\x14.pb.ProductAttributeR\n" +
+	"attributes\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\tR\aversion\x12)\n" +
+	"\x06rating\x18\x11 \x01(\v2\x11.pb.ProductRatingR\x06rating\x12A\n" +
+	"\ftranslations\x18\x12 \x03(\v2\x1d.pb.Product.TranslationsEntryR\ftranslations\x126\n" +
+	"\x10compare_at_price\x18\x13 \x01(\v2\f.money.MoneyR\x0ecompareAtPrice\x12E\n" +
+	"\x18display_compare_at_price\x18\x14 \x01(\v2\f.money.MoneyR\x15displayCompareAtPrice\x12=\n" +
+	"\x10scheduled_prices\x18\x15 \x03(\v2\x12.pb.ScheduledPriceR\x0fscheduledPrices\x12!\n" +
+	"\ftax_category\x18\x16 \x01(\tR\vtaxCategory\x12\x16\n" +
+	"\x06weight\x18\x17 \x01(\rR\x06weight\x1aW\n" +
+	"\x11TranslationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.pb.ProductTranslationR\x05value:\x028\x01\"\xd5\x01\n" +
+	"\x0eScheduledPrice\x12A\n" +
+	"\x0eeffective_from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveFrom\x12\"\n" +
+	"\x05price\x18\x02 \x01(\v2\f.money.MoneyR\x05price\x12$\n" +
+	"\x06prices\x18\x03 \x03(\v2\f.money.MoneyR\x06prices\x126\n" +
+	"\x10compare_at_price\x18\x04 \x01(\v2\f.money.MoneyR\x0ecompareAtPrice\"\x81\x02\n" +
+	"\vPriceChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12A\n" +
+	"\x0eeffective_from\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveFrom\x12\"\n" +
+	"\x05price\x18\x04 \x01(\v2\f.money.MoneyR\x05price\x12$\n" +
+	"\x06prices\x18\x05 \x03(\v2\f.money.MoneyR\x06prices\x126\n" +
+	"\x10compare_at_price\x18\x06 \x01(\v2\f.money.MoneyR\x0ecompareAtPrice\"J\n" +
+	"\x12ProductTranslation\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\"?\n" +
+	"\rProductRating\x12\x18\n" +
+	"\aaverage\x18\x01 \x01(\x01R\aaverage\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"d\n" +
+	"\x10ProductAttribute\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x12\n" +
+	"\x04unit\x18\x04 \x01(\tR\x04unit\"\x85\x01\n" +
+	"\x13AttributeDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\x12\x16\n" +
+	"\x06values\x18\x04 \x03(\tR\x06values\x12\x1a\n" +
+	"\brequired\x18\x05 \x01(\bR\brequired\"g\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x127\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v2\x17.pb.AttributeDefinitionR\n" +
+	"attributes\"y\n" +
+	"\x0fAttributeFilter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x15\n" +
+	"\x03min\x18\x03 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x04 \x01(\x01H\x01R\x03max\x88\x01\x01B\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"\xf8\x01\n" +
+	"\fProductMedia\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12#\n" +
+	"\rthumbnail_key\x18\x04 \x01(\tR\fthumbnailKey\x12#\n" +
+	"\rthumbnail_url\x18\x05 \x01(\tR\fthumbnailUrl\x12!\n" +
+	"\fcontent_type\x18\x06 \x01(\tR\vcontentType\x12\x19\n" +
+	"\balt_text\x18\a \x01(\tR\aaltText\x12\x14\n" +
+	"\x05width\x18\b \x01(\rR\x05width\x12\x16\n" +
+	"\x06height\x18\t \x01(\rR\x06height\"\x94\x06\n" +
 	"\x1cCreateOrUpdateProductRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x02R\x05price\"F\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\"\n" +
+	"\x05price\x18\x04 \x01(\v2\f.money.MoneyR\x05price\x12$\n" +
+	"\x06prices\x18\x05 \x03(\v2\f.money.MoneyR\x06prices\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"publish_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12=\n" +
+	"\funpublish_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vunpublishAt\x12\x1f\n" +
+	"\vcategory_id\x18\t \x01(\tR\n" +
+	"categoryId\x124\n" +
+	"\n" +
+	"attributes\x18\n" +
+	" \x03(\v2\x14.pb.ProductAttributeR\n" +
+	"attributes\x12\x18\n" +
+	"\aversion\x18\v \x01(\tR\aversion\x12V\n" +
+	"\ftranslations\x18\f \x03(\v22.pb.CreateOrUpdateProductRequest.TranslationsEntryR\ftranslations\x126\n" +
+	"\x10compare_at_price\x18\r \x01(\v2\f.money.MoneyR\x0ecompareAtPrice\x12=\n" +
+	"\x10scheduled_prices\x18\x0e \x03(\v2\x12.pb.ScheduledPriceR\x0fscheduledPrices\x12!\n" +
+	"\ftax_category\x18\x0f \x01(\tR\vtaxCategory\x12\x16\n" +
+	"\x06weight\x18\x10 \x01(\rR\x06weight\x1aW\n" +
+	"\x11TranslationsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x02 \x01(\v2\x16.pb.ProductTranslationR\x05value:\x028\x01\"F\n" +
 	"\x1dCreateOrUpdateProductResponse\x12%\n" +
-	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\"'\n" +
+	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\"\xb8\x01\n" +
+	"\x13PatchProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12:\n" +
+	"\aproduct\x18\x03 \x01(\v2 .pb.CreateOrUpdateProductRequestR\aproduct\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"=\n" +
+	"\x14PatchProductResponse\x12%\n" +
+	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\"C\n" +
 	"\x15GetProductByIDRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"?\n" +
 	"\x16GetProductByIDResponse\x12%\n" +
-	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\"=\n" +
+	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\"\x8c\x02\n" +
 	"\x13ListProductsRequest\x12\x12\n" +
 	"\x04skip\x18\x01 \x01(\x04R\x04skip\x12\x12\n" +
-	"\x04take\x18\x02 \x01(\x04R\x04take\"?\n" +
+	"\x04take\x18\x02 \x01(\x04R\x04take\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12/\n" +
+	"\x13include_unpublished\x18\x04 \x01(\bR\x12includeUnpublished\x12\x1f\n" +
+	"\vcategory_id\x18\x05 \x01(\tR\n" +
+	"categoryId\x123\n" +
+	"\n" +
+	"attributes\x18\x06 \x03(\v2\x13.pb.AttributeFilterR\n" +
+	"attributes\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sort\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursor\"\x9b\x01\n" +
 	"\x14ListProductsResponse\x12'\n" +
-	"\bproducts\x18\x01 \x03(\v2\v.pb.ProductR\bproducts\".\n" +
+	"\bproducts\x18\x01 \x03(\v2\v.pb.ProductR\bproducts\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x04R\n" +
+	"totalCount\x12\x18\n" +
+	"\acursors\x18\x04 \x03(\tR\acursors\"J\n" +
 	"\x1aListProductsWithIdsRequest\x12\x10\n" +
-	"\x03ids\x18\x01 \x03(\tR\x03ids\"F\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"F\n" +
 	"\x1bListProductsWithIdsResponse\x12'\n" +
-	"\bproducts\x18\x01 \x03(\v2\v.pb.ProductR\bproducts\"U\n" +
+	"\bproducts\x18\x01 \x03(\v2\v.pb.ProductR\bproducts\"\xbc\x02\n" +
 	"\x15SearchProductsRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
 	"\x04skip\x18\x02 \x01(\x04R\x04skip\x12\x12\n" +
-	"\x04take\x18\x03 \x01(\x04R\x04take\"A\n" +
+	"\x04take\x18\x03 \x01(\x04R\x04take\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12/\n" +
+	"\x13include_unpublished\x18\x05 \x01(\bR\x12includeUnpublished\x12\x1f\n" +
+	"\vcategory_id\x18\x06 \x01(\tR\n" +
+	"categoryId\x123\n" +
+	"\n" +
+	"attributes\x18\a \x03(\v2\x13.pb.AttributeFilterR\n" +
+	"attributes\x12\x12\n" +
+	"\x04sort\x18\b \x01(\tR\x04sort\x12\x16\n" +
+	"\x06locale\x18\t \x01(\tR\x06locale\x12\x16\n" +
+	"\x06cursor\x18\n" +
+	" \x01(\tR\x06cursor\"\x9d\x01\n" +
 	"\x16SearchProductsResponse\x12'\n" +
-	"\bproducts\x18\x01 \x03(\v2\v.pb.ProductR\bproducts2\x9b\x03\n" +
+	"\bproducts\x18\x01 \x03(\v2\v.pb.ProductR\bproducts\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x04R\n" +
+	"totalCount\x12\x18\n" +
+	"\acursors\x18\x04 \x03(\tR\acursors\"k\n" +
+	"\x1aListRelatedProductsRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04take\x18\x02 \x01(\x04R\x04take\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\"F\n" +
+	"\x1bListRelatedProductsResponse\x12'\n" +
+	"\bproducts\x18\x01 \x03(\v2\v.pb.ProductR\bproducts\"\xb5\x01\n" +
+	"\x1dListProductsByMerchantRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12\x12\n" +
+	"\x04skip\x18\x02 \x01(\x04R\x04skip\x12\x12\n" +
+	"\x04take\x18\x03 \x01(\x04R\x04take\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12/\n" +
+	"\x13include_unpublished\x18\x05 \x01(\bR\x12includeUnpublished\"I\n" +
+	"\x1eListProductsByMerchantResponse\x12'\n" +
+	"\bproducts\x18\x01 \x03(\v2\v.pb.ProductR\bproducts\"a\n" +
+	"\x1fTransferProductOwnershipRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\tR\n" +
+	"merchantId\"I\n" +
+	" TransferProductOwnershipResponse\x12%\n" +
+	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\"&\n" +
+	"\x14DeleteProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x15DeleteProductResponse\x12%\n" +
+	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\"e\n" +
+	"\x15ImportProductsRequest\x12:\n" +
+	"\aproduct\x18\x01 \x01(\v2 .pb.CreateOrUpdateProductRequestR\aproduct\x12\x10\n" +
+	"\x03row\x18\x02 \x01(\x04R\x03row\"X\n" +
+	"\vImportError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x04R\x03row\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"]\n" +
+	"\x16ImportProductsResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\x04R\bimported\x12'\n" +
+	"\x06errors\x18\x02 \x03(\v2\x0f.pb.ImportErrorR\x06errors\"i\n" +
+	"\x15ExportProductsRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12/\n" +
+	"\x13include_unpublished\x18\x02 \x01(\bR\x12includeUnpublished\"_\n" +
+	"\x16AddProductMediaRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12&\n" +
+	"\x05media\x18\x02 \x01(\v2\x10.pb.ProductMediaR\x05media\"h\n" +
+	"\x17AddProductMediaResponse\x12%\n" +
+	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\x12&\n" +
+	"\x05media\x18\x02 \x01(\v2\x10.pb.ProductMediaR\x05media\"p\n" +
+	"\x19UpdateProductMediaRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\bmedia_id\x18\x02 \x01(\tR\amediaId\x12\x19\n" +
+	"\balt_text\x18\x03 \x01(\tR\aaltText\"C\n" +
+	"\x1aUpdateProductMediaResponse\x12%\n" +
+	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\"U\n" +
+	"\x19RemoveProductMediaRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x19\n" +
+	"\bmedia_id\x18\x02 \x01(\tR\amediaId\"k\n" +
+	"\x1aRemoveProductMediaResponse\x12%\n" +
+	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\x12&\n" +
+	"\x05media\x18\x02 \x01(\v2\x10.pb.ProductMediaR\x05media\"X\n" +
+	"\x1aReorderProductMediaRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1b\n" +
+	"\tmedia_ids\x18\x02 \x03(\tR\bmediaIds\"D\n" +
+	"\x1bReorderProductMediaResponse\x12%\n" +
+	"\aproduct\x18\x01 \x01(\v2\v.pb.ProductR\aproduct\"I\n" +
+	"\x1dCreateOrUpdateCategoryRequest\x12(\n" +
+	"\bcategory\x18\x01 \x01(\v2\f.pb.CategoryR\bcategory\"J\n" +
+	"\x1eCreateOrUpdateCategoryResponse\x12(\n" +
+	"\bcategory\x18\x01 \x01(\v2\f.pb.CategoryR\bcategory\"$\n" +
+	"\x12GetCategoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x13GetCategoryResponse\x12(\n" +
+	"\bcategory\x18\x01 \x01(\v2\f.pb.CategoryR\bcategory\"?\n" +
+	"\x15ListCategoriesRequest\x12\x12\n" +
+	"\x04skip\x18\x01 \x01(\x04R\x04skip\x12\x12\n" +
+	"\x04take\x18\x02 \x01(\x04R\x04take\"F\n" +
+	"\x16ListCategoriesResponse\x12,\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\f.pb.CategoryR\n" +
+	"categories\"_\n" +
+	"\x16GetPriceHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04skip\x18\x02 \x01(\x04R\x04skip\x12\x12\n" +
+	"\x04take\x18\x03 \x01(\x04R\x04take\"D\n" +
+	"\x17GetPriceHistoryResponse\x12)\n" +
+	"\achanges\x18\x01 \x03(\v2\x0f.pb.PriceChangeR\achanges\",\n" +
+	"\x12GetSynonymsRequest\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\"+\n" +
+	"\x13GetSynonymsResponse\x12\x14\n" +
+	"\x05rules\x18\x01 \x03(\tR\x05rules\"E\n" +
+	"\x15UpdateSynonymsRequest\x12\x16\n" +
+	"\x06locale\x18\x01 \x01(\tR\x06locale\x12\x14\n" +
+	"\x05rules\x18\x02 \x03(\tR\x05rules\"\x18\n" +
+	"\x16UpdateSynonymsResponse\"f\n" +
+	"\x1aUpdateProductRatingRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12)\n" +
+	"\x06rating\x18\x02 \x01(\v2\x11.pb.ProductRatingR\x06rating\"\x1d\n" +
+	"\x1bUpdateProductRatingResponse2\xb0\x0e\n" +
 	"\x0eCatalogService\x12\\\n" +
-	"\x15CreateOrUpdateProduct\x12 .pb.CreateOrUpdateProductRequest\x1a!.pb.CreateOrUpdateProductResponse\x12G\n" +
+	"\x15CreateOrUpdateProduct\x12 .pb.CreateOrUpdateProductRequest\x1a!.pb.CreateOrUpdateProductResponse\x12A\n" +
+	"\fPatchProduct\x12\x17.pb.PatchProductRequest\x1a\x18.pb.PatchProductResponse\x12G\n" +
 	"\x0eGetProductByID\x12\x19.pb.GetProductByIDRequest\x1a\x1a.pb.GetProductByIDResponse\x12A\n" +
 	"\fListProducts\x12\x17.pb.ListProductsRequest\x1a\x18.pb.ListProductsResponse\x12V\n" +
 	"\x13ListProductsWithIds\x12\x1e.pb.ListProductsWithIdsRequest\x1a\x1f.pb.ListProductsWithIdsResponse\x12G\n" +
-	"\x0eSearchProducts\x12\x19.pb.SearchProductsRequest\x1a\x1a.pb.SearchProductsResponseB\x04Z\x02./b\x06proto3"
+	"\x0eSearchProducts\x12\x19.pb.SearchProductsRequest\x1a\x1a.pb.SearchProductsResponse\x12V\n" +
+	"\x13ListRelatedProducts\x12\x1e.pb.ListRelatedProductsRequest\x1a\x1f.pb.ListRelatedProductsResponse\x12_\n" +
+	"\x16ListProductsByMerchant\x12!.pb.ListProductsByMerchantRequest\x1a\".pb.ListProductsByMerchantResponse\x12e\n" +
+	"\x18TransferProductOwnership\x12#.pb.TransferProductOwnershipRequest\x1a$.pb.TransferProductOwnershipResponse\x12D\n" +
+	"\rDeleteProduct\x12\x18.pb.DeleteProductRequest\x1a\x19.pb.DeleteProductResponse\x12I\n" +
+	"\x0eImportProducts\x12\x19.pb.ImportProductsRequest\x1a\x1a.pb.ImportProductsResponse(\x01\x12:\n" +
+	"\x0eExportProducts\x12\x19.pb.ExportProductsRequest\x1a\v.pb.Product0\x01\x12J\n" +
+	"\x0fAddProductMedia\x12\x1a.pb.AddProductMediaRequest\x1a\x1b.pb.AddProductMediaResponse\x12S\n" +
+	"\x12UpdateProductMedia\x12\x1d.pb.UpdateProductMediaRequest\x1a\x1e.pb.UpdateProductMediaResponse\x12S\n" +
+	"\x12RemoveProductMedia\x12\x1d.pb.RemoveProductMediaRequest\x1a\x1e.pb.RemoveProductMediaResponse\x12V\n" +
+	"\x13ReorderProductMedia\x12\x1e.pb.ReorderProductMediaRequest\x1a\x1f.pb.ReorderProductMediaResponse\x12V\n" +
+	"\x13UpdateProductRating\x12\x1e.pb.UpdateProductRatingRequest\x1a\x1f.pb.UpdateProductRatingResponse\x12J\n" +
+	"\x0fGetPriceHistory\x12\x1a.pb.GetPriceHistoryRequest\x1a\x1b.pb.GetPriceHistoryResponse\x12_\n" +
+	"\x16CreateOrUpdateCategory\x12!.pb.CreateOrUpdateCategoryRequest\x1a\".pb.CreateOrUpdateCategoryResponse\x12>\n" +
+	"\vGetCategory\x12\x16.pb.GetCategoryRequest\x1a\x17.pb.GetCategoryResponse\x12G\n" +
+	"\x0eListCategories\x12\x19.pb.ListCategoriesRequest\x1a\x1a.pb.ListCategoriesResponse\x12>\n" +
+	"\vGetSynonyms\x12\x16.pb.GetSynonymsRequest\x1a\x17.pb.GetSynonymsResponse\x12G\n" +
+	"\x0eUpdateSynonyms\x12\x19.pb.UpdateSynonymsRequest\x1a\x1a.pb.UpdateSynonymsResponseB\x04Z\x02./b\x06proto3"
 
 var (
 	file_catalog_proto_rawDescOnce sync.Once
@@ -634,41 +3796,185 @@ func file_catalog_proto_rawDescGZIP() []byte {
 	return file_catalog_proto_rawDescData
 }
 
-var file_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_catalog_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
 var file_catalog_proto_goTypes = []any{
-	(*Product)(nil),                       // 0: pb.Product
-	(*CreateOrUpdateProductRequest)(nil),  // 1: pb.CreateOrUpdateProductRequest
-	(*CreateOrUpdateProductResponse)(nil), // 2: pb.CreateOrUpdateProductResponse
-	(*GetProductByIDRequest)(nil),         // 3: pb.GetProductByIDRequest
-	(*GetProductByIDResponse)(nil),        // 4: pb.GetProductByIDResponse
-	(*ListProductsRequest)(nil),           // 5: pb.ListProductsRequest
-	(*ListProductsResponse)(nil),          // 6: pb.ListProductsResponse
-	(*ListProductsWithIdsRequest)(nil),    // 7: pb.ListProductsWithIdsRequest
-	(*ListProductsWithIdsResponse)(nil),   // 8: pb.ListProductsWithIdsResponse
-	(*SearchProductsRequest)(nil),         // 9: pb.SearchProductsRequest
-	(*SearchProductsResponse)(nil),        // 10: pb.SearchProductsResponse
+	(*Product)(nil),                          // 0: pb.Product
+	(*ScheduledPrice)(nil),                   // 1: pb.ScheduledPrice
+	(*PriceChange)(nil),                      // 2: pb.PriceChange
+	(*ProductTranslation)(nil),               // 3: pb.ProductTranslation
+	(*ProductRating)(nil),                    // 4: pb.ProductRating
+	(*ProductAttribute)(nil),                 // 5: pb.ProductAttribute
+	(*AttributeDefinition)(nil),              // 6: pb.AttributeDefinition
+	(*Category)(nil),                         // 7: pb.Category
+	(*AttributeFilter)(nil),                  // 8: pb.AttributeFilter
+	(*ProductMedia)(nil),                     // 9: pb.ProductMedia
+	(*CreateOrUpdateProductRequest)(nil),     // 10: pb.CreateOrUpdateProductRequest
+	(*CreateOrUpdateProductResponse)(nil),    // 11: pb.CreateOrUpdateProductResponse
+	(*PatchProductRequest)(nil),              // 12: pb.PatchProductRequest
+	(*PatchProductResponse)(nil),             // 13: pb.PatchProductResponse
+	(*GetProductByIDRequest)(nil),            // 14: pb.GetProductByIDRequest
+	(*GetProductByIDResponse)(nil),           // 15: pb.GetProductByIDResponse
+	(*ListProductsRequest)(nil),              // 16: pb.ListProductsRequest
+	(*ListProductsResponse)(nil),             // 17: pb.ListProductsResponse
+	(*ListProductsWithIdsRequest)(nil),       // 18: pb.ListProductsWithIdsRequest
+	(*ListProductsWithIdsResponse)(nil),      // 19: pb.ListProductsWithIdsResponse
+	(*SearchProductsRequest)(nil),            // 20: pb.SearchProductsRequest
+	(*SearchProductsResponse)(nil),           // 21: pb.SearchProductsResponse
+	(*ListRelatedProductsRequest)(nil),       // 22: pb.ListRelatedProductsRequest
+	(*ListRelatedProductsResponse)(nil),      // 23: pb.ListRelatedProductsResponse
+	(*ListProductsByMerchantRequest)(nil),    // 24: pb.ListProductsByMerchantRequest
+	(*ListProductsByMerchantResponse)(nil),   // 25: pb.ListProductsByMerchantResponse
+	(*TransferProductOwnershipRequest)(nil),  // 26: pb.TransferProductOwnershipRequest
+	(*TransferProductOwnershipResponse)(nil), // 27: pb.TransferProductOwnershipResponse
+	(*DeleteProductRequest)(nil),             // 28: pb.DeleteProductRequest
+	(*DeleteProductResponse)(nil),            // 29: pb.DeleteProductResponse
+	(*ImportProductsRequest)(nil),            // 30: pb.ImportProductsRequest
+	(*ImportError)(nil),                      // 31: pb.ImportError
+	(*ImportProductsResponse)(nil),           // 32: pb.ImportProductsResponse
+	(*ExportProductsRequest)(nil),            // 33: pb.ExportProductsRequest
+	(*AddProductMediaRequest)(nil),           // 34: pb.AddProductMediaRequest
+	(*AddProductMediaResponse)(nil),          // 35: pb.AddProductMediaResponse
+	(*UpdateProductMediaRequest)(nil),        // 36: pb.UpdateProductMediaRequest
+	(*UpdateProductMediaResponse)(nil),       // 37: pb.UpdateProductMediaResponse
+	(*RemoveProductMediaRequest)(nil),        // 38: pb.RemoveProductMediaRequest
+	(*RemoveProductMediaResponse)(nil),       // 39: pb.RemoveProductMediaResponse
+	(*ReorderProductMediaRequest)(nil),       // 40: pb.ReorderProductMediaRequest
+	(*ReorderProductMediaResponse)(nil),      // 41: pb.ReorderProductMediaResponse
+	(*CreateOrUpdateCategoryRequest)(nil),    // 42: pb.CreateOrUpdateCategoryRequest
+	(*CreateOrUpdateCategoryResponse)(nil),   // 43: pb.CreateOrUpdateCategoryResponse
+	(*GetCategoryRequest)(nil),               // 44: pb.GetCategoryRequest
+	(*GetCategoryResponse)(nil),              // 45: pb.GetCategoryResponse
+	(*ListCategoriesRequest)(nil),            // 46: pb.ListCategoriesRequest
+	(*ListCategoriesResponse)(nil),           // 47: pb.ListCategoriesResponse
+	(*GetPriceHistoryRequest)(nil),           // 48: pb.GetPriceHistoryRequest
+	(*GetPriceHistoryResponse)(nil),          // 49: pb.GetPriceHistoryResponse
+	(*GetSynonymsRequest)(nil),               // 50: pb.GetSynonymsRequest
+	(*GetSynonymsResponse)(nil),              // 51: pb.GetSynonymsResponse
+	(*UpdateSynonymsRequest)(nil),            // 52: pb.UpdateSynonymsRequest
+	(*UpdateSynonymsResponse)(nil),           // 53: pb.UpdateSynonymsResponse
+	(*UpdateProductRatingRequest)(nil),       // 54: pb.UpdateProductRatingRequest
+	(*UpdateProductRatingResponse)(nil),      // 55: pb.UpdateProductRatingResponse
+	nil,                                      // 56: pb.Product.TranslationsEntry
+	nil,                                      // 57: pb.CreateOrUpdateProductRequest.TranslationsEntry
+	(*pb.Money)(nil),                         // 58: money.Money
+	(*pb.ExchangeRate)(nil),                  // 59: money.ExchangeRate
+	(*timestamppb.Timestamp)(nil),            // 60: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),            // 61: google.protobuf.FieldMask
 }
 var file_catalog_proto_depIdxs = []int32{
-	0,  // 0: pb.CreateOrUpdateProductResponse.product:type_name -> pb.Product
-	0,  // 1: pb.GetProductByIDResponse.product:type_name -> pb.Product
-	0,  // 2: pb.ListProductsResponse.products:type_name -> pb.Product
-	0,  // 3: pb.ListProductsWithIdsResponse.products:type_name -> pb.Product
-	0,  // 4: pb.SearchProductsResponse.products:type_name -> pb.Product
-	1,  // 5: pb.CatalogService.CreateOrUpdateProduct:input_type -> pb.CreateOrUpdateProductRequest
-	3,  // 6: pb.CatalogService.GetProductByID:input_type -> pb.GetProductByIDRequest
-	5,  // 7: pb.CatalogService.ListProducts:input_type -> pb.ListProductsRequest
-	7,  // 8: pb.CatalogService.ListProductsWithIds:input_type -> pb.ListProductsWithIdsRequest
-	9,  // 9: pb.CatalogService.SearchProducts:input_type -> pb.SearchProductsRequest
-	2,  // 10: pb.CatalogService.CreateOrUpdateProduct:output_type -> pb.CreateOrUpdateProductResponse
-	4,  // 11: pb.CatalogService.GetProductByID:output_type -> pb.GetProductByIDResponse
-	6,  // 12: pb.CatalogService.ListProducts:output_type -> pb.ListProductsResponse
-	8,  // 13: pb.CatalogService.ListProductsWithIds:output_type -> pb.ListProductsWithIdsResponse
-	10, // 14: pb.CatalogService.SearchProducts:output_type -> pb.SearchProductsResponse
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	58, // 0: pb.Product.price:type_name -> money.Money
+	58, // 1: pb.Product.prices:type_name -> money.Money
+	58, // 2: pb.Product.display_price:type_name -> money.Money
+	59, // 3: pb.Product.exchange_rate:type_name -> money.ExchangeRate
+	60, // 4: pb.Product.publish_at:type_name -> google.protobuf.Timestamp
+	60, // 5: pb.Product.unpublish_at:type_name -> google.protobuf.Timestamp
+	60, // 6: pb.Product.deleted_at:type_name -> google.protobuf.Timestamp
+	9,  // 7: pb.Product.media:type_name -> pb.ProductMedia
+	5,  // 8: pb.Product.attributes:type_name -> pb.ProductAttribute
+	4,  // 9: pb.Product.rating:type_name -> pb.ProductRating
+	56, // 10: pb.Product.translations:type_name -> pb.Product.TranslationsEntry
+	58, // 11: pb.Product.compare_at_price:type_name -> money.Money
+	58, // 12: pb.Product.display_compare_at_price:type_name -> money.Money
+	1,  // 13: pb.Product.scheduled_prices:type_name -> pb.ScheduledPrice
+	60, // 14: pb.ScheduledPrice.effective_from:type_name -> google.protobuf.Timestamp
+	58, // 15: pb.ScheduledPrice.price:type_name -> money.Money
+	58, // 16: pb.ScheduledPrice.prices:type_name -> money.Money
+	58, // 17: pb.ScheduledPrice.compare_at_price:type_name -> money.Money
+	60, // 18: pb.PriceChange.effective_from:type_name -> google.protobuf.Timestamp
+	58, // 19: pb.PriceChange.price:type_name -> money.Money
+	58, // 20: pb.PriceChange.prices:type_name -> money.Money
+	58, // 21: pb.PriceChange.compare_at_price:type_name -> money.Money
+	6,  // 22: pb.Category.attributes:type_name -> pb.AttributeDefinition
+	58, // 23: pb.CreateOrUpdateProductRequest.price:type_name -> money.Money
+	58, // 24: pb.CreateOrUpdateProductRequest.prices:type_name -> money.Money
+	60, // 25: pb.CreateOrUpdateProductRequest.publish_at:type_name -> google.protobuf.Timestamp
+	60, // 26: pb.CreateOrUpdateProductRequest.unpublish_at:type_name -> google.protobuf.Timestamp
+	5,  // 27: pb.CreateOrUpdateProductRequest.attributes:type_name -> pb.ProductAttribute
+	57, // 28: pb.CreateOrUpdateProductRequest.translations:type_name -> pb.CreateOrUpdateProductRequest.TranslationsEntry
+	58, // 29: pb.CreateOrUpdateProductRequest.compare_at_price:type_name -> money.Money
+	1,  // 30: pb.CreateOrUpdateProductRequest.scheduled_prices:type_name -> pb.ScheduledPrice
+	0,  // 31: pb.CreateOrUpdateProductResponse.product:type_name -> pb.Product
+	10, // 32: pb.PatchProductRequest.product:type_name -> pb.CreateOrUpdateProductRequest
+	61, // 33: pb.PatchProductRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 34: pb.PatchProductResponse.product:type_name -> pb.Product
+	0,  // 35: pb.GetProductByIDResponse.product:type_name -> pb.Product
+	8,  // 36: pb.ListProductsRequest.attributes:type_name -> pb.AttributeFilter
+	0,  // 37: pb.ListProductsResponse.products:type_name -> pb.Product
+	0,  // 38: pb.ListProductsWithIdsResponse.products:type_name -> pb.Product
+	8,  // 39: pb.SearchProductsRequest.attributes:type_name -> pb.AttributeFilter
+	0,  // 40: pb.SearchProductsResponse.products:type_name -> pb.Product
+	0,  // 41: pb.ListRelatedProductsResponse.products:type_name -> pb.Product
+	0,  // 42: pb.ListProductsByMerchantResponse.products:type_name -> pb.Product
+	0,  // 43: pb.TransferProductOwnershipResponse.product:type_name -> pb.Product
+	0,  // 44: pb.DeleteProductResponse.product:type_name -> pb.Product
+	10, // 45: pb.ImportProductsRequest.product:type_name -> pb.CreateOrUpdateProductRequest
+	31, // 46: pb.ImportProductsResponse.errors:type_name -> pb.ImportError
+	9,  // 47: pb.AddProductMediaRequest.media:type_name -> pb.ProductMedia
+	0,  // 48: pb.AddProductMediaResponse.product:type_name -> pb.Product
+	9,  // 49: pb.AddProductMediaResponse.media:type_name -> pb.ProductMedia
+	0,  // 50: pb.UpdateProductMediaResponse.product:type_name -> pb.Product
+	0,  // 51: pb.RemoveProductMediaResponse.product:type_name -> pb.Product
+	9,  // 52: pb.RemoveProductMediaResponse.media:type_name -> pb.ProductMedia
+	0,  // 53: pb.ReorderProductMediaResponse.product:type_name -> pb.Product
+	7,  // 54: pb.CreateOrUpdateCategoryRequest.category:type_name -> pb.Category
+	7,  // 55: pb.CreateOrUpdateCategoryResponse.category:type_name -> pb.Category
+	7,  // 56: pb.GetCategoryResponse.category:type_name -> pb.Category
+	7,  // 57: pb.ListCategoriesResponse.categories:type_name -> pb.Category
+	2,  // 58: pb.GetPriceHistoryResponse.changes:type_name -> pb.PriceChange
+	4,  // 59: pb.UpdateProductRatingRequest.rating:type_name -> pb.ProductRating
+	3,  // 60: pb.Product.TranslationsEntry.value:type_name -> pb.ProductTranslation
+	3,  // 61: pb.CreateOrUpdateProductRequest.TranslationsEntry.value:type_name -> pb.ProductTranslation
+	10, // 62: pb.CatalogService.CreateOrUpdateProduct:input_type -> pb.CreateOrUpdateProductRequest
+	12, // 63: pb.CatalogService.PatchProduct:input_type -> pb.PatchProductRequest
+	14, // 64: pb.CatalogService.GetProductByID:input_type -> pb.GetProductByIDRequest
+	16, // 65: pb.CatalogService.ListProducts:input_type -> pb.ListProductsRequest
+	18, // 66: pb.CatalogService.ListProductsWithIds:input_type -> pb.ListProductsWithIdsRequest
+	20, // 67: pb.CatalogService.SearchProducts:input_type -> pb.SearchProductsRequest
+	22, // 68: pb.CatalogService.ListRelatedProducts:input_type -> pb.ListRelatedProductsRequest
+	24, // 69: pb.CatalogService.ListProductsByMerchant:input_type -> pb.ListProductsByMerchantRequest
+	26, // 70: pb.CatalogService.TransferProductOwnership:input_type -> pb.TransferProductOwnershipRequest
+	28, // 71: pb.CatalogService.DeleteProduct:input_type -> pb.DeleteProductRequest
+	30, // 72: pb.CatalogService.ImportProducts:input_type -> pb.ImportProductsRequest
+	33, // 73: pb.CatalogService.ExportProducts:input_type -> pb.ExportProductsRequest
+	34, // 74: pb.CatalogService.AddProductMedia:input_type -> pb.AddProductMediaRequest
+	36, // 75: pb.CatalogService.UpdateProductMedia:input_type -> pb.UpdateProductMediaRequest
+	38, // 76: pb.CatalogService.RemoveProductMedia:input_type -> pb.RemoveProductMediaRequest
+	40, // 77: pb.CatalogService.ReorderProductMedia:input_type -> pb.ReorderProductMediaRequest
+	54, // 78: pb.CatalogService.UpdateProductRating:input_type -> pb.UpdateProductRatingRequest
+	48, // 79: pb.CatalogService.GetPriceHistory:input_type -> pb.GetPriceHistoryRequest
+	42, // 80: pb.CatalogService.CreateOrUpdateCategory:input_type -> pb.CreateOrUpdateCategoryRequest
+	44, // 81: pb.CatalogService.GetCategory:input_type -> pb.GetCategoryRequest
+	46, // 82: pb.CatalogService.ListCategories:input_type -> pb.ListCategoriesRequest
+	50, // 83: pb.CatalogService.GetSynonyms:input_type -> pb.GetSynonymsRequest
+	52, // 84: pb.CatalogService.UpdateSynonyms:input_type -> pb.UpdateSynonymsRequest
+	11, // 85: pb.CatalogService.CreateOrUpdateProduct:output_type -> pb.CreateOrUpdateProductResponse
+	13, // 86: pb.CatalogService.PatchProduct:output_type -> pb.PatchProductResponse
+	15, // 87: pb.CatalogService.GetProductByID:output_type -> pb.GetProductByIDResponse
+	17, // 88: pb.CatalogService.ListProducts:output_type -> pb.ListProductsResponse
+	19, // 89: pb.CatalogService.ListProductsWithIds:output_type -> pb.ListProductsWithIdsResponse
+	21, // 90: pb.CatalogService.SearchProducts:output_type -> pb.SearchProductsResponse
+	23, // 91: pb.CatalogService.ListRelatedProducts:output_type -> pb.ListRelatedProductsResponse
+	25, // 92: pb.CatalogService.ListProductsByMerchant:output_type -> pb.ListProductsByMerchantResponse
+	27, // 93: pb.CatalogService.TransferProductOwnership:output_type -> pb.TransferProductOwnershipResponse
+	29, // 94: pb.CatalogService.DeleteProduct:output_type -> pb.DeleteProductResponse
+	32, // 95: pb.CatalogService.ImportProducts:output_type -> pb.ImportProductsResponse
+	0,  // 96: pb.CatalogService.ExportProducts:output_type -> pb.Product
+	35, // 97: pb.CatalogService.AddProductMedia:output_type -> pb.AddProductMediaResponse
+	37, // 98: pb.CatalogService.UpdateProductMedia:output_type -> pb.UpdateProductMediaResponse
+	39, // 99: pb.CatalogService.RemoveProductMedia:output_type -> pb.RemoveProductMediaResponse
+	41, // 100: pb.CatalogService.ReorderProductMedia:output_type -> pb.ReorderProductMediaResponse
+	55, // 101: pb.CatalogService.UpdateProductRating:output_type -> pb.UpdateProductRatingResponse
+	49, // 102: pb.CatalogService.GetPriceHistory:output_type -> pb.GetPriceHistoryResponse
+	43, // 103: pb.CatalogService.CreateOrUpdateCategory:output_type -> pb.CreateOrUpdateCategoryResponse
+	45, // 104: pb.CatalogService.GetCategory:output_type -> pb.GetCategoryResponse
+	47, // 105: pb.CatalogService.ListCategories:output_type -> pb.ListCategoriesResponse
+	51, // 106: pb.CatalogService.GetSynonyms:output_type -> pb.GetSynonymsResponse
+	53, // 107: pb.CatalogService.UpdateSynonyms:output_type -> pb.UpdateSynonymsResponse
+	85, // [85:108] is the sub-list for method output_type
+	62, // [62:85] is the sub-list for method input_type
+	62, // [62:62] is the sub-list for extension type_name
+	62, // [62:62] is the sub-list for extension extendee
+	0,  // [0:62] is the sub-list for field type_name
 }
 
 func init() { file_catalog_proto_init() }
@@ -676,13 +3982,14 @@ func file_catalog_proto_init() {
 	if File_catalog_proto != nil {
 		return
 	}
+	file_catalog_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_catalog_proto_rawDesc), len(file_catalog_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,4 +1,4 @@
-// protoc --go_out=./pb --go-grpc_out=./pb catalog.proto
+// protoc -I. -I.. --go_out=./pb --go-grpc_out=./pb catalog.proto
 
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
@@ -21,11 +21,29 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CatalogService_CreateOrUpdateProduct_FullMethodName = "/pb.CatalogService/CreateOrUpdateProduct"
-	CatalogService_GetProductByID_FullMethodName        = "/pb.CatalogService/GetProductByID"
-	CatalogService_ListProducts_FullMethodName          = "/pb.CatalogService/ListProducts"
-	CatalogService_ListProductsWithIds_FullMethodName   = "/pb.CatalogService/ListProductsWithIds"
-	CatalogService_SearchProducts_FullMethodName        = "/pb.CatalogService/SearchProducts"
+	CatalogService_CreateOrUpdateProduct_FullMethodName    = "/pb.CatalogService/CreateOrUpdateProduct"
+	CatalogService_PatchProduct_FullMethodName             = "/pb.CatalogService/PatchProduct"
+	CatalogService_GetProductByID_FullMethodName           = "/pb.CatalogService/GetProductByID"
+	CatalogService_ListProducts_FullMethodName             = "/pb.CatalogService/ListProducts"
+	CatalogService_ListProductsWithIds_FullMethodName      = "/pb.CatalogService/ListProductsWithIds"
+	CatalogService_SearchProducts_FullMethodName           = "/pb.CatalogService/SearchProducts"
+	CatalogService_ListRelatedProducts_FullMethodName      = "/pb.CatalogService/ListRelatedProducts"
+	CatalogService_ListProductsByMerchant_FullMethodName   = "/pb.CatalogService/ListProductsByMerchant"
+	CatalogService_TransferProductOwnership_FullMethodName = "/pb.CatalogService/TransferProductOwnership"
+	CatalogService_DeleteProduct_FullMethodName            = "/pb.CatalogService/DeleteProduct"
+	CatalogService_ImportProducts_FullMethodName           = "/pb.CatalogService/ImportProducts"
+	CatalogService_ExportProducts_FullMethodName           = "/pb.CatalogService/ExportProducts"
+	CatalogService_AddProductMedia_FullMethodName          = "/pb.CatalogService/AddProductMedia"
+	CatalogService_UpdateProductMedia_FullMethodName       = "/pb.CatalogService/UpdateProductMedia"
+	CatalogService_RemoveProductMedia_FullMethodName       = "/pb.CatalogService/RemoveProductMedia"
+	CatalogService_ReorderProductMedia_FullMethodName      = "/pb.CatalogService/ReorderProductMedia"
+	CatalogService_UpdateProductRating_FullMethodName      = "/pb.CatalogService/UpdateProductRating"
+	CatalogService_GetPriceHistory_FullMethodName          = "/pb.CatalogService/GetPriceHistory"
+	CatalogService_CreateOrUpdateCategory_FullMethodName   = "/pb.CatalogService/CreateOrUpdateCategory"
+	CatalogService_GetCategory_FullMethodName              = "/pb.CatalogService/GetCategory"
+	CatalogService_ListCategories_FullMethodName           = "/pb.CatalogService/ListCategories"
+	CatalogService_GetSynonyms_FullMethodName              = "/pb.CatalogService/GetSynonyms"
+	CatalogService_UpdateSynonyms_FullMethodName           = "/pb.CatalogService/UpdateSynonyms"
 )
 
 // CatalogServiceClient is the client API for CatalogService service.
@@ -33,10 +51,28 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CatalogServiceClient interface {
 	CreateOrUpdateProduct(ctx context.Context, in *CreateOrUpdateProductRequest, opts ...grpc.CallOption) (*CreateOrUpdateProductResponse, error)
+	PatchProduct(ctx context.Context, in *PatchProductRequest, opts ...grpc.CallOption) (*PatchProductResponse, error)
 	GetProductByID(ctx context.Context, in *GetProductByIDRequest, opts ...grpc.CallOption) (*GetProductByIDResponse, error)
 	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
 	ListProductsWithIds(ctx context.Context, in *ListProductsWithIdsRequest, opts ...grpc.CallOption) (*ListProductsWithIdsResponse, error)
 	SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error)
+	ListRelatedProducts(ctx context.Context, in *ListRelatedProductsRequest, opts ...grpc.CallOption) (*ListRelatedProductsResponse, error)
+	ListProductsByMerchant(ctx context.Context, in *ListProductsByMerchantRequest, opts ...grpc.CallOption) (*ListProductsByMerchantResponse, error)
+	TransferProductOwnership(ctx context.Context, in *TransferProductOwnershipRequest, opts ...grpc.CallOption) (*TransferProductOwnershipResponse, error)
+	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error)
+	ImportProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse], error)
+	ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Product], error)
+	AddProductMedia(ctx context.Context, in *AddProductMediaRequest, opts ...grpc.CallOption) (*AddProductMediaResponse, error)
+	UpdateProductMedia(ctx context.Context, in *UpdateProductMediaRequest, opts ...grpc.CallOption) (*UpdateProductMediaResponse, error)
+	RemoveProductMedia(ctx context.Context, in *RemoveProductMediaRequest, opts ...grpc.CallOption) (*RemoveProductMediaResponse, error)
+	ReorderProductMedia(ctx context.Context, in *ReorderProductMediaRequest, opts ...grpc.CallOption) (*ReorderProductMediaResponse, error)
+	UpdateProductRating(ctx context.Context, in *UpdateProductRatingRequest, opts ...grpc.CallOption) (*UpdateProductRatingResponse, error)
+	GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error)
+	CreateOrUpdateCategory(ctx context.Context, in *CreateOrUpdateCategoryRequest, opts ...grpc.CallOption) (*CreateOrUpdateCategoryResponse, error)
+	GetCategory(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*GetCategoryResponse, error)
+	ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error)
+	GetSynonyms(ctx context.Context, in *GetSynonymsRequest, opts ...grpc.CallOption) (*GetSynonymsResponse, error)
+	UpdateSynonyms(ctx context.Context, in *UpdateSynonymsRequest, opts ...grpc.CallOption) (*UpdateSynonymsResponse, error)
 }
 
 type catalogServiceClient struct {
@@ -57,6 +93,16 @@ func (c *catalogServiceClient) CreateOrUpdateProduct(ctx context.Context, in *Cr
 	return out, nil
 }
 
+func (c *catalogServiceClient) PatchProduct(ctx context.Context, in *PatchProductRequest, opts ...grpc.CallOption) (*PatchProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PatchProductResponse)
+	err := c.cc.Invoke(ctx, CatalogService_PatchProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *catalogServiceClient) GetProductByID(ctx context.Context, in *GetProductByIDRequest, opts ...grpc.CallOption) (*GetProductByIDResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetProductByIDResponse)
@@ -97,15 +143,215 @@ func (c *catalogServiceClient) SearchProducts(ctx context.Context, in *SearchPro
 	return out, nil
 }
 
+func (c *catalogServiceClient) ListRelatedProducts(ctx context.Context, in *ListRelatedProductsRequest, opts ...grpc.CallOption) (*ListRelatedProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRelatedProductsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListRelatedProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListProductsByMerchant(ctx context.Context, in *ListProductsByMerchantRequest, opts ...grpc.CallOption) (*ListProductsByMerchantResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProductsByMerchantResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListProductsByMerchant_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) TransferProductOwnership(ctx context.Context, in *TransferProductOwnershipRequest, opts ...grpc.CallOption) (*TransferProductOwnershipResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferProductOwnershipResponse)
+	err := c.cc.Invoke(ctx, CatalogService_TransferProductOwnership_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*DeleteProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteProductResponse)
+	err := c.cc.Invoke(ctx, CatalogService_DeleteProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ImportProducts(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[0], CatalogService_ImportProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportProductsRequest, ImportProductsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ImportProductsClient = grpc.ClientStreamingClient[ImportProductsRequest, ImportProductsResponse]
+
+func (c *catalogServiceClient) ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Product], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CatalogService_ServiceDesc.Streams[1], CatalogService_ExportProducts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportProductsRequest, Product]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ExportProductsClient = grpc.ServerStreamingClient[Product]
+
+func (c *catalogServiceClient) AddProductMedia(ctx context.Context, in *AddProductMediaRequest, opts ...grpc.CallOption) (*AddProductMediaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddProductMediaResponse)
+	err := c.cc.Invoke(ctx, CatalogService_AddProductMedia_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateProductMedia(ctx context.Context, in *UpdateProductMediaRequest, opts ...grpc.CallOption) (*UpdateProductMediaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateProductMediaResponse)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateProductMedia_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) RemoveProductMedia(ctx context.Context, in *RemoveProductMediaRequest, opts ...grpc.CallOption) (*RemoveProductMediaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveProductMediaResponse)
+	err := c.cc.Invoke(ctx, CatalogService_RemoveProductMedia_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ReorderProductMedia(ctx context.Context, in *ReorderProductMediaRequest, opts ...grpc.CallOption) (*ReorderProductMediaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReorderProductMediaResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ReorderProductMedia_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateProductRating(ctx context.Context, in *UpdateProductRatingRequest, opts ...grpc.CallOption) (*UpdateProductRatingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateProductRatingResponse)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateProductRating_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetPriceHistory(ctx context.Context, in *GetPriceHistoryRequest, opts ...grpc.CallOption) (*GetPriceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceHistoryResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetPriceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) CreateOrUpdateCategory(ctx context.Context, in *CreateOrUpdateCategoryRequest, opts ...grpc.CallOption) (*CreateOrUpdateCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateOrUpdateCategoryResponse)
+	err := c.cc.Invoke(ctx, CatalogService_CreateOrUpdateCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetCategory(ctx context.Context, in *GetCategoryRequest, opts ...grpc.CallOption) (*GetCategoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoryResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetCategory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) ListCategories(ctx context.Context, in *ListCategoriesRequest, opts ...grpc.CallOption) (*ListCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCategoriesResponse)
+	err := c.cc.Invoke(ctx, CatalogService_ListCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) GetSynonyms(ctx context.Context, in *GetSynonymsRequest, opts ...grpc.CallOption) (*GetSynonymsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSynonymsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_GetSynonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogServiceClient) UpdateSynonyms(ctx context.Context, in *UpdateSynonymsRequest, opts ...grpc.CallOption) (*UpdateSynonymsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSynonymsResponse)
+	err := c.cc.Invoke(ctx, CatalogService_UpdateSynonyms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CatalogServiceServer is the server API for CatalogService service.
 // All implementations must embed UnimplementedCatalogServiceServer
 // for forward compatibility.
 type CatalogServiceServer interface {
 	CreateOrUpdateProduct(context.Context, *CreateOrUpdateProductRequest) (*CreateOrUpdateProductResponse, error)
+	PatchProduct(context.Context, *PatchProductRequest) (*PatchProductResponse, error)
 	GetProductByID(context.Context, *GetProductByIDRequest) (*GetProductByIDResponse, error)
 	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
 	ListProductsWithIds(context.Context, *ListProductsWithIdsRequest) (*ListProductsWithIdsResponse, error)
 	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
+	ListRelatedProducts(context.Context, *ListRelatedProductsRequest) (*ListRelatedProductsResponse, error)
+	ListProductsByMerchant(context.Context, *ListProductsByMerchantRequest) (*ListProductsByMerchantResponse, error)
+	TransferProductOwnership(context.Context, *TransferProductOwnershipRequest) (*TransferProductOwnershipResponse, error)
+	DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error)
+	ImportProducts(grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]) error
+	ExportProducts(*ExportProductsRequest, grpc.ServerStreamingServer[Product]) error
+	AddProductMedia(context.Context, *AddProductMediaRequest) (*AddProductMediaResponse, error)
+	UpdateProductMedia(context.Context, *UpdateProductMediaRequest) (*UpdateProductMediaResponse, error)
+	RemoveProductMedia(context.Context, *RemoveProductMediaRequest) (*RemoveProductMediaResponse, error)
+	ReorderProductMedia(context.Context, *ReorderProductMediaRequest) (*ReorderProductMediaResponse, error)
+	UpdateProductRating(context.Context, *UpdateProductRatingRequest) (*UpdateProductRatingResponse, error)
+	GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error)
+	CreateOrUpdateCategory(context.Context, *CreateOrUpdateCategoryRequest) (*CreateOrUpdateCategoryResponse, error)
+	GetCategory(context.Context, *GetCategoryRequest) (*GetCategoryResponse, error)
+	ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error)
+	GetSynonyms(context.Context, *GetSynonymsRequest) (*GetSynonymsResponse, error)
+	UpdateSynonyms(context.Context, *UpdateSynonymsRequest) (*UpdateSynonymsResponse, error)
 	mustEmbedUnimplementedCatalogServiceServer()
 }
 
@@ -119,6 +365,9 @@ type UnimplementedCatalogServiceServer struct{}
 func (UnimplementedCatalogServiceServer) CreateOrUpdateProduct(context.Context, *CreateOrUpdateProductRequest) (*CreateOrUpdateProductResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateOrUpdateProduct not implemented")
 }
+func (UnimplementedCatalogServiceServer) PatchProduct(context.Context, *PatchProductRequest) (*PatchProductResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PatchProduct not implemented")
+}
 func (UnimplementedCatalogServiceServer) GetProductByID(context.Context, *GetProductByIDRequest) (*GetProductByIDResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetProductByID not implemented")
 }
@@ -131,6 +380,57 @@ func (UnimplementedCatalogServiceServer) ListProductsWithIds(context.Context, *L
 func (UnimplementedCatalogServiceServer) SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SearchProducts not implemented")
 }
+func (UnimplementedCatalogServiceServer) ListRelatedProducts(context.Context, *ListRelatedProductsRequest) (*ListRelatedProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListRelatedProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListProductsByMerchant(context.Context, *ListProductsByMerchantRequest) (*ListProductsByMerchantResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListProductsByMerchant not implemented")
+}
+func (UnimplementedCatalogServiceServer) TransferProductOwnership(context.Context, *TransferProductOwnershipRequest) (*TransferProductOwnershipResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method TransferProductOwnership not implemented")
+}
+func (UnimplementedCatalogServiceServer) DeleteProduct(context.Context, *DeleteProductRequest) (*DeleteProductResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteProduct not implemented")
+}
+func (UnimplementedCatalogServiceServer) ImportProducts(grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]) error {
+	return status.Error(codes.Unimplemented, "method ImportProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) ExportProducts(*ExportProductsRequest, grpc.ServerStreamingServer[Product]) error {
+	return status.Error(codes.Unimplemented, "method ExportProducts not implemented")
+}
+func (UnimplementedCatalogServiceServer) AddProductMedia(context.Context, *AddProductMediaRequest) (*AddProductMediaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddProductMedia not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateProductMedia(context.Context, *UpdateProductMediaRequest) (*UpdateProductMediaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateProductMedia not implemented")
+}
+func (UnimplementedCatalogServiceServer) RemoveProductMedia(context.Context, *RemoveProductMediaRequest) (*RemoveProductMediaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveProductMedia not implemented")
+}
+func (UnimplementedCatalogServiceServer) ReorderProductMedia(context.Context, *ReorderProductMediaRequest) (*ReorderProductMediaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReorderProductMedia not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateProductRating(context.Context, *UpdateProductRatingRequest) (*UpdateProductRatingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateProductRating not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetPriceHistory(context.Context, *GetPriceHistoryRequest) (*GetPriceHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPriceHistory not implemented")
+}
+func (UnimplementedCatalogServiceServer) CreateOrUpdateCategory(context.Context, *CreateOrUpdateCategoryRequest) (*CreateOrUpdateCategoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateOrUpdateCategory not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetCategory(context.Context, *GetCategoryRequest) (*GetCategoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCategory not implemented")
+}
+func (UnimplementedCatalogServiceServer) ListCategories(context.Context, *ListCategoriesRequest) (*ListCategoriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCategories not implemented")
+}
+func (UnimplementedCatalogServiceServer) GetSynonyms(context.Context, *GetSynonymsRequest) (*GetSynonymsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSynonyms not implemented")
+}
+func (UnimplementedCatalogServiceServer) UpdateSynonyms(context.Context, *UpdateSynonymsRequest) (*UpdateSynonymsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateSynonyms not implemented")
+}
 func (UnimplementedCatalogServiceServer) mustEmbedUnimplementedCatalogServiceServer() {}
 func (UnimplementedCatalogServiceServer) testEmbeddedByValue()                        {}
 
@@ -170,6 +470,24 @@ func _CatalogService_CreateOrUpdateProduct_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_PatchProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).PatchProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_PatchProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).PatchProduct(ctx, req.(*PatchProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CatalogService_GetProductByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetProductByIDRequest)
 	if err := dec(in); err != nil {
@@ -242,6 +560,294 @@ func _CatalogService_SearchProducts_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CatalogService_ListRelatedProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRelatedProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListRelatedProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListRelatedProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListRelatedProducts(ctx, req.(*ListRelatedProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListProductsByMerchant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsByMerchantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListProductsByMerchant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListProductsByMerchant_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListProductsByMerchant(ctx, req.(*ListProductsByMerchantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_TransferProductOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferProductOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).TransferProductOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_TransferProductOwnership_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).TransferProductOwnership(ctx, req.(*TransferProductOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).DeleteProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_DeleteProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ImportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CatalogServiceServer).ImportProducts(&grpc.GenericServerStream[ImportProductsRequest, ImportProductsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ImportProductsServer = grpc.ClientStreamingServer[ImportProductsRequest, ImportProductsResponse]
+
+func _CatalogService_ExportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogServiceServer).ExportProducts(m, &grpc.GenericServerStream[ExportProductsRequest, Product]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CatalogService_ExportProductsServer = grpc.ServerStreamingServer[Product]
+
+func _CatalogService_AddProductMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddProductMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).AddProductMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_AddProductMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).AddProductMedia(ctx, req.(*AddProductMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateProductMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProductMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateProductMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateProductMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateProductMedia(ctx, req.(*UpdateProductMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_RemoveProductMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveProductMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).RemoveProductMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_RemoveProductMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).RemoveProductMedia(ctx, req.(*RemoveProductMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ReorderProductMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderProductMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ReorderProductMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ReorderProductMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ReorderProductMedia(ctx, req.(*ReorderProductMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateProductRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProductRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateProductRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateProductRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateProductRating(ctx, req.(*UpdateProductRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetPriceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetPriceHistory(ctx, req.(*GetPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_CreateOrUpdateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrUpdateCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).CreateOrUpdateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_CreateOrUpdateCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).CreateOrUpdateCategory(ctx, req.(*CreateOrUpdateCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetCategory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetCategory(ctx, req.(*GetCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_ListCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).ListCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_ListCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).ListCategories(ctx, req.(*ListCategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_GetSynonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSynonymsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).GetSynonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_GetSynonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).GetSynonyms(ctx, req.(*GetSynonymsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CatalogService_UpdateSynonyms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSynonymsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogServiceServer).UpdateSynonyms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CatalogService_UpdateSynonyms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogServiceServer).UpdateSynonyms(ctx, req.(*UpdateSynonymsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CatalogService_ServiceDesc is the grpc.ServiceDesc for CatalogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -253,6 +859,10 @@ var CatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateOrUpdateProduct",
 			Handler:    _CatalogService_CreateOrUpdateProduct_Handler,
 		},
+		{
+			MethodName: "PatchProduct",
+			Handler:    _CatalogService_PatchProduct_Handler,
+		},
 		{
 			MethodName: "GetProductByID",
 			Handler:    _CatalogService_GetProductByID_Handler,
@@ -269,7 +879,78 @@ var CatalogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchProducts",
 			Handler:    _CatalogService_SearchProducts_Handler,
 		},
+		{
+			MethodName: "ListRelatedProducts",
+			Handler:    _CatalogService_ListRelatedProducts_Handler,
+		},
+		{
+			MethodName: "ListProductsByMerchant",
+			Handler:    _CatalogService_ListProductsByMerchant_Handler,
+		},
+		{
+			MethodName: "TransferProductOwnership",
+			Handler:    _CatalogService_TransferProductOwnership_Handler,
+		},
+		{
+			MethodName: "DeleteProduct",
+			Handler:    _CatalogService_DeleteProduct_Handler,
+		},
+		{
+			MethodName: "AddProductMedia",
+			Handler:    _CatalogService_AddProductMedia_Handler,
+		},
+		{
+			MethodName: "UpdateProductMedia",
+			Handler:    _CatalogService_UpdateProductMedia_Handler,
+		},
+		{
+			MethodName: "RemoveProductMedia",
+			Handler:    _CatalogService_RemoveProductMedia_Handler,
+		},
+		{
+			MethodName: "ReorderProductMedia",
+			Handler:    _CatalogService_ReorderProductMedia_Handler,
+		},
+		{
+			MethodName: "UpdateProductRating",
+			Handler:    _CatalogService_UpdateProductRating_Handler,
+		},
+		{
+			MethodName: "GetPriceHistory",
+			Handler:    _CatalogService_GetPriceHistory_Handler,
+		},
+		{
+			MethodName: "CreateOrUpdateCategory",
+			Handler:    _CatalogService_CreateOrUpdateCategory_Handler,
+		},
+		{
+			MethodName: "GetCategory",
+			Handler:    _CatalogService_GetCategory_Handler,
+		},
+		{
+			MethodName: "ListCategories",
+			Handler:    _CatalogService_ListCategories_Handler,
+		},
+		{
+			MethodName: "GetSynonyms",
+			Handler:    _CatalogService_GetSynonyms_Handler,
+		},
+		{
+			MethodName: "UpdateSynonyms",
+			Handler:    _CatalogService_UpdateSynonyms_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportProducts",
+			Handler:       _CatalogService_ImportProducts_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportProducts",
+			Handler:       _CatalogService_ExportProducts_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "catalog.proto",
 }
@@ -0,0 +1,722 @@
+package catalog
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	"github.com/lib/pq"
+)
+
+// PostgresRepository stores products as the same documents ElasticRepository
+// indexes, with the fields listings filter and sort on copied into columns.
+// Search uses a tsvector of each product's text, stemmed per locale; see
+// up.sql for the schema.
+type PostgresRepository struct {
+	db     *sql.DB
+	logger util.Logger
+}
+
+func NewPostgresRepository(url string, logger util.Logger) (Repository, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+	return &PostgresRepository{db, logger}, nil
+}
+
+// textSearchConfigs maps locales to the Postgres text search configuration
+// their text is stemmed with.
+var textSearchConfigs = map[string]string{
+	"en": "english",
+	"de": "german",
+	"fr": "french",
+	"es": "spanish",
+}
+
+func textSearchConfig(locale string) string {
+	if config, ok := textSearchConfigs[locale]; ok {
+		return config
+	}
+	return "simple"
+}
+
+// productColumns are written from the arguments productArgs returns, in
+// order, followed by the search vector built from the remaining arguments.
+const productColumns = "id, document, merchant_id, status, category_id, publish_at, unpublish_at, deleted_at, next_price_at, rating_average, rating_count, search"
+
+// searchVector weighs names above descriptions, in the default locale and in
+// every translation.
+var searchVector = func() string {
+	parts := []string{}
+	n := 12
+	for _, locale := range Locales {
+		config := textSearchConfig(locale)
+		parts = append(parts,
+			fmt.Sprintf("setweight(to_tsvector('%s', $%d), 'A')", config, n),
+			fmt.Sprintf("setweight(to_tsvector('%s', $%d), 'B')", config, n+1),
+		)
+		n += 2
+	}
+	return strings.Join(parts, " || ")
+}()
+
+func productArgs(product *Product) ([]interface{}, error) {
+	document, err := json.Marshal(toProductDocument(product))
+	if err != nil {
+		return nil, err
+	}
+	status := product.Status
+	if status == "" {
+		status = ProductStatusPublished
+	}
+	var nextPriceAt *time.Time
+	for _, scheduled := range product.ScheduledPrices {
+		if nextPriceAt == nil || scheduled.EffectiveFrom.Before(*nextPriceAt) {
+			effectiveFrom := scheduled.EffectiveFrom
+			nextPriceAt = &effectiveFrom
+		}
+	}
+	args := []interface{}{
+		product.ID,
+		string(document),
+		product.MerchantID,
+		string(status),
+		product.CategoryID,
+		product.PublishAt,
+		product.UnpublishAt,
+		product.DeletedAt,
+		nextPriceAt,
+		product.Rating.Average,
+		product.Rating.Count,
+	}
+	for _, locale := range Locales {
+		if locale == DefaultLocale {
+			args = append(args, product.Name, product.Description)
+			continue
+		}
+		translation := product.Translations[locale]
+		if translation == nil {
+			translation = &ProductTranslation{}
+		}
+		args = append(args, translation.Name, translation.Description)
+	}
+	return args, nil
+}
+
+func (repository *PostgresRepository) Close() {
+	repository.db.Close()
+}
+
+func (repository *PostgresRepository) CreateOrUpdateProduct(ctx context.Context, product *Product) (*Product, error) {
+	if err := writeProduct(ctx, repository.db, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+type rowQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// writeProduct inserts product if its version is empty and otherwise updates
+// it if the stored version matches, then sets its new version.
+func writeProduct(ctx context.Context, db rowQueryer, product *Product) error {
+	args, err := productArgs(product)
+	if err != nil {
+		return err
+	}
+	var version int64
+	if product.Version == "" {
+		err = db.QueryRowContext(
+			ctx,
+			"INSERT INTO products ("+productColumns+", version) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, "+searchVector+", 1) ON CONFLICT (id) DO NOTHING RETURNING version",
+			args...,
+		).Scan(&version)
+	} else {
+		current, parseErr := parsePostgresVersion(product.Version)
+		if parseErr != nil {
+			return parseErr
+		}
+		err = db.QueryRowContext(
+			ctx,
+			"UPDATE products SET ("+productColumns+", version) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, "+searchVector+", version + 1) WHERE id = $1 AND version = $"+strconv.Itoa(len(args)+1)+" RETURNING version",
+			append(args, current)...,
+		).Scan(&version)
+	}
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: product %s", ErrVersionConflict, product.ID)
+	}
+	if err != nil {
+		return err
+	}
+	product.Version = strconv.FormatInt(version, 10)
+	return nil
+}
+
+func parsePostgresVersion(version string) (int64, error) {
+	number, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid product version %q", version)
+	}
+	return number, nil
+}
+
+func (repository *PostgresRepository) GetProductById(ctx context.Context, id string) (*Product, error) {
+	products, err := repository.queryProducts(ctx, "SELECT id, version, document FROM products WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(products) == 0 {
+		return nil, ErrProductNotFound
+	}
+	return products[0], nil
+}
+
+func (repository *PostgresRepository) ListProducts(ctx context.Context, skip uint64, take uint64, cursor string, visibility Visibility, filter ProductFilter) (*ProductPage, error) {
+	listing, err := listingKey("list", visibility, filter)
+	if err != nil {
+		return nil, err
+	}
+	query := &productQuery{}
+	query.visibility(visibility)
+	query.filter(filter)
+	return repository.page(ctx, listing, query, "", skip, take, cursor, filter.Sort)
+}
+
+// ListProductsWithIds returns the products found in the order of ids.
+func (repository *PostgresRepository) ListProductsWithIds(ctx context.Context, ids []string) ([]*Product, error) {
+	found, err := repository.queryProducts(ctx, "SELECT id, version, document FROM products WHERE id = ANY($1)", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]*Product{}
+	for _, product := range found {
+		byID[product.ID] = product
+	}
+	products := []*Product{}
+	for _, id := range ids {
+		if product, ok := byID[id]; ok {
+			products = append(products, product)
+		}
+	}
+	return products, nil
+}
+
+// SearchProducts matches any word of the query, expanded with the synonyms
+// of the default locale and the filter's locale, and ranks products by
+// ts_rank.
+func (repository *PostgresRepository) SearchProducts(ctx context.Context, query string, skip uint64, take uint64, cursor string, visibility Visibility, filter ProductFilter) (*ProductPage, error) {
+	listing, err := listingKey("search", query, visibility, filter)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := repository.GetSynonyms(ctx, DefaultLocale)
+	if err != nil {
+		return nil, err
+	}
+	locales := []string{DefaultLocale}
+	if filter.Locale != "" && filter.Locale != DefaultLocale {
+		localeRules, err := repository.GetSynonyms(ctx, filter.Locale)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, localeRules...)
+		locales = append(locales, filter.Locale)
+	}
+	search := &productQuery{}
+	search.visibility(visibility)
+	search.filter(filter)
+	tsquery := search.tsquery(strings.Join(expandSynonyms(query, rules), " "), locales...)
+	search.where("search @@ " + tsquery)
+	return repository.page(ctx, listing, search, "ts_rank(search, "+tsquery+")", skip, take, cursor, filter.Sort)
+}
+
+func (repository *PostgresRepository) ListProductsByMerchant(ctx context.Context, merchantID string, skip uint64, take uint64, visibility Visibility) ([]*Product, error) {
+	query := &productQuery{}
+	query.visibility(visibility)
+	query.where("merchant_id = " + query.arg(merchantID))
+	return repository.queryProducts(
+		ctx,
+		"SELECT id, version, document FROM products WHERE "+query.conditionSQL()+" ORDER BY id OFFSET "+query.arg(skip)+" LIMIT "+query.arg(take),
+		query.args...,
+	)
+}
+
+// ListRelatedProducts ranks products matching any word of the product's name
+// and description, adding two for the same category.
+func (repository *PostgresRepository) ListRelatedProducts(ctx context.Context, product *Product, take uint64, visibility Visibility) ([]*Product, error) {
+	query := &productQuery{}
+	query.visibility(visibility)
+	query.where("id <> " + query.arg(product.ID))
+	tsquery := query.tsquery(product.Name+" "+product.Description, DefaultLocale)
+	query.where("search @@ " + tsquery)
+	score := "ts_rank(search, " + tsquery + ")"
+	if product.CategoryID != "" {
+		score += " + CASE WHEN category_id = " + query.arg(product.CategoryID) + " THEN 2 ELSE 0 END"
+	}
+	return repository.queryProducts(
+		ctx,
+		"SELECT id, version, document FROM products WHERE "+query.conditionSQL()+" ORDER BY "+score+" DESC, id LIMIT "+query.arg(take),
+		query.args...,
+	)
+}
+
+func (repository *PostgresRepository) ListDueProducts(ctx context.Context, at time.Time, take uint64) ([]*Product, error) {
+	return repository.queryProducts(ctx, `
+		SELECT id, version, document FROM products
+		WHERE deleted_at IS NULL AND (
+			(status = 'draft' AND publish_at <= $1) OR
+			(status = 'published' AND unpublish_at <= $1) OR
+			next_price_at <= $1
+		)
+		ORDER BY id LIMIT $2`, at, take)
+}
+
+// BulkCreateOrUpdateProducts writes the products in one transaction.
+// Version conflicts fail only their product; other errors fail them all.
+func (repository *PostgresRepository) BulkCreateOrUpdateProducts(ctx context.Context, products []*Product) ([]error, error) {
+	errs := make([]error, len(products))
+	if len(products) == 0 {
+		return errs, nil
+	}
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i, product := range products {
+		if product.Version != "" {
+			if _, err := parsePostgresVersion(product.Version); err != nil {
+				errs[i] = err
+				continue
+			}
+		}
+		err := writeProduct(ctx, tx, product)
+		if errors.Is(err, ErrVersionConflict) {
+			errs[i] = err
+			continue
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+func (repository *PostgresRepository) ExportProducts(ctx context.Context, merchantID string, visibility Visibility, fn func(*Product) error) error {
+	query := &productQuery{}
+	query.visibility(visibility)
+	if merchantID != "" {
+		query.where("merchant_id = " + query.arg(merchantID))
+	}
+	rows, err := repository.db.QueryContext(ctx, "SELECT id, version, document FROM products WHERE "+query.conditionSQL()+" ORDER BY id", query.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (repository *PostgresRepository) UpdateProductRating(ctx context.Context, id string, rating ProductRating) error {
+	res, err := repository.db.ExecContext(ctx, `
+		UPDATE products SET
+			rating_average = $2,
+			rating_count = $3,
+			document = document || jsonb_build_object('rating_average', $2::float8, 'rating_count', $3::bigint),
+			version = version + 1
+		WHERE id = $1`, id, rating.Average, rating.Count)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+func (repository *PostgresRepository) AddPriceChanges(ctx context.Context, changes []*PriceChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		document, err := json.Marshal(PriceChangeDocument{
+			ProductID:      change.ProductID,
+			EffectiveFrom:  change.EffectiveFrom,
+			PriceAmount:    change.Price.Amount,
+			Currency:       change.Price.Currency,
+			Prices:         toPriceDocuments(change.Prices),
+			CompareAtPrice: toPriceDocument(change.CompareAtPrice),
+		})
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO price_changes (id, product_id, effective_from, document) VALUES ($1, $2, $3, $4) ON CONFLICT (id) DO UPDATE SET product_id = $2, effective_from = $3, document = $4",
+			change.ID, change.ProductID, change.EffectiveFrom, string(document),
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (repository *PostgresRepository) ListPriceChanges(ctx context.Context, productID string, skip uint64, take uint64) ([]*PriceChange, error) {
+	rows, err := repository.db.QueryContext(ctx, "SELECT id, document FROM price_changes WHERE product_id = $1 ORDER BY effective_from DESC, id DESC OFFSET $2 LIMIT $3", productID, skip, take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	changes := []*PriceChange{}
+	for rows.Next() {
+		var id string
+		var source []byte
+		if err := rows.Scan(&id, &source); err != nil {
+			return nil, err
+		}
+		document := PriceChangeDocument{}
+		if err := json.Unmarshal(source, &document); err != nil {
+			return nil, err
+		}
+		changes = append(changes, &PriceChange{
+			ID:             id,
+			ProductID:      document.ProductID,
+			EffectiveFrom:  document.EffectiveFrom,
+			Price:          money.New(document.PriceAmount, document.Currency),
+			Prices:         fromPriceDocuments(document.Prices),
+			CompareAtPrice: fromPriceDocument(document.CompareAtPrice),
+		})
+	}
+	return changes, rows.Err()
+}
+
+func (repository *PostgresRepository) CreateOrUpdateCategory(ctx context.Context, category *Category) (*Category, error) {
+	document, err := json.Marshal(CategoryDocument{Name: category.Name, Attributes: category.Attributes})
+	if err != nil {
+		return nil, err
+	}
+	_, err = repository.db.ExecContext(
+		ctx,
+		"INSERT INTO categories (id, name, document) VALUES ($1, $2, $3) ON CONFLICT (id) DO UPDATE SET name = $2, document = $3",
+		category.ID, category.Name, string(document),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (repository *PostgresRepository) GetCategoryById(ctx context.Context, id string) (*Category, error) {
+	var source []byte
+	err := repository.db.QueryRowContext(ctx, "SELECT document FROM categories WHERE id = $1", id).Scan(&source)
+	if err == sql.ErrNoRows {
+		return nil, ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeCategory(id, source)
+}
+
+func (repository *PostgresRepository) ListCategories(ctx context.Context, skip uint64, take uint64) ([]*Category, error) {
+	rows, err := repository.db.QueryContext(ctx, "SELECT id, document FROM categories ORDER BY name, id OFFSET $1 LIMIT $2", skip, take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	categories := []*Category{}
+	for rows.Next() {
+		var id string
+		var source []byte
+		if err := rows.Scan(&id, &source); err != nil {
+			return nil, err
+		}
+		category, err := decodeCategory(id, source)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+	return categories, rows.Err()
+}
+
+func (repository *PostgresRepository) GetSynonyms(ctx context.Context, locale string) ([]string, error) {
+	rows, err := repository.db.QueryContext(ctx, "SELECT rule FROM synonyms WHERE locale = $1 ORDER BY position", locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	rules := []string{}
+	for rows.Next() {
+		var rule string
+		if err := rows.Scan(&rule); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+func (repository *PostgresRepository) UpdateSynonyms(ctx context.Context, locale string, rules []string) error {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM synonyms WHERE locale = $1", locale); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for position, rule := range rules {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO synonyms (locale, position, rule) VALUES ($1, $2, $3)", locale, position, rule); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// page lists the products matching query, ranked by score when searching,
+// in the order ElasticRepository lists them in. Cursors hold the sort key
+// of a product and the next page starts after it; those of other listings
+// than listing are rejected.
+func (repository *PostgresRepository) page(ctx context.Context, listing string, query *productQuery, score string, skip uint64, take uint64, cursor string, order ProductSort) (*ProductPage, error) {
+	state := &keysetCursor{Offset: skip}
+	if cursor != "" {
+		var err error
+		state, err = decodeKeysetCursor(cursor, listing)
+		if err != nil {
+			return nil, err
+		}
+	}
+	page := &ProductPage{Products: []*Product{}, Cursors: []string{}}
+	err := repository.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM products WHERE "+query.conditionSQL(), query.args...).Scan(&page.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	terms := []orderTerm{}
+	if order == ProductSortRating {
+		terms = append(terms,
+			orderTerm{column: "rating_average", descending: true, value: state.RatingAverage},
+			orderTerm{column: "rating_count", descending: true, value: state.RatingCount},
+		)
+	}
+	if score != "" {
+		// ts_rank is a real, so the cursor's score is compared as one.
+		terms = append(terms, orderTerm{column: score, descending: true, value: state.Score, cast: "::real"})
+	} else {
+		score = "0"
+	}
+	terms = append(terms, orderTerm{column: "id", value: state.ID})
+	if cursor != "" {
+		query.where(query.after(terms))
+	}
+	orderBy := []string{}
+	for _, term := range terms {
+		if term.descending {
+			orderBy = append(orderBy, term.column+" DESC")
+		} else {
+			orderBy = append(orderBy, term.column)
+		}
+	}
+	statement := "SELECT id, version, document, rating_average, rating_count, " + score + " FROM products WHERE " + query.conditionSQL() +
+		" ORDER BY " + strings.Join(orderBy, ", ") + " LIMIT " + query.arg(take)
+	if cursor == "" {
+		statement += " OFFSET " + query.arg(skip)
+	}
+	rows, err := repository.db.QueryContext(ctx, statement, query.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	offset := state.Offset
+	for rows.Next() {
+		var id string
+		var version int64
+		var source []byte
+		key := &keysetCursor{Listing: listing}
+		if err := rows.Scan(&id, &version, &source, &key.RatingAverage, &key.RatingCount, &key.Score); err != nil {
+			return nil, err
+		}
+		product, err := decodePostgresProduct(id, version, source)
+		if err != nil {
+			return nil, err
+		}
+		offset++
+		key.ID = id
+		key.Offset = offset
+		productCursor, err := encodeKeysetCursor(key)
+		if err != nil {
+			return nil, err
+		}
+		page.Products = append(page.Products, product)
+		page.Cursors = append(page.Cursors, productCursor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(page.Cursors) > 0 && offset < page.TotalCount {
+		page.NextCursor = page.Cursors[len(page.Cursors)-1]
+	}
+	return page, nil
+}
+
+func (repository *PostgresRepository) queryProducts(ctx context.Context, query string, args ...interface{}) ([]*Product, error) {
+	rows, err := repository.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	products := []*Product{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, rows.Err()
+}
+
+// scanProduct reads a product selected as id, version and document.
+func scanProduct(rows *sql.Rows) (*Product, error) {
+	var id string
+	var version int64
+	var source []byte
+	if err := rows.Scan(&id, &version, &source); err != nil {
+		return nil, err
+	}
+	return decodePostgresProduct(id, version, source)
+}
+
+func decodePostgresProduct(id string, version int64, source []byte) (*Product, error) {
+	product, err := decodeProduct(id, source, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	product.Version = strconv.FormatInt(version, 10)
+	return product, nil
+}
+
+// productQuery collects the conditions of a products query and the
+// arguments they refer to.
+type productQuery struct {
+	conditions []string
+	args       []interface{}
+}
+
+// arg adds an argument and returns its placeholder.
+func (query *productQuery) arg(value interface{}) string {
+	query.args = append(query.args, value)
+	return "$" + strconv.Itoa(len(query.args))
+}
+
+func (query *productQuery) where(condition string) {
+	query.conditions = append(query.conditions, condition)
+}
+
+func (query *productQuery) conditionSQL() string {
+	if len(query.conditions) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(query.conditions, " AND ")
+}
+
+func (query *productQuery) visibility(visibility Visibility) {
+	query.where("deleted_at IS NULL")
+	if visibility == VisibilityPublished {
+		query.where("status = 'published'")
+	}
+}
+
+// filter adds the category and attribute filters. Each attribute filter
+// must match a single attribute of the product.
+func (query *productQuery) filter(filter ProductFilter) {
+	if filter.CategoryID != "" {
+		query.where("category_id = " + query.arg(filter.CategoryID))
+	}
+	for _, attribute := range filter.Attributes {
+		match := []string{"attribute->>'name' = " + query.arg(attribute.Name)}
+		if attribute.Value != "" {
+			match = append(match, "attribute->>'value' = "+query.arg(attribute.Value))
+		}
+		if attribute.Min != nil {
+			match = append(match, "(attribute->>'number')::float8 >= "+query.arg(*attribute.Min))
+		}
+		if attribute.Max != nil {
+			match = append(match, "(attribute->>'number')::float8 <= "+query.arg(*attribute.Max))
+		}
+		query.where("EXISTS (SELECT 1 FROM jsonb_array_elements(document->'attributes') AS attribute WHERE " + strings.Join(match, " AND ") + ")")
+	}
+}
+
+// tsquery returns a query matching any word of text, stemmed in each of the
+// locales.
+func (query *productQuery) tsquery(text string, locales ...string) string {
+	placeholder := query.arg(text)
+	parts := []string{}
+	for _, locale := range locales {
+		parts = append(parts, fmt.Sprintf("replace(plainto_tsquery('%s', %s)::text, '&', '|')::tsquery", textSearchConfig(locale), placeholder))
+	}
+	return "(" + strings.Join(parts, " || ") + ")"
+}
+
+// orderTerm is a column, or expression, a page is sorted by and its value in
+// the cursor the page starts after.
+type orderTerm struct {
+	column     string
+	descending bool
+	value      interface{}
+	cast       string
+}
+
+// after returns the condition that a row sorts after the cursor's values.
+func (query *productQuery) after(terms []orderTerm) string {
+	alternatives := []string{}
+	equal := []string{}
+	for _, term := range terms {
+		operator := " > "
+		if term.descending {
+			operator = " < "
+		}
+		value := query.arg(term.value) + term.cast
+		alternative := append(append([]string{}, equal...), term.column+operator+value)
+		alternatives = append(alternatives, "("+strings.Join(alternative, " AND ")+")")
+		equal = append(equal, term.column+" = "+value)
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
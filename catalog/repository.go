@@ -1,22 +1,91 @@
 package catalog
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 	"github.com/olivere/elastic/v7"
 )
 
+var (
+	ErrProductNotFound  = errors.New("product not found")
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrVersionConflict  = errors.New("product was changed by someone else")
+	ErrInvalidCursor    = errors.New("cursor is invalid or has expired")
+)
+
 type Repository interface {
 	Close()
+	// CreateOrUpdateProduct creates the product if its version is empty and
+	// otherwise updates it only if the stored product has that version,
+	// returning ErrVersionConflict if not. The product's version is set to the
+	// new version.
 	CreateOrUpdateProduct(ctx context.Context, product *Product) (*Product, error)
 	GetProductById(ctx context.Context, id string) (*Product, error)
-	ListProducts(ctx context.Context, skip uint64, take uint64) ([]*Product, error)
+	// ListProducts and SearchProducts return a page of products starting
+	// after cursor or, without one, after skipping skip products. Pages
+	// reached through cursors are stable while the index changes, and are not
+	// limited in depth as skip is. A cursor is only valid with the query,
+	// visibility and filter of the page it came from; with others,
+	// ErrInvalidCursor is returned.
+	ListProducts(ctx context.Context, skip uint64, take uint64, cursor string, visibility Visibility, filter ProductFilter) (*ProductPage, error)
 	ListProductsWithIds(ctx context.Context, ids []string) ([]*Product, error)
-	SearchProducts(ctx context.Context, query string, skip uint64, take uint64) ([]*Product, error)
+	SearchProducts(ctx context.Context, query string, skip uint64, take uint64, cursor string, visibility Visibility, filter ProductFilter) (*ProductPage, error)
+	ListProductsByMerchant(ctx context.Context, merchantID string, skip uint64, take uint64, visibility Visibility) ([]*Product, error)
+	// ListRelatedProducts returns products whose name and description are
+	// similar to the given product's, favouring products in its category.
+	ListRelatedProducts(ctx context.Context, product *Product, take uint64, visibility Visibility) ([]*Product, error)
+	// ListDueProducts returns drafts whose publish time, published products
+	// whose unpublish time and products with a scheduled price whose time is
+	// at or before the given time.
+	ListDueProducts(ctx context.Context, at time.Time, take uint64) ([]*Product, error)
+	// BulkCreateOrUpdateProducts writes products in one request, checking
+	// versions like CreateOrUpdateProduct. The returned slice holds the error,
+	// if any, for the product at the same index.
+	BulkCreateOrUpdateProducts(ctx context.Context, products []*Product) ([]error, error)
+	// ExportProducts calls fn for every product matching visibility, and owned
+	// by merchantID when it is set, stopping at the first error.
+	ExportProducts(ctx context.Context, merchantID string, visibility Visibility, fn func(*Product) error) error
+	// UpdateProductRating sets the product's rating without otherwise
+	// changing it.
+	UpdateProductRating(ctx context.Context, id string, rating ProductRating) error
+	// AddPriceChanges records changes to product prices.
+	AddPriceChanges(ctx context.Context, changes []*PriceChange) error
+	// ListPriceChanges returns the price changes of a product, latest first.
+	ListPriceChanges(ctx context.Context, productID string, skip uint64, take uint64) ([]*PriceChange, error)
+	CreateOrUpdateCategory(ctx context.Context, category *Category) (*Category, error)
+	GetCategoryById(ctx context.Context, id string) (*Category, error)
+	ListCategories(ctx context.Context, skip uint64, take uint64) ([]*Category, error)
+	// GetSynonyms returns the synonym rules of a locale, in Solr format such
+	// as "tee, t-shirt".
+	GetSynonyms(ctx context.Context, locale string) ([]string, error)
+	// UpdateSynonyms replaces the synonym rules of a locale. Searches use
+	// them right away, without reindexing.
+	UpdateSynonyms(ctx context.Context, locale string, rules []string) error
 }
 
+// Visibility limits which products list and search queries return. Soft
+// deleted products are never listed.
+type Visibility int
+
+const (
+	VisibilityPublished Visibility = iota
+	VisibilityAll
+)
+
 type ElasticRepository struct {
 	client *elastic.Client
 	logger util.Logger
@@ -27,7 +96,238 @@ func NewElasticRepository(url string, logger util.Logger) (Repository, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &ElasticRepository{client: client, logger: logger}, nil
+	repository := &ElasticRepository{client: client, logger: logger}
+	if err := repository.ensureIndex(context.Background()); err != nil {
+		client.Stop()
+		return nil, err
+	}
+	return repository, nil
+}
+
+// productMapping declares fields that must not be left to dynamic mapping,
+// such as ids matched exactly in term queries.
+var productMapping = map[string]interface{}{
+	"properties": map[string]interface{}{
+		"name":           map[string]interface{}{"type": "text", "fields": map[string]interface{}{"localized": localizedText(DefaultLocale)}},
+		"description":    map[string]interface{}{"type": "text", "fields": map[string]interface{}{"localized": localizedText(DefaultLocale)}},
+		"price_amount":   map[string]interface{}{"type": "long"},
+		"currency":       map[string]interface{}{"type": "keyword"},
+		"merchant_id":    map[string]interface{}{"type": "keyword"},
+		"status":         map[string]interface{}{"type": "keyword"},
+		"publish_at":     map[string]interface{}{"type": "date"},
+		"unpublish_at":   map[string]interface{}{"type": "date"},
+		"deleted_at":     map[string]interface{}{"type": "date"},
+		"media":          map[string]interface{}{"type": "object", "enabled": false},
+		"category_id":    map[string]interface{}{"type": "keyword"},
+		"tax_category":   map[string]interface{}{"type": "keyword"},
+		"weight":         map[string]interface{}{"type": "integer"},
+		"rating_average": map[string]interface{}{"type": "double"},
+		"rating_count":   map[string]interface{}{"type": "long"},
+		"translations":   translationsMapping(),
+		"compare_at_price": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"amount":   map[string]interface{}{"type": "long"},
+				"currency": map[string]interface{}{"type": "keyword"},
+			},
+		},
+		"scheduled_prices": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"effective_from":   map[string]interface{}{"type": "date"},
+				"price_amount":     map[string]interface{}{"type": "long"},
+				"currency":         map[string]interface{}{"type": "keyword"},
+				"prices":           map[string]interface{}{"type": "object", "enabled": false},
+				"compare_at_price": map[string]interface{}{"type": "object", "enabled": false},
+			},
+		},
+		// Attributes are nested so a filter matches name and value of the same attribute.
+		"attributes": map[string]interface{}{
+			"type": "nested",
+			"properties": map[string]interface{}{
+				"name":    map[string]interface{}{"type": "keyword"},
+				"value":   map[string]interface{}{"type": "keyword"},
+				"type":    map[string]interface{}{"type": "keyword"},
+				"unit":    map[string]interface{}{"type": "keyword"},
+				"number":  map[string]interface{}{"type": "double"},
+				"boolean": map[string]interface{}{"type": "boolean"},
+			},
+		},
+	},
+}
+
+var categoryMapping = map[string]interface{}{
+	"properties": map[string]interface{}{
+		"name":       map[string]interface{}{"type": "text", "fields": map[string]interface{}{"keyword": map[string]interface{}{"type": "keyword"}}},
+		"attributes": map[string]interface{}{"type": "object", "enabled": false},
+	},
+}
+
+var priceHistoryMapping = map[string]interface{}{
+	"properties": map[string]interface{}{
+		"product_id":       map[string]interface{}{"type": "keyword"},
+		"effective_from":   map[string]interface{}{"type": "date"},
+		"price_amount":     map[string]interface{}{"type": "long"},
+		"currency":         map[string]interface{}{"type": "keyword"},
+		"prices":           map[string]interface{}{"type": "object", "enabled": false},
+		"compare_at_price": map[string]interface{}{"type": "object", "enabled": false},
+	},
+}
+
+// ensureIndex creates the catalog, categories and price history indices with their mappings,
+// or adds the mappings to indices created before they existed.
+func (repository *ElasticRepository) ensureIndex(ctx context.Context) error {
+	// The analyzers refer to the synonym sets, so these must exist first.
+	for _, locale := range Locales {
+		if err := repository.ensureSynonymSet(ctx, locale); err != nil {
+			return err
+		}
+	}
+	if err := repository.ensureMapping(ctx, "catalog", analysisSettings(), productMapping); err != nil {
+		return err
+	}
+	if err := repository.ensureMapping(ctx, "categories", nil, categoryMapping); err != nil {
+		return err
+	}
+	return repository.ensureMapping(ctx, "price_history", nil, priceHistoryMapping)
+}
+
+func (repository *ElasticRepository) ensureMapping(ctx context.Context, index string, analysis map[string]interface{}, mapping map[string]interface{}) error {
+	exists, err := repository.client.IndexExists(index).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		body := map[string]interface{}{"mappings": mapping}
+		if analysis != nil {
+			body["settings"] = map[string]interface{}{"analysis": analysis}
+		}
+		_, err = repository.client.CreateIndex(index).BodyJson(body).Do(ctx)
+		return err
+	}
+	added := false
+	if analysis != nil {
+		added, err = repository.ensureAnalysis(ctx, index, analysis)
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := repository.client.PutMapping().Index(index).BodyJson(mapping).Do(ctx); err != nil {
+		repository.logger.Database().Warn().Err(err).Str("index", index).Msg("failed to update index mapping")
+		return nil
+	}
+	if added {
+		// Documents indexed before the analyzers existed lack the new
+		// subfields until they are indexed again.
+		_, err := repository.client.UpdateByQuery(index).
+			ProceedOnVersionConflict().
+			WaitForCompletion(false).
+			Do(ctx)
+		if err != nil {
+			repository.logger.Database().Warn().Err(err).Str("index", index).Msg("failed to reindex documents")
+		}
+	}
+	return nil
+}
+
+// ensureAnalysis adds analyzers missing from an existing index, which has to
+// be closed while its analysis settings change. It reports whether any were
+// added.
+func (repository *ElasticRepository) ensureAnalysis(ctx context.Context, index string, analysis map[string]interface{}) (bool, error) {
+	settings, err := repository.client.IndexGetSettings(index).Do(ctx)
+	if err != nil {
+		return false, err
+	}
+	existing := map[string]interface{}{}
+	if indexSettings, ok := settings[index]; ok {
+		existing = nestedMap(indexSettings.Settings, "index", "analysis", "analyzer")
+	}
+	missing := false
+	for name := range analysis["analyzer"].(map[string]interface{}) {
+		if _, ok := existing[name]; !ok {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return false, nil
+	}
+	repository.logger.Database().Info().Str("index", index).Msg("adding analyzers to index")
+	if _, err := repository.client.CloseIndex(index).Do(ctx); err != nil {
+		return false, err
+	}
+	_, err = repository.client.IndexPutSettings(index).
+		BodyJson(map[string]interface{}{"analysis": analysis}).
+		Do(ctx)
+	if _, openErr := repository.client.OpenIndex(index).Do(ctx); openErr != nil && err == nil {
+		err = openErr
+	}
+	if err != nil {
+		return false, err
+	}
+	if _, err := repository.client.ClusterHealth().Index(index).WaitForYellowStatus().Do(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func nestedMap(values map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, key := range keys {
+		next, ok := values[key].(map[string]interface{})
+		if !ok {
+			return map[string]interface{}{}
+		}
+		values = next
+	}
+	return values
+}
+
+// ensureSynonymSet creates the locale's synonym set, without synonyms, if it
+// does not exist yet.
+func (repository *ElasticRepository) ensureSynonymSet(ctx context.Context, locale string) error {
+	_, err := repository.client.PerformRequest(ctx, elastic.PerformRequestOptions{
+		Method: http.MethodGet,
+		Path:   "/_synonyms/" + SynonymSetID(locale),
+	})
+	if elastic.IsNotFound(err) {
+		return repository.UpdateSynonyms(ctx, locale, []string{})
+	}
+	return err
+}
+
+func (repository *ElasticRepository) GetSynonyms(ctx context.Context, locale string) ([]string, error) {
+	res, err := repository.client.PerformRequest(ctx, elastic.PerformRequestOptions{
+		Method: http.MethodGet,
+		Path:   "/_synonyms/" + SynonymSetID(locale),
+		Params: url.Values{"size": []string{strconv.Itoa(maxSynonymRules)}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var body struct {
+		SynonymsSet []struct {
+			Synonyms string `json:"synonyms"`
+		} `json:"synonyms_set"`
+	}
+	if err := json.Unmarshal(res.Body, &body); err != nil {
+		return nil, err
+	}
+	rules := []string{}
+	for _, rule := range body.SynonymsSet {
+		rules = append(rules, rule.Synonyms)
+	}
+	return rules, nil
+}
+
+func (repository *ElasticRepository) UpdateSynonyms(ctx context.Context, locale string, rules []string) error {
+	set := []map[string]string{}
+	for _, rule := range rules {
+		set = append(set, map[string]string{"synonyms": rule})
+	}
+	_, err := repository.client.PerformRequest(ctx, elastic.PerformRequestOptions{
+		Method: http.MethodPut,
+		Path:   "/_synonyms/" + SynonymSetID(locale),
+		Body:   map[string]interface{}{"synonyms_set": set},
+	})
+	return err
 }
 
 func (repository *ElasticRepository) Close() {
@@ -35,18 +335,27 @@ func (repository *ElasticRepository) Close() {
 }
 
 func (repository *ElasticRepository) CreateOrUpdateProduct(ctx context.Context, product *Product) (*Product, error) {
-	_, err := repository.client.Index().
+	request := repository.client.Index().
 		Index("catalog").
 		Id(product.ID).
-		BodyJson(ProductDocument{
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-		}).
-		Do(ctx)
+		BodyJson(toProductDocument(product))
+	if product.Version == "" {
+		request = request.OpType("create")
+	} else {
+		seqNo, primaryTerm, err := parseVersion(product.Version)
+		if err != nil {
+			return nil, err
+		}
+		request = request.IfSeqNo(seqNo).IfPrimaryTerm(primaryTerm)
+	}
+	res, err := request.Do(ctx)
+	if elastic.IsConflict(err) {
+		return nil, fmt.Errorf("%w: product %s", ErrVersionConflict, product.ID)
+	}
 	if err != nil {
 		return nil, err
 	}
+	product.Version = formatVersion(res.SeqNo, res.PrimaryTerm)
 	return product, nil
 }
 
@@ -55,45 +364,21 @@ func (repository *ElasticRepository) GetProductById(ctx context.Context, id stri
 		Index("catalog").
 		Id(id).
 		Do(ctx)
-	if err != nil {
-		return nil, err
+	if elastic.IsNotFound(err) {
+		return nil, ErrProductNotFound
 	}
-	product := ProductDocument{}
-	if err := json.Unmarshal(res.Source, &product); err != nil {
+	if err != nil {
 		return nil, err
 	}
-	return &Product{
-		ID:          id,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-	}, nil
+	return decodeProduct(id, res.Source, res.SeqNo, res.PrimaryTerm)
 }
 
-func (repository *ElasticRepository) ListProducts(ctx context.Context, skip uint64, take uint64) ([]*Product, error) {
-	res, err := repository.client.Search().
-		Index("catalog").
-		Query(elastic.NewMatchAllQuery()).
-		From(int(skip)).
-		Size(int(take)).
-		Do(ctx)
+func (repository *ElasticRepository) ListProducts(ctx context.Context, skip uint64, take uint64, cursor string, visibility Visibility, filter ProductFilter) (*ProductPage, error) {
+	listing, err := listingKey("list", visibility, filter)
 	if err != nil {
 		return nil, err
 	}
-	products := []*Product{}
-	for _, hit := range res.Hits.Hits {
-		product := ProductDocument{}
-		if err := json.Unmarshal(hit.Source, &product); err != nil {
-			return nil, err
-		}
-		products = append(products, &Product{
-			ID:          hit.Id,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-		})
-	}
-	return products, nil
+	return repository.searchPage(ctx, listing, filterQuery(visibilityQuery(visibility), filter), skip, take, cursor, sorters(filter.Sort)...)
 }
 
 func (repository *ElasticRepository) ListProductsWithIds(ctx context.Context, ids []string) ([]*Product, error) {
@@ -114,39 +399,688 @@ func (repository *ElasticRepository) ListProductsWithIds(ctx context.Context, id
 	}
 	products := []*Product{}
 	for _, doc := range res.Docs {
-		product := ProductDocument{}
-		if err = json.Unmarshal(doc.Source, &product); err == nil {
-			products = append(products, &Product{
-				ID:          doc.Id,
-				Name:        product.Name,
-				Description: product.Description,
-				Price:       product.Price,
-			})
+		if !doc.Found {
+			continue
 		}
+		product, err := decodeProduct(doc.Id, doc.Source, doc.SeqNo, doc.PrimaryTerm)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
 	}
 	return products, nil
 }
 
-func (repository *ElasticRepository) SearchProducts(ctx context.Context, query string, skip uint64, take uint64) ([]*Product, error) {
-	res, err := repository.client.Search().
+func (repository *ElasticRepository) SearchProducts(ctx context.Context, query string, skip uint64, take uint64, cursor string, visibility Visibility, filter ProductFilter) (*ProductPage, error) {
+	listing, err := listingKey("search", query, visibility, filter)
+	if err != nil {
+		return nil, err
+	}
+	return repository.searchPage(
+		ctx,
+		listing,
+		filterQuery(visibilityQuery(visibility), filter).Must(elastic.NewMultiMatchQuery(query, searchFields(filter.Locale)...)),
+		skip,
+		take,
+		cursor,
+		sorters(filter.Sort)...,
+	)
+}
+
+func (repository *ElasticRepository) ListProductsByMerchant(ctx context.Context, merchantID string, skip uint64, take uint64, visibility Visibility) ([]*Product, error) {
+	return repository.search(
+		ctx,
+		visibilityQuery(visibility).Filter(elastic.NewTermQuery("merchant_id", merchantID)),
+		skip,
+		take,
+	)
+}
+
+func (repository *ElasticRepository) ListRelatedProducts(ctx context.Context, product *Product, take uint64, visibility Visibility) ([]*Product, error) {
+	// The catalog is small enough that terms used once are still significant.
+	query := visibilityQuery(visibility).Must(
+		elastic.NewMoreLikeThisQuery().
+			Field("name", "description").
+			LikeItems(elastic.NewMoreLikeThisQueryItem().Index("catalog").Id(product.ID)).
+			MinTermFreq(1).
+			MinDocFreq(1),
+	)
+	if product.CategoryID != "" {
+		query = query.Should(elastic.NewTermQuery("category_id", product.CategoryID).Boost(2))
+	}
+	return repository.search(ctx, query, 0, take)
+}
+
+func (repository *ElasticRepository) ListDueProducts(ctx context.Context, at time.Time, take uint64) ([]*Product, error) {
+	query := elastic.NewBoolQuery().
+		MustNot(elastic.NewExistsQuery("deleted_at")).
+		Should(
+			elastic.NewBoolQuery().Filter(
+				elastic.NewTermQuery("status", string(ProductStatusDraft)),
+				elastic.NewRangeQuery("publish_at").Lte(at),
+			),
+			elastic.NewBoolQuery().Filter(
+				elastic.NewTermQuery("status", string(ProductStatusPublished)),
+				elastic.NewRangeQuery("unpublish_at").Lte(at),
+			),
+			elastic.NewRangeQuery("scheduled_prices.effective_from").Lte(at),
+		).
+		MinimumNumberShouldMatch(1)
+	return repository.search(ctx, query, 0, take)
+}
+
+func (repository *ElasticRepository) BulkCreateOrUpdateProducts(ctx context.Context, products []*Product) ([]error, error) {
+	errs := make([]error, len(products))
+	if len(products) == 0 {
+		return errs, nil
+	}
+	bulk := repository.client.Bulk().Index("catalog")
+	// indexes maps bulk items back to products; products with an invalid
+	// version are not sent.
+	indexes := []int{}
+	for i, product := range products {
+		request := elastic.NewBulkIndexRequest().Id(product.ID).Doc(toProductDocument(product))
+		if product.Version == "" {
+			request = request.OpType("create")
+		} else {
+			seqNo, primaryTerm, err := parseVersion(product.Version)
+			if err != nil {
+				errs[i] = err
+				continue
+			}
+			request = request.IfSeqNo(seqNo).IfPrimaryTerm(primaryTerm)
+		}
+		bulk.Add(request)
+		indexes = append(indexes, i)
+	}
+	if len(indexes) == 0 {
+		return errs, nil
+	}
+	res, err := bulk.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for n, item := range res.Items {
+		i := indexes[n]
+		for _, result := range item {
+			switch {
+			case result.Status == http.StatusConflict:
+				errs[i] = fmt.Errorf("%w: product %s", ErrVersionConflict, products[i].ID)
+			case result.Error != nil:
+				errs[i] = fmt.Errorf("%s: %s", result.Error.Type, result.Error.Reason)
+			default:
+				products[i].Version = formatVersion(result.SeqNo, result.PrimaryTerm)
+			}
+		}
+	}
+	return errs, nil
+}
+
+func (repository *ElasticRepository) ExportProducts(ctx context.Context, merchantID string, visibility Visibility, fn func(*Product) error) error {
+	query := visibilityQuery(visibility)
+	if merchantID != "" {
+		query = query.Filter(elastic.NewTermQuery("merchant_id", merchantID))
+	}
+	scroll := repository.client.Scroll("catalog").
+		SearchSource(elastic.NewSearchSource().Query(query).SeqNoAndPrimaryTerm(true)).
+		Size(500)
+	defer scroll.Clear(context.Background())
+	for {
+		res, err := scroll.Do(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, hit := range res.Hits.Hits {
+			product, err := decodeProduct(hit.Id, hit.Source, hit.SeqNo, hit.PrimaryTerm)
+			if err != nil {
+				return err
+			}
+			if err := fn(product); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (repository *ElasticRepository) UpdateProductRating(ctx context.Context, id string, rating ProductRating) error {
+	_, err := repository.client.Update().
 		Index("catalog").
-		Query(elastic.NewMultiMatchQuery(query, "name", "description")).
-		From(int(skip)).Size(int(take)).
+		Id(id).
+		Doc(map[string]interface{}{
+			"rating_average": rating.Average,
+			"rating_count":   rating.Count,
+		}).
+		RetryOnConflict(3).
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return ErrProductNotFound
+	}
+	return err
+}
+
+func (repository *ElasticRepository) AddPriceChanges(ctx context.Context, changes []*PriceChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	bulk := repository.client.Bulk().Index("price_history")
+	for _, change := range changes {
+		bulk.Add(elastic.NewBulkIndexRequest().Id(change.ID).Doc(PriceChangeDocument{
+			ProductID:      change.ProductID,
+			EffectiveFrom:  change.EffectiveFrom,
+			PriceAmount:    change.Price.Amount,
+			Currency:       change.Price.Currency,
+			Prices:         toPriceDocuments(change.Prices),
+			CompareAtPrice: toPriceDocument(change.CompareAtPrice),
+		}))
+	}
+	res, err := bulk.Do(ctx)
+	if err != nil {
+		return err
+	}
+	if failed := res.Failed(); len(failed) > 0 {
+		return fmt.Errorf("failed to record %d price changes: %s: %s", len(failed), failed[0].Error.Type, failed[0].Error.Reason)
+	}
+	return nil
+}
+
+func (repository *ElasticRepository) ListPriceChanges(ctx context.Context, productID string, skip uint64, take uint64) ([]*PriceChange, error) {
+	res, err := repository.client.Search().
+		Index("price_history").
+		Query(elastic.NewTermQuery("product_id", productID)).
+		Sort("effective_from", false).
+		From(int(skip)).
+		Size(int(take)).
 		Do(ctx)
 	if err != nil {
 		return nil, err
 	}
+	changes := []*PriceChange{}
+	for _, hit := range res.Hits.Hits {
+		document := PriceChangeDocument{}
+		if err := json.Unmarshal(hit.Source, &document); err != nil {
+			return nil, err
+		}
+		changes = append(changes, &PriceChange{
+			ID:             hit.Id,
+			ProductID:      document.ProductID,
+			EffectiveFrom:  document.EffectiveFrom,
+			Price:          money.New(document.PriceAmount, document.Currency),
+			Prices:         fromPriceDocuments(document.Prices),
+			CompareAtPrice: fromPriceDocument(document.CompareAtPrice),
+		})
+	}
+	return changes, nil
+}
+
+func (repository *ElasticRepository) CreateOrUpdateCategory(ctx context.Context, category *Category) (*Category, error) {
+	_, err := repository.client.Index().
+		Index("categories").
+		Id(category.ID).
+		BodyJson(CategoryDocument{Name: category.Name, Attributes: category.Attributes}).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+func (repository *ElasticRepository) GetCategoryById(ctx context.Context, id string) (*Category, error) {
+	res, err := repository.client.Get().
+		Index("categories").
+		Id(id).
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return nil, ErrCategoryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	document := CategoryDocument{}
+	if err := json.Unmarshal(res.Source, &document); err != nil {
+		return nil, err
+	}
+	return toCategory(id, document), nil
+}
+
+func (repository *ElasticRepository) ListCategories(ctx context.Context, skip uint64, take uint64) ([]*Category, error) {
+	res, err := repository.client.Search().
+		Index("categories").
+		Query(elastic.NewMatchAllQuery()).
+		Sort("name.keyword", true).
+		From(int(skip)).
+		Size(int(take)).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories := []*Category{}
+	for _, hit := range res.Hits.Hits {
+		document := CategoryDocument{}
+		if err := json.Unmarshal(hit.Source, &document); err != nil {
+			return nil, err
+		}
+		categories = append(categories, toCategory(hit.Id, document))
+	}
+	return categories, nil
+}
+
+func (repository *ElasticRepository) search(ctx context.Context, query elastic.Query, skip uint64, take uint64, sorters ...elastic.Sorter) ([]*Product, error) {
+	search := repository.client.Search().
+		Index("catalog").
+		Query(query).
+		SeqNoAndPrimaryTerm(true).
+		From(int(skip)).
+		Size(int(take))
+	if len(sorters) > 0 {
+		search = search.SortBy(sorters...)
+	}
+	res, err := search.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
 	products := []*Product{}
 	for _, hit := range res.Hits.Hits {
-		product := ProductDocument{}
-		if err = json.Unmarshal(hit.Source, &product); err == nil {
-			products = append(products, &Product{
-				ID:          hit.Id,
-				Name:        product.Name,
-				Description: product.Description,
-				Price:       product.Price,
-			})
+		product, err := decodeProduct(hit.Id, hit.Source, hit.SeqNo, hit.PrimaryTerm)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, nil
+}
+
+// pageKeepAlive is how long the point in time of a paged listing is kept
+// after each page. It is short because a listing whose later pages are
+// never read holds its point in time, and the search contexts behind it,
+// until then.
+const pageKeepAlive = "1m"
+
+// pageCursor is the state behind a cursor: the listing it belongs to, the
+// point in time the listing searches, the sort values of the product the
+// cursor points at and its position in the listing.
+type pageCursor struct {
+	Listing string        `json:"listing"`
+	PitID   string        `json:"pit"`
+	After   []interface{} `json:"after"`
+	Offset  uint64        `json:"offset"`
+}
+
+// searchPage pages through a point in time of the index with search_after,
+// so that later pages are not shifted by writes. listing identifies the
+// listing, and cursors of other listings are rejected. The point in time is
+// closed once the last page has been read, or as soon as the first page
+// holds every product.
+func (repository *ElasticRepository) searchPage(ctx context.Context, listing string, query elastic.Query, skip uint64, take uint64, cursor string, sorters ...elastic.Sorter) (*ProductPage, error) {
+	state := &pageCursor{Listing: listing, Offset: skip}
+	if cursor != "" {
+		var err error
+		state, err = decodeCursor(cursor, listing)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		pit, err := repository.client.OpenPointInTime("catalog").KeepAlive(pageKeepAlive).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		state.PitID = pit.Id
+	}
+	// The point in time adds the shard document as a final tiebreaker.
+	search := repository.client.Search().
+		Query(query).
+		PointInTime(elastic.NewPointInTimeWithKeepAlive(state.PitID, pageKeepAlive)).
+		SeqNoAndPrimaryTerm(true).
+		TrackTotalHits(true).
+		Size(int(take)).
+		SortBy(append(sorters, elastic.NewScoreSort())...)
+	if state.After != nil {
+		search = search.SearchAfter(state.After...)
+	} else {
+		search = search.From(int(skip))
+	}
+	res, err := search.Do(ctx)
+	if err != nil {
+		if cursor != "" && elastic.IsNotFound(err) {
+			return nil, ErrInvalidCursor
+		}
+		return nil, err
+	}
+	if res.PitId != "" {
+		state.PitID = res.PitId
+	}
+	page := &ProductPage{
+		Products:   []*Product{},
+		Cursors:    []string{},
+		TotalCount: uint64(res.TotalHits()),
+	}
+	offset := state.Offset
+	for _, hit := range res.Hits.Hits {
+		product, err := decodeProduct(hit.Id, hit.Source, hit.SeqNo, hit.PrimaryTerm)
+		if err != nil {
+			return nil, err
 		}
+		offset++
+		hitCursor, err := encodeCursor(&pageCursor{Listing: listing, PitID: state.PitID, After: hit.Sort, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		page.Products = append(page.Products, product)
+		page.Cursors = append(page.Cursors, hitCursor)
+	}
+	if len(page.Cursors) > 0 && offset < page.TotalCount {
+		page.NextCursor = page.Cursors[len(page.Cursors)-1]
+	} else if _, err := repository.client.ClosePointInTime(state.PitID).Do(ctx); err != nil {
+		repository.logger.Database().Warn().Err(err).Msg("failed to close point in time")
+	}
+	return page, nil
+}
+
+func encodeCursor(cursor *pageCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor returns the state behind a cursor of listing.
+func decodeCursor(cursor string, listing string) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	// Sort values may be longs that must not be rounded through float64.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	state := &pageCursor{}
+	if err := decoder.Decode(state); err != nil || state.Listing != listing || state.PitID == "" || len(state.After) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	return state, nil
+}
+
+// listingKey identifies a listing by the arguments that choose and order its
+// products, so that its cursors are not followed in another listing.
+func listingKey(arguments ...interface{}) (string, error) {
+	data, err := json.Marshal(arguments)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
+
+func visibilityQuery(visibility Visibility) *elastic.BoolQuery {
+	query := elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery("deleted_at"))
+	if visibility == VisibilityPublished {
+		// Documents indexed before products had a status are published.
+		query = query.Filter(elastic.NewBoolQuery().
+			Should(
+				elastic.NewTermQuery("status", string(ProductStatusPublished)),
+				elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery("status")),
+			).
+			MinimumNumberShouldMatch(1))
+	}
+	return query
+}
+
+// sorters returns the sort order for sort, or none to keep the default order.
+func sorters(sort ProductSort) []elastic.Sorter {
+	if sort == ProductSortRating {
+		return []elastic.Sorter{
+			elastic.NewFieldSort("rating_average").Desc().Missing("_last"),
+			elastic.NewFieldSort("rating_count").Desc().Missing("_last"),
+		}
+	}
+	return nil
+}
+
+// filterQuery adds the category and attribute filters to query. Each
+// attribute filter must match a single attribute of the product.
+func filterQuery(query *elastic.BoolQuery, filter ProductFilter) *elastic.BoolQuery {
+	if filter.CategoryID != "" {
+		query = query.Filter(elastic.NewTermQuery("category_id", filter.CategoryID))
+	}
+	for _, attribute := range filter.Attributes {
+		match := elastic.NewBoolQuery().Filter(elastic.NewTermQuery("attributes.name", attribute.Name))
+		if attribute.Value != "" {
+			match = match.Filter(elastic.NewTermQuery("attributes.value", attribute.Value))
+		}
+		if attribute.Min != nil || attribute.Max != nil {
+			number := elastic.NewRangeQuery("attributes.number")
+			if attribute.Min != nil {
+				number = number.Gte(*attribute.Min)
+			}
+			if attribute.Max != nil {
+				number = number.Lte(*attribute.Max)
+			}
+			match = match.Filter(number)
+		}
+		query = query.Filter(elastic.NewNestedQuery("attributes", match))
+	}
+	return query
+}
+
+// decodeProduct builds a product from its stored document and the sequence
+// number and primary term that make up its version.
+func decodeProduct(id string, source json.RawMessage, seqNo *int64, primaryTerm *int64) (*Product, error) {
+	document := ProductDocument{}
+	if err := json.Unmarshal(source, &document); err != nil {
+		return nil, err
+	}
+	product, err := toProduct(id, document)
+	if err != nil {
+		return nil, err
+	}
+	if seqNo != nil && primaryTerm != nil {
+		product.Version = formatVersion(*seqNo, *primaryTerm)
+	}
+	return product, nil
+}
+
+func formatVersion(seqNo int64, primaryTerm int64) string {
+	return fmt.Sprintf("%d-%d", primaryTerm, seqNo)
+}
+
+func parseVersion(version string) (int64, int64, error) {
+	primaryTerm, seqNo, ok := strings.Cut(version, "-")
+	if ok {
+		term, termErr := strconv.ParseInt(primaryTerm, 10, 64)
+		number, numberErr := strconv.ParseInt(seqNo, 10, 64)
+		if termErr == nil && numberErr == nil {
+			return number, term, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("invalid product version %q", version)
+}
+
+func toProductDocument(product *Product) ProductDocument {
+	scheduledPrices := []ScheduledPriceDocument{}
+	for _, scheduled := range product.ScheduledPrices {
+		scheduledPrices = append(scheduledPrices, ScheduledPriceDocument{
+			EffectiveFrom:  scheduled.EffectiveFrom,
+			PriceAmount:    scheduled.Price.Amount,
+			Currency:       scheduled.Price.Currency,
+			Prices:         toPriceDocuments(scheduled.Prices),
+			CompareAtPrice: toPriceDocument(scheduled.CompareAtPrice),
+		})
+	}
+	media := []MediaDocument{}
+	for _, item := range product.Media {
+		media = append(media, MediaDocument{
+			ID:           item.ID,
+			Key:          item.Key,
+			URL:          item.URL,
+			ThumbnailKey: item.ThumbnailKey,
+			ThumbnailURL: item.ThumbnailURL,
+			ContentType:  item.ContentType,
+			AltText:      item.AltText,
+			Width:        item.Width,
+			Height:       item.Height,
+		})
+	}
+	attributes := []AttributeDocument{}
+	for _, attribute := range product.Attributes {
+		attributes = append(attributes, toAttributeDocument(attribute))
+	}
+	translations := map[string]TranslationDocument{}
+	for locale, translation := range product.Translations {
+		translations[locale] = TranslationDocument{Name: translation.Name, Description: translation.Description}
+	}
+	return ProductDocument{
+		Name:            product.Name,
+		Description:     product.Description,
+		PriceAmount:     product.Price.Amount,
+		Currency:        product.Price.Currency,
+		Prices:          toPriceDocuments(product.Prices),
+		CompareAtPrice:  toPriceDocument(product.CompareAtPrice),
+		ScheduledPrices: scheduledPrices,
+		MerchantID:      product.MerchantID,
+		Status:          string(product.Status),
+		PublishAt:       product.PublishAt,
+		UnpublishAt:     product.UnpublishAt,
+		DeletedAt:       product.DeletedAt,
+		Media:           media,
+		CategoryID:      product.CategoryID,
+		Attributes:      attributes,
+		TaxCategory:     product.TaxCategory,
+		Weight:          product.Weight,
+		Translations:    translations,
+		RatingAverage:   product.Rating.Average,
+		RatingCount:     product.Rating.Count,
+	}
+}
+
+func toAttributeDocument(attribute *ProductAttribute) AttributeDocument {
+	document := AttributeDocument{
+		Name:  attribute.Name,
+		Value: attribute.Value,
+		Type:  string(attribute.Type),
+		Unit:  attribute.Unit,
+	}
+	switch attribute.Type {
+	case AttributeTypeNumber:
+		if number, err := strconv.ParseFloat(attribute.Value, 64); err == nil {
+			document.Number = &number
+		}
+	case AttributeTypeBoolean:
+		if boolean, err := strconv.ParseBool(attribute.Value); err == nil {
+			document.Boolean = &boolean
+		}
+	}
+	return document
+}
+
+func toProduct(id string, document ProductDocument) (*Product, error) {
+	price := money.New(document.PriceAmount, document.Currency)
+	if document.Currency == "" && document.LegacyPrice != nil {
+		legacyPrice, err := money.FromFloat(*document.LegacyPrice, money.DefaultCurrency)
+		if err != nil {
+			return nil, err
+		}
+		price = legacyPrice
+	}
+	scheduledPrices := []*ScheduledPrice{}
+	for _, scheduled := range document.ScheduledPrices {
+		scheduledPrices = append(scheduledPrices, &ScheduledPrice{
+			EffectiveFrom:  scheduled.EffectiveFrom,
+			Price:          money.New(scheduled.PriceAmount, scheduled.Currency),
+			Prices:         fromPriceDocuments(scheduled.Prices),
+			CompareAtPrice: fromPriceDocument(scheduled.CompareAtPrice),
+		})
+	}
+	media := []*ProductMedia{}
+	for _, item := range document.Media {
+		media = append(media, &ProductMedia{
+			ID:           item.ID,
+			Key:          item.Key,
+			URL:          item.URL,
+			ThumbnailKey: item.ThumbnailKey,
+			ThumbnailURL: item.ThumbnailURL,
+			ContentType:  item.ContentType,
+			AltText:      item.AltText,
+			Width:        item.Width,
+			Height:       item.Height,
+		})
+	}
+	attributes := []*ProductAttribute{}
+	for _, attribute := range document.Attributes {
+		attributes = append(attributes, &ProductAttribute{
+			Name:  attribute.Name,
+			Value: attribute.Value,
+			Type:  AttributeType(attribute.Type),
+			Unit:  attribute.Unit,
+		})
+	}
+	translations := map[string]*ProductTranslation{}
+	for locale, translation := range document.Translations {
+		translations[locale] = &ProductTranslation{Name: translation.Name, Description: translation.Description}
+	}
+	status := ProductStatus(document.Status)
+	if status == "" {
+		status = ProductStatusPublished
+	}
+	return &Product{
+		ID:              id,
+		Name:            document.Name,
+		Description:     document.Description,
+		Price:           price,
+		Prices:          fromPriceDocuments(document.Prices),
+		CompareAtPrice:  fromPriceDocument(document.CompareAtPrice),
+		ScheduledPrices: scheduledPrices,
+		MerchantID:      document.MerchantID,
+		Status:          status,
+		PublishAt:       document.PublishAt,
+		UnpublishAt:     document.UnpublishAt,
+		DeletedAt:       document.DeletedAt,
+		Media:           media,
+		CategoryID:      document.CategoryID,
+		Attributes:      attributes,
+		TaxCategory:     document.TaxCategory,
+		Weight:          document.Weight,
+		Translations:    translations,
+		Rating:          ProductRating{Average: document.RatingAverage, Count: document.RatingCount},
+	}, nil
+}
+
+func toPriceDocuments(prices []money.Money) []PriceDocument {
+	documents := []PriceDocument{}
+	for _, price := range prices {
+		documents = append(documents, PriceDocument{Amount: price.Amount, Currency: price.Currency})
+	}
+	return documents
+}
+
+func toPriceDocument(price *money.Money) *PriceDocument {
+	if price == nil {
+		return nil
+	}
+	return &PriceDocument{Amount: price.Amount, Currency: price.Currency}
+}
+
+func fromPriceDocuments(documents []PriceDocument) []money.Money {
+	prices := []money.Money{}
+	for _, document := range documents {
+		prices = append(prices, money.New(document.Amount, document.Currency))
+	}
+	return prices
+}
+
+func fromPriceDocument(document *PriceDocument) *money.Money {
+	if document == nil {
+		return nil
+	}
+	price := money.New(document.Amount, document.Currency)
+	return &price
+}
+
+func toCategory(id string, document CategoryDocument) *Category {
+	attributes := document.Attributes
+	if attributes == nil {
+		attributes = []*AttributeDefinition{}
 	}
-	return products, err
+	return &Category{ID: id, Name: document.Name, Attributes: attributes}
 }
@@ -0,0 +1,686 @@
+// Package repositorytest checks that implementations of catalog.Repository
+// behave alike, in the manner of testing/fstest.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/segmentio/ksuid"
+)
+
+// TestRepository runs the conformance checks against repository, each as a
+// subtest of t. It writes products, categories and price changes under
+// fresh ids and temporarily changes the synonyms of the default locale, so
+// it should be run against a database set aside for it. Writes must be
+// visible to the reads that follow them.
+func TestRepository(t *testing.T, repository catalog.Repository) {
+	checks := []struct {
+		name string
+		run  func(*suite) error
+	}{
+		{"versions", (*suite).testVersions},
+		{"list with ids", (*suite).testListWithIds},
+		{"visibility", (*suite).testVisibility},
+		{"paging", (*suite).testPaging},
+		{"sort by rating", (*suite).testSortByRating},
+		{"attribute filters", (*suite).testAttributeFilters},
+		{"search", (*suite).testSearch},
+		{"synonyms", (*suite).testSynonyms},
+		{"related products", (*suite).testRelatedProducts},
+		{"due products", (*suite).testDueProducts},
+		{"bulk writes", (*suite).testBulkWrites},
+		{"export", (*suite).testExport},
+		{"price changes", (*suite).testPriceChanges},
+		{"categories", (*suite).testCategories},
+	}
+	for _, check := range checks {
+		t.Run(check.name, func(t *testing.T) {
+			s := &suite{ctx: t.Context(), repository: repository, token: "zq" + strings.ToLower(ksuid.New().String())}
+			if err := check.run(s); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// suite holds the state of one check. token is a word no other product
+// contains, so that searches only find the check's own products.
+type suite struct {
+	ctx        context.Context
+	repository catalog.Repository
+	token      string
+}
+
+// newProduct returns a published product, not yet stored.
+func (s *suite) newProduct(name string) *catalog.Product {
+	return &catalog.Product{
+		ID:           ksuid.New().String(),
+		Name:         name,
+		Description:  "A product created by the repository conformance checks.",
+		Price:        money.New(1999, "USD"),
+		Prices:       []money.Money{money.New(1799, "EUR")},
+		Status:       catalog.ProductStatusPublished,
+		Media:        []*catalog.ProductMedia{},
+		Attributes:   []*catalog.ProductAttribute{},
+		Translations: map[string]*catalog.ProductTranslation{},
+	}
+}
+
+func (s *suite) create(products ...*catalog.Product) error {
+	for _, product := range products {
+		if _, err := s.repository.CreateOrUpdateProduct(s.ctx, product); err != nil {
+			return fmt.Errorf("creating product %s: %w", product.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *suite) testVersions() error {
+	compareAt := money.New(2499, "USD")
+	product := s.newProduct(s.token + " lamp")
+	product.CompareAtPrice = &compareAt
+	product.ScheduledPrices = []*catalog.ScheduledPrice{{
+		EffectiveFrom: time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second),
+		Price:         money.New(1499, "USD"),
+		Prices:        []money.Money{},
+	}}
+	product.Attributes = []*catalog.ProductAttribute{{Name: "color", Value: "red", Type: catalog.AttributeTypeString}}
+	product.Translations = map[string]*catalog.ProductTranslation{"de": {Name: "Lampe", Description: "Eine Lampe"}}
+	if err := s.create(product); err != nil {
+		return err
+	}
+	if product.Version == "" {
+		return errors.New("created product has no version")
+	}
+	created, err := s.repository.GetProductById(s.ctx, product.ID)
+	if err != nil {
+		return err
+	}
+	if created.Version != product.Version {
+		return fmt.Errorf("stored version %q, want %q", created.Version, product.Version)
+	}
+	if err := sameProduct(created, product); err != nil {
+		return err
+	}
+	duplicate := s.newProduct(product.Name)
+	duplicate.ID = product.ID
+	if _, err := s.repository.CreateOrUpdateProduct(s.ctx, duplicate); !errors.Is(err, catalog.ErrVersionConflict) {
+		return fmt.Errorf("creating an existing product returned %v, want ErrVersionConflict", err)
+	}
+	stale := created.Version
+	created.Name = s.token + " desk lamp"
+	if _, err := s.repository.CreateOrUpdateProduct(s.ctx, created); err != nil {
+		return fmt.Errorf("updating product: %w", err)
+	}
+	if created.Version == stale {
+		return errors.New("update did not change the version")
+	}
+	created.Version = stale
+	if _, err := s.repository.CreateOrUpdateProduct(s.ctx, created); !errors.Is(err, catalog.ErrVersionConflict) {
+		return fmt.Errorf("updating with a stale version returned %v, want ErrVersionConflict", err)
+	}
+	if _, err := s.repository.GetProductById(s.ctx, ksuid.New().String()); !errors.Is(err, catalog.ErrProductNotFound) {
+		return fmt.Errorf("getting a missing product returned %v, want ErrProductNotFound", err)
+	}
+	if err := s.repository.UpdateProductRating(s.ctx, ksuid.New().String(), catalog.ProductRating{Average: 4, Count: 1}); !errors.Is(err, catalog.ErrProductNotFound) {
+		return fmt.Errorf("rating a missing product returned %v, want ErrProductNotFound", err)
+	}
+	return nil
+}
+
+func (s *suite) testListWithIds() error {
+	first, second := s.newProduct(s.token+" first"), s.newProduct(s.token+" second")
+	if err := s.create(first, second); err != nil {
+		return err
+	}
+	products, err := s.repository.ListProductsWithIds(s.ctx, []string{second.ID, ksuid.New().String(), first.ID})
+	if err != nil {
+		return err
+	}
+	return sameIDs(products, second.ID, first.ID)
+}
+
+func (s *suite) testVisibility() error {
+	categoryID := ksuid.New().String()
+	published, draft, deleted := s.newProduct(s.token+" published"), s.newProduct(s.token+" draft"), s.newProduct(s.token+" deleted")
+	draft.Status = catalog.ProductStatusDraft
+	deletedAt := time.Now().UTC()
+	deleted.DeletedAt = &deletedAt
+	for _, product := range []*catalog.Product{published, draft, deleted} {
+		product.CategoryID = categoryID
+	}
+	if err := s.create(published, draft, deleted); err != nil {
+		return err
+	}
+	filter := catalog.ProductFilter{CategoryID: categoryID}
+	page, err := s.repository.ListProducts(s.ctx, 0, 10, "", catalog.VisibilityPublished, filter)
+	if err != nil {
+		return err
+	}
+	if err := sameIDs(page.Products, published.ID); err != nil {
+		return fmt.Errorf("published listing: %w", err)
+	}
+	page, err = s.repository.ListProducts(s.ctx, 0, 10, "", catalog.VisibilityAll, filter)
+	if err != nil {
+		return err
+	}
+	if err := sameIDSet(page.Products, published.ID, draft.ID); err != nil {
+		return fmt.Errorf("full listing: %w", err)
+	}
+	return nil
+}
+
+func (s *suite) testPaging() error {
+	categoryID := ksuid.New().String()
+	for i := 0; i < 5; i++ {
+		product := s.newProduct(fmt.Sprintf("%s %d", s.token, i))
+		product.CategoryID = categoryID
+		if err := s.create(product); err != nil {
+			return err
+		}
+	}
+	filter := catalog.ProductFilter{CategoryID: categoryID}
+	all, err := s.repository.ListProducts(s.ctx, 0, 10, "", catalog.VisibilityAll, filter)
+	if err != nil {
+		return err
+	}
+	if len(all.Products) != 5 || all.TotalCount != 5 || all.NextCursor != "" {
+		return fmt.Errorf("listing all returned %d products of %d and next cursor %q, want 5 of 5 and none", len(all.Products), all.TotalCount, all.NextCursor)
+	}
+	if len(all.Cursors) != len(all.Products) {
+		return fmt.Errorf("listing returned %d cursors for %d products", len(all.Cursors), len(all.Products))
+	}
+	order := ids(all.Products)
+	paged := []*catalog.Product{}
+	cursor := ""
+	for pages := 0; pages < 5; pages++ {
+		page, err := s.repository.ListProducts(s.ctx, 0, 2, cursor, catalog.VisibilityAll, filter)
+		if err != nil {
+			return err
+		}
+		if page.TotalCount != 5 {
+			return fmt.Errorf("page has total count %d, want 5", page.TotalCount)
+		}
+		paged = append(paged, page.Products...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if err := sameIDs(paged, order...); err != nil {
+		return fmt.Errorf("paging by cursor: %w", err)
+	}
+	page, err := s.repository.ListProducts(s.ctx, 2, 2, "", catalog.VisibilityAll, filter)
+	if err != nil {
+		return err
+	}
+	if err := sameIDs(page.Products, order[2:4]...); err != nil {
+		return fmt.Errorf("paging by skip: %w", err)
+	}
+	// A listing may stop keeping its cursors once its last page is read, so
+	// continue from a page that is not the last.
+	first, err := s.repository.ListProducts(s.ctx, 0, 4, "", catalog.VisibilityAll, filter)
+	if err != nil {
+		return err
+	}
+	page, err = s.repository.ListProducts(s.ctx, 0, 10, first.Cursors[2], catalog.VisibilityAll, filter)
+	if err != nil {
+		return err
+	}
+	if err := sameIDs(page.Products, order[3:]...); err != nil {
+		return fmt.Errorf("continuing after a product: %w", err)
+	}
+	if _, err := s.repository.ListProducts(s.ctx, 0, 10, "not a cursor", catalog.VisibilityAll, filter); !errors.Is(err, catalog.ErrInvalidCursor) {
+		return fmt.Errorf("listing with an invalid cursor returned %v, want ErrInvalidCursor", err)
+	}
+	if _, err := s.repository.ListProducts(s.ctx, 0, 10, first.NextCursor, catalog.VisibilityPublished, filter); !errors.Is(err, catalog.ErrInvalidCursor) {
+		return fmt.Errorf("listing with the cursor of another visibility returned %v, want ErrInvalidCursor", err)
+	}
+	if _, err := s.repository.ListProducts(s.ctx, 0, 10, first.NextCursor, catalog.VisibilityAll, catalog.ProductFilter{CategoryID: categoryID, Sort: catalog.ProductSortRating}); !errors.Is(err, catalog.ErrInvalidCursor) {
+		return fmt.Errorf("listing with the cursor of another sort returned %v, want ErrInvalidCursor", err)
+	}
+	if _, err := s.repository.SearchProducts(s.ctx, s.token, 0, 10, first.NextCursor, catalog.VisibilityAll, filter); !errors.Is(err, catalog.ErrInvalidCursor) {
+		return fmt.Errorf("searching with the cursor of a listing returned %v, want ErrInvalidCursor", err)
+	}
+	return nil
+}
+
+func (s *suite) testSortByRating() error {
+	categoryID := ksuid.New().String()
+	ratings := []catalog.ProductRating{{Average: 3, Count: 10}, {Average: 5, Count: 1}, {Average: 3, Count: 20}, {}}
+	products := []*catalog.Product{}
+	for i, rating := range ratings {
+		product := s.newProduct(fmt.Sprintf("%s rated %d", s.token, i))
+		product.CategoryID = categoryID
+		if err := s.create(product); err != nil {
+			return err
+		}
+		if err := s.repository.UpdateProductRating(s.ctx, product.ID, rating); err != nil {
+			return err
+		}
+		products = append(products, product)
+	}
+	rated, err := s.repository.GetProductById(s.ctx, products[0].ID)
+	if err != nil {
+		return err
+	}
+	if rated.Rating != ratings[0] {
+		return fmt.Errorf("stored rating %+v, want %+v", rated.Rating, ratings[0])
+	}
+	if rated.Version == products[0].Version {
+		return errors.New("rating did not change the version")
+	}
+	filter := catalog.ProductFilter{CategoryID: categoryID, Sort: catalog.ProductSortRating}
+	want := []string{products[1].ID, products[2].ID, products[0].ID, products[3].ID}
+	page, err := s.repository.ListProducts(s.ctx, 0, 10, "", catalog.VisibilityAll, filter)
+	if err != nil {
+		return err
+	}
+	if err := sameIDs(page.Products, want...); err != nil {
+		return err
+	}
+	page, err = s.repository.ListProducts(s.ctx, 0, 10, page.Cursors[1], catalog.VisibilityAll, filter)
+	if err != nil {
+		return err
+	}
+	if err := sameIDs(page.Products, want[2:]...); err != nil {
+		return fmt.Errorf("continuing after a product: %w", err)
+	}
+	return nil
+}
+
+func (s *suite) testAttributeFilters() error {
+	categoryID := ksuid.New().String()
+	light, heavy := s.newProduct(s.token+" light"), s.newProduct(s.token+" heavy")
+	light.Attributes = []*catalog.ProductAttribute{
+		{Name: "weight", Value: "1.5", Type: catalog.AttributeTypeNumber, Unit: "kg"},
+		{Name: "color", Value: "red", Type: catalog.AttributeTypeEnum},
+	}
+	heavy.Attributes = []*catalog.ProductAttribute{
+		{Name: "weight", Value: "12", Type: catalog.AttributeTypeNumber, Unit: "kg"},
+		{Name: "color", Value: "blue", Type: catalog.AttributeTypeEnum},
+	}
+	light.CategoryID, heavy.CategoryID = categoryID, categoryID
+	if err := s.create(light, heavy); err != nil {
+		return err
+	}
+	min, max := 1.0, 2.0
+	filters := []struct {
+		attributes []catalog.AttributeFilter
+		want       []string
+	}{
+		{[]catalog.AttributeFilter{{Name: "color", Value: "blue"}}, []string{heavy.ID}},
+		{[]catalog.AttributeFilter{{Name: "weight", Min: &min, Max: &max}}, []string{light.ID}},
+		{[]catalog.AttributeFilter{{Name: "weight", Min: &max}}, []string{heavy.ID}},
+		{[]catalog.AttributeFilter{{Name: "color", Value: "red"}, {Name: "weight", Min: &max}}, []string{}},
+		// Each filter matches a single attribute, so a name and a value of different attributes do not match.
+		{[]catalog.AttributeFilter{{Name: "weight", Value: "red"}}, []string{}},
+	}
+	for _, filter := range filters {
+		page, err := s.repository.ListProducts(s.ctx, 0, 10, "", catalog.VisibilityAll, catalog.ProductFilter{CategoryID: categoryID, Attributes: filter.attributes})
+		if err != nil {
+			return err
+		}
+		if err := sameIDs(page.Products, filter.want...); err != nil {
+			return fmt.Errorf("filter %+v: %w", filter.attributes, err)
+		}
+	}
+	return nil
+}
+
+func (s *suite) testSearch() error {
+	named, described, translated := s.newProduct(s.token+" lamp"), s.newProduct("Desk lamp"), s.newProduct("Table")
+	described.Description = "Lights a desk. " + s.token
+	translated.Translations = map[string]*catalog.ProductTranslation{"de": {Name: s.token + "lampe", Description: "Tisch"}}
+	draft := s.newProduct(s.token + " draft")
+	draft.Status = catalog.ProductStatusDraft
+	if err := s.create(named, described, translated, draft); err != nil {
+		return err
+	}
+	page, err := s.repository.SearchProducts(s.ctx, s.token, 0, 10, "", catalog.VisibilityPublished, catalog.ProductFilter{})
+	if err != nil {
+		return err
+	}
+	if err := sameIDSet(page.Products, named.ID, described.ID); err != nil {
+		return fmt.Errorf("searching name and description: %w", err)
+	}
+	page, err = s.repository.SearchProducts(s.ctx, s.token+"lampe", 0, 10, "", catalog.VisibilityPublished, catalog.ProductFilter{Locale: "de"})
+	if err != nil {
+		return err
+	}
+	if err := sameIDs(page.Products, translated.ID); err != nil {
+		return fmt.Errorf("searching translations: %w", err)
+	}
+	page, err = s.repository.SearchProducts(s.ctx, s.token, 0, 10, "", catalog.VisibilityAll, catalog.ProductFilter{})
+	if err != nil {
+		return err
+	}
+	if err := sameIDSet(page.Products, named.ID, described.ID, draft.ID); err != nil {
+		return fmt.Errorf("searching drafts: %w", err)
+	}
+	page, err = s.repository.SearchProducts(s.ctx, s.token, 0, 1, "", catalog.VisibilityAll, catalog.ProductFilter{})
+	if err != nil {
+		return err
+	}
+	rest, err := s.repository.SearchProducts(s.ctx, s.token, 0, 10, page.NextCursor, catalog.VisibilityAll, catalog.ProductFilter{})
+	if err != nil {
+		return err
+	}
+	if err := sameIDSet(append(page.Products, rest.Products...), named.ID, described.ID, draft.ID); err != nil {
+		return fmt.Errorf("paging through results: %w", err)
+	}
+	page, err = s.repository.SearchProducts(s.ctx, s.token+"missing", 0, 10, "", catalog.VisibilityAll, catalog.ProductFilter{})
+	if err != nil {
+		return err
+	}
+	return sameIDs(page.Products)
+}
+
+func (s *suite) testSynonyms() error {
+	rules, err := s.repository.GetSynonyms(s.ctx, catalog.DefaultLocale)
+	if err != nil {
+		return err
+	}
+	defer s.repository.UpdateSynonyms(context.Background(), catalog.DefaultLocale, rules)
+	alias := s.token + "alias"
+	updated := append(append([]string{}, rules...), s.token+", "+alias)
+	if err := s.repository.UpdateSynonyms(s.ctx, catalog.DefaultLocale, updated); err != nil {
+		return err
+	}
+	stored, err := s.repository.GetSynonyms(s.ctx, catalog.DefaultLocale)
+	if err != nil {
+		return err
+	}
+	if strings.Join(stored, "\n") != strings.Join(updated, "\n") {
+		return fmt.Errorf("stored rules %q, want %q", stored, updated)
+	}
+	product := s.newProduct(s.token + " lamp")
+	if err := s.create(product); err != nil {
+		return err
+	}
+	page, err := s.repository.SearchProducts(s.ctx, alias, 0, 10, "", catalog.VisibilityAll, catalog.ProductFilter{})
+	if err != nil {
+		return err
+	}
+	return sameIDs(page.Products, product.ID)
+}
+
+func (s *suite) testRelatedProducts() error {
+	categoryID := ksuid.New().String()
+	product, sameCategory, other, unrelated := s.newProduct(s.token+" lamp"), s.newProduct(s.token+" shade"), s.newProduct(s.token+" bulb"), s.newProduct("Armchair")
+	product.CategoryID, sameCategory.CategoryID = categoryID, categoryID
+	product.Description, sameCategory.Description, other.Description, unrelated.Description = "", "", "", ""
+	if err := s.create(product, sameCategory, other, unrelated); err != nil {
+		return err
+	}
+	related, err := s.repository.ListRelatedProducts(s.ctx, product, 100, catalog.VisibilityAll)
+	if err != nil {
+		return err
+	}
+	position := map[string]int{}
+	for i, relatedProduct := range related {
+		position[relatedProduct.ID] = i + 1
+	}
+	if position[product.ID] != 0 || position[unrelated.ID] != 0 {
+		return errors.New("related products include the product itself or an unrelated product")
+	}
+	if position[sameCategory.ID] == 0 || position[other.ID] == 0 || position[sameCategory.ID] > position[other.ID] {
+		return fmt.Errorf("related products %v do not put the same category first", ids(related))
+	}
+	return nil
+}
+
+func (s *suite) testDueProducts() error {
+	past := time.Now().Add(-time.Hour).UTC()
+	future := time.Now().Add(time.Hour).UTC()
+	publish, unpublish, reprice, later := s.newProduct(s.token+" publish"), s.newProduct(s.token+" unpublish"), s.newProduct(s.token+" reprice"), s.newProduct(s.token+" later")
+	publish.Status, publish.PublishAt = catalog.ProductStatusDraft, &past
+	unpublish.UnpublishAt = &past
+	reprice.ScheduledPrices = []*catalog.ScheduledPrice{{EffectiveFrom: past, Price: money.New(999, "USD"), Prices: []money.Money{}}}
+	later.Status, later.PublishAt = catalog.ProductStatusDraft, &future
+	if err := s.create(publish, unpublish, reprice, later); err != nil {
+		return err
+	}
+	due, err := s.repository.ListDueProducts(s.ctx, time.Now(), 10000)
+	if err != nil {
+		return err
+	}
+	found := map[string]bool{}
+	for _, product := range due {
+		found[product.ID] = true
+	}
+	if !found[publish.ID] || !found[unpublish.ID] || !found[reprice.ID] || found[later.ID] {
+		return errors.New("due products do not match the products' schedules")
+	}
+	return nil
+}
+
+func (s *suite) testBulkWrites() error {
+	existing := s.newProduct(s.token + " existing")
+	if err := s.create(existing); err != nil {
+		return err
+	}
+	stale := *existing
+	stale.Version = existing.Version
+	existing.Name = s.token + " renamed"
+	if _, err := s.repository.CreateOrUpdateProduct(s.ctx, existing); err != nil {
+		return err
+	}
+	created := s.newProduct(s.token + " created")
+	errs, err := s.repository.BulkCreateOrUpdateProducts(s.ctx, []*catalog.Product{created, &stale, existing})
+	if err != nil {
+		return err
+	}
+	if len(errs) != 3 || errs[0] != nil || !errors.Is(errs[1], catalog.ErrVersionConflict) || errs[2] != nil {
+		return fmt.Errorf("bulk write returned %v, want a version conflict for the second product only", errs)
+	}
+	stored, err := s.repository.ListProductsWithIds(s.ctx, []string{created.ID, existing.ID})
+	if err != nil {
+		return err
+	}
+	if err := sameIDs(stored, created.ID, existing.ID); err != nil {
+		return err
+	}
+	for i, product := range []*catalog.Product{created, existing} {
+		if stored[i].Version != product.Version {
+			return fmt.Errorf("stored version %q, want %q", stored[i].Version, product.Version)
+		}
+	}
+	return nil
+}
+
+func (s *suite) testExport() error {
+	merchantID := ksuid.New().String()
+	published, draft := s.newProduct(s.token+" published"), s.newProduct(s.token+" draft")
+	draft.Status = catalog.ProductStatusDraft
+	published.MerchantID, draft.MerchantID = merchantID, merchantID
+	if err := s.create(published, draft, s.newProduct(s.token+" other")); err != nil {
+		return err
+	}
+	exported := []*catalog.Product{}
+	err := s.repository.ExportProducts(s.ctx, merchantID, catalog.VisibilityAll, func(product *catalog.Product) error {
+		exported = append(exported, product)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := sameIDSet(exported, published.ID, draft.ID); err != nil {
+		return err
+	}
+	listed, err := s.repository.ListProductsByMerchant(s.ctx, merchantID, 0, 10, catalog.VisibilityPublished)
+	if err != nil {
+		return err
+	}
+	if err := sameIDs(listed, published.ID); err != nil {
+		return fmt.Errorf("listing by merchant: %w", err)
+	}
+	stop := errors.New("stop")
+	calls := 0
+	err = s.repository.ExportProducts(s.ctx, merchantID, catalog.VisibilityAll, func(*catalog.Product) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		return errors.New("export did not stop at the first error")
+	}
+	return nil
+}
+
+func (s *suite) testPriceChanges() error {
+	productID := ksuid.New().String()
+	start := time.Now().Add(-72 * time.Hour).UTC().Truncate(time.Second)
+	compareAt := money.New(1500, "USD")
+	changes := []*catalog.PriceChange{}
+	for i := 0; i < 3; i++ {
+		changes = append(changes, &catalog.PriceChange{
+			ID:             ksuid.New().String(),
+			ProductID:      productID,
+			EffectiveFrom:  start.Add(time.Duration(i) * 24 * time.Hour),
+			Price:          money.New(int64(1000+i), "USD"),
+			Prices:         []money.Money{money.New(int64(900+i), "EUR")},
+			CompareAtPrice: &compareAt,
+		})
+	}
+	if err := s.repository.AddPriceChanges(s.ctx, changes); err != nil {
+		return err
+	}
+	listed, err := s.repository.ListPriceChanges(s.ctx, productID, 0, 10)
+	if err != nil {
+		return err
+	}
+	if len(listed) != 3 {
+		return fmt.Errorf("listed %d price changes, want 3", len(listed))
+	}
+	for i, change := range listed {
+		want := changes[2-i]
+		if change.ID != want.ID || !change.EffectiveFrom.Equal(want.EffectiveFrom) || change.Price != want.Price ||
+			len(change.Prices) != 1 || change.Prices[0] != want.Prices[0] || change.CompareAtPrice == nil || *change.CompareAtPrice != compareAt {
+			return fmt.Errorf("price change %d is %+v, want %+v", i, change, want)
+		}
+	}
+	page, err := s.repository.ListPriceChanges(s.ctx, productID, 1, 1)
+	if err != nil {
+		return err
+	}
+	if len(page) != 1 || page[0].ID != changes[1].ID {
+		return errors.New("skip and take do not page price changes")
+	}
+	return nil
+}
+
+func (s *suite) testCategories() error {
+	category := &catalog.Category{
+		ID:   ksuid.New().String(),
+		Name: s.token,
+		Attributes: []*catalog.AttributeDefinition{
+			{Name: "size", Type: catalog.AttributeTypeEnum, Values: []string{"s", "m"}, Required: true},
+		},
+	}
+	if _, err := s.repository.CreateOrUpdateCategory(s.ctx, category); err != nil {
+		return err
+	}
+	category.Name = s.token + " renamed"
+	if _, err := s.repository.CreateOrUpdateCategory(s.ctx, category); err != nil {
+		return err
+	}
+	stored, err := s.repository.GetCategoryById(s.ctx, category.ID)
+	if err != nil {
+		return err
+	}
+	if stored.Name != category.Name || len(stored.Attributes) != 1 || stored.Attributes[0].Name != "size" || len(stored.Attributes[0].Values) != 2 {
+		return fmt.Errorf("stored category %+v, want %+v", stored, category)
+	}
+	if _, err := s.repository.GetCategoryById(s.ctx, ksuid.New().String()); !errors.Is(err, catalog.ErrCategoryNotFound) {
+		return fmt.Errorf("getting a missing category returned %v, want ErrCategoryNotFound", err)
+	}
+	categories, err := s.repository.ListCategories(s.ctx, 0, 10000)
+	if err != nil {
+		return err
+	}
+	for i, listed := range categories {
+		if i > 0 && categories[i-1].Name > listed.Name {
+			return errors.New("categories are not sorted by name")
+		}
+		if listed.ID == category.ID {
+			return nil
+		}
+	}
+	return errors.New("categories do not include the stored category")
+}
+
+// sameProduct compares the stored fields of two products.
+func sameProduct(got *catalog.Product, want *catalog.Product) error {
+	switch {
+	case got.ID != want.ID, got.Name != want.Name, got.Description != want.Description:
+		return fmt.Errorf("stored product %q %q, want %q %q", got.Name, got.Description, want.Name, want.Description)
+	case got.Price != want.Price, fmt.Sprint(got.Prices) != fmt.Sprint(want.Prices):
+		return fmt.Errorf("stored prices %v %v, want %v %v", got.Price, got.Prices, want.Price, want.Prices)
+	case (got.CompareAtPrice == nil) != (want.CompareAtPrice == nil), got.CompareAtPrice != nil && *got.CompareAtPrice != *want.CompareAtPrice:
+		return errors.New("stored compare-at price differs")
+	case len(got.ScheduledPrices) != len(want.ScheduledPrices):
+		return fmt.Errorf("stored %d scheduled prices, want %d", len(got.ScheduledPrices), len(want.ScheduledPrices))
+	case got.Status != want.Status:
+		return fmt.Errorf("stored status %q, want %q", got.Status, want.Status)
+	case len(got.Attributes) != len(want.Attributes):
+		return fmt.Errorf("stored %d attributes, want %d", len(got.Attributes), len(want.Attributes))
+	case len(got.Translations) != len(want.Translations):
+		return fmt.Errorf("stored %d translations, want %d", len(got.Translations), len(want.Translations))
+	}
+	for i, scheduled := range got.ScheduledPrices {
+		if !scheduled.EffectiveFrom.Equal(want.ScheduledPrices[i].EffectiveFrom) || scheduled.Price != want.ScheduledPrices[i].Price {
+			return fmt.Errorf("stored scheduled price %+v, want %+v", scheduled, want.ScheduledPrices[i])
+		}
+	}
+	for i, attribute := range got.Attributes {
+		if *attribute != *want.Attributes[i] {
+			return fmt.Errorf("stored attribute %+v, want %+v", attribute, want.Attributes[i])
+		}
+	}
+	for locale, translation := range want.Translations {
+		if stored, ok := got.Translations[locale]; !ok || *stored != *translation {
+			return fmt.Errorf("stored translation %s differs", locale)
+		}
+	}
+	return nil
+}
+
+func ids(products []*catalog.Product) []string {
+	list := []string{}
+	for _, product := range products {
+		list = append(list, product.ID)
+	}
+	return list
+}
+
+// sameIDs checks that products have the given ids in order.
+func sameIDs(products []*catalog.Product, want ...string) error {
+	if got := ids(products); strings.Join(got, ",") != strings.Join(want, ",") {
+		return fmt.Errorf("got products %v, want %v", got, want)
+	}
+	return nil
+}
+
+// sameIDSet checks that products have the given ids in any order.
+func sameIDSet(products []*catalog.Product, want ...string) error {
+	found := map[string]bool{}
+	for _, product := range products {
+		found[product.ID] = true
+	}
+	if len(products) != len(want) || len(found) != len(want) {
+		return fmt.Errorf("got products %v, want %v", ids(products), want)
+	}
+	for _, id := range want {
+		if !found[id] {
+			return fmt.Errorf("got products %v, want %v", ids(products), want)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package catalog
+
+import (
+	"context"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+)
+
+// RunProductScheduler applies product publish and unpublish times every
+// interval until ctx is cancelled.
+func RunProductScheduler(ctx context.Context, service Service, interval time.Duration, logger util.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		changed, err := service.ApplyProductSchedules(ctx, time.Now().UTC())
+		if err != nil {
+			logger.Service().Error().Err(err).Msg("failed to apply product schedules")
+		} else if changed > 0 {
+			logger.Service().Info().Int("products", changed).Msg("applied product schedules")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
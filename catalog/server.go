@@ -3,118 +3,683 @@ package catalog
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
+	"time"
 
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/account"
 	pb "github.com/Asif-Faizal/Minimum-Viable-Shop/catalog/pb"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	moneypb "github.com/Asif-Faizal/Minimum-Viable-Shop/money/pb"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/pricing"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type GrpcServer struct {
 	catalogService Service
+	accountClient  *account.AccountClient
 	logger         util.Logger
 	pb.UnimplementedCatalogServiceServer
 }
 
-func ListenGrpcServer(service Service, logger util.Logger, port int) error {
+func ListenGrpcServer(service Service, accountUrl string, jwtSecret string, logger util.Logger, port int) error {
+	accountClient, err := account.NewAccountClient(accountUrl)
+	if err != nil {
+		return err
+	}
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
+		accountClient.Close()
 		return err
 	}
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			util.UnaryServerInterceptor(logger),
+			util.AuthUnaryServerInterceptor(jwtSecret),
+		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			util.StreamServerInterceptor(logger),
+			util.AuthStreamServerInterceptor(jwtSecret),
 		)),
 	)
-	server := &GrpcServer{catalogService: service, logger: logger}
+	server := &GrpcServer{catalogService: service, accountClient: accountClient, logger: logger}
 	pb.RegisterCatalogServiceServer(grpcServer, server)
 	reflection.Register(grpcServer)
 	return grpcServer.Serve(lis)
 }
 
 func (server *GrpcServer) CreateOrUpdateProduct(ctx context.Context, request *pb.CreateOrUpdateProductRequest) (*pb.CreateOrUpdateProductResponse, error) {
-	product, err := server.catalogService.CreateOrUpdateProduct(ctx, &Product{
-		Name:        request.Name,
-		Description: request.Description,
-		Price:       request.Price,
-	})
+	product, err := server.catalogService.CreateOrUpdateProduct(ctx, productFromRequest(request))
 	if err != nil {
 		return nil, err
 	}
 	return &pb.CreateOrUpdateProductResponse{
-		Product: &pb.Product{
-			Id:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-		},
+		Product: toProtoProduct(product),
+	}, nil
+}
+
+func (server *GrpcServer) PatchProduct(ctx context.Context, request *pb.PatchProductRequest) (*pb.PatchProductResponse, error) {
+	product, err := server.catalogService.PatchProduct(
+		ctx,
+		request.Id,
+		request.Version,
+		productFromRequest(request.Product),
+		request.GetUpdateMask().GetPaths(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PatchProductResponse{
+		Product: toProtoProduct(product),
 	}, nil
 }
 
 func (server *GrpcServer) GetProductByID(ctx context.Context, request *pb.GetProductByIDRequest) (*pb.GetProductByIDResponse, error) {
-	product, err := server.catalogService.GetProductById(ctx, request.Id)
+	product, err := server.catalogService.GetProductById(ctx, request.Id, request.Currency)
 	if err != nil {
 		return nil, err
 	}
 	return &pb.GetProductByIDResponse{
-		Product: &pb.Product{
-			Id:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-		},
+		Product: toProtoProduct(product),
 	}, nil
 }
 
 func (server *GrpcServer) ListProducts(ctx context.Context, request *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
-	domainProducts, err := server.catalogService.ListProducts(ctx, request.Skip, request.Take)
+	page, err := server.catalogService.ListProducts(ctx, request.Skip, request.Take, request.Cursor, request.Currency, request.IncludeUnpublished, filterFromProto(request.CategoryId, request.Attributes, request.Sort))
 	if err != nil {
 		return nil, err
 	}
 	products := []*pb.Product{}
-	for _, product := range domainProducts {
-		products = append(products, &pb.Product{
-			Id:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-		})
+	for _, product := range page.Products {
+		products = append(products, toProtoProduct(product))
 	}
-	return &pb.ListProductsResponse{Products: products}, nil
+	return &pb.ListProductsResponse{
+		Products:   products,
+		NextCursor: page.NextCursor,
+		TotalCount: page.TotalCount,
+		Cursors:    page.Cursors,
+	}, nil
 }
 
 func (server *GrpcServer) ListProductsWithIds(ctx context.Context, request *pb.ListProductsWithIdsRequest) (*pb.ListProductsWithIdsResponse, error) {
-	products, err := server.catalogService.ListProductsWithIds(ctx, request.Ids)
+	products, err := server.catalogService.ListProductsWithIds(ctx, request.Ids, request.Currency)
 	if err != nil {
 		return nil, err
 	}
 	grpcProducts := []*pb.Product{}
 	for _, product := range products {
-		grpcProducts = append(grpcProducts, &pb.Product{
-			Id:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-		})
+		grpcProducts = append(grpcProducts, toProtoProduct(product))
 	}
 	return &pb.ListProductsWithIdsResponse{Products: grpcProducts}, nil
 }
 
 func (server *GrpcServer) SearchProducts(ctx context.Context, request *pb.SearchProductsRequest) (*pb.SearchProductsResponse, error) {
-	products, err := server.catalogService.SearchProducts(ctx, request.Query, request.Skip, request.Take)
+	filter := filterFromProto(request.CategoryId, request.Attributes, request.Sort)
+	filter.Locale = request.Locale
+	page, err := server.catalogService.SearchProducts(ctx, request.Query, request.Skip, request.Take, request.Cursor, request.Currency, request.IncludeUnpublished, filter)
+	if err != nil {
+		return nil, err
+	}
+	grpcProducts := []*pb.Product{}
+	for _, product := range page.Products {
+		grpcProducts = append(grpcProducts, toProtoProduct(product))
+	}
+	return &pb.SearchProductsResponse{
+		Products:   grpcProducts,
+		NextCursor: page.NextCursor,
+		TotalCount: page.TotalCount,
+		Cursors:    page.Cursors,
+	}, nil
+}
+
+func (server *GrpcServer) ListProductsByMerchant(ctx context.Context, request *pb.ListProductsByMerchantRequest) (*pb.ListProductsByMerchantResponse, error) {
+	products, err := server.catalogService.ListProductsByMerchant(ctx, request.MerchantId, request.Skip, request.Take, request.Currency, request.IncludeUnpublished)
 	if err != nil {
 		return nil, err
 	}
 	grpcProducts := []*pb.Product{}
 	for _, product := range products {
-		grpcProducts = append(grpcProducts, &pb.Product{
-			Id:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
+		grpcProducts = append(grpcProducts, toProtoProduct(product))
+	}
+	return &pb.ListProductsByMerchantResponse{Products: grpcProducts}, nil
+}
+
+func (server *GrpcServer) ListRelatedProducts(ctx context.Context, request *pb.ListRelatedProductsRequest) (*pb.ListRelatedProductsResponse, error) {
+	products, err := server.catalogService.ListRelatedProducts(ctx, request.ProductId, request.Take, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+	grpcProducts := []*pb.Product{}
+	for _, product := range products {
+		grpcProducts = append(grpcProducts, toProtoProduct(product))
+	}
+	return &pb.ListRelatedProductsResponse{Products: grpcProducts}, nil
+}
+
+func (server *GrpcServer) TransferProductOwnership(ctx context.Context, request *pb.TransferProductOwnershipRequest) (*pb.TransferProductOwnershipResponse, error) {
+	// The new owner must be an existing merchant account
+	accountResponse, err := server.accountClient.GetAccountByID(ctx, request.MerchantId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch merchant account: %w", err)
+	}
+	if accountResponse == nil || accountResponse.Account == nil {
+		return nil, fmt.Errorf("merchant account not found: %s", request.MerchantId)
+	}
+	if accountResponse.Account.Usertype != util.UserTypeMerchant {
+		return nil, fmt.Errorf("account %s is not a merchant", request.MerchantId)
+	}
+	product, err := server.catalogService.TransferProductOwnership(ctx, request.ProductId, request.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.TransferProductOwnershipResponse{
+		Product: toProtoProduct(product),
+	}, nil
+}
+
+func (server *GrpcServer) DeleteProduct(ctx context.Context, request *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	product, err := server.catalogService.DeleteProduct(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteProductResponse{
+		Product: toProtoProduct(product),
+	}, nil
+}
+
+func (server *GrpcServer) AddProductMedia(ctx context.Context, request *pb.AddProductMediaRequest) (*pb.AddProductMediaResponse, error) {
+	product, media, err := server.catalogService.AddProductMedia(ctx, request.ProductId, fromProtoMedia(request.Media))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AddProductMediaResponse{
+		Product: toProtoProduct(product),
+		Media:   toProtoMedia(media),
+	}, nil
+}
+
+func (server *GrpcServer) UpdateProductMedia(ctx context.Context, request *pb.UpdateProductMediaRequest) (*pb.UpdateProductMediaResponse, error) {
+	product, err := server.catalogService.UpdateProductMedia(ctx, request.ProductId, request.MediaId, request.AltText)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateProductMediaResponse{
+		Product: toProtoProduct(product),
+	}, nil
+}
+
+func (server *GrpcServer) RemoveProductMedia(ctx context.Context, request *pb.RemoveProductMediaRequest) (*pb.RemoveProductMediaResponse, error) {
+	product, media, err := server.catalogService.RemoveProductMedia(ctx, request.ProductId, request.MediaId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RemoveProductMediaResponse{
+		Product: toProtoProduct(product),
+		Media:   toProtoMedia(media),
+	}, nil
+}
+
+func (server *GrpcServer) ReorderProductMedia(ctx context.Context, request *pb.ReorderProductMediaRequest) (*pb.ReorderProductMediaResponse, error) {
+	product, err := server.catalogService.ReorderProductMedia(ctx, request.ProductId, request.MediaIds)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReorderProductMediaResponse{
+		Product: toProtoProduct(product),
+	}, nil
+}
+
+func (server *GrpcServer) UpdateProductRating(ctx context.Context, request *pb.UpdateProductRatingRequest) (*pb.UpdateProductRatingResponse, error) {
+	if request.Rating == nil {
+		return nil, fmt.Errorf("rating is required")
+	}
+	rating := ProductRating{Average: request.Rating.Average, Count: request.Rating.Count}
+	if err := server.catalogService.UpdateProductRating(ctx, request.ProductId, rating); err != nil {
+		return nil, err
+	}
+	return &pb.UpdateProductRatingResponse{}, nil
+}
+
+func (server *GrpcServer) GetPriceHistory(ctx context.Context, request *pb.GetPriceHistoryRequest) (*pb.GetPriceHistoryResponse, error) {
+	changes, err := server.catalogService.GetPriceHistory(ctx, request.ProductId, request.Skip, request.Take)
+	if err != nil {
+		return nil, err
+	}
+	protoChanges := []*pb.PriceChange{}
+	for _, change := range changes {
+		protoChanges = append(protoChanges, &pb.PriceChange{
+			Id:             change.ID,
+			ProductId:      change.ProductID,
+			EffectiveFrom:  timestamppb.New(change.EffectiveFrom),
+			Price:          change.Price.ToProto(),
+			Prices:         pricesToProto(change.Prices),
+			CompareAtPrice: optionalPriceToProto(change.CompareAtPrice),
+		})
+	}
+	return &pb.GetPriceHistoryResponse{Changes: protoChanges}, nil
+}
+
+func (server *GrpcServer) CreateOrUpdateCategory(ctx context.Context, request *pb.CreateOrUpdateCategoryRequest) (*pb.CreateOrUpdateCategoryResponse, error) {
+	category, err := server.catalogService.CreateOrUpdateCategory(ctx, fromProtoCategory(request.Category))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreateOrUpdateCategoryResponse{
+		Category: toProtoCategory(category),
+	}, nil
+}
+
+func (server *GrpcServer) GetCategory(ctx context.Context, request *pb.GetCategoryRequest) (*pb.GetCategoryResponse, error) {
+	category, err := server.catalogService.GetCategory(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetCategoryResponse{
+		Category: toProtoCategory(category),
+	}, nil
+}
+
+func (server *GrpcServer) ListCategories(ctx context.Context, request *pb.ListCategoriesRequest) (*pb.ListCategoriesResponse, error) {
+	categories, err := server.catalogService.ListCategories(ctx, request.Skip, request.Take)
+	if err != nil {
+		return nil, err
+	}
+	grpcCategories := []*pb.Category{}
+	for _, category := range categories {
+		grpcCategories = append(grpcCategories, toProtoCategory(category))
+	}
+	return &pb.ListCategoriesResponse{Categories: grpcCategories}, nil
+}
+
+func (server *GrpcServer) GetSynonyms(ctx context.Context, request *pb.GetSynonymsRequest) (*pb.GetSynonymsResponse, error) {
+	rules, err := server.catalogService.GetSynonyms(ctx, request.Locale)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetSynonymsResponse{Rules: rules}, nil
+}
+
+func (server *GrpcServer) UpdateSynonyms(ctx context.Context, request *pb.UpdateSynonymsRequest) (*pb.UpdateSynonymsResponse, error) {
+	if err := server.catalogService.UpdateSynonyms(ctx, request.Locale, request.Rules); err != nil {
+		return nil, err
+	}
+	return &pb.UpdateSynonymsResponse{}, nil
+}
+
+// importBatchSize is the number of streamed rows written per bulk request.
+const importBatchSize = 500
+
+func (server *GrpcServer) ImportProducts(stream pb.CatalogService_ImportProductsServer) error {
+	ctx := stream.Context()
+	response := &pb.ImportProductsResponse{}
+	batch := []*ImportRow{}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		result, err := server.catalogService.ImportProducts(ctx, batch)
+		if err != nil {
+			return err
+		}
+		response.Imported += result.Imported
+		for _, importError := range result.Errors {
+			response.Errors = append(response.Errors, &pb.ImportError{
+				Row:       importError.Row,
+				ProductId: importError.ProductID,
+				Message:   importError.Message,
+			})
+		}
+		batch = []*ImportRow{}
+		return nil
+	}
+	position := uint64(0)
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		position++
+		row := &ImportRow{Row: request.Row, Product: productFromRequest(request.Product)}
+		if row.Row == 0 {
+			row.Row = position
+		}
+		batch = append(batch, row)
+		if len(batch) == importBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return stream.SendAndClose(response)
+}
+
+func (server *GrpcServer) ExportProducts(request *pb.ExportProductsRequest, stream pb.CatalogService_ExportProductsServer) error {
+	return server.catalogService.ExportProducts(stream.Context(), request.MerchantId, request.IncludeUnpublished, func(product *Product) error {
+		return stream.Send(toProtoProduct(product))
+	})
+}
+
+func productFromRequest(request *pb.CreateOrUpdateProductRequest) *Product {
+	if request == nil {
+		return &Product{}
+	}
+	return &Product{
+		ID:              request.Id,
+		Name:            request.Name,
+		Description:     request.Description,
+		Price:           money.FromProto(request.Price),
+		Prices:          pricesFromProto(request.Prices),
+		CompareAtPrice:  optionalPriceFromProto(request.CompareAtPrice),
+		ScheduledPrices: scheduledPricesFromProto(request.ScheduledPrices),
+		Status:          ProductStatus(request.Status),
+		PublishAt:       timeFromProto(request.PublishAt),
+		UnpublishAt:     timeFromProto(request.UnpublishAt),
+		CategoryID:      request.CategoryId,
+		Attributes:      attributesFromProto(request.Attributes),
+		TaxCategory:     request.TaxCategory,
+		Weight:          request.Weight,
+		Translations:    translationsFromProto(request.Translations),
+		Version:         request.Version,
+	}
+}
+
+func toProtoProduct(product *Product) *pb.Product {
+	media := []*pb.ProductMedia{}
+	for _, item := range product.Media {
+		media = append(media, toProtoMedia(item))
+	}
+	return &pb.Product{
+		Id:                    product.ID,
+		Name:                  product.Name,
+		Description:           product.Description,
+		Price:                 product.Price.ToProto(),
+		Prices:                pricesToProto(product.Prices),
+		DisplayPrice:          product.DisplayPrice.ToProto(),
+		ExchangeRate:          product.ExchangeRate.ToProto(),
+		CompareAtPrice:        optionalPriceToProto(product.CompareAtPrice),
+		DisplayCompareAtPrice: optionalPriceToProto(product.DisplayCompareAtPrice),
+		ScheduledPrices:       scheduledPricesToProto(product.ScheduledPrices),
+		MerchantId:            product.MerchantID,
+		Status:                string(product.Status),
+		PublishAt:             timeToProto(product.PublishAt),
+		UnpublishAt:           timeToProto(product.UnpublishAt),
+		DeletedAt:             timeToProto(product.DeletedAt),
+		Media:                 media,
+		CategoryId:            product.CategoryID,
+		Attributes:            attributesToProto(product.Attributes),
+		TaxCategory:           product.TaxCategory,
+		Weight:                product.Weight,
+		Version:               product.Version,
+		Translations:          translationsToProto(product.Translations),
+		Rating: &pb.ProductRating{
+			Average: product.Rating.Average,
+			Count:   product.Rating.Count,
+		},
+	}
+}
+
+func fromProtoProduct(product *pb.Product) *Product {
+	var exchangeRate *pricing.Rate
+	if product.ExchangeRate != nil {
+		exchangeRate, _ = pricing.RateFromProto(product.ExchangeRate)
+	}
+	media := []*ProductMedia{}
+	for _, item := range product.Media {
+		media = append(media, fromProtoMedia(item))
+	}
+	rating := ProductRating{
+		Average: product.GetRating().GetAverage(),
+		Count:   product.GetRating().GetCount(),
+	}
+	return &Product{
+		ID:                    product.Id,
+		Name:                  product.Name,
+		Description:           product.Description,
+		Price:                 money.FromProto(product.Price),
+		Prices:                pricesFromProto(product.Prices),
+		CompareAtPrice:        optionalPriceFromProto(product.CompareAtPrice),
+		ScheduledPrices:       scheduledPricesFromProto(product.ScheduledPrices),
+		MerchantID:            product.MerchantId,
+		Status:                ProductStatus(product.Status),
+		PublishAt:             timeFromProto(product.PublishAt),
+		UnpublishAt:           timeFromProto(product.UnpublishAt),
+		DeletedAt:             timeFromProto(product.DeletedAt),
+		Media:                 media,
+		CategoryID:            product.CategoryId,
+		Attributes:            attributesFromProto(product.Attributes),
+		TaxCategory:           product.TaxCategory,
+		Weight:                product.Weight,
+		Version:               product.Version,
+		Translations:          translationsFromProto(product.Translations),
+		Rating:                rating,
+		DisplayPrice:          money.FromProto(product.DisplayPrice),
+		DisplayCompareAtPrice: optionalPriceFromProto(product.DisplayCompareAtPrice),
+		ExchangeRate:          exchangeRate,
+	}
+}
+
+func toProtoMedia(media *ProductMedia) *pb.ProductMedia {
+	return &pb.ProductMedia{
+		Id:           media.ID,
+		Key:          media.Key,
+		Url:          media.URL,
+		ThumbnailKey: media.ThumbnailKey,
+		ThumbnailUrl: media.ThumbnailURL,
+		ContentType:  media.ContentType,
+		AltText:      media.AltText,
+		Width:        media.Width,
+		Height:       media.Height,
+	}
+}
+
+func fromProtoMedia(media *pb.ProductMedia) *ProductMedia {
+	if media == nil {
+		return nil
+	}
+	return &ProductMedia{
+		ID:           media.Id,
+		Key:          media.Key,
+		URL:          media.Url,
+		ThumbnailKey: media.ThumbnailKey,
+		ThumbnailURL: media.ThumbnailUrl,
+		ContentType:  media.ContentType,
+		AltText:      media.AltText,
+		Width:        media.Width,
+		Height:       media.Height,
+	}
+}
+
+func attributesToProto(attributes []*ProductAttribute) []*pb.ProductAttribute {
+	protoAttributes := []*pb.ProductAttribute{}
+	for _, attribute := range attributes {
+		protoAttributes = append(protoAttributes, &pb.ProductAttribute{
+			Name:  attribute.Name,
+			Value: attribute.Value,
+			Type:  string(attribute.Type),
+			Unit:  attribute.Unit,
+		})
+	}
+	return protoAttributes
+}
+
+func attributesFromProto(attributes []*pb.ProductAttribute) []*ProductAttribute {
+	domainAttributes := []*ProductAttribute{}
+	for _, attribute := range attributes {
+		domainAttributes = append(domainAttributes, &ProductAttribute{
+			Name:  attribute.Name,
+			Value: attribute.Value,
+			Type:  AttributeType(attribute.Type),
+			Unit:  attribute.Unit,
 		})
 	}
-	return &pb.SearchProductsResponse{Products: grpcProducts}, nil
+	return domainAttributes
+}
+
+func translationsToProto(translations map[string]*ProductTranslation) map[string]*pb.ProductTranslation {
+	protoTranslations := map[string]*pb.ProductTranslation{}
+	for locale, translation := range translations {
+		protoTranslations[locale] = &pb.ProductTranslation{
+			Name:        translation.Name,
+			Description: translation.Description,
+		}
+	}
+	return protoTranslations
+}
+
+func translationsFromProto(translations map[string]*pb.ProductTranslation) map[string]*ProductTranslation {
+	domainTranslations := map[string]*ProductTranslation{}
+	for locale, translation := range translations {
+		domainTranslations[locale] = &ProductTranslation{
+			Name:        translation.GetName(),
+			Description: translation.GetDescription(),
+		}
+	}
+	return domainTranslations
+}
+
+func toProtoCategory(category *Category) *pb.Category {
+	attributes := []*pb.AttributeDefinition{}
+	for _, definition := range category.Attributes {
+		attributes = append(attributes, &pb.AttributeDefinition{
+			Name:     definition.Name,
+			Type:     string(definition.Type),
+			Unit:     definition.Unit,
+			Values:   definition.Values,
+			Required: definition.Required,
+		})
+	}
+	return &pb.Category{
+		Id:         category.ID,
+		Name:       category.Name,
+		Attributes: attributes,
+	}
+}
+
+func fromProtoCategory(category *pb.Category) *Category {
+	if category == nil {
+		return &Category{}
+	}
+	attributes := []*AttributeDefinition{}
+	for _, definition := range category.Attributes {
+		attributes = append(attributes, &AttributeDefinition{
+			Name:     definition.Name,
+			Type:     AttributeType(definition.Type),
+			Unit:     definition.Unit,
+			Values:   definition.Values,
+			Required: definition.Required,
+		})
+	}
+	return &Category{
+		ID:         category.Id,
+		Name:       category.Name,
+		Attributes: attributes,
+	}
+}
+
+func filterFromProto(categoryID string, attributes []*pb.AttributeFilter, sort string) ProductFilter {
+	filter := ProductFilter{CategoryID: categoryID, Sort: ProductSort(sort)}
+	for _, attribute := range attributes {
+		filter.Attributes = append(filter.Attributes, AttributeFilter{
+			Name:  attribute.Name,
+			Value: attribute.Value,
+			Min:   attribute.Min,
+			Max:   attribute.Max,
+		})
+	}
+	return filter
+}
+
+func filterToProto(filter ProductFilter) []*pb.AttributeFilter {
+	attributes := []*pb.AttributeFilter{}
+	for _, attribute := range filter.Attributes {
+		attributes = append(attributes, &pb.AttributeFilter{
+			Name:  attribute.Name,
+			Value: attribute.Value,
+			Min:   attribute.Min,
+			Max:   attribute.Max,
+		})
+	}
+	return attributes
+}
+
+func pricesFromProto(prices []*moneypb.Money) []money.Money {
+	domainPrices := []money.Money{}
+	for _, price := range prices {
+		domainPrices = append(domainPrices, money.FromProto(price))
+	}
+	return domainPrices
+}
+
+func pricesToProto(prices []money.Money) []*moneypb.Money {
+	protoPrices := []*moneypb.Money{}
+	for _, price := range prices {
+		protoPrices = append(protoPrices, price.ToProto())
+	}
+	return protoPrices
+}
+
+func optionalPriceFromProto(price *moneypb.Money) *money.Money {
+	if price == nil {
+		return nil
+	}
+	domainPrice := money.FromProto(price)
+	return &domainPrice
+}
+
+func optionalPriceToProto(price *money.Money) *moneypb.Money {
+	if price == nil {
+		return nil
+	}
+	return price.ToProto()
+}
+
+func scheduledPricesFromProto(scheduledPrices []*pb.ScheduledPrice) []*ScheduledPrice {
+	domainPrices := []*ScheduledPrice{}
+	for _, scheduled := range scheduledPrices {
+		domainPrices = append(domainPrices, &ScheduledPrice{
+			EffectiveFrom:  scheduled.GetEffectiveFrom().AsTime(),
+			Price:          money.FromProto(scheduled.Price),
+			Prices:         pricesFromProto(scheduled.Prices),
+			CompareAtPrice: optionalPriceFromProto(scheduled.CompareAtPrice),
+		})
+	}
+	return domainPrices
+}
+
+func scheduledPricesToProto(scheduledPrices []*ScheduledPrice) []*pb.ScheduledPrice {
+	protoPrices := []*pb.ScheduledPrice{}
+	for _, scheduled := range scheduledPrices {
+		protoPrices = append(protoPrices, &pb.ScheduledPrice{
+			EffectiveFrom:  timestamppb.New(scheduled.EffectiveFrom),
+			Price:          scheduled.Price.ToProto(),
+			Prices:         pricesToProto(scheduled.Prices),
+			CompareAtPrice: optionalPriceToProto(scheduled.CompareAtPrice),
+		})
+	}
+	return protoPrices
+}
+
+func timeFromProto(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	t := timestamp.AsTime()
+	return &t
+}
+
+func timeToProto(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
 }
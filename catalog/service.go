@@ -2,77 +2,1052 @@ package catalog
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/pricing"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 	"github.com/segmentio/ksuid"
 )
 
 type Service interface {
 	CreateOrUpdateProduct(ctx context.Context, product *Product) (*Product, error)
-	GetProductById(ctx context.Context, id string) (*Product, error)
-	ListProducts(ctx context.Context, skip uint64, take uint64) ([]*Product, error)
-	ListProductsWithIds(ctx context.Context, ids []string) ([]*Product, error)
-	SearchProducts(ctx context.Context, query string, skip uint64, take uint64) ([]*Product, error)
+	PatchProduct(ctx context.Context, id string, version string, patch *Product, fields []string) (*Product, error)
+	GetProductById(ctx context.Context, id string, currency string) (*Product, error)
+	ListProducts(ctx context.Context, skip uint64, take uint64, cursor string, currency string, includeUnpublished bool, filter ProductFilter) (*ProductPage, error)
+	ListProductsWithIds(ctx context.Context, ids []string, currency string) ([]*Product, error)
+	SearchProducts(ctx context.Context, query string, skip uint64, take uint64, cursor string, currency string, includeUnpublished bool, filter ProductFilter) (*ProductPage, error)
+	ListProductsByMerchant(ctx context.Context, merchantID string, skip uint64, take uint64, currency string, includeUnpublished bool) ([]*Product, error)
+	ListRelatedProducts(ctx context.Context, productID string, take uint64, currency string) ([]*Product, error)
+	TransferProductOwnership(ctx context.Context, productID string, merchantID string) (*Product, error)
+	DeleteProduct(ctx context.Context, id string) (*Product, error)
+	ImportProducts(ctx context.Context, rows []*ImportRow) (*ImportResult, error)
+	ExportProducts(ctx context.Context, merchantID string, includeUnpublished bool, fn func(*Product) error) error
+	AddProductMedia(ctx context.Context, productID string, media *ProductMedia) (*Product, *ProductMedia, error)
+	UpdateProductMedia(ctx context.Context, productID string, mediaID string, altText string) (*Product, error)
+	RemoveProductMedia(ctx context.Context, productID string, mediaID string) (*Product, *ProductMedia, error)
+	ReorderProductMedia(ctx context.Context, productID string, mediaIDs []string) (*Product, error)
+	ApplyProductSchedules(ctx context.Context, at time.Time) (int, error)
+	UpdateProductRating(ctx context.Context, productID string, rating ProductRating) error
+	CreateOrUpdateCategory(ctx context.Context, category *Category) (*Category, error)
+	GetCategory(ctx context.Context, id string) (*Category, error)
+	ListCategories(ctx context.Context, skip uint64, take uint64) ([]*Category, error)
+	GetSynonyms(ctx context.Context, locale string) ([]string, error)
+	UpdateSynonyms(ctx context.Context, locale string, rules []string) error
+	GetPriceHistory(ctx context.Context, productID string, skip uint64, take uint64) ([]*PriceChange, error)
 }
 
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("permission denied")
+	ErrMediaNotFound   = errors.New("product media not found")
+)
+
+// maxProductMedia limits the number of images per product.
+const maxProductMedia = 20
+
+// maxScheduledPrices limits the number of upcoming price changes per product.
+const maxScheduledPrices = 20
+
 type CatalogService struct {
 	repository Repository
+	pricing    pricing.Service
 }
 
-func NewCatalogService(repository Repository) *CatalogService {
-	return &CatalogService{repository: repository}
+func NewCatalogService(repository Repository, pricing pricing.Service) *CatalogService {
+	return &CatalogService{repository: repository, pricing: pricing}
 }
 
 func (service *CatalogService) CreateOrUpdateProduct(ctx context.Context, product *Product) (*Product, error) {
-	id := product.ID
-	if id == "" {
-		id = ksuid.New().String()
+	if err := validateProduct(product); err != nil {
+		return nil, err
 	}
-	newProduct := &Product{
-		ID:          id,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
+	claims, err := productManager(ctx)
+	if err != nil {
+		return nil, err
 	}
+	var existing *Product
+	if product.ID != "" {
+		existing, err = service.repository.GetProductById(ctx, product.ID)
+		if errors.Is(err, ErrProductNotFound) {
+			existing, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil && product.Version == "" {
+			return nil, fmt.Errorf("version is required to update product %s", product.ID)
+		}
+	}
+	attributes, err := service.productAttributes(ctx, product, map[string]*Category{})
+	if err != nil {
+		return nil, err
+	}
+	newProduct, err := mergeProduct(claims, product, existing)
+	if err != nil {
+		return nil, err
+	}
+	newProduct.Attributes = attributes
 	if _, err := service.repository.CreateOrUpdateProduct(ctx, newProduct); err != nil {
 		return nil, err
 	}
+	if err := service.recordPriceChanges(ctx, priceChange(existing, newProduct, time.Now().UTC())); err != nil {
+		return nil, err
+	}
+	newProduct.DisplayPrice = newProduct.Price
+	newProduct.DisplayCompareAtPrice = newProduct.CompareAtPrice
 	return newProduct, nil
 }
 
-func (service *CatalogService) GetProductById(ctx context.Context, id string) (*Product, error) {
+// PatchProduct changes the named fields of a product to their values in patch,
+// leaving the others as they are. version must be the product's current
+// version. Attributes are validated again only when the category or the
+// attributes change.
+func (service *CatalogService) PatchProduct(ctx context.Context, id string, version string, patch *Product, fields []string) (*Product, error) {
+	if version == "" {
+		return nil, fmt.Errorf("version is required to update product %s", id)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+	product, err := service.editableProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if product.Version != version {
+		return nil, fmt.Errorf("%w: product %s", ErrVersionConflict, id)
+	}
+	previous := *product
+	attributesChanged, err := applyProductPatch(product, patch, fields)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateProduct(product); err != nil {
+		return nil, err
+	}
+	if attributesChanged {
+		attributes, err := service.productAttributes(ctx, product, map[string]*Category{})
+		if err != nil {
+			return nil, err
+		}
+		product.Attributes = attributes
+	}
+	if err := service.saveProduct(ctx, product); err != nil {
+		return nil, err
+	}
+	if err := service.recordPriceChanges(ctx, priceChange(&previous, product, time.Now().UTC())); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// ImportProducts creates or updates a batch of products with the same rules
+// as CreateOrUpdateProduct, except that rows without a version overwrite the
+// product as it was read for the batch. Rows that fail are reported and
+// skipped; the rest are written in a single bulk request.
+func (service *CatalogService) ImportProducts(ctx context.Context, rows []*ImportRow) (*ImportResult, error) {
+	claims, err := productManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{}
+	for _, row := range rows {
+		if row.Product.ID != "" {
+			ids = append(ids, row.Product.ID)
+		}
+	}
+	existing := map[string]*Product{}
+	if len(ids) > 0 {
+		found, err := service.repository.ListProductsWithIds(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, product := range found {
+			existing[product.ID] = product
+		}
+	}
+
+	result := &ImportResult{}
+	valid := []*ImportRow{}
+	products := []*Product{}
+	categories := map[string]*Category{}
+	imported := map[string]uint64{}
+	for _, row := range rows {
+		if previous, ok := imported[row.Product.ID]; ok {
+			result.Errors = append(result.Errors, newImportError(row, fmt.Errorf("product is already imported by row %d of the same batch", previous)))
+			continue
+		}
+		if err := validateProduct(row.Product); err != nil {
+			result.Errors = append(result.Errors, newImportError(row, err))
+			continue
+		}
+		attributes, err := service.productAttributes(ctx, row.Product, categories)
+		if err != nil {
+			result.Errors = append(result.Errors, newImportError(row, err))
+			continue
+		}
+		product, err := mergeProduct(claims, row.Product, existing[row.Product.ID])
+		if err != nil {
+			result.Errors = append(result.Errors, newImportError(row, err))
+			continue
+		}
+		product.Attributes = attributes
+		imported[product.ID] = row.Row
+		valid = append(valid, &ImportRow{Row: row.Row, Product: product})
+		products = append(products, product)
+	}
+	errs, err := service.repository.BulkCreateOrUpdateProducts(ctx, products)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	changes := []*PriceChange{}
+	for i, err := range errs {
+		if err != nil {
+			result.Errors = append(result.Errors, newImportError(valid[i], err))
+			continue
+		}
+		result.Imported++
+		if change := priceChange(existing[products[i].ID], products[i], now); change != nil {
+			changes = append(changes, change)
+		}
+	}
+	if err := service.recordPriceChanges(ctx, changes...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExportProducts calls fn for every product the caller may see, optionally
+// limited to one merchant, priced in its base currency.
+func (service *CatalogService) ExportProducts(ctx context.Context, merchantID string, includeUnpublished bool, fn func(*Product) error) error {
+	return service.repository.ExportProducts(ctx, merchantID, visibility(ctx, includeUnpublished, merchantID), func(product *Product) error {
+		product.DisplayPrice = product.Price
+		product.DisplayCompareAtPrice = product.CompareAtPrice
+		return fn(product)
+	})
+}
+
+func (service *CatalogService) GetProductById(ctx context.Context, id string, currency string) (*Product, error) {
 	product, err := service.repository.GetProductById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if !canView(ctx, product) {
+		return nil, ErrProductNotFound
+	}
+	if err := service.resolvePrices([]*Product{product}, currency); err != nil {
+		return nil, err
+	}
 	return product, nil
 }
 
-func (service *CatalogService) ListProducts(ctx context.Context, skip uint64, take uint64) ([]*Product, error) {
+func (service *CatalogService) ListProducts(ctx context.Context, skip uint64, take uint64, cursor string, currency string, includeUnpublished bool, filter ProductFilter) (*ProductPage, error) {
 	if take > 100 || (skip == 0 && take == 0) {
 		take = 100
 	}
-	products, err := service.repository.ListProducts(ctx, skip, take)
+	if !filter.Sort.Valid() {
+		return nil, fmt.Errorf("invalid sort %q", filter.Sort)
+	}
+	page, err := service.repository.ListProducts(ctx, skip, take, cursor, visibility(ctx, includeUnpublished, ""), filter)
 	if err != nil {
 		return nil, err
 	}
-	return products, nil
+	if err := service.resolvePrices(page.Products, currency); err != nil {
+		return nil, err
+	}
+	return page, nil
 }
 
-func (service *CatalogService) ListProductsWithIds(ctx context.Context, ids []string) ([]*Product, error) {
-	products, err := service.repository.ListProductsWithIds(ctx, ids)
+func (service *CatalogService) ListProductsWithIds(ctx context.Context, ids []string, currency string) ([]*Product, error) {
+	found, err := service.repository.ListProductsWithIds(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
+	// Products looked up by id are being ordered, so only public ones count.
+	products := []*Product{}
+	for _, product := range found {
+		if product.IsPublic() {
+			products = append(products, product)
+		}
+	}
+	if err := service.resolvePrices(products, currency); err != nil {
+		return nil, err
+	}
 	return products, nil
 }
 
-func (service *CatalogService) SearchProducts(ctx context.Context, query string, skip uint64, take uint64) ([]*Product, error) {
+func (service *CatalogService) SearchProducts(ctx context.Context, query string, skip uint64, take uint64, cursor string, currency string, includeUnpublished bool, filter ProductFilter) (*ProductPage, error) {
 	if take > 100 || (skip == 0 && take == 0) {
 		take = 100
 	}
-	products, err := service.repository.SearchProducts(ctx, query, skip, take)
+	if !filter.Sort.Valid() {
+		return nil, fmt.Errorf("invalid sort %q", filter.Sort)
+	}
+	if filter.Locale != "" && !ValidLocale(filter.Locale) {
+		return nil, fmt.Errorf("unsupported locale %q", filter.Locale)
+	}
+	page, err := service.repository.SearchProducts(ctx, query, skip, take, cursor, visibility(ctx, includeUnpublished, ""), filter)
 	if err != nil {
 		return nil, err
 	}
+	if err := service.resolvePrices(page.Products, currency); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (service *CatalogService) ListProductsByMerchant(ctx context.Context, merchantID string, skip uint64, take uint64, currency string, includeUnpublished bool) ([]*Product, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("merchant id is required")
+	}
+	if take > 100 || (skip == 0 && take == 0) {
+		take = 100
+	}
+	products, err := service.repository.ListProductsByMerchant(ctx, merchantID, skip, take, visibility(ctx, includeUnpublished, merchantID))
+	if err != nil {
+		return nil, err
+	}
+	if err := service.resolvePrices(products, currency); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// ListRelatedProducts recommends published products similar to the given one.
+func (service *CatalogService) ListRelatedProducts(ctx context.Context, productID string, take uint64, currency string) ([]*Product, error) {
+	if take == 0 || take > 100 {
+		take = 100
+	}
+	product, err := service.repository.GetProductById(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(ctx, product) {
+		return nil, ErrProductNotFound
+	}
+	products, err := service.repository.ListRelatedProducts(ctx, product, take, VisibilityPublished)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.resolvePrices(products, currency); err != nil {
+		return nil, err
+	}
 	return products, nil
 }
+
+// TransferProductOwnership assigns a product to another merchant. Only admins
+// may transfer products; the gRPC server checks that the new owner is a
+// merchant account.
+func (service *CatalogService) TransferProductOwnership(ctx context.Context, productID string, merchantID string) (*Product, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can transfer products", ErrForbidden)
+	}
+	if merchantID == "" {
+		return nil, fmt.Errorf("merchant id is required")
+	}
+	product, err := service.repository.GetProductById(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.DeletedAt != nil {
+		return nil, fmt.Errorf("%w: product %s was deleted", ErrProductNotFound, productID)
+	}
+	product.MerchantID = merchantID
+	if _, err := service.repository.CreateOrUpdateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+	product.DisplayPrice = product.Price
+	product.DisplayCompareAtPrice = product.CompareAtPrice
+	return product, nil
+}
+
+// DeleteProduct soft deletes a product: it is archived and hidden from every
+// listing but kept so past orders and reports can still refer to it.
+func (service *CatalogService) DeleteProduct(ctx context.Context, id string) (*Product, error) {
+	product, err := service.editableProduct(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	product.Status = ProductStatusArchived
+	product.PublishAt = nil
+	product.UnpublishAt = nil
+	product.DeletedAt = &now
+	if _, err := service.repository.CreateOrUpdateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+	product.DisplayPrice = product.Price
+	product.DisplayCompareAtPrice = product.CompareAtPrice
+	return product, nil
+}
+
+// AddProductMedia appends an image, already stored in the blob store, to the
+// product's media and returns the record with its assigned id.
+func (service *CatalogService) AddProductMedia(ctx context.Context, productID string, media *ProductMedia) (*Product, *ProductMedia, error) {
+	if media == nil || media.Key == "" || media.URL == "" {
+		return nil, nil, fmt.Errorf("media key and url are required")
+	}
+	product, err := service.editableProduct(ctx, productID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(product.Media) >= maxProductMedia {
+		return nil, nil, fmt.Errorf("a product can have at most %d images", maxProductMedia)
+	}
+	added := *media
+	added.ID = ksuid.New().String()
+	product.Media = append(product.Media, &added)
+	if err := service.saveProduct(ctx, product); err != nil {
+		return nil, nil, err
+	}
+	return product, &added, nil
+}
+
+func (service *CatalogService) UpdateProductMedia(ctx context.Context, productID string, mediaID string, altText string) (*Product, error) {
+	product, err := service.editableProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	index := mediaIndex(product, mediaID)
+	if index < 0 {
+		return nil, ErrMediaNotFound
+	}
+	product.Media[index].AltText = altText
+	if err := service.saveProduct(ctx, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// RemoveProductMedia removes an image from the product and returns the removed
+// record; deleting its blobs is left to the caller.
+func (service *CatalogService) RemoveProductMedia(ctx context.Context, productID string, mediaID string) (*Product, *ProductMedia, error) {
+	product, err := service.editableProduct(ctx, productID)
+	if err != nil {
+		return nil, nil, err
+	}
+	index := mediaIndex(product, mediaID)
+	if index < 0 {
+		return nil, nil, ErrMediaNotFound
+	}
+	removed := product.Media[index]
+	product.Media = append(product.Media[:index], product.Media[index+1:]...)
+	if err := service.saveProduct(ctx, product); err != nil {
+		return nil, nil, err
+	}
+	return product, removed, nil
+}
+
+// ReorderProductMedia puts the product's images in the order of mediaIDs,
+// which must list each of them exactly once.
+func (service *CatalogService) ReorderProductMedia(ctx context.Context, productID string, mediaIDs []string) (*Product, error) {
+	product, err := service.editableProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(mediaIDs) != len(product.Media) {
+		return nil, fmt.Errorf("media ids must list all %d images of the product", len(product.Media))
+	}
+	reordered := make([]*ProductMedia, 0, len(mediaIDs))
+	seen := map[string]bool{}
+	for _, id := range mediaIDs {
+		index := mediaIndex(product, id)
+		if index < 0 || seen[id] {
+			return nil, fmt.Errorf("%w: %s", ErrMediaNotFound, id)
+		}
+		seen[id] = true
+		reordered = append(reordered, product.Media[index])
+	}
+	product.Media = reordered
+	if err := service.saveProduct(ctx, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// UpdateProductRating stores the aggregate rating computed by the review
+// service, the only caller allowed to set it.
+func (service *CatalogService) UpdateProductRating(ctx context.Context, productID string, rating ProductRating) error {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if claims.UserType != util.UserTypeService {
+		return fmt.Errorf("%w: ratings are maintained by the review service", ErrForbidden)
+	}
+	if rating.Average < 0 || rating.Average > 5 {
+		return fmt.Errorf("invalid average rating %v", rating.Average)
+	}
+	return service.repository.UpdateProductRating(ctx, productID, rating)
+}
+
+// CreateOrUpdateCategory stores a category and its attribute schema. Only
+// admins manage categories. Products are checked against the schema when they
+// are written, so changing it does not affect products already stored.
+func (service *CatalogService) CreateOrUpdateCategory(ctx context.Context, category *Category) (*Category, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can manage categories", ErrForbidden)
+	}
+	if err := validateCategory(category); err != nil {
+		return nil, err
+	}
+	newCategory := *category
+	if newCategory.ID == "" {
+		newCategory.ID = ksuid.New().String()
+	}
+	if newCategory.Attributes == nil {
+		newCategory.Attributes = []*AttributeDefinition{}
+	}
+	return service.repository.CreateOrUpdateCategory(ctx, &newCategory)
+}
+
+func (service *CatalogService) GetCategory(ctx context.Context, id string) (*Category, error) {
+	return service.repository.GetCategoryById(ctx, id)
+}
+
+func (service *CatalogService) ListCategories(ctx context.Context, skip uint64, take uint64) ([]*Category, error) {
+	if take > 100 || (skip == 0 && take == 0) {
+		take = 100
+	}
+	return service.repository.ListCategories(ctx, skip, take)
+}
+
+func (service *CatalogService) GetSynonyms(ctx context.Context, locale string) ([]string, error) {
+	if err := synonymsManager(ctx, locale); err != nil {
+		return nil, err
+	}
+	return service.repository.GetSynonyms(ctx, locale)
+}
+
+// UpdateSynonyms replaces the search synonyms of a locale. Each rule is either
+// a list of equivalent terms, "tee, t-shirt", or an explicit mapping,
+// "tee => t-shirt".
+func (service *CatalogService) UpdateSynonyms(ctx context.Context, locale string, rules []string) error {
+	if err := synonymsManager(ctx, locale); err != nil {
+		return err
+	}
+	if len(rules) > maxSynonymRules {
+		return fmt.Errorf("at most %d synonym rules are allowed", maxSynonymRules)
+	}
+	trimmed := []string{}
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		if !strings.Contains(rule, ",") && !strings.Contains(rule, "=>") {
+			return fmt.Errorf("invalid synonym rule %q, expected e.g. \"tee, t-shirt\"", rule)
+		}
+		trimmed = append(trimmed, rule)
+	}
+	return service.repository.UpdateSynonyms(ctx, locale, trimmed)
+}
+
+// synonymsManager checks that the caller is an admin and the locale supported.
+func synonymsManager(ctx context.Context, locale string) error {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return fmt.Errorf("%w: only admins can manage synonyms", ErrForbidden)
+	}
+	if !ValidLocale(locale) {
+		return fmt.Errorf("unsupported locale %q", locale)
+	}
+	return nil
+}
+
+// productAttributes validates the product's attributes against its category,
+// loading categories not already in the cache.
+func (service *CatalogService) productAttributes(ctx context.Context, product *Product, categories map[string]*Category) ([]*ProductAttribute, error) {
+	if product.CategoryID == "" {
+		return validateAttributes(nil, product.Attributes)
+	}
+	category, ok := categories[product.CategoryID]
+	if !ok {
+		var err error
+		category, err = service.repository.GetCategoryById(ctx, product.CategoryID)
+		if errors.Is(err, ErrCategoryNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrCategoryNotFound, product.CategoryID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		categories[product.CategoryID] = category
+	}
+	return validateAttributes(category, product.Attributes)
+}
+
+// editableProduct loads a product the caller may change: its owner or an admin.
+func (service *CatalogService) editableProduct(ctx context.Context, id string) (*Product, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	product, err := service.repository.GetProductById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if product.DeletedAt != nil {
+		return nil, fmt.Errorf("%w: product %s was deleted", ErrProductNotFound, id)
+	}
+	if !claims.IsAdmin() && product.MerchantID != claims.AccountID {
+		return nil, fmt.Errorf("%w: product %s belongs to another merchant", ErrForbidden, id)
+	}
+	return product, nil
+}
+
+func (service *CatalogService) saveProduct(ctx context.Context, product *Product) error {
+	if _, err := service.repository.CreateOrUpdateProduct(ctx, product); err != nil {
+		return err
+	}
+	product.DisplayPrice = product.Price
+	product.DisplayCompareAtPrice = product.CompareAtPrice
+	return nil
+}
+
+func mediaIndex(product *Product, mediaID string) int {
+	for i, media := range product.Media {
+		if media.ID == mediaID {
+			return i
+		}
+	}
+	return -1
+}
+
+// ApplyProductSchedules publishes drafts whose publish time and archives
+// published products whose unpublish time is at or before at, and applies
+// scheduled prices that are due. It returns the number of products changed.
+func (service *CatalogService) ApplyProductSchedules(ctx context.Context, at time.Time) (int, error) {
+	products, err := service.repository.ListDueProducts(ctx, at, 100)
+	if err != nil {
+		return 0, err
+	}
+	changed := 0
+	for _, product := range products {
+		previous := *product
+		applied := applyScheduledPrices(product, at)
+		if product.Status == ProductStatusDraft && product.PublishAt != nil && !product.PublishAt.After(at) {
+			product.Status = ProductStatusPublished
+			product.PublishAt = nil
+		}
+		if product.Status == ProductStatusPublished && product.UnpublishAt != nil && !product.UnpublishAt.After(at) {
+			product.Status = ProductStatusArchived
+			product.UnpublishAt = nil
+		}
+		_, err := service.repository.CreateOrUpdateProduct(ctx, product)
+		if errors.Is(err, ErrVersionConflict) {
+			// The product was edited meanwhile; it is reconsidered on the next run.
+			continue
+		}
+		if err != nil {
+			return changed, fmt.Errorf("failed to update product %s: %w", product.ID, err)
+		}
+		changed++
+		if applied != nil {
+			if err := service.recordPriceChanges(ctx, priceChange(&previous, product, applied.EffectiveFrom)); err != nil {
+				return changed, err
+			}
+		}
+	}
+	return changed, nil
+}
+
+// applyScheduledPrices gives the product the prices of its latest scheduled
+// price that is due at the given time, which it returns, and drops every
+// due scheduled price. It returns nil if none is due.
+func applyScheduledPrices(product *Product, at time.Time) *ScheduledPrice {
+	var applied *ScheduledPrice
+	pending := []*ScheduledPrice{}
+	for _, scheduled := range product.ScheduledPrices {
+		if scheduled.EffectiveFrom.After(at) {
+			pending = append(pending, scheduled)
+			continue
+		}
+		if applied == nil || scheduled.EffectiveFrom.After(applied.EffectiveFrom) {
+			applied = scheduled
+		}
+	}
+	if applied == nil {
+		return nil
+	}
+	product.Price = applied.Price
+	product.Prices = applied.Prices
+	product.CompareAtPrice = applied.CompareAtPrice
+	product.ScheduledPrices = pending
+	return applied
+}
+
+// GetPriceHistory returns the price changes of a product the caller may see,
+// latest first.
+func (service *CatalogService) GetPriceHistory(ctx context.Context, productID string, skip uint64, take uint64) ([]*PriceChange, error) {
+	if take > 100 || (skip == 0 && take == 0) {
+		take = 100
+	}
+	product, err := service.repository.GetProductById(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !canView(ctx, product) {
+		return nil, ErrProductNotFound
+	}
+	return service.repository.ListPriceChanges(ctx, productID, skip, take)
+}
+
+// recordPriceChanges adds the given changes, skipping nil ones, to the price
+// history. Products are saved before their changes are recorded, so an
+// error means the history is missing the change, not that it was undone.
+func (service *CatalogService) recordPriceChanges(ctx context.Context, changes ...*PriceChange) error {
+	recorded := []*PriceChange{}
+	for _, change := range changes {
+		if change != nil {
+			recorded = append(recorded, change)
+		}
+	}
+	if err := service.repository.AddPriceChanges(ctx, recorded); err != nil {
+		return fmt.Errorf("failed to record price history: %w", err)
+	}
+	return nil
+}
+
+// priceChange returns the record of product's prices from the given time if
+// they differ from those of previous, which is nil for a new product.
+func priceChange(previous *Product, product *Product, at time.Time) *PriceChange {
+	if previous != nil && samePrices(previous, product) {
+		return nil
+	}
+	return &PriceChange{
+		ID:             ksuid.New().String(),
+		ProductID:      product.ID,
+		EffectiveFrom:  at,
+		Price:          product.Price,
+		Prices:         product.Prices,
+		CompareAtPrice: product.CompareAtPrice,
+	}
+}
+
+func samePrices(a *Product, b *Product) bool {
+	if a.Price != b.Price || len(a.Prices) != len(b.Prices) {
+		return false
+	}
+	if (a.CompareAtPrice == nil) != (b.CompareAtPrice == nil) {
+		return false
+	}
+	if a.CompareAtPrice != nil && *a.CompareAtPrice != *b.CompareAtPrice {
+		return false
+	}
+	listed := map[money.Money]bool{}
+	for _, price := range a.Prices {
+		listed[price] = true
+	}
+	for _, price := range b.Prices {
+		if !listed[price] {
+			return false
+		}
+	}
+	return true
+}
+
+func validateProduct(product *Product) error {
+	if product.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if err := product.Price.Validate(); err != nil {
+		return fmt.Errorf("invalid price: %w", err)
+	}
+	if err := validatePriceList(product.Price, product.Prices); err != nil {
+		return err
+	}
+	if err := validateCompareAtPrice(product.Price, product.CompareAtPrice); err != nil {
+		return err
+	}
+	if err := validateScheduledPrices(product.ScheduledPrices); err != nil {
+		return err
+	}
+	if product.Status != "" && !product.Status.Valid() {
+		return fmt.Errorf("invalid status %q", product.Status)
+	}
+	if product.PublishAt != nil && product.UnpublishAt != nil && !product.UnpublishAt.After(*product.PublishAt) {
+		return fmt.Errorf("unpublish time must be after publish time")
+	}
+	for locale := range product.Translations {
+		if locale == DefaultLocale {
+			return fmt.Errorf("name and description are already in %s, the default locale", DefaultLocale)
+		}
+		if !ValidLocale(locale) {
+			return fmt.Errorf("unsupported locale %q", locale)
+		}
+	}
+	return nil
+}
+
+// productManager returns the claims of a caller allowed to create products.
+func productManager(ctx context.Context) (*util.JWTClaims, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if claims.UserType != util.UserTypeMerchant && !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only merchants and admins can manage products", ErrForbidden)
+	}
+	return claims, nil
+}
+
+// mergeProduct builds the product to store from the caller's input and the
+// stored product with the same id, if any. New products belong to the caller.
+// Existing products keep their owner and status unless one is given, and
+// merchants may only change products they own. An update without a version
+// applies to the existing product as it was read.
+func mergeProduct(claims *util.JWTClaims, product *Product, existing *Product) (*Product, error) {
+	id := product.ID
+	merchantID := claims.AccountID
+	status := product.Status
+	version := ""
+	var media []*ProductMedia
+	var rating ProductRating
+	if id == "" {
+		id = ksuid.New().String()
+	}
+	if existing == nil && product.Version != "" {
+		return nil, fmt.Errorf("%w: product %s", ErrProductNotFound, id)
+	}
+	if existing != nil {
+		version = product.Version
+		if version == "" {
+			version = existing.Version
+		}
+		if version != existing.Version {
+			return nil, fmt.Errorf("%w: product %s", ErrVersionConflict, id)
+		}
+		if existing.DeletedAt != nil {
+			return nil, fmt.Errorf("%w: product %s was deleted", ErrProductNotFound, id)
+		}
+		if !claims.IsAdmin() && existing.MerchantID != claims.AccountID {
+			return nil, fmt.Errorf("%w: product %s belongs to another merchant", ErrForbidden, id)
+		}
+		merchantID = existing.MerchantID
+		media = existing.Media
+		rating = existing.Rating
+		if status == "" {
+			status = existing.Status
+		}
+	}
+	if status == "" {
+		status = ProductStatusPublished
+		if product.PublishAt != nil && product.PublishAt.After(time.Now()) {
+			status = ProductStatusDraft
+		}
+	}
+	return &Product{
+		ID:              id,
+		Name:            product.Name,
+		Description:     product.Description,
+		Price:           product.Price,
+		Prices:          product.Prices,
+		CompareAtPrice:  product.CompareAtPrice,
+		ScheduledPrices: sortScheduledPrices(product.ScheduledPrices),
+		MerchantID:      merchantID,
+		Status:          status,
+		PublishAt:       product.PublishAt,
+		UnpublishAt:     product.UnpublishAt,
+		Media:           media,
+		CategoryID:      product.CategoryID,
+		Attributes:      product.Attributes,
+		TaxCategory:     product.TaxCategory,
+		Weight:          product.Weight,
+		Translations:    product.Translations,
+		Version:         version,
+		Rating:          rating,
+	}, nil
+}
+
+// applyProductPatch copies the named fields from patch to product and reports
+// whether the category or attributes changed.
+func applyProductPatch(product *Product, patch *Product, fields []string) (bool, error) {
+	attributesChanged := false
+	for _, field := range fields {
+		switch field {
+		case "name":
+			product.Name = patch.Name
+		case "description":
+			product.Description = patch.Description
+		case "price":
+			product.Price = patch.Price
+		case "prices":
+			product.Prices = patch.Prices
+		case "compare_at_price":
+			product.CompareAtPrice = patch.CompareAtPrice
+		case "scheduled_prices":
+			product.ScheduledPrices = sortScheduledPrices(patch.ScheduledPrices)
+		case "status":
+			if patch.Status == "" {
+				return false, fmt.Errorf("status cannot be empty")
+			}
+			product.Status = patch.Status
+		case "publish_at":
+			product.PublishAt = patch.PublishAt
+		case "unpublish_at":
+			product.UnpublishAt = patch.UnpublishAt
+		case "category_id":
+			product.CategoryID = patch.CategoryID
+			attributesChanged = true
+		case "attributes":
+			product.Attributes = patch.Attributes
+			attributesChanged = true
+		case "tax_category":
+			product.TaxCategory = patch.TaxCategory
+		case "weight":
+			product.Weight = patch.Weight
+		case "translations":
+			product.Translations = patch.Translations
+		default:
+			return false, fmt.Errorf("unknown product field %q", field)
+		}
+	}
+	return attributesChanged, nil
+}
+
+// sortScheduledPrices orders scheduled prices by their effective time.
+func sortScheduledPrices(scheduledPrices []*ScheduledPrice) []*ScheduledPrice {
+	sort.SliceStable(scheduledPrices, func(i, j int) bool {
+		return scheduledPrices[i].EffectiveFrom.Before(scheduledPrices[j].EffectiveFrom)
+	})
+	return scheduledPrices
+}
+
+func newImportError(row *ImportRow, err error) ImportError {
+	return ImportError{Row: row.Row, ProductID: row.Product.ID, Message: err.Error()}
+}
+
+// canView reports whether the caller may see a product that is not public:
+// admins see every product and merchants see their own unless deleted.
+func canView(ctx context.Context, product *Product) bool {
+	if product.IsPublic() {
+		return true
+	}
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return false
+	}
+	if claims.IsAdmin() {
+		return true
+	}
+	return product.DeletedAt == nil && product.MerchantID == claims.AccountID
+}
+
+// visibility honours includeUnpublished only for admins, or for the merchant
+// whose own products are being listed.
+func visibility(ctx context.Context, includeUnpublished bool, merchantID string) Visibility {
+	if !includeUnpublished {
+		return VisibilityPublished
+	}
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return VisibilityPublished
+	}
+	if claims.IsAdmin() || (merchantID != "" && claims.AccountID == merchantID) {
+		return VisibilityAll
+	}
+	return VisibilityPublished
+}
+
+// resolvePrices fills in DisplayPrice and DisplayCompareAtPrice for the
+// requested currency using the rates in effect now. A product priced from its
+// price list has no was-price in that currency, so none is shown.
+func (service *CatalogService) resolvePrices(products []*Product, currency string) error {
+	now := time.Now().UTC()
+	for _, product := range products {
+		quote, err := service.pricing.Resolve(product.Price, product.Prices, currency, now)
+		if err != nil {
+			return fmt.Errorf("failed to price product %s in %s: %w", product.ID, currency, err)
+		}
+		product.DisplayPrice = quote.Price
+		product.ExchangeRate = quote.Rate
+		product.DisplayCompareAtPrice = nil
+		if product.CompareAtPrice == nil || (quote.Rate == nil && quote.Price.Currency != product.Price.Currency) {
+			continue
+		}
+		compareAt, err := service.pricing.Convert(*product.CompareAtPrice, quote.Price.Currency, now)
+		if err != nil {
+			return fmt.Errorf("failed to price product %s in %s: %w", product.ID, currency, err)
+		}
+		// Rounding must not turn a sale into an equal or lower was-price.
+		if compareAt.Price.Amount > quote.Price.Amount {
+			product.DisplayCompareAtPrice = &compareAt.Price
+		}
+	}
+	return nil
+}
+
+// validateCompareAtPrice checks that a was-price, if any, is higher than the
+// price it is shown next to.
+func validateCompareAtPrice(price money.Money, compareAtPrice *money.Money) error {
+	if compareAtPrice == nil {
+		return nil
+	}
+	if err := compareAtPrice.Validate(); err != nil {
+		return fmt.Errorf("invalid compare-at price: %w", err)
+	}
+	cmp, err := compareAtPrice.Cmp(price)
+	if err != nil {
+		return fmt.Errorf("compare-at price must be in the price's currency: %w", err)
+	}
+	if cmp <= 0 {
+		return fmt.Errorf("compare-at price must be higher than the price")
+	}
+	return nil
+}
+
+func validateScheduledPrices(scheduledPrices []*ScheduledPrice) error {
+	if len(scheduledPrices) > maxScheduledPrices {
+		return fmt.Errorf("a product can have at most %d scheduled prices", maxScheduledPrices)
+	}
+	seen := map[time.Time]bool{}
+	for _, scheduled := range scheduledPrices {
+		if scheduled.EffectiveFrom.IsZero() {
+			return fmt.Errorf("scheduled prices need an effective time")
+		}
+		if seen[scheduled.EffectiveFrom] {
+			return fmt.Errorf("more than one price is scheduled for %s", scheduled.EffectiveFrom.Format(time.RFC3339))
+		}
+		seen[scheduled.EffectiveFrom] = true
+		if err := scheduled.Price.Validate(); err != nil {
+			return fmt.Errorf("invalid scheduled price: %w", err)
+		}
+		if err := validatePriceList(scheduled.Price, scheduled.Prices); err != nil {
+			return fmt.Errorf("invalid scheduled price: %w", err)
+		}
+		if err := validateCompareAtPrice(scheduled.Price, scheduled.CompareAtPrice); err != nil {
+			return fmt.Errorf("invalid scheduled price: %w", err)
+		}
+	}
+	return nil
+}
+
+func validatePriceList(price money.Money, prices []money.Money) error {
+	seen := map[string]bool{price.Currency: true}
+	for _, listed := range prices {
+		if err := listed.Validate(); err != nil {
+			return fmt.Errorf("invalid price list entry: %w", err)
+		}
+		if seen[listed.Currency] {
+			return fmt.Errorf("price list has more than one %s price", listed.Currency)
+		}
+		seen[listed.Currency] = true
+	}
+	return nil
+}
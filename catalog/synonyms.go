@@ -0,0 +1,70 @@
+package catalog
+
+import "strings"
+
+// maxQueryExpansions bounds the number of queries a search is expanded into.
+const maxQueryExpansions = 32
+
+// expandSynonyms returns the queries a search for query stands for under the
+// given synonym rules, for repositories that cannot apply them while
+// searching. Terms match whole words, ignoring case. An equivalence such as
+// "tee, t-shirt" adds a query for each other term, while an explicit mapping
+// such as "tee => t-shirt" replaces the query.
+func expandSynonyms(query string, rules []string) []string {
+	queries := []string{strings.ToLower(strings.Join(strings.Fields(query), " "))}
+	for _, rule := range rules {
+		from, to, explicit := strings.Cut(rule, "=>")
+		terms := synonymTerms(from)
+		replacements := terms
+		if explicit {
+			replacements = synonymTerms(to)
+		}
+		expanded := []string{}
+		seen := map[string]bool{}
+		add := func(query string) {
+			if !seen[query] && len(expanded) < maxQueryExpansions {
+				seen[query] = true
+				expanded = append(expanded, query)
+			}
+		}
+		for _, query := range queries {
+			term := matchingTerm(query, terms)
+			if term == "" {
+				add(query)
+				continue
+			}
+			if !explicit {
+				add(query)
+			}
+			for _, replacement := range replacements {
+				add(replaceWord(query, term, replacement))
+			}
+		}
+		queries = expanded
+	}
+	return queries
+}
+
+func synonymTerms(terms string) []string {
+	normalized := []string{}
+	for _, term := range strings.Split(terms, ",") {
+		term = strings.ToLower(strings.Join(strings.Fields(term), " "))
+		if term != "" {
+			normalized = append(normalized, term)
+		}
+	}
+	return normalized
+}
+
+func matchingTerm(query string, terms []string) string {
+	for _, term := range terms {
+		if strings.Contains(" "+query+" ", " "+term+" ") {
+			return term
+		}
+	}
+	return ""
+}
+
+func replaceWord(query string, term string, replacement string) string {
+	return strings.TrimSpace(strings.ReplaceAll(" "+query+" ", " "+term+" ", " "+replacement+" "))
+}
@@ -1,6 +1,47 @@
+-- Schema of the Postgres catalog repository. Products are stored as the
+-- documents Elasticsearch indexes, with the fields listings filter and sort
+-- on copied into columns and a search vector of the product's text.
 CREATE TABLE IF NOT EXISTS products (
-    id CHAR(27) PRIMARY KEY,
-    name VARCHAR(24) NOT NULL,
-    description TEXT,
-    price MONEY NOT NULL
-);
\ No newline at end of file
+    id VARCHAR(64) PRIMARY KEY,
+    version BIGINT NOT NULL,
+    document JSONB NOT NULL,
+    merchant_id VARCHAR(64) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL,
+    category_id VARCHAR(64) NOT NULL DEFAULT '',
+    publish_at TIMESTAMP WITH TIME ZONE,
+    unpublish_at TIMESTAMP WITH TIME ZONE,
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    -- next_price_at is the time of the earliest scheduled price.
+    next_price_at TIMESTAMP WITH TIME ZONE,
+    rating_average DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rating_count BIGINT NOT NULL DEFAULT 0,
+    search TSVECTOR NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS products_search_idx ON products USING GIN (search);
+CREATE INDEX IF NOT EXISTS products_merchant_idx ON products (merchant_id, id);
+CREATE INDEX IF NOT EXISTS products_category_idx ON products (category_id, id);
+CREATE INDEX IF NOT EXISTS products_rating_idx ON products (rating_average DESC, rating_count DESC, id);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id VARCHAR(64) PRIMARY KEY,
+    name TEXT NOT NULL,
+    document JSONB NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS price_changes (
+    id VARCHAR(64) PRIMARY KEY,
+    product_id VARCHAR(64) NOT NULL,
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    document JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS price_changes_product_idx ON price_changes (product_id, effective_from DESC);
+
+-- Synonym rules of each locale, in Solr format, in the order they were given.
+CREATE TABLE IF NOT EXISTS synonyms (
+    locale VARCHAR(8) NOT NULL,
+    position INT NOT NULL,
+    rule TEXT NOT NULL,
+    PRIMARY KEY (locale, position)
+);
@@ -9,45 +9,43 @@ type accountResolver struct {
 	server *Server
 }
 
-// Orders retrieves all orders for a given account
-func (resolver *accountResolver) Orders(ctx context.Context, account *Account) ([]*Order, error) {
+// Orders retrieves a page of the orders of an account, newest first
+func (resolver *accountResolver) Orders(ctx context.Context, account *Account, first *int, after *string) (*OrderConnection, error) {
 	if account == nil || account.ID == "" {
 		return nil, fmt.Errorf("account id is required")
 	}
 
-	// Call order service to get orders for account
-	resp, err := resolver.server.orderClient.ListOrdersForAccount(ctx, account.ID)
+	take := uint64(10)
+	if first != nil {
+		if *first < 1 || *first > 100 {
+			return nil, fmt.Errorf("first must be between 1 and 100")
+		}
+		take = uint64(*first)
+	}
+
+	// Call order service to get a page of the account's orders
+	resp, err := resolver.server.orderClient.ListOrdersForAccount(ctx, account.ID, take, stringArg(after), "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch orders for account %s: %w", account.ID, err)
 	}
 
-	if resp == nil {
-		return []*Order{}, nil
-	}
+	return toOrderConnection(resp.Orders, resp.Cursors, resp.NextCursor, resp.TotalCount, stringArg(after)), nil
+}
 
-	// Convert proto orders to GraphQL orders
-	orders := make([]*Order, 0, len(resp.Orders))
-	for _, order := range resp.Orders {
-		// Convert products
-		orderedProducts := make([]*OrderedProduct, 0, len(order.Products))
-		for _, p := range order.Products {
-			orderedProducts = append(orderedProducts, &OrderedProduct{
-				ID:          p.ProductId,
-				Name:        p.ProductName,
-				Description: p.ProductDescription,
-				Price:       p.Price,
-				Quantity:    int(p.Quantity),
-			})
-		}
+// Addresses retrieves the address book of an account
+func (resolver *accountResolver) Addresses(ctx context.Context, account *Account) ([]*Address, error) {
+	if account == nil || account.ID == "" {
+		return nil, fmt.Errorf("account id is required")
+	}
 
-		createdAt := order.CreatedAt.AsTime()
-		orders = append(orders, &Order{
-			ID:         order.Id,
-			CreatedAt:  createdAt,
-			TotalPrice: order.TotalPrice,
-			Products:   orderedProducts,
-		})
+	resp, err := resolver.server.accountClient.ListAddresses(ctx, account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch addresses for account %s: %w", account.ID, err)
 	}
 
-	return orders, nil
+	addresses := make([]*Address, 0, len(resp.Addresses))
+	for _, address := range resp.Addresses {
+		addresses = append(addresses, toAddress(address))
+	}
+	return addresses, nil
 }
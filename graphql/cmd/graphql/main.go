@@ -21,6 +21,7 @@ type AppConfig struct {
 	AccountURL string `envconfig:"ACCOUNT_SERVICE_URL" default:"localhost:50051"`
 	CatalogURL string `envconfig:"CATALOG_SERVICE_URL" default:"localhost:50052"`
 	OrderURL   string `envconfig:"ORDER_SERVICE_URL" default:"localhost:50053"`
+	ReviewURL  string `envconfig:"REVIEW_SERVICE_URL" default:"localhost:50054"`
 	Port       int    `envconfig:"PORT" default:"8080"`
 	Env        string `envconfig:"ENVIRONMENT" default:"development"`
 	LogLevel   string `envconfig:"LOG_LEVEL" default:"info"`
@@ -35,7 +36,7 @@ func main() {
 	logger := util.NewLogger(cfg.LogLevel)
 
 	// Initialize GraphQL server
-	server, err := graphql.NewGraphQLServer(cfg.AccountURL, cfg.CatalogURL, cfg.OrderURL)
+	server, err := graphql.NewGraphQLServer(cfg.AccountURL, cfg.CatalogURL, cfg.OrderURL, cfg.ReviewURL)
 	if err != nil {
 		logger.Service().Fatal().Err(err).Msg("failed to initialize GraphQL server")
 	}
@@ -48,8 +49,8 @@ func main() {
 	// Create HTTP server
 	mux := http.NewServeMux()
 
-	// GraphQL endpoint
-	mux.Handle("/graphql", handler.NewDefaultServer(server.ToExecutableSchema()))
+	// GraphQL endpoint; the caller's access token is forwarded to the services
+	mux.Handle("/graphql", util.AccessTokenMiddleware(handler.NewDefaultServer(server.ToExecutableSchema())))
 
 	// Playground endpoint
 	mux.Handle("/playground", playground.Handler("GraphQL Playground", "/graphql"))
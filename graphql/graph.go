@@ -7,18 +7,20 @@ import (
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/account"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/order"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/review"
 )
 
 type Server struct {
 	accountClient *account.AccountClient
 	catalogClient *catalog.CatalogClient
 	orderClient   *order.OrderClient
+	reviewClient  *review.ReviewClient
 }
 
 // NewGraphQLServer initializes and returns a new GraphQL server with all microservice clients
-func NewGraphQLServer(accountURL, catalogURL, orderURL string) (*Server, error) {
+func NewGraphQLServer(accountURL, catalogURL, orderURL, reviewURL string) (*Server, error) {
 	// Validate URLs
-	if accountURL == "" || catalogURL == "" || orderURL == "" {
+	if accountURL == "" || catalogURL == "" || orderURL == "" || reviewURL == "" {
 		return nil, fmt.Errorf("all service URLs must be provided")
 	}
 
@@ -43,10 +45,20 @@ func NewGraphQLServer(accountURL, catalogURL, orderURL string) (*Server, error)
 		return nil, fmt.Errorf("failed to connect to order service: %w", err)
 	}
 
+	// Initialize Review Client
+	reviewClient, err := review.NewReviewClient(reviewURL)
+	if err != nil {
+		accountClient.Close()
+		catalogClient.Close()
+		orderClient.Close()
+		return nil, fmt.Errorf("failed to connect to review service: %w", err)
+	}
+
 	return &Server{
 		accountClient: accountClient,
 		catalogClient: catalogClient,
 		orderClient:   orderClient,
+		reviewClient:  reviewClient,
 	}, nil
 }
 
@@ -61,6 +73,9 @@ func (s *Server) Close() error {
 	if s.orderClient != nil {
 		s.orderClient.Close()
 	}
+	if s.reviewClient != nil {
+		s.reviewClient.Close()
+	}
 	return nil
 }
 
@@ -82,6 +97,18 @@ func (s *Server) Account() AccountResolver {
 	}
 }
 
+func (s *Server) Product() ProductResolver {
+	return &productResolver{
+		server: s,
+	}
+}
+
+func (s *Server) Order() OrderResolver {
+	return &orderResolver{
+		server: s,
+	}
+}
+
 func (s *Server) ToExecutableSchema() graphql.ExecutableSchema {
 	return NewExecutableSchema(Config{
 		Resolvers: s,
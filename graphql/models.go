@@ -1,9 +1,713 @@
 package graphql
 
+import (
+	"strings"
+	"time"
+
+	accountpb "github.com/Asif-Faizal/Minimum-Viable-Shop/account/pb"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	catalogpb "github.com/Asif-Faizal/Minimum-Viable-Shop/catalog/pb"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	moneypb "github.com/Asif-Faizal/Minimum-Viable-Shop/money/pb"
+	orderpb "github.com/Asif-Faizal/Minimum-Viable-Shop/order/pb/pb"
+	reviewpb "github.com/Asif-Faizal/Minimum-Viable-Shop/review/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
 type Account struct {
-	ID       string  `json:"id"`
-	Name     string  `json:"name"`
-	UserType string  `json:"user_type"`
-	Email    string  `json:"email"`
-	Orders   []Order `json:"orders"`
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	UserType  string  `json:"user_type"`
+	Email     string  `json:"email"`
+	TaxExempt bool    `json:"tax_exempt"`
+	Orders    []Order `json:"orders"`
+}
+
+func toMoney(price *moneypb.Money) *money.Money {
+	m := money.FromProto(price)
+	return &m
+}
+
+func toExchangeRate(rate *moneypb.ExchangeRate) *ExchangeRate {
+	if rate == nil {
+		return nil
+	}
+	return &ExchangeRate{
+		Base:          rate.Base,
+		Quote:         rate.Quote,
+		Rate:          rate.Rate,
+		EffectiveFrom: rate.EffectiveFrom.AsTime(),
+	}
+}
+
+// toOptionalMoney converts an amount that may be unset.
+func toOptionalMoney(price *moneypb.Money) *money.Money {
+	if price == nil {
+		return nil
+	}
+	return toMoney(price)
+}
+
+func toMoneyList(prices []*moneypb.Money) []*money.Money {
+	list := make([]*money.Money, 0, len(prices))
+	for _, price := range prices {
+		list = append(list, toMoney(price))
+	}
+	return list
+}
+
+func toProduct(product *catalogpb.Product) *Product {
+	images := make([]*ProductImage, 0, len(product.Media))
+	for i, media := range product.Media {
+		images = append(images, &ProductImage{
+			ID:           media.Id,
+			URL:          media.Url,
+			ThumbnailURL: media.ThumbnailUrl,
+			AltText:      media.AltText,
+			ContentType:  media.ContentType,
+			Width:        int(media.Width),
+			Height:       int(media.Height),
+			Position:     i,
+		})
+	}
+	var merchantID *string
+	if product.MerchantId != "" {
+		merchantID = &product.MerchantId
+	}
+	var categoryID *string
+	if product.CategoryId != "" {
+		categoryID = &product.CategoryId
+	}
+	attributes := make([]*ProductAttribute, 0, len(product.Attributes))
+	for _, attribute := range product.Attributes {
+		attributes = append(attributes, &ProductAttribute{
+			Name:  attribute.Name,
+			Value: attribute.Value,
+			Type:  attribute.Type,
+			Unit:  optionalString(attribute.Unit),
+		})
+	}
+	translations := make([]*ProductTranslation, 0, len(product.Translations))
+	for _, locale := range catalog.Locales {
+		if translation, ok := product.Translations[locale]; ok {
+			translations = append(translations, &ProductTranslation{
+				Locale:      locale,
+				Name:        translation.Name,
+				Description: translation.Description,
+			})
+		}
+	}
+	scheduledPrices := make([]*ScheduledPrice, 0, len(product.ScheduledPrices))
+	for _, scheduled := range product.ScheduledPrices {
+		scheduledPrices = append(scheduledPrices, &ScheduledPrice{
+			EffectiveFrom:  scheduled.EffectiveFrom.AsTime(),
+			Price:          toMoney(scheduled.Price),
+			Prices:         toMoneyList(scheduled.Prices),
+			CompareAtPrice: toOptionalMoney(scheduled.CompareAtPrice),
+		})
+	}
+	return &Product{
+		ID:              product.Id,
+		Name:            product.Name,
+		Description:     product.Description,
+		Locale:          catalog.DefaultLocale,
+		Translations:    translations,
+		Price:           toMoney(product.DisplayPrice),
+		BasePrice:       toMoney(product.Price),
+		Prices:          toMoneyList(product.Prices),
+		ExchangeRate:    toExchangeRate(product.ExchangeRate),
+		CompareAtPrice:  toOptionalMoney(product.DisplayCompareAtPrice),
+		ScheduledPrices: scheduledPrices,
+		MerchantID:      merchantID,
+		Status:          product.Status,
+		PublishAt:       toTime(product.PublishAt),
+		UnpublishAt:     toTime(product.UnpublishAt),
+		DeletedAt:       toTime(product.DeletedAt),
+		Images:          images,
+		CategoryID:      categoryID,
+		Attributes:      attributes,
+		TaxCategory:     optionalString(product.TaxCategory),
+		Weight:          optionalInt(int(product.Weight)),
+		Version:         product.Version,
+		Rating: &ProductRating{
+			Average: product.GetRating().GetAverage(),
+			Count:   int(product.GetRating().GetCount()),
+		},
+	}
+}
+
+// toLocalizedProduct converts a product with its name and description in
+// locale, where the product has them, and in the default locale otherwise.
+func toLocalizedProduct(product *catalogpb.Product, locale string) *Product {
+	localized := toProduct(product)
+	translation, ok := product.Translations[locale]
+	if locale == catalog.DefaultLocale || !ok {
+		return localized
+	}
+	if translation.Name != "" {
+		localized.Name = translation.Name
+	}
+	if translation.Description != "" {
+		localized.Description = translation.Description
+	}
+	localized.Locale = locale
+	return localized
+}
+
+// toProductConnection builds a connection from a page of products and the
+// cursor after each of them.
+func toProductConnection(products []*catalogpb.Product, cursors []string, nextCursor string, totalCount uint64, after *string, locale string) *ProductConnection {
+	edges := make([]*ProductEdge, 0, len(products))
+	for i, product := range products {
+		cursor := ""
+		if i < len(cursors) {
+			cursor = cursors[i]
+		}
+		edges = append(edges, &ProductEdge{
+			Cursor: cursor,
+			Node:   toLocalizedProduct(product, locale),
+		})
+	}
+	pageInfo := &PageInfo{
+		HasNextPage:     nextCursor != "",
+		HasPreviousPage: stringArg(after) != "",
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+	return &ProductConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: int(totalCount),
+	}
+}
+
+func toPriceChange(change *catalogpb.PriceChange) *PriceChange {
+	return &PriceChange{
+		EffectiveFrom:  change.EffectiveFrom.AsTime(),
+		Price:          toMoney(change.Price),
+		Prices:         toMoneyList(change.Prices),
+		CompareAtPrice: toOptionalMoney(change.CompareAtPrice),
+	}
+}
+
+func toReview(review *reviewpb.Review) *Review {
+	return &Review{
+		ID:        review.Id,
+		ProductID: review.ProductId,
+		AccountID: review.AccountId,
+		Rating:    int(review.Rating),
+		Title:     review.Title,
+		Body:      review.Body,
+		Status:    review.Status,
+		CreatedAt: review.CreatedAt.AsTime(),
+		UpdatedAt: review.UpdatedAt.AsTime(),
+	}
+}
+
+func toProductAttributes(inputs []*ProductAttributeInput) []*catalog.ProductAttribute {
+	attributes := make([]*catalog.ProductAttribute, 0, len(inputs))
+	for _, input := range inputs {
+		attributes = append(attributes, &catalog.ProductAttribute{
+			Name:  input.Name,
+			Value: input.Value,
+		})
+	}
+	return attributes
+}
+
+func toProductTranslations(inputs []*ProductTranslationInput) map[string]*catalog.ProductTranslation {
+	translations := make(map[string]*catalog.ProductTranslation, len(inputs))
+	for _, input := range inputs {
+		translations[input.Locale] = &catalog.ProductTranslation{
+			Name:        stringArg(input.Name),
+			Description: stringArg(input.Description),
+		}
+	}
+	return translations
+}
+
+func toScheduledPrices(inputs []*ScheduledPriceInput) []*catalog.ScheduledPrice {
+	scheduledPrices := make([]*catalog.ScheduledPrice, 0, len(inputs))
+	for _, input := range inputs {
+		prices := make([]money.Money, 0, len(input.Prices))
+		for _, price := range input.Prices {
+			prices = append(prices, *price)
+		}
+		scheduledPrices = append(scheduledPrices, &catalog.ScheduledPrice{
+			EffectiveFrom:  input.EffectiveFrom,
+			Price:          *input.Price,
+			Prices:         prices,
+			CompareAtPrice: input.CompareAtPrice,
+		})
+	}
+	return scheduledPrices
+}
+
+func toCategory(category *catalogpb.Category) *Category {
+	attributes := make([]*AttributeDefinition, 0, len(category.Attributes))
+	for _, definition := range category.Attributes {
+		values := definition.Values
+		if values == nil {
+			values = []string{}
+		}
+		attributes = append(attributes, &AttributeDefinition{
+			Name:     definition.Name,
+			Type:     definition.Type,
+			Unit:     optionalString(definition.Unit),
+			Values:   values,
+			Required: definition.Required,
+		})
+	}
+	return &Category{
+		ID:         category.Id,
+		Name:       category.Name,
+		Attributes: attributes,
+	}
+}
+
+func toProductFilter(categoryID *string, attributes []*AttributeFilterInput, sort *string) catalog.ProductFilter {
+	filter := catalog.ProductFilter{CategoryID: stringArg(categoryID), Sort: catalog.ProductSort(stringArg(sort))}
+	for _, attribute := range attributes {
+		filter.Attributes = append(filter.Attributes, catalog.AttributeFilter{
+			Name:  attribute.Name,
+			Value: stringArg(attribute.Value),
+			Min:   attribute.Min,
+			Max:   attribute.Max,
+		})
+	}
+	return filter
+}
+
+func toOrder(order *orderpb.Order) *Order {
+	orderedProducts := make([]*OrderedProduct, 0, len(order.Products))
+	for _, p := range order.Products {
+		orderedProducts = append(orderedProducts, toOrderedProduct(p))
+	}
+	exchangeRates := make([]*ExchangeRate, 0, len(order.ExchangeRates))
+	for _, rate := range order.ExchangeRates {
+		exchangeRates = append(exchangeRates, toExchangeRate(rate))
+	}
+	discounts := make([]*OrderDiscount, 0, len(order.Discounts))
+	for _, discount := range order.Discounts {
+		discounts = append(discounts, &OrderDiscount{
+			PromotionID: discount.PromotionId,
+			Code:        discount.Code,
+			Description: discount.Description,
+			ProductID:   discount.ProductId,
+			Amount:      toMoney(discount.Amount),
+		})
+	}
+
+	return &Order{
+		ID:              order.Id,
+		CreatedAt:       order.CreatedAt.AsTime(),
+		AccountID:       order.AccountId,
+		TotalPrice:      toMoney(order.TotalPrice),
+		Products:        orderedProducts,
+		ExchangeRates:   exchangeRates,
+		Status:          order.Status,
+		Discounts:       discounts,
+		DiscountTotal:   toMoney(order.DiscountTotal),
+		CouponCodes:     nonNilStrings(order.CouponCodes),
+		Subtotal:        toMoney(order.Subtotal),
+		TaxTotal:        toMoney(order.TaxTotal),
+		TaxCountry:      optionalString(order.TaxCountry),
+		TaxRegion:       optionalString(order.TaxRegion),
+		TaxExempt:       order.TaxExempt,
+		ShippingAddress: toShippingAddress(order.ShippingAddress),
+		ShippingMethod:  optionalString(order.ShippingMethod),
+		ShippingFee:     toOptionalMoney(order.ShippingFee),
+	}
+}
+
+func toShippingQuote(quote *orderpb.ShippingQuote) *ShippingQuote {
+	return &ShippingQuote{
+		Method:  quote.Method,
+		Name:    quote.Name,
+		Carrier: quote.Carrier,
+		Zone:    quote.Zone,
+		Fee:     toMoney(quote.Fee),
+		Free:    quote.Free,
+	}
+}
+
+func toShippingAddress(address *orderpb.ShippingAddress) *ShippingAddress {
+	if address == nil {
+		return nil
+	}
+	return &ShippingAddress{
+		AddressID:  address.AddressId,
+		Name:       address.Name,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     address.Region,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	}
+}
+
+func toOrderedProduct(product *orderpb.OrderProduct) *OrderedProduct {
+	return &OrderedProduct{
+		ID:          product.ProductId,
+		Name:        product.ProductName,
+		Description: product.ProductDescription,
+		Price:       toMoney(product.Price),
+		Quantity:    int(product.Quantity),
+		TaxCategory: optionalString(product.TaxCategory),
+		Tax:         toOrderTax(product.Tax),
+		MerchantID:  optionalString(product.MerchantId),
+	}
+}
+
+func toMerchantOrder(merchantOrder *orderpb.MerchantOrder) *MerchantOrder {
+	products := make([]*OrderedProduct, 0, len(merchantOrder.Products))
+	for _, product := range merchantOrder.Products {
+		products = append(products, toOrderedProduct(product))
+	}
+	return &MerchantOrder{
+		OrderID:    merchantOrder.OrderId,
+		MerchantID: merchantOrder.MerchantId,
+		AccountID:  merchantOrder.AccountId,
+		Status:     merchantOrder.Status,
+		Products:   products,
+		Subtotal:   toMoney(merchantOrder.Subtotal),
+		Discount:   toMoney(merchantOrder.Discount),
+		Tax:        toMoney(merchantOrder.Tax),
+		Shipping:   toMoney(merchantOrder.Shipping),
+		Total:      toMoney(merchantOrder.Total),
+		Refunded:   toMoney(merchantOrder.Refunded),
+		Payout:     toMoney(merchantOrder.Payout),
+		CreatedAt:  merchantOrder.CreatedAt.AsTime(),
+		UpdatedAt:  merchantOrder.UpdatedAt.AsTime(),
+	}
+}
+
+func toMerchantOrderConnection(merchantOrders []*orderpb.MerchantOrder, cursors []string, nextCursor string, totalCount uint64, after string) *MerchantOrderConnection {
+	edges := make([]*MerchantOrderEdge, 0, len(merchantOrders))
+	for i, merchantOrder := range merchantOrders {
+		cursor := ""
+		if i < len(cursors) {
+			cursor = cursors[i]
+		}
+		edges = append(edges, &MerchantOrderEdge{
+			Cursor: cursor,
+			Node:   toMerchantOrder(merchantOrder),
+		})
+	}
+	pageInfo := &PageInfo{
+		HasNextPage:     nextCursor != "",
+		HasPreviousPage: after != "",
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+	return &MerchantOrderConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: int(totalCount),
+	}
+}
+
+func toShipment(shipment *orderpb.Shipment) *Shipment {
+	items := make([]*ShipmentItem, 0, len(shipment.Items))
+	for _, item := range shipment.Items {
+		items = append(items, &ShipmentItem{
+			ProductID: item.ProductId,
+			Quantity:  int(item.Quantity),
+		})
+	}
+	return &Shipment{
+		ID:             shipment.Id,
+		OrderID:        shipment.OrderId,
+		MerchantID:     shipment.MerchantId,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		Status:         shipment.Status,
+		Items:          items,
+		ShippedAt:      shipment.ShippedAt.AsTime(),
+		DeliveredAt:    toTime(shipment.DeliveredAt),
+	}
+}
+
+func toReturn(orderReturn *orderpb.Return) *Return {
+	items := make([]*ReturnItem, 0, len(orderReturn.Items))
+	for _, item := range orderReturn.Items {
+		items = append(items, &ReturnItem{
+			ProductID:        item.ProductId,
+			Quantity:         int(item.Quantity),
+			Reason:           item.Reason,
+			Comment:          optionalString(item.Comment),
+			AcceptedQuantity: int(item.AcceptedQuantity),
+			Restock:          item.Restock,
+			Refund:           toMoney(item.Refund),
+		})
+	}
+	return &Return{
+		ID:        orderReturn.Id,
+		OrderID:   orderReturn.OrderId,
+		AccountID: orderReturn.AccountId,
+		Status:    orderReturn.Status,
+		Items:     items,
+		Refund:    toMoney(orderReturn.Refund),
+		Note:      optionalString(orderReturn.Note),
+		CreatedAt: orderReturn.CreatedAt.AsTime(),
+		UpdatedAt: orderReturn.UpdatedAt.AsTime(),
+	}
+}
+
+func toInvoice(invoice *orderpb.Invoice) *Invoice {
+	lines := make([]*InvoiceLine, 0, len(invoice.Lines))
+	for _, line := range invoice.Lines {
+		lines = append(lines, &InvoiceLine{
+			ProductID:    line.ProductId,
+			Description:  line.Description,
+			Quantity:     int(line.Quantity),
+			UnitPrice:    toMoney(line.UnitPrice),
+			Amount:       toMoney(line.Amount),
+			Discount:     toMoney(line.Discount),
+			Tax:          toMoney(line.Tax),
+			TaxRate:      optionalString(line.TaxRate),
+			TaxInclusive: line.TaxInclusive,
+			Total:        toMoney(line.Total),
+		})
+	}
+	return &Invoice{
+		ID:         invoice.Id,
+		Number:     invoice.Number,
+		Kind:       invoice.Kind,
+		MerchantID: invoice.MerchantId,
+		OrderID:    invoice.OrderId,
+		AccountID:  invoice.AccountId,
+		ReturnID:   optionalString(invoice.ReturnId),
+		InvoiceID:  optionalString(invoice.InvoiceId),
+		BillTo:     nonNilStrings(invoice.BillTo),
+		Lines:      lines,
+		Subtotal:   toMoney(invoice.Subtotal),
+		Discount:   toMoney(invoice.Discount),
+		Tax:        toMoney(invoice.Tax),
+		Shipping:   toMoney(invoice.Shipping),
+		Total:      toMoney(invoice.Total),
+		IssuedAt:   invoice.IssuedAt.AsTime(),
+	}
+}
+
+func toAddress(address *accountpb.Address) *Address {
+	return &Address{
+		ID:              address.Id,
+		AccountID:       address.AccountId,
+		Name:            address.Name,
+		Line1:           address.Line1,
+		Line2:           address.Line2,
+		City:            address.City,
+		Region:          address.Region,
+		PostalCode:      address.PostalCode,
+		Country:         address.Country,
+		Phone:           address.Phone,
+		DefaultShipping: address.DefaultShipping,
+		DefaultBilling:  address.DefaultBilling,
+		CreatedAt:       address.CreatedAt.AsTime(),
+	}
+}
+
+func toOrderTax(tax *orderpb.OrderTax) *OrderTax {
+	if tax == nil {
+		return nil
+	}
+	return &OrderTax{
+		Country:   tax.Country,
+		Region:    tax.Region,
+		Category:  tax.Category,
+		Rate:      tax.Rate,
+		Inclusive: tax.Inclusive,
+		Amount:    toMoney(tax.Amount),
+	}
+}
+
+func toPromotion(promotion *orderpb.Promotion) *Promotion {
+	return &Promotion{
+		ID:                   promotion.Id,
+		Code:                 promotion.Code,
+		Description:          promotion.Description,
+		Type:                 promotion.Type,
+		PercentOff:           int(promotion.PercentOff),
+		AmountOff:            toOptionalMoney(promotion.AmountOff),
+		BuyQuantity:          int(promotion.BuyQuantity),
+		GetQuantity:          int(promotion.GetQuantity),
+		ProductIds:           nonNilStrings(promotion.ProductIds),
+		CategoryIds:          nonNilStrings(promotion.CategoryIds),
+		AccountIds:           nonNilStrings(promotion.AccountIds),
+		Stackable:            promotion.Stackable,
+		StartsAt:             toTime(promotion.StartsAt),
+		EndsAt:               toTime(promotion.EndsAt),
+		UsageLimit:           int(promotion.UsageLimit),
+		UsageLimitPerAccount: int(promotion.UsageLimitPerAccount),
+		CreatedAt:            promotion.CreatedAt.AsTime(),
+	}
+}
+
+func toProtoPromotion(input PromotionInput) *orderpb.Promotion {
+	promotion := &orderpb.Promotion{
+		Id:                   stringArg(input.ID),
+		Code:                 stringArg(input.Code),
+		Description:          stringArg(input.Description),
+		Type:                 input.Type,
+		PercentOff:           uint32(intArg(input.PercentOff)),
+		BuyQuantity:          uint32(intArg(input.BuyQuantity)),
+		GetQuantity:          uint32(intArg(input.GetQuantity)),
+		ProductIds:           input.ProductIds,
+		CategoryIds:          input.CategoryIds,
+		AccountIds:           input.AccountIds,
+		Stackable:            boolArg(input.Stackable),
+		UsageLimit:           uint64(intArg(input.UsageLimit)),
+		UsageLimitPerAccount: uint64(intArg(input.UsageLimitPerAccount)),
+	}
+	if input.AmountOff != nil {
+		promotion.AmountOff = input.AmountOff.ToProto()
+	}
+	if input.StartsAt != nil {
+		promotion.StartsAt = timestamppb.New(*input.StartsAt)
+	}
+	if input.EndsAt != nil {
+		promotion.EndsAt = timestamppb.New(*input.EndsAt)
+	}
+	return promotion
+}
+
+func toProtoOrderFilter(input *OrderFilterInput) *orderpb.OrderFilter {
+	if input == nil {
+		return nil
+	}
+	filter := &orderpb.OrderFilter{
+		Statuses:  input.Statuses,
+		AccountId: stringArg(input.AccountID),
+		ProductId: stringArg(input.ProductID),
+		Currency:  stringArg(input.Currency),
+		Sort:      stringArg(input.Sort),
+	}
+	if input.CreatedFrom != nil {
+		filter.CreatedFrom = timestamppb.New(*input.CreatedFrom)
+	}
+	if input.CreatedTo != nil {
+		filter.CreatedTo = timestamppb.New(*input.CreatedTo)
+	}
+	if input.MinTotal != nil {
+		filter.MinTotal = input.MinTotal.ToProto()
+	}
+	if input.MaxTotal != nil {
+		filter.MaxTotal = input.MaxTotal.ToProto()
+	}
+	return filter
+}
+
+func toOrderConnection(orders []*orderpb.Order, cursors []string, nextCursor string, totalCount uint64, after string) *OrderConnection {
+	edges := make([]*OrderEdge, 0, len(orders))
+	for i, order := range orders {
+		cursor := ""
+		if i < len(cursors) {
+			cursor = cursors[i]
+		}
+		edges = append(edges, &OrderEdge{
+			Cursor: cursor,
+			Node:   toOrder(order),
+		})
+	}
+	pageInfo := &PageInfo{
+		HasNextPage:     nextCursor != "",
+		HasPreviousPage: after != "",
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+	return &OrderConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: int(totalCount),
+	}
+}
+
+func currencyArg(currency *string) string {
+	if currency == nil {
+		return ""
+	}
+	return *currency
+}
+
+// recommendationTake limits recommendation lists to 10 products by default
+// and 50 at most.
+func recommendationTake(take *int) uint64 {
+	if take == nil || *take <= 0 {
+		return 10
+	}
+	if *take > 50 {
+		return 50
+	}
+	return uint64(*take)
+}
+
+// localeArg returns the supported locale matching a requested one such as
+// "de" or "de-AT", or the default locale.
+func localeArg(locale *string) string {
+	if locale == nil {
+		return catalog.DefaultLocale
+	}
+	language, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(*locale)), "-")
+	language, _, _ = strings.Cut(language, "_")
+	if catalog.ValidLocale(language) {
+		return language
+	}
+	return catalog.DefaultLocale
+}
+
+func stringArg(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func optionalInt(value int) *int {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
+
+func boolArg(value *bool) bool {
+	return value != nil && *value
+}
+
+// intArg returns 0 for unset and negative values.
+func intArg(value *int) int {
+	if value == nil || *value < 0 {
+		return 0
+	}
+	return *value
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func toTime(timestamp *timestamppb.Timestamp) *time.Time {
+	if timestamp == nil {
+		return nil
+	}
+	t := timestamp.AsTime()
+	return &t
 }
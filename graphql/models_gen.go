@@ -4,6 +4,8 @@ package graphql
 
 import (
 	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
 )
 
 type AccountInput struct {
@@ -14,6 +16,190 @@ type AccountInput struct {
 	Password string  `json:"password"`
 }
 
+type Address struct {
+	ID        string `json:"id"`
+	AccountID string `json:"accountId"`
+	// Who the address is for.
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postalCode"`
+	// Two letter country code.
+	Country         string    `json:"country"`
+	Phone           string    `json:"phone"`
+	DefaultShipping bool      `json:"defaultShipping"`
+	DefaultBilling  bool      `json:"defaultBilling"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+type AddressInput struct {
+	// Id of the address being updated.
+	ID *string `json:"id,omitempty"`
+	// Account whose address book the address is added to; ignored for updates.
+	AccountID  *string `json:"accountId,omitempty"`
+	Name       string  `json:"name"`
+	Line1      string  `json:"line1"`
+	Line2      *string `json:"line2,omitempty"`
+	City       string  `json:"city"`
+	Region     *string `json:"region,omitempty"`
+	PostalCode *string `json:"postalCode,omitempty"`
+	// Two letter country code.
+	Country string  `json:"country"`
+	Phone   *string `json:"phone,omitempty"`
+	// Makes the address the account's default shipping address, in place of any other.
+	DefaultShipping *bool `json:"defaultShipping,omitempty"`
+	// Makes the address the account's default billing address, in place of any other.
+	DefaultBilling *bool `json:"defaultBilling,omitempty"`
+}
+
+type AttributeDefinition struct {
+	Name string `json:"name"`
+	// One of string, number, enum or boolean.
+	Type string  `json:"type"`
+	Unit *string `json:"unit,omitempty"`
+	// Allowed values of enum attributes.
+	Values   []string `json:"values"`
+	Required bool     `json:"required"`
+}
+
+type AttributeDefinitionInput struct {
+	Name string `json:"name"`
+	// string, number, enum or boolean.
+	Type string `json:"type"`
+	// Only for number attributes.
+	Unit *string `json:"unit,omitempty"`
+	// Required for enum attributes.
+	Values   []string `json:"values,omitempty"`
+	Required *bool    `json:"required,omitempty"`
+}
+
+// Matches products whose attribute equals value or, for number attributes,
+// lies within min and max.
+type AttributeFilterInput struct {
+	Name  string   `json:"name"`
+	Value *string  `json:"value,omitempty"`
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+}
+
+type Category struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	Attributes []*AttributeDefinition `json:"attributes"`
+}
+
+type CategoryInput struct {
+	ID         *string                     `json:"id,omitempty"`
+	Name       string                      `json:"name"`
+	Attributes []*AttributeDefinitionInput `json:"attributes,omitempty"`
+}
+
+// Pages through a connection: first items after the cursor after, e.g. pageInfo.endCursor.
+type CursorPaginationInput struct {
+	First *int    `json:"first,omitempty"`
+	After *string `json:"after,omitempty"`
+}
+
+// Converts amounts between currencies: 1 base = rate quote.
+type ExchangeRate struct {
+	Base          string    `json:"base"`
+	Quote         string    `json:"quote"`
+	Rate          string    `json:"rate"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+}
+
+// Bills a merchant's products of a paid order, or, for kind credit_note, refunds the units of them accepted
+// by a return. Numbers run in sequence per merchant and kind, e.g. INV-000042 and CN-000007.
+type Invoice struct {
+	ID     string `json:"id"`
+	Number string `json:"number"`
+	// invoice or credit_note.
+	Kind       string `json:"kind"`
+	MerchantID string `json:"merchantId"`
+	OrderID    string `json:"orderId"`
+	AccountID  string `json:"accountId"`
+	// The return a credit note refunds.
+	ReturnID *string `json:"returnId,omitempty"`
+	// The invoice a credit note corrects.
+	InvoiceID *string `json:"invoiceId,omitempty"`
+	// The lines of the order's shipping address.
+	BillTo   []string       `json:"billTo"`
+	Lines    []*InvoiceLine `json:"lines"`
+	Subtotal *money.Money   `json:"subtotal"`
+	Discount *money.Money   `json:"discount"`
+	// Taxes of every line, whether included in prices or not.
+	Tax      *money.Money `json:"tax"`
+	Shipping *money.Money `json:"shipping"`
+	// subtotal less discount, plus taxes not included in prices and shipping.
+	Total    *money.Money `json:"total"`
+	IssuedAt time.Time    `json:"issuedAt"`
+}
+
+type InvoiceLine struct {
+	ProductID   string       `json:"productId"`
+	Description string       `json:"description"`
+	Quantity    int          `json:"quantity"`
+	UnitPrice   *money.Money `json:"unitPrice"`
+	Amount      *money.Money `json:"amount"`
+	Discount    *money.Money `json:"discount"`
+	Tax         *money.Money `json:"tax"`
+	// A decimal fraction, e.g. 0.19.
+	TaxRate      *string      `json:"taxRate,omitempty"`
+	TaxInclusive bool         `json:"taxInclusive"`
+	Total        *money.Money `json:"total"`
+}
+
+// The part of an order sold by one merchant, which the merchant ships and is paid out for. status is the
+// order's until every unit of the merchant's products has shipped, then shipped, and delivered once those
+// shipments have been delivered. The amounts are split out of the order's as its invoices are: total is
+// what the merchant's products cost after discounts, with the taxes not included in their prices and a
+// share of the shipping fee.
+type MerchantOrder struct {
+	OrderID    string            `json:"orderId"`
+	MerchantID string            `json:"merchantId"`
+	AccountID  string            `json:"accountId"`
+	Status     string            `json:"status"`
+	Products   []*OrderedProduct `json:"products"`
+	Subtotal   *money.Money      `json:"subtotal"`
+	Discount   *money.Money      `json:"discount"`
+	Tax        *money.Money      `json:"tax"`
+	Shipping   *money.Money      `json:"shipping"`
+	Total      *money.Money      `json:"total"`
+	// Sum of the merchant's credit notes for returned products.
+	Refunded *money.Money `json:"refunded"`
+	// What the merchant is owed: total less refunded, or nothing while the order is pending or if it was cancelled.
+	Payout    *money.Money `json:"payout"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+// A page of merchant orders, following the Relay cursor connection specification.
+type MerchantOrderConnection struct {
+	Edges    []*MerchantOrderEdge `json:"edges"`
+	PageInfo *PageInfo            `json:"pageInfo"`
+	// Number of merchant orders in the whole listing.
+	TotalCount int `json:"totalCount"`
+}
+
+type MerchantOrderEdge struct {
+	// Pass as after to continue after this merchant order.
+	Cursor string         `json:"cursor"`
+	Node   *MerchantOrder `json:"node"`
+}
+
+// What a merchant is owed in one currency for its orders that have been paid and not cancelled.
+type MerchantPayout struct {
+	MerchantID string `json:"merchantId"`
+	Currency   string `json:"currency"`
+	// Number of merchant orders summed.
+	Orders   int          `json:"orders"`
+	Total    *money.Money `json:"total"`
+	Refunded *money.Money `json:"refunded"`
+	Payout   *money.Money `json:"payout"`
+}
+
 type Mutation struct {
 }
 
@@ -21,14 +207,119 @@ type Order struct {
 	ID         string            `json:"id"`
 	CreatedAt  time.Time         `json:"createdAt"`
 	AccountID  string            `json:"accountId"`
-	TotalPrice float64           `json:"totalPrice"`
+	TotalPrice *money.Money      `json:"totalPrice"`
 	Products   []*OrderedProduct `json:"products"`
+	// Rates used to price the order when it was placed.
+	ExchangeRates []*ExchangeRate `json:"exchangeRates"`
+	// One of pending, paid, shipped, delivered or cancelled. Only pending orders can change their products.
+	Status string `json:"status"`
+	// Changes made to the order's products after it was placed, oldest first, for the order's account or an admin.
+	ItemChanges []*OrderItemChange `json:"itemChanges"`
+	// Discounts taken off the products, discountTotal in all, to give totalPrice.
+	Discounts     []*OrderDiscount `json:"discounts"`
+	DiscountTotal *money.Money     `json:"discountTotal"`
+	// Coupons applied to the order. A coupon only gives a discount while the order is eligible for it.
+	CouponCodes []string `json:"couponCodes"`
+	// The products' prices before discounts and taxes.
+	Subtotal *money.Money `json:"subtotal"`
+	// Taxes of all products, inclusive or not.
+	TaxTotal *money.Money `json:"taxTotal"`
+	// Where the order is taxed; orders without a country are not taxed.
+	TaxCountry *string `json:"taxCountry,omitempty"`
+	TaxRegion  *string `json:"taxRegion,omitempty"`
+	// Whether the account was tax exempt when the order was placed.
+	TaxExempt bool `json:"taxExempt"`
+	// Where the order ships to, copied from the account's address book when it was placed.
+	ShippingAddress *ShippingAddress `json:"shippingAddress,omitempty"`
+	// Shipping method the order ships with; its shippingFee is part of totalPrice.
+	ShippingMethod *string      `json:"shippingMethod,omitempty"`
+	ShippingFee    *money.Money `json:"shippingFee,omitempty"`
+	// Parcels sent for the order, oldest first; merchants only see their own.
+	Shipments []*Shipment `json:"shipments"`
+	// Returns of the order, newest first.
+	Returns []*Return `json:"returns"`
+	// Invoices and credit notes of the order, oldest first. Merchants only see their own. Download one as a
+	// PDF or HTML from the rest gateway at /invoices/{id}.
+	Invoices []*Invoice `json:"invoices"`
+	// The order split into the parts sold by each merchant. Merchants only see their own.
+	MerchantOrders []*MerchantOrder `json:"merchantOrders"`
+}
+
+// A page of orders, following the Relay cursor connection specification.
+type OrderConnection struct {
+	Edges    []*OrderEdge `json:"edges"`
+	PageInfo *PageInfo    `json:"pageInfo"`
+	// Number of orders in the whole search.
+	TotalCount int `json:"totalCount"`
+}
+
+type OrderDiscount struct {
+	PromotionID string `json:"promotionId"`
+	// Empty for promotions that apply without a coupon.
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	// The product discounted, or empty for discounts on several products together.
+	ProductID string       `json:"productId"`
+	Amount    *money.Money `json:"amount"`
+}
+
+type OrderEdge struct {
+	// Pass as after to continue after this order.
+	Cursor string `json:"cursor"`
+	Node   *Order `json:"node"`
+}
+
+// Narrows an order search; unset fields match every order. statuses matches orders in any of
+// them, e.g. ["paid", "shipped"]. Orders placed from createdFrom, inclusive, to createdTo,
+// exclusive, match. minTotal and maxTotal are inclusive and only match orders in their currency.
+// sort is oldest, total_desc or total_asc, newest first by default; totals are compared in minor
+// units, so sorting by them requires currency.
+type OrderFilterInput struct {
+	Statuses  []string `json:"statuses,omitempty"`
+	AccountID *string  `json:"accountId,omitempty"`
+	// Matches orders with a line of the product.
+	ProductID   *string      `json:"productId,omitempty"`
+	CreatedFrom *time.Time   `json:"createdFrom,omitempty"`
+	CreatedTo   *time.Time   `json:"createdTo,omitempty"`
+	Currency    *string      `json:"currency,omitempty"`
+	MinTotal    *money.Money `json:"minTotal,omitempty"`
+	MaxTotal    *money.Money `json:"maxTotal,omitempty"`
+	Sort        *string      `json:"sort,omitempty"`
 }
 
 type OrderInput struct {
 	ID        *string              `json:"id,omitempty"`
 	AccountID string               `json:"accountId"`
 	Products  []*OrderProductInput `json:"products"`
+	// Currency to price the order in; defaults to the products' own currency.
+	Currency *string `json:"currency,omitempty"`
+	// Coupons to apply; each must give the order a discount.
+	CouponCodes []string `json:"couponCodes,omitempty"`
+	// Two letter country code to tax the order in instead of its shipping address. Requires an admin
+	// access token.
+	TaxCountry *string `json:"taxCountry,omitempty"`
+	// State or province within taxCountry.
+	TaxRegion *string `json:"taxRegion,omitempty"`
+	// Exempts the order from tax. Requires an admin access token.
+	TaxExempt *bool `json:"taxExempt,omitempty"`
+	// Address of the account to ship to; defaults to its default shipping address. The order is taxed
+	// at its shipping address; orders without one are not taxed.
+	ShippingAddressID *string `json:"shippingAddressId,omitempty"`
+	// Method of a shippingQuotes quote to ship with; needs a shipping address.
+	ShippingMethod *string `json:"shippingMethod,omitempty"`
+}
+
+type OrderItemChange struct {
+	ProductID string `json:"productId"`
+	// One of replace, add or remove.
+	Operation string `json:"operation"`
+	// 0 for added products.
+	OldQuantity int `json:"oldQuantity"`
+	// 0 for removed products.
+	NewQuantity int `json:"newQuantity"`
+	// Unit price after the change, or before it for removed products.
+	Price     *money.Money `json:"price"`
+	ChangedAt time.Time    `json:"changedAt"`
 }
 
 type OrderProductInput struct {
@@ -36,12 +327,37 @@ type OrderProductInput struct {
 	Quantity int    `json:"quantity"`
 }
 
+type OrderTax struct {
+	Country string `json:"country"`
+	// Empty for taxes of the whole country.
+	Region   string `json:"region"`
+	Category string `json:"category"`
+	// Fraction of the taxable amount, such as 0.19.
+	Rate string `json:"rate"`
+	// Inclusive taxes are part of the price; others are added to totalPrice.
+	Inclusive bool         `json:"inclusive"`
+	Amount    *money.Money `json:"amount"`
+}
+
 type OrderedProduct struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Quantity    int     `json:"quantity"`
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Price       *money.Money `json:"price"`
+	Quantity    int          `json:"quantity"`
+	// Empty for the standard category.
+	TaxCategory *string `json:"taxCategory,omitempty"`
+	// Tax on the product after its discounts; null if it is not taxed.
+	Tax *OrderTax `json:"tax,omitempty"`
+	// Account of the merchant that sold the product.
+	MerchantID *string `json:"merchantId,omitempty"`
+}
+
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
 }
 
 type PaginationInput struct {
@@ -49,19 +365,360 @@ type PaginationInput struct {
 	Take *int `json:"take,omitempty"`
 }
 
+// A product's prices from effectiveFrom until the next change.
+type PriceChange struct {
+	EffectiveFrom  time.Time      `json:"effectiveFrom"`
+	Price          *money.Money   `json:"price"`
+	Prices         []*money.Money `json:"prices"`
+	CompareAtPrice *money.Money   `json:"compareAtPrice,omitempty"`
+}
+
 type Product struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	ID string `json:"id"`
+	// In the requested locale if the product is translated into it, otherwise in the default locale.
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Locale of name and description.
+	Locale string `json:"locale"`
+	// Name and description in locales other than the default.
+	Translations []*ProductTranslation `json:"translations"`
+	// Price in the requested currency, or the base price if none was requested.
+	Price     *money.Money `json:"price"`
+	BasePrice *money.Money `json:"basePrice"`
+	// Explicit prices in other currencies.
+	Prices []*money.Money `json:"prices"`
+	// Set when price was converted from basePrice with an exchange rate.
+	ExchangeRate *ExchangeRate `json:"exchangeRate,omitempty"`
+	// Was-price in the currency of price, set while the product is on sale. Not set when
+	// price comes from prices rather than basePrice.
+	CompareAtPrice *money.Money `json:"compareAtPrice,omitempty"`
+	// Upcoming price changes, soonest first.
+	ScheduledPrices []*ScheduledPrice `json:"scheduledPrices"`
+	// Prices the product has had, latest first.
+	PriceHistory []*PriceChange `json:"priceHistory"`
+	// Account of the merchant that owns the product.
+	MerchantID *string `json:"merchantId,omitempty"`
+	// One of draft, published or archived. Only published products are listed publicly.
+	Status      string     `json:"status"`
+	PublishAt   *time.Time `json:"publishAt,omitempty"`
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+	// Set once the product has been deleted.
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	// Images in display order. Upload and delete them through the REST gateway.
+	Images     []*ProductImage `json:"images"`
+	CategoryID *string         `json:"categoryId,omitempty"`
+	// Structured specs such as material or weight.
+	Attributes []*ProductAttribute `json:"attributes"`
+	// Selects the tax rates that apply, e.g. reduced or exempt; the standard rate when not set.
+	TaxCategory *string `json:"taxCategory,omitempty"`
+	// Shipping weight in grams.
+	Weight *int `json:"weight,omitempty"`
+	// Changes on every update; pass it back to update the product.
+	Version string `json:"version"`
+	// Average of the approved reviews.
+	Rating *ProductRating `json:"rating"`
+	// Approved reviews, newest first.
+	Reviews []*Review `json:"reviews"`
+}
+
+type ProductAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	// One of string, number, enum or boolean.
+	Type string `json:"type"`
+	// Unit of number attributes, e.g. kg.
+	Unit *string `json:"unit,omitempty"`
+}
+
+type ProductAttributeInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// A page of products, following the Relay cursor connection specification.
+type ProductConnection struct {
+	Edges    []*ProductEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+	// Number of products in the whole listing.
+	TotalCount int `json:"totalCount"`
+}
+
+type ProductEdge struct {
+	// Pass as after to continue after this product.
+	Cursor string   `json:"cursor"`
+	Node   *Product `json:"node"`
+}
+
+type ProductImage struct {
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnailUrl"`
+	AltText      string `json:"altText"`
+	ContentType  string `json:"contentType"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	// Zero-based display position.
+	Position int `json:"position"`
 }
 
 type ProductInput struct {
-	ID          *string `json:"id,omitempty"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	ID          *string        `json:"id,omitempty"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Price       *money.Money   `json:"price"`
+	Prices      []*money.Money `json:"prices,omitempty"`
+	// Was-price shown during a sale; in the currency of price and higher than it.
+	CompareAtPrice *money.Money `json:"compareAtPrice,omitempty"`
+	// Prices applied automatically at later times, e.g. the start and end of a sale.
+	ScheduledPrices []*ScheduledPriceInput `json:"scheduledPrices,omitempty"`
+	// draft, published or archived; defaults to draft when publishAt is in the future, otherwise published.
+	Status *string `json:"status,omitempty"`
+	// When a draft is published automatically.
+	PublishAt *time.Time `json:"publishAt,omitempty"`
+	// When a published product is archived automatically.
+	UnpublishAt *time.Time `json:"unpublishAt,omitempty"`
+	// Attributes must match the category's attribute definitions; without a category they are free-form.
+	CategoryID *string                  `json:"categoryId,omitempty"`
+	Attributes []*ProductAttributeInput `json:"attributes,omitempty"`
+	// Tax category such as reduced or exempt; the standard rate when not set.
+	TaxCategory *string `json:"taxCategory,omitempty"`
+	// Shipping weight in grams.
+	Weight *int `json:"weight,omitempty"`
+	// Version of the product being updated; required when updating.
+	Version *string `json:"version,omitempty"`
+	// Name and description in locales other than en, the default.
+	Translations []*ProductTranslationInput `json:"translations,omitempty"`
+}
+
+// Fields to change with patchProduct; fields that are not set are left as they are.
+type ProductPatchInput struct {
+	Name        *string        `json:"name,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	Price       *money.Money   `json:"price,omitempty"`
+	Prices      []*money.Money `json:"prices,omitempty"`
+	// An amount of 0 removes the compare-at price.
+	CompareAtPrice *money.Money `json:"compareAtPrice,omitempty"`
+	// Replaces all scheduled prices; an empty list cancels them.
+	ScheduledPrices []*ScheduledPriceInput   `json:"scheduledPrices,omitempty"`
+	Status          *string                  `json:"status,omitempty"`
+	PublishAt       *time.Time               `json:"publishAt,omitempty"`
+	UnpublishAt     *time.Time               `json:"unpublishAt,omitempty"`
+	CategoryID      *string                  `json:"categoryId,omitempty"`
+	Attributes      []*ProductAttributeInput `json:"attributes,omitempty"`
+	// An empty string restores the standard rate.
+	TaxCategory  *string                    `json:"taxCategory,omitempty"`
+	Weight       *int                       `json:"weight,omitempty"`
+	Translations []*ProductTranslationInput `json:"translations,omitempty"`
+}
+
+type ProductRating struct {
+	// From 1 to 5, or 0 without reviews.
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+type ProductTranslation struct {
+	Locale      string `json:"locale"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ProductTranslationInput struct {
+	// One of de, fr or es.
+	Locale string `json:"locale"`
+	// Empty fields fall back to the default locale.
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// A discount rule. Promotions with a code are coupons customers apply to their orders; promotions
+// without one apply to every eligible order.
+type Promotion struct {
+	ID          string `json:"id"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+	// One of percentage, fixed or buy_x_get_y.
+	Type        string       `json:"type"`
+	PercentOff  int          `json:"percentOff"`
+	AmountOff   *money.Money `json:"amountOff,omitempty"`
+	BuyQuantity int          `json:"buyQuantity"`
+	GetQuantity int          `json:"getQuantity"`
+	ProductIds  []string     `json:"productIds"`
+	CategoryIds []string     `json:"categoryIds"`
+	AccountIds  []string     `json:"accountIds"`
+	Stackable   bool         `json:"stackable"`
+	StartsAt    *time.Time   `json:"startsAt,omitempty"`
+	EndsAt      *time.Time   `json:"endsAt,omitempty"`
+	// 0 is no limit.
+	UsageLimit int `json:"usageLimit"`
+	// 0 is no limit.
+	UsageLimitPerAccount int       `json:"usageLimitPerAccount"`
+	CreatedAt            time.Time `json:"createdAt"`
+}
+
+type PromotionInput struct {
+	// Id of the promotion being updated.
+	ID *string `json:"id,omitempty"`
+	// Customers enter the code to apply the promotion; without one it applies to every eligible order.
+	Code        *string `json:"code,omitempty"`
+	Description *string `json:"description,omitempty"`
+	// percentage takes percentOff percent off, fixed takes amountOff off the eligible products together, and buy_x_get_y makes getQuantity units free for every buyQuantity units bought.
+	Type        string       `json:"type"`
+	PercentOff  *int         `json:"percentOff,omitempty"`
+	AmountOff   *money.Money `json:"amountOff,omitempty"`
+	BuyQuantity *int         `json:"buyQuantity,omitempty"`
+	GetQuantity *int         `json:"getQuantity,omitempty"`
+	// productIds and categoryIds limit the products discounted; with neither every product is eligible.
+	ProductIds  []string `json:"productIds,omitempty"`
+	CategoryIds []string `json:"categoryIds,omitempty"`
+	// Limits the accounts that can use the promotion.
+	AccountIds []string `json:"accountIds,omitempty"`
+	// Stackable promotions combine with each other; others are only used alone, when no combination gives a bigger discount.
+	Stackable *bool      `json:"stackable,omitempty"`
+	StartsAt  *time.Time `json:"startsAt,omitempty"`
+	EndsAt    *time.Time `json:"endsAt,omitempty"`
+	// Caps the orders that use the promotion; cancelled orders do not count.
+	UsageLimit           *int `json:"usageLimit,omitempty"`
+	UsageLimitPerAccount *int `json:"usageLimitPerAccount,omitempty"`
 }
 
 type Query struct {
 }
+
+// Units of a delivered order sent back. status is requested, approved, rejected, received or inspected.
+type Return struct {
+	ID        string        `json:"id"`
+	OrderID   string        `json:"orderId"`
+	AccountID string        `json:"accountId"`
+	Status    string        `json:"status"`
+	Items     []*ReturnItem `json:"items"`
+	// Set when the return is inspected; the sum of the refunds of its items.
+	Refund *money.Money `json:"refund"`
+	// The admin's note, e.g. why the return was rejected.
+	Note      *string   `json:"note,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ReturnInspectionInput struct {
+	ProductID string `json:"productId"`
+	// Units that passed inspection and are refunded; at most the units returned.
+	AcceptedQuantity int `json:"acceptedQuantity"`
+	// Puts the accepted units back into stock.
+	Restock *bool `json:"restock,omitempty"`
+}
+
+type ReturnItem struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+	// damaged, defective, wrong_item, not_as_described, no_longer_needed or other.
+	Reason  string  `json:"reason"`
+	Comment *string `json:"comment,omitempty"`
+	// Units that passed inspection and are refunded.
+	AcceptedQuantity int `json:"acceptedQuantity"`
+	// Whether the accepted units were put back into stock.
+	Restock bool `json:"restock"`
+	// What the accepted units cost after discounts, with taxes that were added to the order total.
+	Refund *money.Money `json:"refund"`
+}
+
+type ReturnItemInput struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+	// damaged, defective, wrong_item, not_as_described, no_longer_needed or other.
+	Reason  string  `json:"reason"`
+	Comment *string `json:"comment,omitempty"`
+}
+
+type Review struct {
+	ID        string `json:"id"`
+	ProductID string `json:"productId"`
+	AccountID string `json:"accountId"`
+	// From 1 to 5 stars.
+	Rating int    `json:"rating"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	// One of pending, approved or rejected. Only approved reviews are public.
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ReviewInput struct {
+	ProductID string  `json:"productId"`
+	Rating    int     `json:"rating"`
+	Title     *string `json:"title,omitempty"`
+	Body      *string `json:"body,omitempty"`
+}
+
+// Prices that replace a product's price, prices and compareAtPrice at effectiveFrom.
+type ScheduledPrice struct {
+	EffectiveFrom  time.Time      `json:"effectiveFrom"`
+	Price          *money.Money   `json:"price"`
+	Prices         []*money.Money `json:"prices"`
+	CompareAtPrice *money.Money   `json:"compareAtPrice,omitempty"`
+}
+
+type ScheduledPriceInput struct {
+	EffectiveFrom  time.Time      `json:"effectiveFrom"`
+	Price          *money.Money   `json:"price"`
+	Prices         []*money.Money `json:"prices,omitempty"`
+	CompareAtPrice *money.Money   `json:"compareAtPrice,omitempty"`
+}
+
+type Shipment struct {
+	ID      string `json:"id"`
+	OrderID string `json:"orderId"`
+	// Merchant who sent the parcel; empty for parcels sent by admins.
+	MerchantID     string `json:"merchantId"`
+	Carrier        string `json:"carrier"`
+	TrackingNumber string `json:"trackingNumber"`
+	// shipped or delivered.
+	Status      string          `json:"status"`
+	Items       []*ShipmentItem `json:"items"`
+	ShippedAt   time.Time       `json:"shippedAt"`
+	DeliveredAt *time.Time      `json:"deliveredAt,omitempty"`
+}
+
+type ShipmentItem struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+type ShipmentItemInput struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+type ShippingAddress struct {
+	// The address book entry the address was copied from.
+	AddressID  string `json:"addressId"`
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
+	Phone      string `json:"phone"`
+}
+
+// Where a cart would ship to, for quotes before an address is saved.
+type ShippingDestinationInput struct {
+	// Two letter country code.
+	Country    string  `json:"country"`
+	Region     *string `json:"region,omitempty"`
+	PostalCode *string `json:"postalCode,omitempty"`
+}
+
+// What a shipping method charges to deliver an order or cart.
+type ShippingQuote struct {
+	// Pass it as shippingMethod when placing the order.
+	Method  string       `json:"method"`
+	Name    string       `json:"name"`
+	Carrier string       `json:"carrier"`
+	Zone    string       `json:"zone"`
+	Fee     *money.Money `json:"fee"`
+	// Whether the fee is waived because the order reaches the method's free shipping threshold.
+	Free bool `json:"free"`
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	accountpb "github.com/Asif-Faizal/Minimum-Viable-Shop/account/pb"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
 	orderpb "github.com/Asif-Faizal/Minimum-Viable-Shop/order/pb/pb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -49,10 +52,11 @@ func (r *mutationResolver) CreateAccount(ctx context.Context, input AccountInput
 	}
 
 	return &Account{
-		ID:       resp.Account.Id,
-		Name:     resp.Account.Name,
-		UserType: resp.Account.Usertype,
-		Email:    resp.Account.Email,
+		ID:        resp.Account.Id,
+		Name:      resp.Account.Name,
+		UserType:  resp.Account.Usertype,
+		Email:     resp.Account.Email,
+		TaxExempt: resp.Account.TaxExempt,
 	}, nil
 }
 
@@ -62,7 +66,7 @@ func (r *mutationResolver) CreateProduct(ctx context.Context, input ProductInput
 	if input.Name == "" {
 		return nil, fmt.Errorf("product name is required")
 	}
-	if input.Price <= 0 {
+	if input.Price == nil || input.Price.Amount <= 0 {
 		return nil, fmt.Errorf("product price must be positive")
 	}
 
@@ -72,8 +76,35 @@ func (r *mutationResolver) CreateProduct(ctx context.Context, input ProductInput
 		id = *input.ID
 	}
 
-	// Call catalog service (convert float64 to float32)
-	response, err := r.server.catalogClient.CreateOrUpdateProduct(ctx, id, input.Name, input.Description, float32(input.Price))
+	prices := make([]money.Money, 0, len(input.Prices))
+	for _, price := range input.Prices {
+		prices = append(prices, *price)
+	}
+
+	status := ""
+	if input.Status != nil {
+		status = *input.Status
+	}
+
+	// Call catalog service
+	response, err := r.server.catalogClient.CreateOrUpdateProduct(ctx, &catalog.Product{
+		ID:              id,
+		Name:            input.Name,
+		Description:     input.Description,
+		Price:           *input.Price,
+		Prices:          prices,
+		CompareAtPrice:  input.CompareAtPrice,
+		ScheduledPrices: toScheduledPrices(input.ScheduledPrices),
+		Status:          catalog.ProductStatus(status),
+		PublishAt:       input.PublishAt,
+		UnpublishAt:     input.UnpublishAt,
+		CategoryID:      stringArg(input.CategoryID),
+		Attributes:      toProductAttributes(input.Attributes),
+		TaxCategory:     stringArg(input.TaxCategory),
+		Weight:          uint32(intArg(input.Weight)),
+		Translations:    toProductTranslations(input.Translations),
+		Version:         stringArg(input.Version),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create/update product: %w", err)
 	}
@@ -82,12 +113,227 @@ func (r *mutationResolver) CreateProduct(ctx context.Context, input ProductInput
 		return nil, fmt.Errorf("unexpected response from catalog service")
 	}
 
-	return &Product{
-		ID:          response.Product.Id,
-		Name:        response.Product.Name,
-		Description: response.Product.Description,
-		Price:       float64(response.Product.Price),
-	}, nil
+	return toProduct(response.Product), nil
+}
+
+// PatchProduct updates the fields set in input, provided the product is still at version
+func (r *mutationResolver) PatchProduct(ctx context.Context, id string, version string, input ProductPatchInput) (*Product, error) {
+	patch := &catalog.Product{}
+	fields := []string{}
+	if input.Name != nil {
+		patch.Name = *input.Name
+		fields = append(fields, "name")
+	}
+	if input.Description != nil {
+		patch.Description = *input.Description
+		fields = append(fields, "description")
+	}
+	if input.Price != nil {
+		patch.Price = *input.Price
+		fields = append(fields, "price")
+	}
+	if input.Prices != nil {
+		for _, price := range input.Prices {
+			patch.Prices = append(patch.Prices, *price)
+		}
+		fields = append(fields, "prices")
+	}
+	if input.CompareAtPrice != nil {
+		if !input.CompareAtPrice.IsZero() {
+			patch.CompareAtPrice = input.CompareAtPrice
+		}
+		fields = append(fields, "compare_at_price")
+	}
+	if input.ScheduledPrices != nil {
+		patch.ScheduledPrices = toScheduledPrices(input.ScheduledPrices)
+		fields = append(fields, "scheduled_prices")
+	}
+	if input.Status != nil {
+		patch.Status = catalog.ProductStatus(*input.Status)
+		fields = append(fields, "status")
+	}
+	if input.PublishAt != nil {
+		patch.PublishAt = input.PublishAt
+		fields = append(fields, "publish_at")
+	}
+	if input.UnpublishAt != nil {
+		patch.UnpublishAt = input.UnpublishAt
+		fields = append(fields, "unpublish_at")
+	}
+	if input.CategoryID != nil {
+		patch.CategoryID = *input.CategoryID
+		fields = append(fields, "category_id")
+	}
+	if input.Attributes != nil {
+		patch.Attributes = toProductAttributes(input.Attributes)
+		fields = append(fields, "attributes")
+	}
+	if input.TaxCategory != nil {
+		patch.TaxCategory = *input.TaxCategory
+		fields = append(fields, "tax_category")
+	}
+	if input.Weight != nil {
+		patch.Weight = uint32(intArg(input.Weight))
+		fields = append(fields, "weight")
+	}
+	if input.Translations != nil {
+		patch.Translations = toProductTranslations(input.Translations)
+		fields = append(fields, "translations")
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	response, err := r.server.catalogClient.PatchProduct(ctx, id, version, patch, fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	if response == nil || response.Product == nil {
+		return nil, fmt.Errorf("unexpected response from catalog service")
+	}
+
+	return toProduct(response.Product), nil
+}
+
+// CreateCategory creates or updates a product category and its attribute schema
+func (r *mutationResolver) CreateCategory(ctx context.Context, input CategoryInput) (*Category, error) {
+	if input.Name == "" {
+		return nil, fmt.Errorf("category name is required")
+	}
+
+	id := ""
+	if input.ID != nil {
+		id = *input.ID
+	}
+
+	definitions := make([]*catalog.AttributeDefinition, 0, len(input.Attributes))
+	for _, attribute := range input.Attributes {
+		definitions = append(definitions, &catalog.AttributeDefinition{
+			Name:     attribute.Name,
+			Type:     catalog.AttributeType(attribute.Type),
+			Unit:     stringArg(attribute.Unit),
+			Values:   attribute.Values,
+			Required: boolArg(attribute.Required),
+		})
+	}
+
+	response, err := r.server.catalogClient.CreateOrUpdateCategory(ctx, &catalog.Category{
+		ID:         id,
+		Name:       input.Name,
+		Attributes: definitions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create/update category: %w", err)
+	}
+
+	if response == nil || response.Category == nil {
+		return nil, fmt.Errorf("unexpected response from catalog service")
+	}
+
+	return toCategory(response.Category), nil
+}
+
+// ReviewProduct creates or replaces the caller's review of a product they ordered
+func (r *mutationResolver) ReviewProduct(ctx context.Context, input ReviewInput) (*Review, error) {
+	if input.ProductID == "" {
+		return nil, fmt.Errorf("product id is required")
+	}
+	if input.Rating < 1 || input.Rating > 5 {
+		return nil, fmt.Errorf("rating must be between 1 and 5")
+	}
+
+	response, err := r.server.reviewClient.CreateOrUpdateReview(ctx, input.ProductID, uint32(input.Rating), stringArg(input.Title), stringArg(input.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to review product: %w", err)
+	}
+
+	if response == nil || response.Review == nil {
+		return nil, fmt.Errorf("unexpected response from review service")
+	}
+
+	return toReview(response.Review), nil
+}
+
+// ModerateReview approves or rejects a review
+func (r *mutationResolver) ModerateReview(ctx context.Context, id string, status string) (*Review, error) {
+	response, err := r.server.reviewClient.ModerateReview(ctx, id, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to moderate review: %w", err)
+	}
+
+	if response == nil || response.Review == nil {
+		return nil, fmt.Errorf("unexpected response from review service")
+	}
+
+	return toReview(response.Review), nil
+}
+
+// TransferProductOwnership assigns a product to another merchant account
+func (r *mutationResolver) TransferProductOwnership(ctx context.Context, productID string, merchantID string) (*Product, error) {
+	if productID == "" {
+		return nil, fmt.Errorf("product id is required")
+	}
+	if merchantID == "" {
+		return nil, fmt.Errorf("merchant id is required")
+	}
+
+	response, err := r.server.catalogClient.TransferProductOwnership(ctx, productID, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer product ownership: %w", err)
+	}
+
+	if response == nil || response.Product == nil {
+		return nil, fmt.Errorf("unexpected response from catalog service")
+	}
+
+	return toProduct(response.Product), nil
+}
+
+// DeleteProduct soft deletes a product from the catalog
+func (r *mutationResolver) DeleteProduct(ctx context.Context, id string) (*Product, error) {
+	if id == "" {
+		return nil, fmt.Errorf("product id is required")
+	}
+
+	response, err := r.server.catalogClient.DeleteProduct(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	if response == nil || response.Product == nil {
+		return nil, fmt.Errorf("unexpected response from catalog service")
+	}
+
+	return toProduct(response.Product), nil
+}
+
+// UpdateProductImage changes the alt text of a product image
+func (r *mutationResolver) UpdateProductImage(ctx context.Context, productID string, imageID string, altText string) (*Product, error) {
+	response, err := r.server.catalogClient.UpdateProductMedia(ctx, productID, imageID, altText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product image: %w", err)
+	}
+
+	if response == nil || response.Product == nil {
+		return nil, fmt.Errorf("unexpected response from catalog service")
+	}
+
+	return toProduct(response.Product), nil
+}
+
+// ReorderProductImages changes the display order of a product's images
+func (r *mutationResolver) ReorderProductImages(ctx context.Context, productID string, imageIDs []string) (*Product, error) {
+	response, err := r.server.catalogClient.ReorderProductMedia(ctx, productID, imageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reorder product images: %w", err)
+	}
+
+	if response == nil || response.Product == nil {
+		return nil, fmt.Errorf("unexpected response from catalog service")
+	}
+
+	return toProduct(response.Product), nil
 }
 
 // CreateOrder creates or updates an order
@@ -123,11 +369,15 @@ func (r *mutationResolver) CreateOrder(ctx context.Context, input OrderInput) (*
 
 	// Call order service
 	response, err := r.server.orderClient.CreateOrUpdateOrder(ctx, &orderpb.Order{
-		Id:        id,
-		AccountId: input.AccountID,
-		Products:  protoProducts,
-		CreatedAt: timestamppb.Now(),
-	})
+		Id:             id,
+		AccountId:      input.AccountID,
+		Products:       protoProducts,
+		CreatedAt:      timestamppb.Now(),
+		TaxCountry:     stringArg(input.TaxCountry),
+		TaxRegion:      stringArg(input.TaxRegion),
+		TaxExempt:      boolArg(input.TaxExempt),
+		ShippingMethod: stringArg(input.ShippingMethod),
+	}, currencyArg(input.Currency), input.CouponCodes, stringArg(input.ShippingAddressID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create/update order: %w", err)
 	}
@@ -137,22 +387,244 @@ func (r *mutationResolver) CreateOrder(ctx context.Context, input OrderInput) (*
 	}
 
 	// Convert response to GraphQL type
-	orderedProducts := make([]*OrderedProduct, 0, len(response.Order.Products))
-	for _, product := range response.Order.Products {
-		orderedProducts = append(orderedProducts, &OrderedProduct{
-			ID:          product.ProductId,
-			Name:        product.ProductName,
-			Description: product.ProductDescription,
-			Price:       product.Price,
-			Quantity:    int(product.Quantity),
+	return toOrder(response.Order), nil
+}
+
+// UpdateOrderItems replaces, adds or removes products of a pending order
+func (r *mutationResolver) UpdateOrderItems(ctx context.Context, orderID string, operation string, products []*OrderProductInput) (*Order, error) {
+	if orderID == "" {
+		return nil, fmt.Errorf("order id is required")
+	}
+	if len(products) == 0 {
+		return nil, fmt.Errorf("at least one product is required")
+	}
+
+	protoProducts := make([]*orderpb.OrderProduct, 0, len(products))
+	for _, product := range products {
+		if product.ID == "" {
+			return nil, fmt.Errorf("product id is required")
+		}
+		if product.Quantity < 0 {
+			return nil, fmt.Errorf("product quantity must not be negative")
+		}
+		protoProducts = append(protoProducts, &orderpb.OrderProduct{
+			ProductId: product.ID,
+			Quantity:  int32(product.Quantity),
 		})
 	}
 
-	createdAt := response.Order.CreatedAt.AsTime()
-	return &Order{
-		ID:         response.Order.Id,
-		CreatedAt:  createdAt,
-		TotalPrice: response.Order.TotalPrice,
-		Products:   orderedProducts,
+	response, err := r.server.orderClient.UpdateOrderItems(ctx, orderID, operation, protoProducts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order items: %w", err)
+	}
+
+	if response == nil || response.Order == nil {
+		return nil, fmt.Errorf("unexpected response from order service")
+	}
+
+	return toOrder(response.Order), nil
+}
+
+// ApplyCoupon applies a coupon to a pending order
+func (r *mutationResolver) ApplyCoupon(ctx context.Context, orderID string, code string) (*Order, error) {
+	response, err := r.server.orderClient.ApplyCoupon(ctx, orderID, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply coupon: %w", err)
+	}
+
+	if response == nil || response.Order == nil {
+		return nil, fmt.Errorf("unexpected response from order service")
+	}
+
+	return toOrder(response.Order), nil
+}
+
+// CreatePromotion creates or updates a promotion or coupon
+func (r *mutationResolver) CreatePromotion(ctx context.Context, input PromotionInput) (*Promotion, error) {
+	response, err := r.server.orderClient.CreateOrUpdatePromotion(ctx, toProtoPromotion(input))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create/update promotion: %w", err)
+	}
+
+	if response == nil || response.Promotion == nil {
+		return nil, fmt.Errorf("unexpected response from order service")
+	}
+
+	return toPromotion(response.Promotion), nil
+}
+
+// UpdateOrderStatus moves an order along its lifecycle
+func (r *mutationResolver) UpdateOrderStatus(ctx context.Context, orderID string, status string) (*Order, error) {
+	response, err := r.server.orderClient.UpdateOrderStatus(ctx, orderID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	if response == nil || response.Order == nil {
+		return nil, fmt.Errorf("unexpected response from order service")
+	}
+
+	return toOrder(response.Order), nil
+}
+
+// SetAccountTaxExempt marks an account as tax exempt or not. Requires an admin access token.
+func (r *mutationResolver) SetAccountTaxExempt(ctx context.Context, accountID string, taxExempt bool) (*Account, error) {
+	response, err := r.server.accountClient.SetTaxExempt(ctx, accountID, taxExempt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set account tax exemption: %w", err)
+	}
+
+	if response == nil || response.Account == nil {
+		return nil, fmt.Errorf("unexpected response from account service")
+	}
+
+	return &Account{
+		ID:        response.Account.Id,
+		Name:      response.Account.Name,
+		UserType:  response.Account.Usertype,
+		Email:     response.Account.Email,
+		TaxExempt: response.Account.TaxExempt,
 	}, nil
 }
+
+// CreateAddress creates or updates an address in an account's address book
+func (r *mutationResolver) CreateAddress(ctx context.Context, input AddressInput) (*Address, error) {
+	response, err := r.server.accountClient.CreateOrUpdateAddress(ctx, &accountpb.Address{
+		Id:              stringArg(input.ID),
+		AccountId:       stringArg(input.AccountID),
+		Name:            input.Name,
+		Line1:           input.Line1,
+		Line2:           stringArg(input.Line2),
+		City:            input.City,
+		Region:          stringArg(input.Region),
+		PostalCode:      stringArg(input.PostalCode),
+		Country:         input.Country,
+		Phone:           stringArg(input.Phone),
+		DefaultShipping: boolArg(input.DefaultShipping),
+		DefaultBilling:  boolArg(input.DefaultBilling),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save address: %w", err)
+	}
+
+	if response == nil || response.Address == nil {
+		return nil, fmt.Errorf("unexpected response from account service")
+	}
+
+	return toAddress(response.Address), nil
+}
+
+// DeleteAddress removes an address from its account's address book
+func (r *mutationResolver) DeleteAddress(ctx context.Context, id string) (bool, error) {
+	response, err := r.server.accountClient.DeleteAddress(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete address: %w", err)
+	}
+	return response.Success, nil
+}
+
+// CreateShipment records a parcel sent for an order. Requires an admin access token.
+func (r *mutationResolver) CreateShipment(ctx context.Context, orderID string, carrier string, trackingNumber *string, items []*ShipmentItemInput) (*Shipment, error) {
+	protoItems := make([]*orderpb.ShipmentItem, 0, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("shipment item quantity must be positive")
+		}
+		protoItems = append(protoItems, &orderpb.ShipmentItem{
+			ProductId: item.ProductID,
+			Quantity:  int32(item.Quantity),
+		})
+	}
+
+	response, err := r.server.orderClient.CreateShipment(ctx, orderID, carrier, stringArg(trackingNumber), protoItems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shipment: %w", err)
+	}
+
+	if response == nil || response.Shipment == nil {
+		return nil, fmt.Errorf("unexpected response from order service")
+	}
+
+	return toShipment(response.Shipment), nil
+}
+
+// MarkShipmentDelivered marks a shipment as delivered. Requires an admin access token.
+func (r *mutationResolver) MarkShipmentDelivered(ctx context.Context, shipmentID string) (*Shipment, error) {
+	response, err := r.server.orderClient.MarkDelivered(ctx, shipmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark shipment delivered: %w", err)
+	}
+
+	if response == nil || response.Shipment == nil {
+		return nil, fmt.Errorf("unexpected response from order service")
+	}
+
+	return toShipment(response.Shipment), nil
+}
+
+// RequestReturn asks to send back products of a delivered order
+func (r *mutationResolver) RequestReturn(ctx context.Context, orderID string, items []*ReturnItemInput) (*Return, error) {
+	protoItems := make([]*orderpb.ReturnItem, 0, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("return item quantity must be positive")
+		}
+		protoItems = append(protoItems, &orderpb.ReturnItem{
+			ProductId: item.ProductID,
+			Quantity:  int32(item.Quantity),
+			Reason:    item.Reason,
+			Comment:   stringArg(item.Comment),
+		})
+	}
+
+	response, err := r.server.orderClient.RequestReturn(ctx, orderID, protoItems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request return: %w", err)
+	}
+
+	if response == nil || response.Return == nil {
+		return nil, fmt.Errorf("unexpected response from order service")
+	}
+
+	return toReturn(response.Return), nil
+}
+
+// UpdateReturnStatus approves, rejects or receives a return. Requires an admin access token.
+func (r *mutationResolver) UpdateReturnStatus(ctx context.Context, id string, status string, note *string) (*Return, error) {
+	response, err := r.server.orderClient.UpdateReturnStatus(ctx, id, status, stringArg(note))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update return status: %w", err)
+	}
+
+	if response == nil || response.Return == nil {
+		return nil, fmt.Errorf("unexpected response from order service")
+	}
+
+	return toReturn(response.Return), nil
+}
+
+// InspectReturn records the inspection of a received return. Requires an admin access token.
+func (r *mutationResolver) InspectReturn(ctx context.Context, id string, items []*ReturnInspectionInput, note *string) (*Return, error) {
+	protoItems := make([]*orderpb.ReturnItem, 0, len(items))
+	for _, item := range items {
+		if item.AcceptedQuantity < 0 {
+			return nil, fmt.Errorf("accepted quantity must not be negative")
+		}
+		protoItems = append(protoItems, &orderpb.ReturnItem{
+			ProductId:        item.ProductID,
+			AcceptedQuantity: int32(item.AcceptedQuantity),
+			Restock:          boolArg(item.Restock),
+		})
+	}
+
+	response, err := r.server.orderClient.InspectReturn(ctx, id, protoItems, stringArg(note))
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect return: %w", err)
+	}
+
+	if response == nil || response.Return == nil {
+		return nil, fmt.Errorf("unexpected response from order service")
+	}
+
+	return toReturn(response.Return), nil
+}
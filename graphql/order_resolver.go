@@ -0,0 +1,107 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+)
+
+type orderResolver struct {
+	server *Server
+}
+
+// ItemChanges retrieves the changes made to the products of an order, oldest first
+func (resolver *orderResolver) ItemChanges(ctx context.Context, order *Order) ([]*OrderItemChange, error) {
+	if order == nil || order.ID == "" {
+		return nil, fmt.Errorf("order id is required")
+	}
+
+	resp, err := resolver.server.orderClient.ListOrderItemChanges(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch item changes for order %s: %w", order.ID, err)
+	}
+
+	changes := make([]*OrderItemChange, 0, len(resp.Changes))
+	for _, change := range resp.Changes {
+		changes = append(changes, &OrderItemChange{
+			ProductID:   change.ProductId,
+			Operation:   change.Operation,
+			OldQuantity: int(change.OldQuantity),
+			NewQuantity: int(change.NewQuantity),
+			Price:       toMoney(change.Price),
+			ChangedAt:   change.ChangedAt.AsTime(),
+		})
+	}
+	return changes, nil
+}
+
+// Shipments retrieves the parcels sent for an order, oldest first
+func (resolver *orderResolver) Shipments(ctx context.Context, order *Order) ([]*Shipment, error) {
+	if order == nil || order.ID == "" {
+		return nil, fmt.Errorf("order id is required")
+	}
+
+	resp, err := resolver.server.orderClient.ListShipments(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch shipments for order %s: %w", order.ID, err)
+	}
+
+	shipments := make([]*Shipment, 0, len(resp.Shipments))
+	for _, shipment := range resp.Shipments {
+		shipments = append(shipments, toShipment(shipment))
+	}
+	return shipments, nil
+}
+
+// Returns retrieves the returns of an order, newest first
+func (resolver *orderResolver) Returns(ctx context.Context, order *Order) ([]*Return, error) {
+	if order == nil || order.ID == "" {
+		return nil, fmt.Errorf("order id is required")
+	}
+
+	resp, err := resolver.server.orderClient.ListReturns(ctx, order.ID, "", 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch returns for order %s: %w", order.ID, err)
+	}
+
+	returns := make([]*Return, 0, len(resp.Returns))
+	for _, orderReturn := range resp.Returns {
+		returns = append(returns, toReturn(orderReturn))
+	}
+	return returns, nil
+}
+
+// Invoices retrieves the invoices and credit notes of an order, oldest first
+func (resolver *orderResolver) Invoices(ctx context.Context, order *Order) ([]*Invoice, error) {
+	if order == nil || order.ID == "" {
+		return nil, fmt.Errorf("order id is required")
+	}
+
+	resp, err := resolver.server.orderClient.ListInvoices(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch invoices for order %s: %w", order.ID, err)
+	}
+
+	invoices := make([]*Invoice, 0, len(resp.Invoices))
+	for _, invoice := range resp.Invoices {
+		invoices = append(invoices, toInvoice(invoice))
+	}
+	return invoices, nil
+}
+
+// MerchantOrders retrieves the parts of an order sold by each merchant
+func (resolver *orderResolver) MerchantOrders(ctx context.Context, order *Order) ([]*MerchantOrder, error) {
+	if order == nil || order.ID == "" {
+		return nil, fmt.Errorf("order id is required")
+	}
+
+	resp, err := resolver.server.orderClient.ListMerchantOrders(ctx, "", order.ID, "", 100, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch merchant orders for order %s: %w", order.ID, err)
+	}
+
+	merchantOrders := make([]*MerchantOrder, 0, len(resp.MerchantOrders))
+	for _, merchantOrder := range resp.MerchantOrders {
+		merchantOrders = append(merchantOrders, toMerchantOrder(merchantOrder))
+	}
+	return merchantOrders, nil
+}
@@ -0,0 +1,71 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+)
+
+type productResolver struct {
+	server *Server
+}
+
+// Reviews retrieves the approved reviews of a product, newest first
+func (resolver *productResolver) Reviews(ctx context.Context, product *Product, pagination *PaginationInput) ([]*Review, error) {
+	if product == nil || product.ID == "" {
+		return nil, fmt.Errorf("product id is required")
+	}
+
+	skip := uint64(0)
+	take := uint64(10)
+
+	if pagination != nil {
+		if pagination.Skip != nil {
+			skip = uint64(*pagination.Skip)
+		}
+		if pagination.Take != nil {
+			take = uint64(*pagination.Take)
+		}
+	}
+
+	// Public reviews only, whatever the caller's access token allows
+	resp, err := resolver.server.reviewClient.ListReviews(ctx, product.ID, "approved", skip, take)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews for product %s: %w", product.ID, err)
+	}
+
+	reviews := make([]*Review, 0, len(resp.Reviews))
+	for _, review := range resp.Reviews {
+		reviews = append(reviews, toReview(review))
+	}
+	return reviews, nil
+}
+
+// PriceHistory retrieves the prices a product has had, latest first
+func (resolver *productResolver) PriceHistory(ctx context.Context, product *Product, pagination *PaginationInput) ([]*PriceChange, error) {
+	if product == nil || product.ID == "" {
+		return nil, fmt.Errorf("product id is required")
+	}
+
+	skip := uint64(0)
+	take := uint64(10)
+
+	if pagination != nil {
+		if pagination.Skip != nil {
+			skip = uint64(*pagination.Skip)
+		}
+		if pagination.Take != nil {
+			take = uint64(*pagination.Take)
+		}
+	}
+
+	resp, err := resolver.server.catalogClient.GetPriceHistory(ctx, product.ID, skip, take)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch price history for product %s: %w", product.ID, err)
+	}
+
+	changes := make([]*PriceChange, 0, len(resp.Changes))
+	for _, change := range resp.Changes {
+		changes = append(changes, toPriceChange(change))
+	}
+	return changes, nil
+}
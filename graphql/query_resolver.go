@@ -3,6 +3,9 @@ package graphql
 import (
 	"context"
 	"fmt"
+	"time"
+
+	orderpb "github.com/Asif-Faizal/Minimum-Viable-Shop/order/pb/pb"
 )
 
 type queryResolver struct {
@@ -51,50 +54,53 @@ func (r *queryResolver) Accounts(ctx context.Context, pagination *PaginationInpu
 	accounts := make([]*Account, 0, len(accountsResp.Accounts))
 	for _, account := range accountsResp.Accounts {
 		accounts = append(accounts, &Account{
-			ID:       account.Id,
-			Name:     account.Name,
-			UserType: account.Usertype,
-			Email:    account.Email,
+			ID:        account.Id,
+			Name:      account.Name,
+			UserType:  account.Usertype,
+			Email:     account.Email,
+			TaxExempt: account.TaxExempt,
 		})
 	}
 	return accounts, nil
 }
 
-// Products retrieves products with optional pagination, filtering by ID, and search query
-func (r *queryResolver) Products(ctx context.Context, pagination *PaginationInput, id *string, query *string) ([]*Product, error) {
+// Products retrieves products with optional pagination, filtering by ID, category and
+// attributes, and search query, priced in the requested currency
+func (r *queryResolver) Products(ctx context.Context, pagination *PaginationInput, id *string, query *string, currency *string, includeUnpublished *bool, categoryID *string, attributes []*AttributeFilterInput, sort *string, locale *string) ([]*Product, error) {
 	// If specific ID requested, get single product
 	if id != nil {
-		productResp, err := r.server.catalogClient.GetProductByID(ctx, *id)
+		productResp, err := r.server.catalogClient.GetProductByID(ctx, *id, currencyArg(currency))
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch product: %w", err)
 		}
 		if productResp == nil || productResp.Product == nil {
 			return nil, fmt.Errorf("product not found: %s", *id)
 		}
-		return []*Product{
-			{
-				ID:          productResp.Product.Id,
-				Name:        productResp.Product.Name,
-				Description: productResp.Product.Description,
-				Price:       float64(productResp.Product.Price),
-			},
-		}, nil
+		return []*Product{toLocalizedProduct(productResp.Product, localeArg(locale))}, nil
 	}
 
+	filter := toProductFilter(categoryID, attributes, sort)
+	filter.Locale = localeArg(locale)
+
 	// If search query provided, use search
 	if query != nil {
-		searchResp, err := r.server.catalogClient.SearchProducts(ctx, *query)
+		skip := uint64(0)
+		take := uint64(0)
+		if pagination != nil {
+			if pagination.Skip != nil {
+				skip = uint64(*pagination.Skip)
+			}
+			if pagination.Take != nil {
+				take = uint64(*pagination.Take)
+			}
+		}
+		searchResp, err := r.server.catalogClient.SearchProducts(ctx, *query, skip, take, "", currencyArg(currency), boolArg(includeUnpublished), filter)
 		if err != nil {
 			return nil, fmt.Errorf("failed to search products: %w", err)
 		}
 		products := make([]*Product, 0, len(searchResp.Products))
 		for _, p := range searchResp.Products {
-			products = append(products, &Product{
-				ID:          p.Id,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       float64(p.Price),
-			})
+			products = append(products, toLocalizedProduct(p, localeArg(locale)))
 		}
 		return products, nil
 	}
@@ -112,80 +118,402 @@ func (r *queryResolver) Products(ctx context.Context, pagination *PaginationInpu
 		}
 	}
 
-	productsResp, err := r.server.catalogClient.ListProducts(ctx, skip, take)
+	productsResp, err := r.server.catalogClient.ListProducts(ctx, skip, take, "", currencyArg(currency), boolArg(includeUnpublished), filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
 
 	products := make([]*Product, 0, len(productsResp.Products))
 	for _, p := range productsResp.Products {
-		products = append(products, &Product{
-			ID:          p.Id,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       float64(p.Price),
-		})
+		products = append(products, toLocalizedProduct(p, localeArg(locale)))
 	}
 	return products, nil
 }
 
-// Order retrieves a single order by ID
-func (r *queryResolver) Order(ctx context.Context, id string) (*Order, error) {
-	orderResp, err := r.server.orderClient.GetOrderByID(ctx, id)
+// ProductsConnection pages through products, or the products matching query, with cursors
+func (r *queryResolver) ProductsConnection(ctx context.Context, first *int, after *string, query *string, currency *string, includeUnpublished *bool, categoryID *string, attributes []*AttributeFilterInput, sort *string, locale *string) (*ProductConnection, error) {
+	take := uint64(10)
+	if first != nil {
+		if *first < 1 || *first > 100 {
+			return nil, fmt.Errorf("first must be between 1 and 100")
+		}
+		take = uint64(*first)
+	}
+
+	filter := toProductFilter(categoryID, attributes, sort)
+	filter.Locale = localeArg(locale)
+
+	if query != nil {
+		searchResp, err := r.server.catalogClient.SearchProducts(ctx, *query, 0, take, stringArg(after), currencyArg(currency), boolArg(includeUnpublished), filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search products: %w", err)
+		}
+		return toProductConnection(searchResp.Products, searchResp.Cursors, searchResp.NextCursor, searchResp.TotalCount, after, filter.Locale), nil
+	}
+
+	productsResp, err := r.server.catalogClient.ListProducts(ctx, 0, take, stringArg(after), currencyArg(currency), boolArg(includeUnpublished), filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+	return toProductConnection(productsResp.Products, productsResp.Cursors, productsResp.NextCursor, productsResp.TotalCount, after, filter.Locale), nil
+}
+
+// ProductsByMerchant retrieves the products owned by a merchant with optional pagination,
+// priced in the requested currency
+func (r *queryResolver) ProductsByMerchant(ctx context.Context, merchantID string, pagination *PaginationInput, currency *string, includeUnpublished *bool, locale *string) ([]*Product, error) {
+	if merchantID == "" {
+		return nil, fmt.Errorf("merchant id is required")
+	}
+
+	skip := uint64(0)
+	take := uint64(10)
+
+	if pagination != nil {
+		if pagination.Skip != nil {
+			skip = uint64(*pagination.Skip)
+		}
+		if pagination.Take != nil {
+			take = uint64(*pagination.Take)
+		}
+	}
+
+	productsResp, err := r.server.catalogClient.ListProductsByMerchant(ctx, merchantID, skip, take, currencyArg(currency), boolArg(includeUnpublished))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products for merchant: %w", err)
+	}
+
+	products := make([]*Product, 0, len(productsResp.Products))
+	for _, p := range productsResp.Products {
+		products = append(products, toLocalizedProduct(p, localeArg(locale)))
+	}
+	return products, nil
+}
+
+// RelatedProducts recommends products similar to a product, priced in the requested currency
+func (r *queryResolver) RelatedProducts(ctx context.Context, productID string, take *int, currency *string, locale *string) ([]*Product, error) {
+	productsResp, err := r.server.catalogClient.ListRelatedProducts(ctx, productID, recommendationTake(take), currencyArg(currency))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list related products: %w", err)
+	}
+
+	products := make([]*Product, 0, len(productsResp.Products))
+	for _, p := range productsResp.Products {
+		products = append(products, toLocalizedProduct(p, localeArg(locale)))
+	}
+	return products, nil
+}
+
+// FrequentlyBoughtTogether recommends the products most often ordered with a product. When
+// too few orders contain it, the list is filled up with related products.
+func (r *queryResolver) FrequentlyBoughtTogether(ctx context.Context, productID string, take *int, currency *string, locale *string) ([]*Product, error) {
+	limit := recommendationTake(take)
+
+	coPurchasesResp, err := r.server.orderClient.ListCoPurchases(ctx, productID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list frequently bought together products: %w", err)
+	}
+
+	products := make([]*Product, 0, limit)
+	seen := map[string]bool{productID: true}
+	if len(coPurchasesResp.Products) > 0 {
+		ids := make([]string, 0, len(coPurchasesResp.Products))
+		for _, coPurchase := range coPurchasesResp.Products {
+			ids = append(ids, coPurchase.ProductId)
+		}
+		// Only published products are returned, in the order of the ids.
+		productsResp, err := r.server.catalogClient.ListProductsWithIDs(ctx, ids, currencyArg(currency))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get frequently bought together products: %w", err)
+		}
+		for _, p := range productsResp.Products {
+			seen[p.Id] = true
+			products = append(products, toLocalizedProduct(p, localeArg(locale)))
+		}
+	}
+	if uint64(len(products)) >= limit {
+		return products, nil
+	}
+
+	relatedResp, err := r.server.catalogClient.ListRelatedProducts(ctx, productID, limit, currencyArg(currency))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list related products: %w", err)
+	}
+	for _, p := range relatedResp.Products {
+		if uint64(len(products)) >= limit {
+			break
+		}
+		if seen[p.Id] {
+			continue
+		}
+		seen[p.Id] = true
+		products = append(products, toLocalizedProduct(p, localeArg(locale)))
+	}
+	return products, nil
+}
+
+// Categories retrieves product categories with optional pagination
+func (r *queryResolver) Categories(ctx context.Context, pagination *PaginationInput) ([]*Category, error) {
+	skip := uint64(0)
+	take := uint64(100)
+
+	if pagination != nil {
+		if pagination.Skip != nil {
+			skip = uint64(*pagination.Skip)
+		}
+		if pagination.Take != nil {
+			take = uint64(*pagination.Take)
+		}
+	}
+
+	categoriesResp, err := r.server.catalogClient.ListCategories(ctx, skip, take)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	categories := make([]*Category, 0, len(categoriesResp.Categories))
+	for _, c := range categoriesResp.Categories {
+		categories = append(categories, toCategory(c))
+	}
+	return categories, nil
+}
+
+// Category retrieves a single category and its attribute schema
+func (r *queryResolver) Category(ctx context.Context, id string) (*Category, error) {
+	categoryResp, err := r.server.catalogClient.GetCategory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch category: %w", err)
+	}
+	if categoryResp == nil || categoryResp.Category == nil {
+		return nil, fmt.Errorf("category not found: %s", id)
+	}
+	return toCategory(categoryResp.Category), nil
+}
+
+// Reviews retrieves reviews, optionally of one product and, for admins, in a given status
+func (r *queryResolver) Reviews(ctx context.Context, productID *string, status *string, pagination *PaginationInput) ([]*Review, error) {
+	skip := uint64(0)
+	take := uint64(10)
+
+	if pagination != nil {
+		if pagination.Skip != nil {
+			skip = uint64(*pagination.Skip)
+		}
+		if pagination.Take != nil {
+			take = uint64(*pagination.Take)
+		}
+	}
+
+	reviewsResp, err := r.server.reviewClient.ListReviews(ctx, stringArg(productID), stringArg(status), skip, take)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	reviews := make([]*Review, 0, len(reviewsResp.Reviews))
+	for _, review := range reviewsResp.Reviews {
+		reviews = append(reviews, toReview(review))
+	}
+	return reviews, nil
+}
+
+// Order retrieves a single order by ID, optionally converted to another currency
+func (r *queryResolver) Order(ctx context.Context, id string, currency *string) (*Order, error) {
+	orderResp, err := r.server.orderClient.GetOrderByID(ctx, id, currencyArg(currency))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch order: %w", err)
 	}
 	if orderResp == nil || orderResp.Order == nil {
 		return nil, fmt.Errorf("order not found: %s", id)
 	}
+	return toOrder(orderResp.Order), nil
+}
+
+// OrdersForAccount retrieves all orders for a given account, optionally converted to another currency
+func (r *queryResolver) OrdersForAccount(ctx context.Context, accountID string, currency *string) ([]*Order, error) {
+	orders := []*Order{}
+	cursor := ""
+	for {
+		ordersResp, err := r.server.orderClient.ListOrdersForAccount(ctx, accountID, 100, cursor, currencyArg(currency))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list orders for account: %w", err)
+		}
+		for _, o := range ordersResp.Orders {
+			orders = append(orders, toOrder(o))
+		}
+		if ordersResp.NextCursor == "" {
+			return orders, nil
+		}
+		cursor = ordersResp.NextCursor
+	}
+}
+
+// Orders searches every order for admins, a page at a time
+func (r *queryResolver) Orders(ctx context.Context, filter *OrderFilterInput, pagination *CursorPaginationInput, currency *string) (*OrderConnection, error) {
+	take := uint64(10)
+	after := ""
+	if pagination != nil {
+		if pagination.First != nil {
+			if *pagination.First < 1 || *pagination.First > 100 {
+				return nil, fmt.Errorf("first must be between 1 and 100")
+			}
+			take = uint64(*pagination.First)
+		}
+		after = stringArg(pagination.After)
+	}
 
-	orderedProducts := make([]*OrderedProduct, 0, len(orderResp.Order.Products))
-	for _, p := range orderResp.Order.Products {
-		orderedProducts = append(orderedProducts, &OrderedProduct{
-			ID:          p.ProductId,
-			Name:        p.ProductName,
-			Description: p.ProductDescription,
-			Price:       p.Price,
-			Quantity:    int(p.Quantity),
+	ordersResp, err := r.server.orderClient.SearchOrders(ctx, toProtoOrderFilter(filter), take, after, currencyArg(currency))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search orders: %w", err)
+	}
+	return toOrderConnection(ordersResp.Orders, ordersResp.Cursors, ordersResp.NextCursor, ordersResp.TotalCount, after), nil
+}
+
+// ShippingQuotes quotes shipping for an order, or for a cart of products
+func (r *queryResolver) ShippingQuotes(ctx context.Context, orderID *string, products []*OrderProductInput, currency *string, accountID *string, shippingAddressID *string, destination *ShippingDestinationInput) ([]*ShippingQuote, error) {
+	if stringArg(orderID) == "" && len(products) == 0 {
+		return nil, fmt.Errorf("orderId or products are required")
+	}
+
+	protoProducts := make([]*orderpb.OrderProduct, 0, len(products))
+	for _, product := range products {
+		if product.Quantity <= 0 {
+			return nil, fmt.Errorf("product quantity must be positive")
+		}
+		protoProducts = append(protoProducts, &orderpb.OrderProduct{
+			ProductId: product.ID,
+			Quantity:  int32(product.Quantity),
 		})
 	}
-	createdAt := orderResp.Order.CreatedAt.AsTime()
-	return &Order{
-		ID:         orderResp.Order.Id,
-		CreatedAt:  createdAt,
-		AccountID:  orderResp.Order.AccountId,
-		TotalPrice: orderResp.Order.TotalPrice,
-		Products:   orderedProducts,
-	}, nil
-}
-
-// OrdersForAccount retrieves all orders for a given account
-func (r *queryResolver) OrdersForAccount(ctx context.Context, accountID string) ([]*Order, error) {
-	ordersResp, err := r.server.orderClient.ListOrdersForAccount(ctx, accountID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list orders for account: %w", err)
-	}
-
-	orders := make([]*Order, 0, len(ordersResp.Orders))
-	for _, o := range ordersResp.Orders {
-		orderedProducts := make([]*OrderedProduct, 0, len(o.Products))
-		for _, p := range o.Products {
-			orderedProducts = append(orderedProducts, &OrderedProduct{
-				ID:          p.ProductId,
-				Name:        p.ProductName,
-				Description: p.ProductDescription,
-				Price:       p.Price,
-				Quantity:    int(p.Quantity),
-			})
-		}
-		createdAt := o.CreatedAt.AsTime()
-		orders = append(orders, &Order{
-			ID:         o.Id,
-			CreatedAt:  createdAt,
-			AccountID:  o.AccountId,
-			TotalPrice: o.TotalPrice,
-			Products:   orderedProducts,
+	var address *orderpb.ShippingAddress
+	if destination != nil {
+		address = &orderpb.ShippingAddress{
+			Country:    destination.Country,
+			Region:     stringArg(destination.Region),
+			PostalCode: stringArg(destination.PostalCode),
+		}
+	}
+
+	response, err := r.server.orderClient.GetShippingQuotes(ctx, stringArg(orderID), protoProducts, currencyArg(currency), stringArg(accountID), stringArg(shippingAddressID), address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shipping quotes: %w", err)
+	}
+
+	quotes := make([]*ShippingQuote, 0, len(response.Quotes))
+	for _, quote := range response.Quotes {
+		quotes = append(quotes, toShippingQuote(quote))
+	}
+	return quotes, nil
+}
+
+// Promotions lists promotions and coupons, newest first
+func (r *queryResolver) Promotions(ctx context.Context, pagination *PaginationInput) ([]*Promotion, error) {
+	skip := uint64(0)
+	take := uint64(10)
+
+	if pagination != nil {
+		if pagination.Skip != nil {
+			skip = uint64(*pagination.Skip)
+		}
+		if pagination.Take != nil {
+			take = uint64(*pagination.Take)
+		}
+	}
+
+	promotionsResp, err := r.server.orderClient.ListPromotions(ctx, skip, take)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	promotions := make([]*Promotion, 0, len(promotionsResp.Promotions))
+	for _, promotion := range promotionsResp.Promotions {
+		promotions = append(promotions, toPromotion(promotion))
+	}
+	return promotions, nil
+}
+
+// Returns lists returns, newest first: those of an order, or of every order for admins
+func (r *queryResolver) Returns(ctx context.Context, orderID *string, status *string, pagination *PaginationInput) ([]*Return, error) {
+	skip := uint64(0)
+	take := uint64(10)
+
+	if pagination != nil {
+		if pagination.Skip != nil {
+			skip = uint64(*pagination.Skip)
+		}
+		if pagination.Take != nil {
+			take = uint64(*pagination.Take)
+		}
+	}
+
+	returnsResp, err := r.server.orderClient.ListReturns(ctx, stringArg(orderID), stringArg(status), skip, take)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list returns: %w", err)
+	}
+
+	returns := make([]*Return, 0, len(returnsResp.Returns))
+	for _, orderReturn := range returnsResp.Returns {
+		returns = append(returns, toReturn(orderReturn))
+	}
+	return returns, nil
+}
+
+// Return retrieves a return by its ID
+func (r *queryResolver) Return(ctx context.Context, id string) (*Return, error) {
+	returnResp, err := r.server.orderClient.GetReturn(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch return: %w", err)
+	}
+	if returnResp == nil || returnResp.Return == nil {
+		return nil, fmt.Errorf("return not found: %s", id)
+	}
+	return toReturn(returnResp.Return), nil
+}
+
+// MerchantOrders lists a merchant's parts of orders, newest first, a page at a time
+func (r *queryResolver) MerchantOrders(ctx context.Context, merchantID string, status *string, first *int, after *string) (*MerchantOrderConnection, error) {
+	take := uint64(10)
+	if first != nil {
+		if *first < 1 || *first > 100 {
+			return nil, fmt.Errorf("first must be between 1 and 100")
+		}
+		take = uint64(*first)
+	}
+
+	merchantOrdersResp, err := r.server.orderClient.ListMerchantOrders(ctx, merchantID, "", stringArg(status), take, stringArg(after))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merchant orders: %w", err)
+	}
+	return toMerchantOrderConnection(merchantOrdersResp.MerchantOrders, merchantOrdersResp.Cursors, merchantOrdersResp.NextCursor, merchantOrdersResp.TotalCount, stringArg(after)), nil
+}
+
+// MerchantOrder retrieves the part of an order sold by a merchant
+func (r *queryResolver) MerchantOrder(ctx context.Context, orderID string, merchantID string) (*MerchantOrder, error) {
+	merchantOrderResp, err := r.server.orderClient.GetMerchantOrder(ctx, orderID, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch merchant order: %w", err)
+	}
+	if merchantOrderResp == nil || merchantOrderResp.MerchantOrder == nil {
+		return nil, fmt.Errorf("merchant order not found: %s/%s", orderID, merchantID)
+	}
+	return toMerchantOrder(merchantOrderResp.MerchantOrder), nil
+}
+
+// MerchantPayouts sums what a merchant is owed in each currency
+func (r *queryResolver) MerchantPayouts(ctx context.Context, merchantID string, from *time.Time, to *time.Time) ([]*MerchantPayout, error) {
+	payoutsResp, err := r.server.orderClient.GetMerchantPayouts(ctx, merchantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchant payouts: %w", err)
+	}
+
+	payouts := make([]*MerchantPayout, 0, len(payoutsResp.Payouts))
+	for _, payout := range payoutsResp.Payouts {
+		payouts = append(payouts, &MerchantPayout{
+			MerchantID: payout.MerchantId,
+			Currency:   payout.Currency,
+			Orders:     int(payout.Orders),
+			Total:      toMoney(payout.Total),
+			Refunded:   toMoney(payout.Refunded),
+			Payout:     toMoney(payout.Payout),
 		})
 	}
-	return orders, nil
+	return payouts, nil
 }
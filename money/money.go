@@ -0,0 +1,219 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	pb "github.com/Asif-Faizal/Minimum-Viable-Shop/money/pb"
+)
+
+// DefaultCurrency is assumed for prices stored before amounts carried a currency.
+const DefaultCurrency = "USD"
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidCurrency  = errors.New("invalid currency code")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrOverflow         = errors.New("amount out of range")
+)
+
+// Money is an exact amount in the minor units (cents, pence, yen...) of an
+// ISO 4217 currency. Arithmetic never goes through floating point.
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// exponents lists currencies whose minor unit is not 1/100 of the major unit.
+var exponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// Exponent returns the number of decimal places of the currency's minor unit.
+func Exponent(currency string) int {
+	if exponent, ok := exponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// Parse converts a decimal string in major units ("19.99") to Money, rounding
+// half away from zero to the currency's minor unit.
+func Parse(amount string, currency string) (Money, error) {
+	if err := ValidateCurrency(currency); err != nil {
+		return Money{}, err
+	}
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+	minor, err := RoundToMinor(value, currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// FromFloat converts a legacy floating point price to Money. The float is
+// first formatted with the shortest representation that round-trips, so
+// 19.99 becomes 1999 rather than 1998.
+func FromFloat(amount float64, currency string) (Money, error) {
+	return Parse(strconv.FormatFloat(amount, 'f', -1, 64), currency)
+}
+
+// RoundToMinor scales a major-unit amount to minor units of currency,
+// rounding half away from zero (matching Postgres ROUND on NUMERIC).
+func RoundToMinor(value *big.Rat, currency string) (int64, error) {
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(pow10(Exponent(currency))))
+	return roundHalfAwayFromZero(scaled)
+}
+
+func roundHalfAwayFromZero(value *big.Rat) (int64, error) {
+	numerator := new(big.Int).Abs(value.Num())
+	denominator := value.Denom()
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Lsh(remainder, 1).Cmp(denominator) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if value.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+	if !quotient.IsInt64() {
+		return 0, fmt.Errorf("%w: amount out of range", ErrInvalidAmount)
+	}
+	return quotient.Int64(), nil
+}
+
+func pow10(exponent int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+// ValidateCurrency checks that currency looks like an ISO 4217 alphabetic code.
+func ValidateCurrency(currency string) error {
+	if len(currency) != 3 {
+		return fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+	}
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+		}
+	}
+	return nil
+}
+
+// Validate checks that money is a non-negative amount in a valid currency.
+func (m Money) Validate() error {
+	if err := ValidateCurrency(m.Currency); err != nil {
+		return err
+	}
+	if m.Amount < 0 {
+		return fmt.Errorf("%w: amount must not be negative", ErrInvalidAmount)
+	}
+	return nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, fmt.Errorf("%w: %s plus %s", ErrOverflow, m, other)
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	difference := m.Amount - other.Amount
+	if (other.Amount > 0 && difference > m.Amount) || (other.Amount < 0 && difference < m.Amount) {
+		return Money{}, fmt.Errorf("%w: %s minus %s", ErrOverflow, m, other)
+	}
+	return Money{Amount: difference, Currency: m.Currency}, nil
+}
+
+// Mul multiplies the amount by an integer quantity.
+func (m Money) Mul(quantity int64) (Money, error) {
+	if m.Amount == 0 || quantity == 0 {
+		return Money{Amount: 0, Currency: m.Currency}, nil
+	}
+	product := m.Amount * quantity
+	if product/quantity != m.Amount || (quantity == -1 && m.Amount == math.MinInt64) {
+		return Money{}, fmt.Errorf("%w: %s times %d", ErrOverflow, m, quantity)
+	}
+	return Money{Amount: product, Currency: m.Currency}, nil
+}
+
+// Cmp compares two amounts of the same currency, returning -1, 0 or +1.
+func (m Money) Cmp(other Money) (int, error) {
+	if m.Currency != other.Currency {
+		return 0, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	switch {
+	case m.Amount < other.Amount:
+		return -1, nil
+	case m.Amount > other.Amount:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Rat returns the amount in major units as an exact rational.
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.Amount), pow10(Exponent(m.Currency)))
+}
+
+// Decimal formats the amount in major units, e.g. "19.99".
+func (m Money) Decimal() string {
+	exponent := Exponent(m.Currency)
+	amount := m.Amount
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// String formats the amount with its currency code, e.g. "19.99 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+func FromProto(money *pb.Money) Money {
+	if money == nil {
+		return Money{}
+	}
+	return Money{Amount: money.Amount, Currency: money.Currency}
+}
+
+func (m Money) ToProto() *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
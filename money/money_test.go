@@ -0,0 +1,168 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestParseRoundsHalfAwayFromZero(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     int64
+	}{
+		{"19.99", "USD", 1999},
+		{"0.005", "USD", 1},
+		{"0.0049", "USD", 0},
+		{"-0.005", "USD", -1},
+		{"2.675", "USD", 268},
+		{"-2.675", "USD", -268},
+		{"1.5", "JPY", 2},
+		{"-1.5", "JPY", -2},
+		{"1.2345", "KWD", 1235},
+		{" 10 ", "EUR", 1000},
+	}
+	for _, test := range tests {
+		got, err := Parse(test.amount, test.currency)
+		if err != nil {
+			t.Errorf("Parse(%q, %s): %v", test.amount, test.currency, err)
+			continue
+		}
+		if got.Amount != test.want || got.Currency != test.currency {
+			t.Errorf("Parse(%q, %s) = %v, want %d %s", test.amount, test.currency, got, test.want, test.currency)
+		}
+	}
+}
+
+func TestParseRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		amount   string
+		currency string
+		want     error
+	}{
+		{"abc", "USD", ErrInvalidAmount},
+		{"1.00", "usd", ErrInvalidCurrency},
+		{"1.00", "US", ErrInvalidCurrency},
+		{"100000000000000000000", "USD", ErrInvalidAmount},
+	}
+	for _, test := range tests {
+		if _, err := Parse(test.amount, test.currency); !errors.Is(err, test.want) {
+			t.Errorf("Parse(%q, %s) error = %v, want %v", test.amount, test.currency, err, test.want)
+		}
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     int64
+	}{
+		{19.99, "USD", 1999},
+		{0.1 + 0.2, "USD", 30},
+		{1.005, "USD", 101},
+		{1234.5, "JPY", 1235},
+		{0.0005, "BHD", 1},
+	}
+	for _, test := range tests {
+		got, err := FromFloat(test.amount, test.currency)
+		if err != nil {
+			t.Errorf("FromFloat(%v, %s): %v", test.amount, test.currency, err)
+			continue
+		}
+		if got.Amount != test.want {
+			t.Errorf("FromFloat(%v, %s) = %d, want %d", test.amount, test.currency, got.Amount, test.want)
+		}
+	}
+}
+
+func TestRoundToMinor(t *testing.T) {
+	tests := []struct {
+		value    *big.Rat
+		currency string
+		want     int64
+	}{
+		{big.NewRat(1, 3), "USD", 33},
+		{big.NewRat(2, 3), "USD", 67},
+		{big.NewRat(-1, 200), "USD", -1},
+		{big.NewRat(1, 2), "JPY", 1},
+		{big.NewRat(1, 2000), "OMR", 1},
+	}
+	for _, test := range tests {
+		got, err := RoundToMinor(test.value, test.currency)
+		if err != nil {
+			t.Errorf("RoundToMinor(%v, %s): %v", test.value, test.currency, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("RoundToMinor(%v, %s) = %d, want %d", test.value, test.currency, got, test.want)
+		}
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{New(1999, "USD"), "19.99"},
+		{New(5, "USD"), "0.05"},
+		{New(-5, "USD"), "-0.05"},
+		{New(1235, "JPY"), "1235"},
+		{New(1235, "KWD"), "1.235"},
+	}
+	for _, test := range tests {
+		if got := test.money.Decimal(); got != test.want {
+			t.Errorf("%#v.Decimal() = %q, want %q", test.money, got, test.want)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	sum, err := New(150, "USD").Add(New(250, "USD"))
+	if err != nil || sum != New(400, "USD") {
+		t.Errorf("Add = %v, %v, want 4.00 USD", sum, err)
+	}
+	difference, err := New(150, "USD").Sub(New(250, "USD"))
+	if err != nil || difference != New(-100, "USD") {
+		t.Errorf("Sub = %v, %v, want -1.00 USD", difference, err)
+	}
+	product, err := New(1999, "USD").Mul(3)
+	if err != nil || product != New(5997, "USD") {
+		t.Errorf("Mul = %v, %v, want 59.97 USD", product, err)
+	}
+	if _, err := New(1, "USD").Add(New(1, "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add across currencies error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+	if _, err := New(1, "USD").Cmp(New(1, "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Cmp across currencies error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+}
+
+func TestArithmeticOverflow(t *testing.T) {
+	tests := []struct {
+		name string
+		do   func() (Money, error)
+	}{
+		{"add", func() (Money, error) { return New(math.MaxInt64, "USD").Add(New(1, "USD")) }},
+		{"add negative", func() (Money, error) { return New(math.MinInt64, "USD").Add(New(-1, "USD")) }},
+		{"sub", func() (Money, error) { return New(math.MinInt64, "USD").Sub(New(1, "USD")) }},
+		{"sub negative", func() (Money, error) { return New(math.MaxInt64, "USD").Sub(New(-1, "USD")) }},
+		{"mul", func() (Money, error) { return New(math.MaxInt64/2+1, "USD").Mul(2) }},
+		{"mul large quantity", func() (Money, error) { return New(1999, "USD").Mul(math.MaxInt64 / 1000) }},
+		{"mul negative", func() (Money, error) { return New(math.MinInt64, "USD").Mul(-1) }},
+	}
+	for _, test := range tests {
+		if got, err := test.do(); !errors.Is(err, ErrOverflow) {
+			t.Errorf("%s = %v, %v, want %v", test.name, got, err, ErrOverflow)
+		}
+	}
+	if got, err := New(math.MaxInt64, "USD").Mul(1); err != nil || got.Amount != math.MaxInt64 {
+		t.Errorf("Mul(1) = %v, %v, want the amount unchanged", got, err)
+	}
+	if got, err := New(math.MaxInt64, "USD").Add(New(math.MinInt64, "USD")); err != nil || got.Amount != -1 {
+		t.Errorf("Add of opposite extremes = %v, %v, want -0.01 USD", got, err)
+	}
+}
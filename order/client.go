@@ -2,9 +2,12 @@ package order
 
 import (
 	"context"
+	"time"
 
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/order/pb/pb"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type OrderClient struct {
@@ -13,7 +16,11 @@ type OrderClient struct {
 }
 
 func NewOrderClient(url string) (*OrderClient, error) {
-	connection, err := grpc.Dial(url, grpc.WithInsecure())
+	connection, err := grpc.Dial(
+		url,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(util.AccessTokenClientInterceptor()),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -28,9 +35,60 @@ func (client *OrderClient) Close() {
 }
 
 // CreateOrUpdate Order
-func (client *OrderClient) CreateOrUpdateOrder(ctx context.Context, order *pb.Order) (*pb.CreateOrUpdateOrderResponse, error) {
+func (client *OrderClient) CreateOrUpdateOrder(ctx context.Context, order *pb.Order, currency string, couponCodes []string, shippingAddressID string) (*pb.CreateOrUpdateOrderResponse, error) {
 	response, err := client.client.CreateOrUpdateOrder(ctx, &pb.CreateOrUpdateOrderRequest{
-		Order: order,
+		Order:             order,
+		Currency:          currency,
+		CouponCodes:       couponCodes,
+		ShippingAddressId: shippingAddressID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Add, remove or replace the products of a pending order
+func (client *OrderClient) UpdateOrderItems(ctx context.Context, orderID string, operation string, products []*pb.OrderProduct) (*pb.UpdateOrderItemsResponse, error) {
+	response, err := client.client.UpdateOrderItems(ctx, &pb.UpdateOrderItemsRequest{
+		OrderId:   orderID,
+		Operation: operation,
+		Products:  products,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Apply a coupon to a pending order
+func (client *OrderClient) ApplyCoupon(ctx context.Context, orderID string, code string) (*pb.ApplyCouponResponse, error) {
+	response, err := client.client.ApplyCoupon(ctx, &pb.ApplyCouponRequest{
+		OrderId: orderID,
+		Code:    code,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Update Order Status
+func (client *OrderClient) UpdateOrderStatus(ctx context.Context, id string, status string) (*pb.UpdateOrderStatusResponse, error) {
+	response, err := client.client.UpdateOrderStatus(ctx, &pb.UpdateOrderStatusRequest{
+		Id:     id,
+		Status: status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List the changes made to the products of an order
+func (client *OrderClient) ListOrderItemChanges(ctx context.Context, orderID string) (*pb.ListOrderItemChangesResponse, error) {
+	response, err := client.client.ListOrderItemChanges(ctx, &pb.ListOrderItemChangesRequest{
+		OrderId: orderID,
 	})
 	if err != nil {
 		return nil, err
@@ -39,9 +97,10 @@ func (client *OrderClient) CreateOrUpdateOrder(ctx context.Context, order *pb.Or
 }
 
 // Get Order by ID
-func (client *OrderClient) GetOrderByID(ctx context.Context, id string) (*pb.GetOrderByIDResponse, error) {
+func (client *OrderClient) GetOrderByID(ctx context.Context, id string, currency string) (*pb.GetOrderByIDResponse, error) {
 	response, err := client.client.GetOrderByID(ctx, &pb.GetOrderByIDRequest{
-		Id: id,
+		Id:       id,
+		Currency: currency,
 	})
 	if err != nil {
 		return nil, err
@@ -49,13 +108,248 @@ func (client *OrderClient) GetOrderByID(ctx context.Context, id string) (*pb.Get
 	return response, nil
 }
 
-// List Orders for Account
-func (client *OrderClient) ListOrdersForAccount(ctx context.Context, accountId string) (*pb.GetOrdersForAccountResponse, error) {
+// List a page of the Orders of an Account, newest first
+func (client *OrderClient) ListOrdersForAccount(ctx context.Context, accountId string, take uint64, cursor string, currency string) (*pb.GetOrdersForAccountResponse, error) {
 	response, err := client.client.GetOrdersForAccount(ctx, &pb.GetOrdersForAccountRequest{
 		AccountId: accountId,
+		Currency:  currency,
+		Take:      take,
+		Cursor:    cursor,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Search orders, for admins
+func (client *OrderClient) SearchOrders(ctx context.Context, filter *pb.OrderFilter, take uint64, cursor string, currency string) (*pb.SearchOrdersResponse, error) {
+	response, err := client.client.SearchOrders(ctx, &pb.SearchOrdersRequest{
+		Filter:   filter,
+		Take:     take,
+		Cursor:   cursor,
+		Currency: currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List products most often bought together with a product
+func (client *OrderClient) ListCoPurchases(ctx context.Context, productID string, take uint64) (*pb.ListCoPurchasesResponse, error) {
+	response, err := client.client.ListCoPurchases(ctx, &pb.ListCoPurchasesRequest{
+		ProductId: productID,
+		Take:      take,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// CreateOrUpdate Promotion
+func (client *OrderClient) CreateOrUpdatePromotion(ctx context.Context, promotion *pb.Promotion) (*pb.CreateOrUpdatePromotionResponse, error) {
+	response, err := client.client.CreateOrUpdatePromotion(ctx, &pb.CreateOrUpdatePromotionRequest{
+		Promotion: promotion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List Promotions
+func (client *OrderClient) ListPromotions(ctx context.Context, skip uint64, take uint64) (*pb.ListPromotionsResponse, error) {
+	response, err := client.client.ListPromotions(ctx, &pb.ListPromotionsRequest{
+		Skip: skip,
+		Take: take,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Ship some or all of a paid order
+func (client *OrderClient) CreateShipment(ctx context.Context, orderID string, carrier string, trackingNumber string, items []*pb.ShipmentItem) (*pb.CreateShipmentResponse, error) {
+	response, err := client.client.CreateShipment(ctx, &pb.CreateShipmentRequest{
+		OrderId:        orderID,
+		Carrier:        carrier,
+		TrackingNumber: trackingNumber,
+		Items:          items,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Mark a shipment as delivered
+func (client *OrderClient) MarkDelivered(ctx context.Context, shipmentID string) (*pb.MarkDeliveredResponse, error) {
+	response, err := client.client.MarkDelivered(ctx, &pb.MarkDeliveredRequest{
+		ShipmentId: shipmentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List the shipments of an order
+func (client *OrderClient) ListShipments(ctx context.Context, orderID string) (*pb.ListShipmentsResponse, error) {
+	response, err := client.client.ListShipments(ctx, &pb.ListShipmentsRequest{
+		OrderId: orderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Quote shipping for an order, or for a cart of products priced in currency
+func (client *OrderClient) GetShippingQuotes(ctx context.Context, orderID string, products []*pb.OrderProduct, currency string, accountID string, shippingAddressID string, address *pb.ShippingAddress) (*pb.GetShippingQuotesResponse, error) {
+	response, err := client.client.GetShippingQuotes(ctx, &pb.GetShippingQuotesRequest{
+		OrderId:           orderID,
+		Products:          products,
+		Currency:          currency,
+		AccountId:         accountID,
+		ShippingAddressId: shippingAddressID,
+		Address:           address,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Ask to return products of a delivered order
+func (client *OrderClient) RequestReturn(ctx context.Context, orderID string, items []*pb.ReturnItem) (*pb.RequestReturnResponse, error) {
+	response, err := client.client.RequestReturn(ctx, &pb.RequestReturnRequest{
+		OrderId: orderID,
+		Items:   items,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Approve, reject or receive a return
+func (client *OrderClient) UpdateReturnStatus(ctx context.Context, id string, status string, note string) (*pb.UpdateReturnStatusResponse, error) {
+	response, err := client.client.UpdateReturnStatus(ctx, &pb.UpdateReturnStatusRequest{
+		Id:     id,
+		Status: status,
+		Note:   note,
 	})
 	if err != nil {
 		return nil, err
 	}
 	return response, nil
 }
+
+// Record the inspection of a received return, refunding the accepted units
+func (client *OrderClient) InspectReturn(ctx context.Context, id string, items []*pb.ReturnItem, note string) (*pb.InspectReturnResponse, error) {
+	response, err := client.client.InspectReturn(ctx, &pb.InspectReturnRequest{
+		Id:    id,
+		Items: items,
+		Note:  note,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Get Return
+func (client *OrderClient) GetReturn(ctx context.Context, id string) (*pb.GetReturnResponse, error) {
+	response, err := client.client.GetReturn(ctx, &pb.GetReturnRequest{
+		Id: id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List the returns of an order, or of every order
+func (client *OrderClient) ListReturns(ctx context.Context, orderID string, status string, skip uint64, take uint64) (*pb.ListReturnsResponse, error) {
+	response, err := client.client.ListReturns(ctx, &pb.ListReturnsRequest{
+		OrderId: orderID,
+		Status:  status,
+		Skip:    skip,
+		Take:    take,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Get Invoice, rendered as html or pdf if a format is given
+func (client *OrderClient) GetInvoice(ctx context.Context, id string, format string) (*pb.GetInvoiceResponse, error) {
+	response, err := client.client.GetInvoice(ctx, &pb.GetInvoiceRequest{
+		Id:     id,
+		Format: format,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List the invoices and credit notes of an order
+func (client *OrderClient) ListInvoices(ctx context.Context, orderID string) (*pb.ListInvoicesResponse, error) {
+	response, err := client.client.ListInvoices(ctx, &pb.ListInvoicesRequest{
+		OrderId: orderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List merchant orders, newest first
+func (client *OrderClient) ListMerchantOrders(ctx context.Context, merchantID string, orderID string, status string, take uint64, cursor string) (*pb.ListMerchantOrdersResponse, error) {
+	response, err := client.client.ListMerchantOrders(ctx, &pb.ListMerchantOrdersRequest{
+		MerchantId: merchantID,
+		OrderId:    orderID,
+		Status:     status,
+		Take:       take,
+		Cursor:     cursor,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Get the part of an order sold by a merchant
+func (client *OrderClient) GetMerchantOrder(ctx context.Context, orderID string, merchantID string) (*pb.GetMerchantOrderResponse, error) {
+	response, err := client.client.GetMerchantOrder(ctx, &pb.GetMerchantOrderRequest{
+		OrderId:    orderID,
+		MerchantId: merchantID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Get the payouts of a merchant for the orders placed in a period
+func (client *OrderClient) GetMerchantPayouts(ctx context.Context, merchantID string, from *time.Time, to *time.Time) (*pb.GetMerchantPayoutsResponse, error) {
+	request := &pb.GetMerchantPayoutsRequest{
+		MerchantId: merchantID,
+	}
+	if from != nil {
+		request.From = timestamppb.New(*from)
+	}
+	if to != nil {
+		request.To = timestamppb.New(*to)
+	}
+	response, err := client.client.GetMerchantPayouts(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -1,21 +1,30 @@
 package main
 
 import (
+	"context"
 	"log"
 	"time"
 
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/order"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/pricing"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/shipping"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/tax"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/tinrab/retry"
 )
 
 type Config struct {
-	DatabaseUrl string `envconfig:"DATABASE_URL"`
-	AccountUrl  string `envconfig:"ACCOUNT_SERVICE_URL"`
-	CatalogUrl  string `envconfig:"CATALOG_SERVICE_URL"`
-	Port        int    `envconfig:"GRPC_PORT" default:"8080"`
-	LogLevel    string `envconfig:"LOG_LEVEL" default:"info"`
+	DatabaseUrl        string        `envconfig:"DATABASE_URL"`
+	AccountUrl         string        `envconfig:"ACCOUNT_SERVICE_URL"`
+	CatalogUrl         string        `envconfig:"CATALOG_SERVICE_URL"`
+	Port               int           `envconfig:"GRPC_PORT" default:"8080"`
+	LogLevel           string        `envconfig:"LOG_LEVEL" default:"info"`
+	RatesFile          string        `envconfig:"EXCHANGE_RATES_FILE"`
+	TaxRatesFile       string        `envconfig:"TAX_RATES_FILE"`
+	ShippingRatesFile  string        `envconfig:"SHIPPING_RATES_FILE"`
+	CoPurchaseInterval time.Duration `envconfig:"CO_PURCHASE_INTERVAL" default:"1h"`
+	JwtSecret          string        `envconfig:"JWT_SECRET" default:"my-secret-key"`
 }
 
 func main() {
@@ -25,9 +34,21 @@ func main() {
 		log.Fatal(err)
 	}
 	logger := util.NewLogger(config.LogLevel)
+	rates, err := pricing.LoadRateTable(config.RatesFile)
+	if err != nil {
+		logger.Service().Fatal().Err(err).Msg("failed to load exchange rates")
+	}
+	taxRates, err := tax.LoadRateTable(config.TaxRatesFile)
+	if err != nil {
+		logger.Service().Fatal().Err(err).Msg("failed to load tax rates")
+	}
+	shippingRates, err := shipping.LoadTableRateProvider(config.ShippingRatesFile)
+	if err != nil {
+		logger.Service().Fatal().Err(err).Msg("failed to load shipping rates")
+	}
 	var repository order.Repository
 	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
-		repository, err = order.NewPostgresRepository(config.DatabaseUrl, logger)
+		repository, err = order.NewPostgresRepository(config.DatabaseUrl, taxRates, shippingRates, order.NewLogRestocker(logger), logger)
 		if err != nil {
 			logger.Service().Error().Err(err).Msg("failed to connect to database")
 			return err
@@ -36,7 +57,10 @@ func main() {
 	})
 	defer repository.Close()
 	logger.Service().Info().Msg("connected to database")
-	service := order.NewOrderService(repository)
+	service := order.NewOrderService(repository, pricing.NewPricingService(rates), shippingRates)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go order.RunCoPurchaseScheduler(ctx, service, config.CoPurchaseInterval, logger)
 	logger.Service().Info().Int("port", config.Port).Msg("starting order service")
-	log.Fatal(order.ListenGrpcServer(service, config.AccountUrl, config.CatalogUrl, logger, config.Port))
+	log.Fatal(order.ListenGrpcServer(service, config.AccountUrl, config.CatalogUrl, config.JwtSecret, logger, config.Port))
 }
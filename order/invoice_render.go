@@ -0,0 +1,140 @@
+package order
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"math/big"
+	"strings"
+	texttemplate "text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/tax"
+)
+
+const (
+	InvoiceFormatHTML = "html"
+	InvoiceFormatPDF  = "pdf"
+)
+
+// The HTML template renders invoices as a web page and the text template
+// lays them out in fixed-width columns for PDFs.
+//
+//go:embed templates/invoice.html templates/invoice.txt
+var invoiceTemplates embed.FS
+
+var invoiceTemplateFuncs = map[string]any{
+	"money": func(amount money.Money) string {
+		return amount.Decimal()
+	},
+	"date": func(at time.Time) string {
+		return at.UTC().Format("2 January 2006")
+	},
+	// rate formats the tax rate of a line as a percentage, marked with an
+	// asterisk if the tax is included in the price.
+	"rate": func(line *InvoiceLine) string {
+		if line.TaxRate == "" {
+			return ""
+		}
+		rate := line.TaxRate
+		if value, ok := new(big.Rat).SetString(rate); ok {
+			rate = tax.FormatRate(value.Mul(value, big.NewRat(100, 1))) + "%"
+		}
+		if line.TaxInclusive {
+			rate += "*"
+		}
+		return rate
+	},
+	"left": func(width int, text string) string {
+		return padText(text, width, false)
+	},
+	"right": func(width int, text string) string {
+		return padText(text, width, true)
+	},
+	"rule": func(width int) string {
+		return strings.Repeat("-", width)
+	},
+}
+
+var (
+	invoiceHTML = htmltemplate.Must(htmltemplate.New("invoice.html").Funcs(invoiceTemplateFuncs).ParseFS(invoiceTemplates, "templates/invoice.html"))
+	invoiceText = texttemplate.Must(texttemplate.New("invoice.txt").Funcs(invoiceTemplateFuncs).ParseFS(invoiceTemplates, "templates/invoice.txt"))
+)
+
+// InvoiceDocument is what an invoice is rendered from: the invoice, the
+// names of its merchant and of the order's account, and, for credit notes,
+// the number of the invoice corrected.
+type InvoiceDocument struct {
+	Invoice         *Invoice
+	MerchantName    string
+	CustomerName    string
+	CorrectedNumber string
+}
+
+func (document *InvoiceDocument) Title() string {
+	if document.Invoice.Kind == InvoiceKindCreditNote {
+		return "Credit note"
+	}
+	return "Invoice"
+}
+
+// AddedTax sums the taxes of the lines that are added to their prices, and
+// IncludedTax those that are part of them.
+func (document *InvoiceDocument) AddedTax() money.Money {
+	return document.sumTax(false)
+}
+
+func (document *InvoiceDocument) IncludedTax() money.Money {
+	return document.sumTax(true)
+}
+
+func (document *InvoiceDocument) sumTax(inclusive bool) money.Money {
+	sum := money.Zero(document.Invoice.Total.Currency)
+	for _, line := range document.Invoice.Lines {
+		if line.TaxInclusive == inclusive {
+			sum.Amount += line.Tax.Amount
+		}
+	}
+	return sum
+}
+
+// RenderInvoice renders document as html or pdf and returns it with its
+// content type.
+func RenderInvoice(document *InvoiceDocument, format string) ([]byte, string, error) {
+	var buffer bytes.Buffer
+	switch format {
+	case InvoiceFormatHTML:
+		if err := invoiceHTML.Execute(&buffer, document); err != nil {
+			return nil, "", fmt.Errorf("failed to render invoice %s: %w", document.Invoice.ID, err)
+		}
+		return buffer.Bytes(), "text/html; charset=utf-8", nil
+	case InvoiceFormatPDF:
+		var text strings.Builder
+		if err := invoiceText.Execute(&text, document); err != nil {
+			return nil, "", fmt.Errorf("failed to render invoice %s: %w", document.Invoice.ID, err)
+		}
+		title := document.Title() + " " + document.Invoice.Number
+		if err := writeTextPDF(&buffer, title, text.String()); err != nil {
+			return nil, "", fmt.Errorf("failed to render invoice %s: %w", document.Invoice.ID, err)
+		}
+		return buffer.Bytes(), "application/pdf", nil
+	}
+	return nil, "", fmt.Errorf("unknown invoice format %q, expected html or pdf", format)
+}
+
+// padText pads text with spaces to width characters, on the left if
+// alignRight is set, cutting it short if it is longer.
+func padText(text string, width int, alignRight bool) string {
+	length := utf8.RuneCountInString(text)
+	if length > width {
+		return string([]rune(text)[:width])
+	}
+	padding := strings.Repeat(" ", width-length)
+	if alignRight {
+		return padding + text
+	}
+	return text + padding
+}
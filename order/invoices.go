@@ -0,0 +1,230 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/tax"
+	"github.com/segmentio/ksuid"
+)
+
+// ErrInvoiceNotFound is returned when there is no invoice with the id given.
+var ErrInvoiceNotFound = errors.New("invoice not found")
+
+// invoiceNumber formats the sequence number of an invoice of kind, e.g.
+// INV-000042 or CN-000007.
+func invoiceNumber(kind InvoiceKind, sequence int64) string {
+	prefix := "INV"
+	if kind == InvoiceKindCreditNote {
+		prefix = "CN"
+	}
+	return fmt.Sprintf("%s-%06d", prefix, sequence)
+}
+
+// orderInvoices returns an invoice of a paid order for each merchant that
+// sold some of its lines, in the order of their first lines. Each invoice
+// bills the merchant's lines after their discounts, with their taxes; the
+// shipping fee is shared out among the invoices in proportion to what their
+// lines cost after discounts, so that the invoices add up to the order's
+// total. Invoices are not numbered yet.
+func orderInvoices(order *Order, issuedAt time.Time) ([]*Invoice, error) {
+	amounts, err := taxableAmounts(order)
+	if err != nil {
+		return nil, err
+	}
+	currency := order.TotalPrice.Currency
+	invoices := []*Invoice{}
+	byMerchant := map[string]int{}
+	net := []int64{}
+	for i, product := range order.Products {
+		index, ok := byMerchant[product.MerchantID]
+		if !ok {
+			index = len(invoices)
+			byMerchant[product.MerchantID] = index
+			invoices = append(invoices, newInvoice(InvoiceKindInvoice, order, product.MerchantID, issuedAt))
+			net = append(net, 0)
+		}
+		amount, err := product.Price.Mul(int64(product.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		line := &InvoiceLine{
+			ProductID:   product.ProductID,
+			Description: product.ProductName,
+			Quantity:    product.Quantity,
+			UnitPrice:   product.Price,
+			Amount:      amount,
+			Tax:         money.Zero(currency),
+		}
+		line.Discount = money.New(line.Amount.Amount-amounts[i].Amount, currency)
+		line.Total = amounts[i]
+		if product.Tax != nil {
+			line.Tax = product.Tax.Amount
+			line.TaxRate = tax.FormatRate(product.Tax.Rate)
+			line.TaxInclusive = product.Tax.Inclusive
+			if !product.Tax.Inclusive {
+				line.Total = money.New(line.Total.Amount+line.Tax.Amount, currency)
+			}
+		}
+		invoices[index].Lines = append(invoices[index].Lines, line)
+		net[index] += amounts[i].Amount
+	}
+	shipping := int64(0)
+	if order.ShippingMethod != "" {
+		shipping = order.ShippingFee.Amount
+	}
+	for i, share := range shareOut(shipping, net) {
+		invoices[i].Shipping = money.New(share, currency)
+		sumInvoice(invoices[i])
+	}
+	return invoices, nil
+}
+
+// creditNotes returns a credit note for each merchant with units accepted by
+// the inspection of orderReturn, a return of order. Each line credits the
+// refund of a return item, split into the share of its line's discount and
+// tax that the accepted units carry. invoices are the order's invoices,
+// which the credit notes correct. Credit notes are not numbered yet.
+func creditNotes(order *Order, orderReturn *Return, invoices []*Invoice, issuedAt time.Time) ([]*Invoice, error) {
+	currency := order.TotalPrice.Currency
+	products := map[string]*OrderProduct{}
+	for _, product := range order.Products {
+		products[product.ProductID] = product
+	}
+	notes := []*Invoice{}
+	byMerchant := map[string]*Invoice{}
+	for _, item := range orderReturn.Items {
+		product, ok := products[item.ProductID]
+		if !ok || item.AcceptedQuantity <= 0 {
+			continue
+		}
+		note, ok := byMerchant[product.MerchantID]
+		if !ok {
+			note = newInvoice(InvoiceKindCreditNote, order, product.MerchantID, issuedAt)
+			note.ReturnID = orderReturn.ID
+			for _, invoice := range invoices {
+				if invoice.Kind == InvoiceKindInvoice && invoice.MerchantID == product.MerchantID {
+					note.InvoiceID = invoice.ID
+				}
+			}
+			byMerchant[product.MerchantID] = note
+			notes = append(notes, note)
+		}
+		amount, err := product.Price.Mul(int64(item.AcceptedQuantity))
+		if err != nil {
+			return nil, err
+		}
+		line := &InvoiceLine{
+			ProductID:   product.ProductID,
+			Description: product.ProductName,
+			Quantity:    item.AcceptedQuantity,
+			UnitPrice:   product.Price,
+			Amount:      amount,
+			Tax:         money.Zero(currency),
+			Total:       item.Refund,
+		}
+		added := int64(0)
+		if product.Tax != nil {
+			line.Tax = money.New(unitsShare(product.Tax.Amount.Amount, item.AcceptedQuantity, product.Quantity), currency)
+			line.TaxRate = tax.FormatRate(product.Tax.Rate)
+			line.TaxInclusive = product.Tax.Inclusive
+			if !product.Tax.Inclusive {
+				added = line.Tax.Amount
+			}
+		}
+		line.Discount = money.New(max(line.Amount.Amount+added-line.Total.Amount, 0), currency)
+		note.Lines = append(note.Lines, line)
+	}
+	for _, note := range notes {
+		note.Shipping = money.Zero(currency)
+		sumInvoice(note)
+		// Rounding the tax shares may leave a minor unit between the lines
+		// and the refund; the refund is what was paid back.
+		note.Total = money.Zero(currency)
+		for _, line := range note.Lines {
+			note.Total.Amount += line.Total.Amount
+		}
+	}
+	return notes, nil
+}
+
+func newInvoice(kind InvoiceKind, order *Order, merchantID string, issuedAt time.Time) *Invoice {
+	invoice := &Invoice{
+		ID:         ksuid.New().String(),
+		Kind:       kind,
+		MerchantID: merchantID,
+		OrderID:    order.ID,
+		AccountID:  order.AccountID,
+		BillTo:     []string{},
+		Lines:      []*InvoiceLine{},
+		IssuedAt:   issuedAt,
+	}
+	if address := order.ShippingAddress; address != nil {
+		for _, line := range []string{
+			address.Name,
+			address.Line1,
+			address.Line2,
+			strings.Join(strings.Fields(address.PostalCode+" "+address.City+" "+address.Region), " "),
+			address.Country,
+		} {
+			if line = strings.TrimSpace(line); line != "" {
+				invoice.BillTo = append(invoice.BillTo, line)
+			}
+		}
+	}
+	return invoice
+}
+
+// sumInvoice sets the totals of invoice from its lines and shipping.
+func sumInvoice(invoice *Invoice) {
+	currency := invoice.Shipping.Currency
+	invoice.Subtotal = money.Zero(currency)
+	invoice.Discount = money.Zero(currency)
+	invoice.Tax = money.Zero(currency)
+	invoice.Total = money.New(invoice.Shipping.Amount, currency)
+	for _, line := range invoice.Lines {
+		invoice.Subtotal.Amount += line.Amount.Amount
+		invoice.Discount.Amount += line.Discount.Amount
+		invoice.Tax.Amount += line.Tax.Amount
+		invoice.Total.Amount += line.Amount.Amount - line.Discount.Amount
+		if !line.TaxInclusive {
+			invoice.Total.Amount += line.Tax.Amount
+		}
+	}
+}
+
+// shareOut splits amount in proportion to weights, rounding down and giving
+// what rounding leaves over to the first shares. Without any weight the
+// amount is split evenly.
+func shareOut(amount int64, weights []int64) []int64 {
+	shares := make([]int64, len(weights))
+	if len(weights) == 0 {
+		return shares
+	}
+	total := int64(0)
+	for _, weight := range weights {
+		total += weight
+	}
+	if total <= 0 {
+		weights = make([]int64, len(weights))
+		for i := range weights {
+			weights[i] = 1
+		}
+		total = int64(len(weights))
+	}
+	left := amount
+	for i, weight := range weights {
+		share := new(big.Int).Mul(big.NewInt(amount), big.NewInt(weight))
+		shares[i] = share.Quo(share, big.NewInt(total)).Int64()
+		left -= shares[i]
+	}
+	for i := 0; left > 0; i = (i + 1) % len(shares) {
+		shares[i]++
+		left--
+	}
+	return shares
+}
@@ -0,0 +1,200 @@
+package order
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/tax"
+)
+
+var invoiceTestTime = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// invoiceTestOrders returns paid orders of lines sold by two merchants,
+// with discounts, taxes and shipping fees that do not divide evenly.
+func invoiceTestOrders(t *testing.T) map[string]*Order {
+	t.Helper()
+	rates, err := tax.ReadRateTable(strings.NewReader("DE,,standard,0.19,true\nUS,CA,standard,0.0725,false\nUS,CA,food,0,false\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newOrder := func(currency string, location tax.Location, exempt bool, shippingFee int64, discounts ...*OrderDiscount) *Order {
+		order := &Order{
+			ID:        "order",
+			AccountID: "customer",
+			Status:    OrderStatusPaid,
+			Products: []*OrderProduct{
+				{ProductID: "lamp", ProductName: "Lamp", Price: money.New(1999, currency), Quantity: 2, MerchantID: "lights"},
+				{ProductID: "bulb", ProductName: "Bulb", Price: money.New(333, currency), Quantity: 3, MerchantID: "parts"},
+				{ProductID: "snack", ProductName: "Snack", Price: money.New(250, currency), Quantity: 1, MerchantID: "lights", TaxCategory: "food"},
+			},
+			Discounts:   discounts,
+			TaxLocation: location,
+			TaxExempt:   exempt,
+		}
+		if shippingFee > 0 {
+			order.ShippingMethod = "standard"
+			order.ShippingFee = money.New(shippingFee, currency)
+		}
+		if err := applyTaxes(order, rates); err != nil {
+			t.Fatal(err)
+		}
+		return order
+	}
+	california := tax.Location{Country: "US", Region: "CA"}
+	return map[string]*Order{
+		"untaxed":              newOrder("USD", tax.Location{}, false, 0),
+		"exclusive tax":        newOrder("USD", california, false, 0),
+		"inclusive tax":        newOrder("EUR", tax.Location{Country: "DE"}, false, 0),
+		"tax exempt":           newOrder("USD", california, true, 499),
+		"shipping":             newOrder("USD", california, false, 1001),
+		"line discount":        newOrder("USD", california, false, 499, &OrderDiscount{ProductID: "bulb", Amount: money.New(100, "USD")}),
+		"shared discount":      newOrder("USD", california, false, 499, &OrderDiscount{Amount: money.New(1001, "USD")}),
+		"stacked discounts":    newOrder("EUR", tax.Location{Country: "DE"}, false, 777, &OrderDiscount{ProductID: "lamp", Amount: money.New(500, "EUR")}, &OrderDiscount{Amount: money.New(333, "EUR")}),
+		"discounted to nought": newOrder("USD", california, false, 499, &OrderDiscount{Amount: money.New(5247, "USD")}),
+	}
+}
+
+func TestOrderInvoicesAddUpToTheOrder(t *testing.T) {
+	for name, order := range invoiceTestOrders(t) {
+		invoices, err := orderInvoices(order, invoiceTestTime)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		merchants := []string{}
+		var subtotal, discount, taxTotal, shipping, total int64
+		for _, invoice := range invoices {
+			merchants = append(merchants, invoice.MerchantID)
+			if invoice.Kind != InvoiceKindInvoice || invoice.OrderID != order.ID || invoice.AccountID != order.AccountID {
+				t.Errorf("%s: invoice of merchant %s is a %s of order %s for %s", name, invoice.MerchantID, invoice.Kind, invoice.OrderID, invoice.AccountID)
+			}
+			for _, line := range invoice.Lines {
+				if line.Amount.Amount != line.UnitPrice.Amount*int64(line.Quantity) {
+					t.Errorf("%s: line %s amount %v is not %d units of %v", name, line.ProductID, line.Amount, line.Quantity, line.UnitPrice)
+				}
+			}
+			added := int64(0)
+			for _, line := range invoice.Lines {
+				if !line.TaxInclusive {
+					added += line.Tax.Amount
+				}
+			}
+			if want := invoice.Subtotal.Amount - invoice.Discount.Amount + added + invoice.Shipping.Amount; invoice.Total.Amount != want {
+				t.Errorf("%s: invoice of merchant %s totals %v, want %d", name, invoice.MerchantID, invoice.Total, want)
+			}
+			subtotal += invoice.Subtotal.Amount
+			discount += invoice.Discount.Amount
+			taxTotal += invoice.Tax.Amount
+			shipping += invoice.Shipping.Amount
+			total += invoice.Total.Amount
+		}
+		if !slices.Equal(merchants, []string{"lights", "parts"}) {
+			t.Errorf("%s: invoices of merchants %v, want lights and parts", name, merchants)
+		}
+		if subtotal != order.Subtotal.Amount || discount != order.DiscountTotal.Amount || taxTotal != order.TaxTotal.Amount || shipping != order.ShippingFee.Amount || total != order.TotalPrice.Amount {
+			t.Errorf("%s: invoices sum to subtotal %d, discount %d, tax %d, shipping %d, total %d; order has %v, %v, %v, %v, %v",
+				name, subtotal, discount, taxTotal, shipping, total, order.Subtotal, order.DiscountTotal, order.TaxTotal, order.ShippingFee, order.TotalPrice)
+		}
+	}
+}
+
+func TestCreditNotesAddUpToTheRefunds(t *testing.T) {
+	for name, order := range invoiceTestOrders(t) {
+		invoices, err := orderInvoices(order, invoiceTestTime)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		for i, invoice := range invoices {
+			invoice.ID = "invoice-" + invoice.MerchantID
+			invoice.Number = invoiceNumber(invoice.Kind, int64(i+1))
+		}
+		// Return the lines in two parts, so that every unit is refunded.
+		returns := []*Return{
+			{ID: "first", Items: []*ReturnItem{{ProductID: "lamp", AcceptedQuantity: 1}, {ProductID: "bulb", AcceptedQuantity: 2}}},
+			{ID: "second", Items: []*ReturnItem{{ProductID: "lamp", AcceptedQuantity: 1}, {ProductID: "bulb", AcceptedQuantity: 1}, {ProductID: "snack", AcceptedQuantity: 1}}},
+		}
+		refunded := int64(0)
+		for _, orderReturn := range returns {
+			if err := refundReturn(order, returns, orderReturn); err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			orderReturn.Status = ReturnStatusInspected
+			notes, err := creditNotes(order, orderReturn, invoices, invoiceTestTime)
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			credited := int64(0)
+			for _, note := range notes {
+				if note.Kind != InvoiceKindCreditNote || note.ReturnID != orderReturn.ID || note.InvoiceID != "invoice-"+note.MerchantID {
+					t.Errorf("%s: credit note of merchant %s is a %s of return %s correcting %s", name, note.MerchantID, note.Kind, note.ReturnID, note.InvoiceID)
+				}
+				lines := int64(0)
+				for _, line := range note.Lines {
+					lines += line.Total.Amount
+				}
+				if note.Total.Amount != lines || note.Shipping.Amount != 0 {
+					t.Errorf("%s: credit note of merchant %s totals %v with %v shipping, want its lines' %d", name, note.MerchantID, note.Total, note.Shipping, lines)
+				}
+				credited += note.Total.Amount
+			}
+			if credited != orderReturn.Refund.Amount {
+				t.Errorf("%s: return %s credits %d, want its refund %v", name, orderReturn.ID, credited, orderReturn.Refund)
+			}
+			refunded += credited
+		}
+		shipping := int64(0)
+		for _, invoice := range invoices {
+			shipping += invoice.Shipping.Amount
+		}
+		if refunded != order.TotalPrice.Amount-shipping {
+			t.Errorf("%s: returning every unit credits %d, want the order's %v less %d shipping", name, refunded, order.TotalPrice, shipping)
+		}
+	}
+}
+
+func TestInvoiceNumber(t *testing.T) {
+	tests := []struct {
+		kind     InvoiceKind
+		sequence int64
+		want     string
+	}{
+		{InvoiceKindInvoice, 1, "INV-000001"},
+		{InvoiceKindInvoice, 42, "INV-000042"},
+		{InvoiceKindCreditNote, 7, "CN-000007"},
+		{InvoiceKindInvoice, 1234567, "INV-1234567"},
+	}
+	for _, test := range tests {
+		if got := invoiceNumber(test.kind, test.sequence); got != test.want {
+			t.Errorf("invoiceNumber(%s, %d) = %q, want %q", test.kind, test.sequence, got, test.want)
+		}
+	}
+}
+
+func TestShareOut(t *testing.T) {
+	tests := []struct {
+		amount  int64
+		weights []int64
+		want    []int64
+	}{
+		{1000, []int64{1, 1}, []int64{500, 500}},
+		{1001, []int64{1, 1}, []int64{501, 500}},
+		{100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{499, []int64{3000, 1000}, []int64{375, 124}},
+		{10, []int64{0, 0}, []int64{5, 5}},
+		{10, []int64{0, 7}, []int64{0, 10}},
+		{0, []int64{5, 5}, []int64{0, 0}},
+		{10, nil, []int64{}},
+	}
+	for _, test := range tests {
+		got := shareOut(test.amount, test.weights)
+		if !slices.Equal(got, test.want) {
+			t.Errorf("shareOut(%d, %v) = %v, want %v", test.amount, test.weights, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package order
+
+import (
+	"fmt"
+	"time"
+)
+
+// applyItemChanges applies operation with products to the lines of order,
+// recomputes its total and returns the changes made to each line. Lines keep
+// their position, and added lines follow in the order given.
+func applyItemChanges(order *Order, operation ItemOperation, products []*OrderProduct, changedAt time.Time) ([]*OrderItemChange, error) {
+	if order.Status != OrderStatusPending {
+		return nil, fmt.Errorf("%w: order %s is %s", ErrOrderNotPending, order.ID, order.Status)
+	}
+	previous := map[string]*OrderProduct{}
+	lines := map[string]*OrderProduct{}
+	for _, line := range order.Products {
+		previous[line.ProductID] = line
+		updated := *line
+		lines[line.ProductID] = &updated
+	}
+	switch operation {
+	case ItemOperationReplace:
+		lines = map[string]*OrderProduct{}
+		for _, product := range products {
+			line := *product
+			lines[product.ProductID] = &line
+		}
+	case ItemOperationAdd:
+		for _, product := range products {
+			if line, ok := lines[product.ProductID]; ok {
+				line.Quantity += product.Quantity
+				continue
+			}
+			line := *product
+			lines[product.ProductID] = &line
+		}
+	case ItemOperationRemove:
+		for _, product := range products {
+			line, ok := lines[product.ProductID]
+			if !ok {
+				return nil, fmt.Errorf("product %s is not on order %s", product.ProductID, order.ID)
+			}
+			if product.Quantity == 0 || product.Quantity >= line.Quantity {
+				delete(lines, product.ProductID)
+				continue
+			}
+			line.Quantity -= product.Quantity
+		}
+	default:
+		return nil, fmt.Errorf("invalid item operation %q", operation)
+	}
+
+	updated := []*OrderProduct{}
+	changes := []*OrderItemChange{}
+	for _, old := range order.Products {
+		line, ok := lines[old.ProductID]
+		if !ok {
+			changes = append(changes, &OrderItemChange{
+				OrderID:     order.ID,
+				ProductID:   old.ProductID,
+				Operation:   operation,
+				OldQuantity: old.Quantity,
+				Price:       old.Price,
+				ChangedAt:   changedAt,
+			})
+			continue
+		}
+		updated = append(updated, line)
+		if line.Quantity != old.Quantity || line.Price != old.Price {
+			changes = append(changes, &OrderItemChange{
+				OrderID:     order.ID,
+				ProductID:   line.ProductID,
+				Operation:   operation,
+				OldQuantity: old.Quantity,
+				NewQuantity: line.Quantity,
+				Price:       line.Price,
+				ChangedAt:   changedAt,
+			})
+		}
+	}
+	for _, product := range products {
+		line, ok := lines[product.ProductID]
+		if !ok || previous[product.ProductID] != nil {
+			continue
+		}
+		updated = append(updated, line)
+		changes = append(changes, &OrderItemChange{
+			OrderID:     order.ID,
+			ProductID:   line.ProductID,
+			Operation:   operation,
+			NewQuantity: line.Quantity,
+			Price:       line.Price,
+			ChangedAt:   changedAt,
+		})
+	}
+	for _, line := range updated {
+		line.OrderID = order.ID
+	}
+
+	totalPrice, err := orderTotal(updated)
+	if err != nil {
+		return nil, err
+	}
+	order.Products = updated
+	order.TotalPrice = totalPrice
+	return changes, nil
+}
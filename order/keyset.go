@@ -0,0 +1,59 @@
+package order
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrInvalidCursor is returned when a cursor was not given out by the
+// listing it is used with: one with the same filter and sort.
+var ErrInvalidCursor = errors.New("cursor is invalid")
+
+// keysetCursor is the state behind order cursors: the listing it belongs
+// to, the sort key of the order the cursor points at and its position in
+// the listing. Orders are sorted by creation time or total price, then by
+// id, and merchant orders by the creation time and id of their order, then
+// by merchant. Cursors do not expire.
+type keysetCursor struct {
+	Listing    string    `json:"listing"`
+	CreatedAt  time.Time `json:"created_at"`
+	TotalPrice int64     `json:"total_price,omitempty"`
+	ID         string    `json:"id"`
+	MerchantID string    `json:"merchant_id,omitempty"`
+	Offset     uint64    `json:"offset"`
+}
+
+func encodeKeysetCursor(cursor *keysetCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeKeysetCursor returns the state behind a cursor of listing.
+func decodeKeysetCursor(cursor string, listing string) (*keysetCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	state := &keysetCursor{}
+	if err := json.Unmarshal(data, state); err != nil || state.Listing != listing || state.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	return state, nil
+}
+
+// listingKey identifies a listing by its filter and sort, so that its
+// cursors are not followed in another listing.
+func listingKey(arguments ...interface{}) (string, error) {
+	data, err := json.Marshal(arguments)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12]), nil
+}
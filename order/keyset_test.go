@@ -0,0 +1,44 @@
+package order
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKeysetCursorsOnlyContinueTheirListing(t *testing.T) {
+	newest, err := listingKey("orders", OrderFilter{AccountID: "customer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursor, err := encodeKeysetCursor(&keysetCursor{Listing: newest, CreatedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), ID: "order", Offset: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, err := decodeKeysetCursor(cursor, newest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.ID != "order" || state.Offset != 10 || !state.CreatedAt.Equal(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("decoded cursor %+v, want the order's", state)
+	}
+	others := map[string][]interface{}{
+		"another sort":    {"orders", OrderFilter{AccountID: "customer", Sort: OrderSortTotalDesc}},
+		"another filter":  {"orders", OrderFilter{AccountID: "someone else"}},
+		"another listing": {"merchant orders", MerchantOrderFilter{}},
+	}
+	for name, arguments := range others {
+		listing, err := listingKey(arguments...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := decodeKeysetCursor(cursor, listing); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("%s: decoding returned %v, want %v", name, err, ErrInvalidCursor)
+		}
+	}
+	for _, invalid := range []string{"not a cursor", "e30"} {
+		if _, err := decodeKeysetCursor(invalid, newest); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("decoding %q returned %v, want %v", invalid, err, ErrInvalidCursor)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package order
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+// ErrMerchantOrderNotFound is returned when an order has no lines of the
+// merchant given.
+var ErrMerchantOrderNotFound = errors.New("merchant order not found")
+
+// merchantOrders splits order into a merchant order for each merchant that
+// sold some of its lines, in the order of their first lines, with the
+// amounts of the invoices the order is billed with. shipments are the
+// order's shipments, which the statuses of the merchant orders follow.
+// Refunds are not set.
+func merchantOrders(order *Order, shipments []*Shipment, at time.Time) ([]*MerchantOrder, error) {
+	invoices, err := orderInvoices(order, at)
+	if err != nil {
+		return nil, err
+	}
+	currency := order.TotalPrice.Currency
+	result := make([]*MerchantOrder, 0, len(invoices))
+	for _, invoice := range invoices {
+		merchantOrder := &MerchantOrder{
+			OrderID:    order.ID,
+			MerchantID: invoice.MerchantID,
+			AccountID:  order.AccountID,
+			Status:     merchantOrderStatus(order, invoice.MerchantID, shipments),
+			Products:   []*OrderProduct{},
+			Subtotal:   invoice.Subtotal,
+			Discount:   invoice.Discount,
+			Tax:        invoice.Tax,
+			Shipping:   invoice.Shipping,
+			Total:      invoice.Total,
+			Refunded:   money.Zero(currency),
+			CreatedAt:  order.CreatedAt,
+			UpdatedAt:  at,
+		}
+		for _, product := range order.Products {
+			if product.MerchantID == invoice.MerchantID {
+				merchantOrder.Products = append(merchantOrder.Products, product)
+			}
+		}
+		result = append(result, merchantOrder)
+	}
+	return result, nil
+}
+
+// merchantOrderStatus returns the status of the lines of order sold by
+// merchantID: the order's until every unit of them has shipped, then
+// shipped, and delivered once every shipment holding some of them has been
+// delivered.
+func merchantOrderStatus(order *Order, merchantID string, shipments []*Shipment) OrderStatus {
+	if order.Status != OrderStatusShipped && order.Status != OrderStatusDelivered {
+		return order.Status
+	}
+	lines := map[string]bool{}
+	left := unshippedQuantities(order, shipments)
+	for _, product := range order.Products {
+		if product.MerchantID != merchantID {
+			continue
+		}
+		if left[product.ProductID] > 0 {
+			return OrderStatusPaid
+		}
+		lines[product.ProductID] = true
+	}
+	for _, shipment := range shipments {
+		if shipment.Status == ShipmentStatusDelivered {
+			continue
+		}
+		for _, item := range shipment.Items {
+			if lines[item.ProductID] {
+				return OrderStatusShipped
+			}
+		}
+	}
+	return OrderStatusDelivered
+}
@@ -0,0 +1,9 @@
+-- Adds the merchant of each ordered product to an existing order database,
+-- which invoices need. Products ordered before have no merchant, so their
+-- lines are invoiced together under an empty merchant. Re-run order/up.sql
+-- afterwards for the invoice tables. Safe to run more than once.
+BEGIN;
+
+ALTER TABLE order_products ADD COLUMN IF NOT EXISTS merchantId VARCHAR(27) NOT NULL DEFAULT '';
+
+COMMIT;
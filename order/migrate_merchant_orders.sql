@@ -0,0 +1,50 @@
+-- Records the merchant of shipments and splits existing orders into merchant
+-- orders. Run order/up.sql first for the merchant_orders table. Discounts
+-- and shipping fees are shared out in proportion to what the merchants'
+-- lines cost before discounts, so the split of an order with several
+-- merchants can differ by a minor unit or so from the one new orders get;
+-- merchant orders take their order's status. Safe to run more than once.
+BEGIN;
+
+ALTER TABLE shipments ADD COLUMN IF NOT EXISTS merchantId VARCHAR(27) NOT NULL DEFAULT '';
+
+WITH lines AS (
+  SELECT
+    op.orderId,
+    op.merchantId,
+    SUM(op.price * op.quantity) AS subtotal,
+    COALESCE(SUM(ot.amount), 0) AS tax,
+    COALESCE(SUM(ot.amount) FILTER (WHERE NOT ot.inclusive), 0) AS addedTax
+  FROM order_products op
+  LEFT JOIN order_taxes ot ON (ot.orderId = op.orderId AND ot.productId = op.productId)
+  GROUP BY op.orderId, op.merchantId
+), shares AS (
+  SELECT
+    lines.*,
+    CASE
+      WHEN SUM(subtotal) OVER orderLines > 0 THEN subtotal::NUMERIC / SUM(subtotal) OVER orderLines
+      ELSE 1.0 / COUNT(*) OVER orderLines
+    END AS share
+  FROM lines
+  WINDOW orderLines AS (PARTITION BY orderId)
+)
+INSERT INTO merchant_orders (orderId, merchantId, accountId, status, subtotal, discount, tax, shipping, total, refunded, currency, createdAt, updatedAt)
+SELECT
+  o.id,
+  s.merchantId,
+  o.accountId,
+  o.status,
+  s.subtotal,
+  ROUND(o.discount * s.share),
+  s.tax,
+  ROUND(o.shippingFee * s.share),
+  s.subtotal - ROUND(o.discount * s.share) + s.addedTax + ROUND(o.shippingFee * s.share),
+  COALESCE((SELECT SUM(i.total) FROM invoices i WHERE i.orderId = o.id AND i.merchantId = s.merchantId AND i.kind = 'credit_note'), 0),
+  o.currency,
+  o.createdAt,
+  CURRENT_TIMESTAMP
+FROM shares s
+JOIN orders o ON (o.id = s.orderId)
+ON CONFLICT (orderId, merchantId) DO NOTHING;
+
+COMMIT;
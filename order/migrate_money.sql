@@ -0,0 +1,21 @@
+-- Migrates an existing order database from NUMERIC(19,4) prices in major
+-- units to BIGINT minor units. Orders created before currencies were tracked
+-- are assumed to be USD. Amounts are rounded half away from zero to cents,
+-- the same rule money.Parse applies. Safe to run more than once.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE orders ALTER COLUMN currency DROP DEFAULT;
+
+DO $$ BEGIN
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'orders' AND column_name = 'totalprice') = 'numeric' THEN
+        ALTER TABLE orders ALTER COLUMN totalPrice TYPE BIGINT USING ROUND(totalPrice * 100);
+    END IF;
+    IF (SELECT data_type FROM information_schema.columns
+        WHERE table_name = 'order_products' AND column_name = 'price') = 'numeric' THEN
+        ALTER TABLE order_products ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100);
+    END IF;
+END $$;
+
+COMMIT;
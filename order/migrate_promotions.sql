@@ -0,0 +1,10 @@
+-- Adds the columns promotions need to an existing order database: the
+-- discount taken off each order and the category of each ordered product.
+-- Products ordered before categories were kept only match promotions that
+-- are not limited to categories. Safe to run more than once.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE order_products ADD COLUMN IF NOT EXISTS categoryId VARCHAR(64) NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,5 @@
+-- Widens order tax regions to hold the regions of shipping addresses, which
+-- orders are taxed at unless given a tax location of their own. The new
+-- shipping and shipment tables come from re-running up.sql. Safe to run more
+-- than once.
+ALTER TABLE orders ALTER COLUMN taxRegion TYPE VARCHAR(64);
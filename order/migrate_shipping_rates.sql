@@ -0,0 +1,11 @@
+-- Adds the columns shipping rates need to an existing order database: the
+-- shipping method and fee of each order and the weight of each ordered
+-- product. Existing orders have no shipping method and no shipping fee.
+-- Safe to run more than once.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shippingMethod VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shippingFee BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE order_products ADD COLUMN IF NOT EXISTS weight INT NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,3 @@
+-- Adds the status of orders to an existing order database. Orders placed
+-- before statuses were tracked are left pending. Safe to run more than once.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'pending';
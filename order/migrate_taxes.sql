@@ -0,0 +1,12 @@
+-- Adds the columns taxes need to an existing order database: where each order
+-- is taxed, whether its account was exempt and the tax category of each
+-- ordered product. Existing orders have no tax location and stay untaxed.
+-- Safe to run more than once.
+BEGIN;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS taxCountry VARCHAR(2) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS taxRegion VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS taxExempt BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE order_products ADD COLUMN IF NOT EXISTS taxCategory VARCHAR(32) NOT NULL DEFAULT '';
+
+COMMIT;
@@ -1,20 +1,471 @@
 package order
 
-import "time"
+import (
+	"math/big"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/pricing"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/tax"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "pending"
+	OrderStatusPaid      OrderStatus = "paid"
+	OrderStatusShipped   OrderStatus = "shipped"
+	OrderStatusDelivered OrderStatus = "delivered"
+	OrderStatusCancelled OrderStatus = "cancelled"
+)
+
+// orderStatusTransitions lists the statuses each status can move to.
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:    {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped: {OrderStatusDelivered},
+}
+
+func (status OrderStatus) Valid() bool {
+	switch status {
+	case OrderStatusPending, OrderStatusPaid, OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// Payable reports whether merchants are paid out for orders in status: those
+// that have been paid and not cancelled.
+func (status OrderStatus) Payable() bool {
+	return status == OrderStatusPaid || status == OrderStatusShipped || status == OrderStatusDelivered
+}
+
+// CanBecome reports whether an order in status can be moved to next.
+func (status OrderStatus) CanBecome(next OrderStatus) bool {
+	for _, allowed := range orderStatusTransitions[status] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
 
 type Order struct {
 	ID         string          `json:"id"`
 	CreatedAt  time.Time       `json:"createdAt"`
 	AccountID  string          `json:"accountId"`
-	TotalPrice float64         `json:"totalPrice"`
+	TotalPrice money.Money     `json:"totalPrice"`
 	Products   []*OrderProduct `json:"products"`
+	// Status is pending until the order is paid. Only pending orders can
+	// change their products.
+	Status OrderStatus `json:"status"`
+	// Subtotal is the sum of the order's lines. Discounts are taken off it,
+	// DiscountTotal in all, and taxes that are not included in prices and
+	// the shipping fee are added to give TotalPrice. TaxTotal sums the taxes
+	// of the lines, included or not.
+	Subtotal      money.Money      `json:"subtotal"`
+	Discounts     []*OrderDiscount `json:"discounts"`
+	DiscountTotal money.Money      `json:"discountTotal"`
+	TaxTotal      money.Money      `json:"taxTotal"`
+	// TaxLocation is where the order is taxed. Orders without one, and
+	// orders of accounts that were tax exempt when they ordered, are not
+	// taxed.
+	TaxLocation tax.Location `json:"taxLocation"`
+	TaxExempt   bool         `json:"taxExempt"`
+	// CouponCodes are the coupons applied to the order. A coupon only gives a
+	// discount while the order is eligible for it.
+	CouponCodes []string `json:"couponCodes"`
+	// ExchangeRates snapshots the rates used to price lines in the order currency.
+	ExchangeRates []*pricing.Rate `json:"exchangeRates"`
+	// ShippingAddress is where the order ships to, if it has been given one.
+	ShippingAddress *ShippingAddress `json:"shippingAddress,omitempty"`
+	// ShippingMethod is the shipping method the order ships with, and
+	// ShippingFee what it charges, requoted whenever the order is priced.
+	// Orders without a method have no shipping fee.
+	ShippingMethod string      `json:"shippingMethod,omitempty"`
+	ShippingFee    money.Money `json:"shippingFee"`
+}
+
+// OrderSort is the order search results are listed in.
+type OrderSort string
+
+const (
+	// OrderSortNewest puts the most recently placed orders first.
+	OrderSortNewest OrderSort = ""
+	OrderSortOldest OrderSort = "oldest"
+	// OrderSortTotalDesc and OrderSortTotalAsc sort by total price in minor
+	// units, which only compares orders of one currency, so searches sorted
+	// by total must filter by currency.
+	OrderSortTotalDesc OrderSort = "total_desc"
+	OrderSortTotalAsc  OrderSort = "total_asc"
+)
+
+func (sort OrderSort) Valid() bool {
+	switch sort {
+	case OrderSortNewest, OrderSortOldest, OrderSortTotalDesc, OrderSortTotalAsc:
+		return true
+	}
+	return false
+}
+
+// OrderFilter narrows an order search; zero fields match every order.
+type OrderFilter struct {
+	// Statuses matches orders in any of them.
+	Statuses  []OrderStatus
+	AccountID string
+	// ProductID matches orders with a line of the product.
+	ProductID string
+	// CreatedFrom and CreatedTo match orders placed at or after CreatedFrom
+	// and before CreatedTo.
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Currency matches orders placed in it. MinTotal and MaxTotal bound the
+	// total price, inclusive, and only match orders in their currency.
+	Currency string
+	MinTotal *money.Money
+	MaxTotal *money.Money
+	Sort     OrderSort
+}
+
+// OrderPage is one page of an order search.
+type OrderPage struct {
+	Orders []*Order
+	// Cursors[i] continues the search after Orders[i].
+	Cursors []string
+	// NextCursor continues the search after this page; it is empty on the
+	// last page.
+	NextCursor string
+	TotalCount uint64
+}
+
+// ShippingAddress is a copy of an address from the account's address book,
+// taken when the order was placed so later edits to the address book do not
+// change where the order goes.
+type ShippingAddress struct {
+	// AddressID is the address book entry it was copied from.
+	AddressID  string `json:"addressId"`
+	Name       string `json:"name"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
+	Phone      string `json:"phone"`
 }
 
 type OrderProduct struct {
-	OrderID            string  `json:"orderId"`
-	ProductID          string  `json:"productId"`
-	ProductName        string  `json:"productName"`
-	ProductDescription string  `json:"productDescription"`
-	Price              float64 `json:"price"`
-	Quantity           int32   `json:"quantity"`
+	OrderID            string      `json:"orderId"`
+	ProductID          string      `json:"productId"`
+	ProductName        string      `json:"productName"`
+	ProductDescription string      `json:"productDescription"`
+	Price              money.Money `json:"price"`
+	Quantity           int32       `json:"quantity"`
+	// CategoryID is the product's category when it was ordered, which
+	// promotions can be limited to.
+	CategoryID string `json:"categoryId"`
+	// TaxCategory is the product's tax category when it was ordered, and Tax
+	// the tax on the line, if any.
+	TaxCategory string    `json:"taxCategory"`
+	Tax         *OrderTax `json:"tax,omitempty"`
+	// Weight is the product's shipping weight in grams when it was ordered.
+	Weight uint32 `json:"weight"`
+	// MerchantID is the account that sold the product, which invoices the
+	// line.
+	MerchantID string `json:"merchantId"`
+}
+
+// OrderTax is the tax on an order line, at the rate for the order's tax
+// location and the line's tax category.
+type OrderTax struct {
+	Country   string   `json:"country"`
+	Region    string   `json:"region"`
+	Category  string   `json:"category"`
+	Rate      *big.Rat `json:"rate"`
+	Inclusive bool     `json:"inclusive"`
+	// Amount is the tax on what the line costs after its discounts.
+	Amount money.Money `json:"amount"`
+}
+
+// ItemOperation is how UpdateOrderItems applies the given lines to an order.
+type ItemOperation string
+
+const (
+	// ItemOperationReplace makes the given lines the order's only lines.
+	ItemOperationReplace ItemOperation = "replace"
+	// ItemOperationAdd adds the given lines. Adding a product already on the
+	// order raises the quantity of its line, at the line's price.
+	ItemOperationAdd ItemOperation = "add"
+	// ItemOperationRemove lowers the quantity of lines by the given quantities
+	// and drops lines that reach zero. A quantity of zero drops the line.
+	ItemOperationRemove ItemOperation = "remove"
+)
+
+func (operation ItemOperation) Valid() bool {
+	switch operation {
+	case ItemOperationReplace, ItemOperationAdd, ItemOperationRemove:
+		return true
+	}
+	return false
+}
+
+// OrderItemChange records a change to one line of an order. OldQuantity is 0
+// for added lines and NewQuantity is 0 for removed ones. Price is the unit
+// price of the line after the change, or before it for removed lines.
+type OrderItemChange struct {
+	OrderID     string        `json:"orderId"`
+	ProductID   string        `json:"productId"`
+	Operation   ItemOperation `json:"operation"`
+	OldQuantity int32         `json:"oldQuantity"`
+	NewQuantity int32         `json:"newQuantity"`
+	Price       money.Money   `json:"price"`
+	ChangedAt   time.Time     `json:"changedAt"`
+}
+
+type ShipmentStatus string
+
+const (
+	ShipmentStatusShipped   ShipmentStatus = "shipped"
+	ShipmentStatusDelivered ShipmentStatus = "delivered"
+)
+
+// Shipment is a parcel sent for an order. Orders can ship in several
+// shipments, each with some of the units of some of their lines.
+type Shipment struct {
+	ID      string `json:"id"`
+	OrderID string `json:"orderId"`
+	// MerchantID is the merchant who sent the shipment, which only holds
+	// units of the merchant's lines; it is empty for shipments sent by
+	// admins.
+	MerchantID     string          `json:"merchantId,omitempty"`
+	Carrier        string          `json:"carrier"`
+	TrackingNumber string          `json:"trackingNumber"`
+	Status         ShipmentStatus  `json:"status"`
+	Items          []*ShipmentItem `json:"items"`
+	ShippedAt      time.Time       `json:"shippedAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`
+}
+
+// ShipmentItem is the number of units of an order line in a shipment.
+type ShipmentItem struct {
+	ProductID string `json:"productId"`
+	Quantity  int32  `json:"quantity"`
+}
+
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested ReturnStatus = "requested"
+	ReturnStatusApproved  ReturnStatus = "approved"
+	ReturnStatusRejected  ReturnStatus = "rejected"
+	ReturnStatusReceived  ReturnStatus = "received"
+	ReturnStatusInspected ReturnStatus = "inspected"
+)
+
+// returnStatusTransitions lists the statuses each return status can move to.
+// Returns only become inspected through InspectReturn, which sets their
+// refund.
+var returnStatusTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnStatusRequested: {ReturnStatusApproved, ReturnStatusRejected},
+	ReturnStatusApproved:  {ReturnStatusReceived},
+	ReturnStatusReceived:  {ReturnStatusInspected},
+}
+
+func (status ReturnStatus) Valid() bool {
+	switch status {
+	case ReturnStatusRequested, ReturnStatusApproved, ReturnStatusRejected, ReturnStatusReceived, ReturnStatusInspected:
+		return true
+	}
+	return false
+}
+
+// CanBecome reports whether a return in status can be moved to next.
+func (status ReturnStatus) CanBecome(next ReturnStatus) bool {
+	for _, allowed := range returnStatusTransitions[status] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+type ReturnReason string
+
+const (
+	ReturnReasonDamaged        ReturnReason = "damaged"
+	ReturnReasonDefective      ReturnReason = "defective"
+	ReturnReasonWrongItem      ReturnReason = "wrong_item"
+	ReturnReasonNotAsDescribed ReturnReason = "not_as_described"
+	ReturnReasonNoLongerNeeded ReturnReason = "no_longer_needed"
+	ReturnReasonOther          ReturnReason = "other"
+)
+
+func (reason ReturnReason) Valid() bool {
+	switch reason {
+	case ReturnReasonDamaged, ReturnReasonDefective, ReturnReasonWrongItem, ReturnReasonNotAsDescribed, ReturnReasonNoLongerNeeded, ReturnReasonOther:
+		return true
+	}
+	return false
+}
+
+// Return is a request to send back units of the lines of a delivered order.
+// Admins approve or reject it, mark it received when the parcel arrives and
+// then inspect it, which sets how many units of each line are refunded and
+// restocked. Refund is the sum of the refunds of its items.
+type Return struct {
+	ID        string        `json:"id"`
+	OrderID   string        `json:"orderId"`
+	AccountID string        `json:"accountId"`
+	Status    ReturnStatus  `json:"status"`
+	Items     []*ReturnItem `json:"items"`
+	Refund    money.Money   `json:"refund"`
+	// Note is the admin's note to the customer, such as why the return was
+	// rejected.
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ReturnItem is the number of units of an order line in a return and why
+// they are sent back. AcceptedQuantity, Restock and Refund are set when the
+// return is inspected: the units accepted are refunded, and put back into
+// stock if Restock is set.
+type ReturnItem struct {
+	ProductID        string       `json:"productId"`
+	Quantity         int32        `json:"quantity"`
+	Reason           ReturnReason `json:"reason"`
+	Comment          string       `json:"comment"`
+	AcceptedQuantity int32        `json:"acceptedQuantity"`
+	Restock          bool         `json:"restock"`
+	Refund           money.Money  `json:"refund"`
+}
+
+type InvoiceKind string
+
+const (
+	InvoiceKindInvoice    InvoiceKind = "invoice"
+	InvoiceKindCreditNote InvoiceKind = "credit_note"
+)
+
+// Invoice bills a merchant's lines of an order when the order is paid. A
+// credit note is an Invoice of kind credit_note that refunds the units of
+// the merchant's lines accepted by a return inspection. Numbers are
+// sequential and gap-free for each merchant and kind, e.g. INV-000042 and
+// CN-000007.
+type Invoice struct {
+	ID         string      `json:"id"`
+	Number     string      `json:"number"`
+	Kind       InvoiceKind `json:"kind"`
+	MerchantID string      `json:"merchantId"`
+	OrderID    string      `json:"orderId"`
+	AccountID  string      `json:"accountId"`
+	// ReturnID is the return a credit note refunds, and InvoiceID the
+	// merchant's invoice of the order it corrects, if there is one.
+	ReturnID  string `json:"returnId,omitempty"`
+	InvoiceID string `json:"invoiceId,omitempty"`
+	// BillTo is the lines of the order's shipping address when the invoice
+	// was issued.
+	BillTo []string       `json:"billTo"`
+	Lines  []*InvoiceLine `json:"lines"`
+	// Subtotal sums the amounts of the lines. Discount is taken off it and
+	// the taxes not included in prices and Shipping are added to give Total.
+	// Tax sums the taxes of the lines, included or not.
+	Subtotal money.Money `json:"subtotal"`
+	Discount money.Money `json:"discount"`
+	Tax      money.Money `json:"tax"`
+	Shipping money.Money `json:"shipping"`
+	Total    money.Money `json:"total"`
+	IssuedAt time.Time   `json:"issuedAt"`
+}
+
+// InvoiceLine is the units of an order line on an invoice. Amount is
+// UnitPrice times Quantity; Total is Amount less Discount, plus Tax unless
+// it is included in the price.
+type InvoiceLine struct {
+	ProductID    string      `json:"productId"`
+	Description  string      `json:"description"`
+	Quantity     int32       `json:"quantity"`
+	UnitPrice    money.Money `json:"unitPrice"`
+	Amount       money.Money `json:"amount"`
+	Discount     money.Money `json:"discount"`
+	Tax          money.Money `json:"tax"`
+	TaxRate      string      `json:"taxRate"`
+	TaxInclusive bool        `json:"taxInclusive"`
+	Total        money.Money `json:"total"`
+}
+
+// MerchantOrder is the part of an order sold by one merchant, which the
+// merchant fulfils and is paid out for. Orders are split into a merchant
+// order for each merchant of their lines when they are placed, and the split
+// follows their lines while they are pending.
+type MerchantOrder struct {
+	OrderID    string `json:"orderId"`
+	MerchantID string `json:"merchantId"`
+	AccountID  string `json:"accountId"`
+	// Status is the order's status until every unit of the merchant's lines
+	// has shipped, when it becomes shipped, and then delivered once those
+	// shipments have been delivered.
+	Status   OrderStatus     `json:"status"`
+	Products []*OrderProduct `json:"products"`
+	// The amounts are split out of the order's as its invoices are: Total is
+	// what the merchant's lines cost after their discounts, with the taxes
+	// not included in their prices and a share of the shipping fee in
+	// proportion to what they cost.
+	Subtotal money.Money `json:"subtotal"`
+	Discount money.Money `json:"discount"`
+	Tax      money.Money `json:"tax"`
+	Shipping money.Money `json:"shipping"`
+	Total    money.Money `json:"total"`
+	// Refunded sums the credit notes of the merchant's returned units.
+	Refunded  money.Money `json:"refunded"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+// Payout is what the merchant is owed for a merchant order: its total less
+// its refunds once the order has been paid, and nothing while it is pending
+// or if it was cancelled.
+func (merchantOrder *MerchantOrder) Payout() money.Money {
+	if !merchantOrder.Status.Payable() {
+		return money.Zero(merchantOrder.Total.Currency)
+	}
+	return money.New(merchantOrder.Total.Amount-merchantOrder.Refunded.Amount, merchantOrder.Total.Currency)
+}
+
+// MerchantOrderFilter narrows a listing of merchant orders; zero fields match
+// every merchant order.
+type MerchantOrderFilter struct {
+	MerchantID string
+	OrderID    string
+	Status     OrderStatus
+}
+
+// MerchantOrderPage is one page of a listing of merchant orders, newest
+// first.
+type MerchantOrderPage struct {
+	MerchantOrders []*MerchantOrder
+	// Cursors[i] continues the listing after MerchantOrders[i].
+	Cursors []string
+	// NextCursor continues the listing after this page; it is empty on the
+	// last page.
+	NextCursor string
+	TotalCount uint64
+}
+
+// MerchantPayout sums the payable merchant orders of a merchant in one
+// currency: Total less Refunded is the Payout.
+type MerchantPayout struct {
+	MerchantID string      `json:"merchantId"`
+	Currency   string      `json:"currency"`
+	Orders     uint64      `json:"orders"`
+	Total      money.Money `json:"total"`
+	Refunded   money.Money `json:"refunded"`
+	Payout     money.Money `json:"payout"`
+}
+
+// CoPurchase counts the orders in which a product was bought together with
+// the product it was looked up for.
+type CoPurchase struct {
+	ProductID string `json:"productId"`
+	Orders    uint64 `json:"orders"`
 }
@@ -1,4 +1,4 @@
-// protoc --go_out=./pb --go-grpc_out=./pb order.proto
+// protoc -I. -I.. --go_out=./pb --go-grpc_out=./pb order.proto
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
@@ -9,6 +9,7 @@
 package pb
 
 import (
+	pb "github.com/Asif-Faizal/Minimum-Viable-Shop/money/pb"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
@@ -25,108 +26,4298 @@ const (
 )
 
 type Order struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId  string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Products   []*OrderProduct        `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
+	TotalPrice *pb.Money              `protobuf:"bytes,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// exchange_rates are the rates used to price lines in the order currency.
+	ExchangeRates []*pb.ExchangeRate `protobuf:"bytes,6,rep,name=exchange_rates,json=exchangeRates,proto3" json:"exchange_rates,omitempty"`
+	// status is one of pending, paid, shipped, delivered or cancelled. Only
+	// pending orders can change their products.
+	Status string `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	// discounts are taken off the products, discount_total in all, to give
+	// total_price.
+	Discounts     []*OrderDiscount `protobuf:"bytes,8,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	DiscountTotal *pb.Money        `protobuf:"bytes,9,opt,name=discount_total,json=discountTotal,proto3" json:"discount_total,omitempty"`
+	// coupon_codes are the coupons applied to the order. A coupon only gives a
+	// discount while the order is eligible for it.
+	CouponCodes []string `protobuf:"bytes,10,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`
+	// subtotal is the sum of the products. total_price is subtotal less
+	// discount_total plus the taxes not included in prices and shipping_fee;
+	// tax_total sums the taxes of the products, included or not.
+	Subtotal *pb.Money `protobuf:"bytes,11,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	TaxTotal *pb.Money `protobuf:"bytes,12,opt,name=tax_total,json=taxTotal,proto3" json:"tax_total,omitempty"`
+	// tax_country, an ISO 3166-1 alpha-2 code, and tax_region are where the
+	// order is taxed. Orders without a tax_country are not taxed. Orders are
+	// taxed at their shipping address; only admins may set these in requests,
+	// to tax an order elsewhere.
+	TaxCountry string `protobuf:"bytes,13,opt,name=tax_country,json=taxCountry,proto3" json:"tax_country,omitempty"`
+	TaxRegion  string `protobuf:"bytes,14,opt,name=tax_region,json=taxRegion,proto3" json:"tax_region,omitempty"`
+	// tax_exempt is set when the account was tax exempt when it ordered, or
+	// when an admin exempted the order in its request.
+	TaxExempt bool `protobuf:"varint,15,opt,name=tax_exempt,json=taxExempt,proto3" json:"tax_exempt,omitempty"`
+	// shipping_address is where the order ships to, if anywhere; it is
+	// ignored in requests.
+	ShippingAddress *ShippingAddress `protobuf:"bytes,16,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	// shipping_method is the method of a GetShippingQuotes quote the order
+	// ships with, and shipping_fee what it charges; empty is no shipping fee.
+	// Orders with a shipping_method need a shipping address. shipping_fee is
+	// ignored in requests.
+	ShippingMethod string    `protobuf:"bytes,17,opt,name=shipping_method,json=shippingMethod,proto3" json:"shipping_method,omitempty"`
+	ShippingFee    *pb.Money `protobuf:"bytes,18,opt,name=shipping_fee,json=shippingFee,proto3" json:"shipping_fee,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_order_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Order) GetProducts() []*OrderProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *Order) GetTotalPrice() *pb.Money {
+	if x != nil {
+		return x.TotalPrice
+	}
+	return nil
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetExchangeRates() []*pb.ExchangeRate {
+	if x != nil {
+		return x.ExchangeRates
+	}
+	return nil
+}
+
+func (x *Order) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Order) GetDiscounts() []*OrderDiscount {
+	if x != nil {
+		return x.Discounts
+	}
+	return nil
+}
+
+func (x *Order) GetDiscountTotal() *pb.Money {
+	if x != nil {
+		return x.DiscountTotal
+	}
+	return nil
+}
+
+func (x *Order) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *Order) GetSubtotal() *pb.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Order) GetTaxTotal() *pb.Money {
+	if x != nil {
+		return x.TaxTotal
+	}
+	return nil
+}
+
+func (x *Order) GetTaxCountry() string {
+	if x != nil {
+		return x.TaxCountry
+	}
+	return ""
+}
+
+func (x *Order) GetTaxRegion() string {
+	if x != nil {
+		return x.TaxRegion
+	}
+	return ""
+}
+
+func (x *Order) GetTaxExempt() bool {
+	if x != nil {
+		return x.TaxExempt
+	}
+	return false
+}
+
+func (x *Order) GetShippingAddress() *ShippingAddress {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *Order) GetShippingMethod() string {
+	if x != nil {
+		return x.ShippingMethod
+	}
+	return ""
+}
+
+func (x *Order) GetShippingFee() *pb.Money {
+	if x != nil {
+		return x.ShippingFee
+	}
+	return nil
+}
+
+// ShippingAddress is a copy of an address from the account's address book,
+// taken when the order was placed.
+type ShippingAddress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AddressId     string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Line1         string                 `protobuf:"bytes,3,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2         string                 `protobuf:"bytes,4,opt,name=line2,proto3" json:"line2,omitempty"`
+	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	Region        string                 `protobuf:"bytes,6,opt,name=region,proto3" json:"region,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,7,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Country       string                 `protobuf:"bytes,8,opt,name=country,proto3" json:"country,omitempty"`
+	Phone         string                 `protobuf:"bytes,9,opt,name=phone,proto3" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippingAddress) Reset() {
+	*x = ShippingAddress{}
+	mi := &file_order_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingAddress) ProtoMessage() {}
+
+func (x *ShippingAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingAddress.ProtoReflect.Descriptor instead.
+func (*ShippingAddress) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ShippingAddress) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+type OrderProduct struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	OrderId            string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId          string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName        string                 `protobuf:"bytes,3,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	ProductDescription string                 `protobuf:"bytes,4,opt,name=product_description,json=productDescription,proto3" json:"product_description,omitempty"`
+	Price              *pb.Money              `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity           int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	CategoryId         string                 `protobuf:"bytes,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	TaxCategory        string                 `protobuf:"bytes,8,opt,name=tax_category,json=taxCategory,proto3" json:"tax_category,omitempty"`
+	// tax is the tax on the product, if any.
+	Tax *OrderTax `protobuf:"bytes,9,opt,name=tax,proto3" json:"tax,omitempty"`
+	// merchant_id is the account that sold the product.
+	MerchantId    string `protobuf:"bytes,10,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderProduct) Reset() {
+	*x = OrderProduct{}
+	mi := &file_order_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderProduct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderProduct) ProtoMessage() {}
+
+func (x *OrderProduct) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderProduct.ProtoReflect.Descriptor instead.
+func (*OrderProduct) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *OrderProduct) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetProductDescription() string {
+	if x != nil {
+		return x.ProductDescription
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetPrice() *pb.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *OrderProduct) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *OrderProduct) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetTaxCategory() string {
+	if x != nil {
+		return x.TaxCategory
+	}
+	return ""
+}
+
+func (x *OrderProduct) GetTax() *OrderTax {
+	if x != nil {
+		return x.Tax
+	}
+	return nil
+}
+
+func (x *OrderProduct) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+// OrderTax is the tax on an order product at the rate for the order's tax
+// location and the product's tax category.
+type OrderTax struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Country  string                 `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	Region   string                 `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	Category string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	// rate is a decimal fraction of what the product costs after its
+	// discounts, e.g. "0.19".
+	Rate string `protobuf:"bytes,4,opt,name=rate,proto3" json:"rate,omitempty"`
+	// inclusive taxes are part of the product's price; others are added to
+	// the order total.
+	Inclusive     bool      `protobuf:"varint,5,opt,name=inclusive,proto3" json:"inclusive,omitempty"`
+	Amount        *pb.Money `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderTax) Reset() {
+	*x = OrderTax{}
+	mi := &file_order_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderTax) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderTax) ProtoMessage() {}
+
+func (x *OrderTax) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderTax.ProtoReflect.Descriptor instead.
+func (*OrderTax) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OrderTax) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *OrderTax) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *OrderTax) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *OrderTax) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *OrderTax) GetInclusive() bool {
+	if x != nil {
+		return x.Inclusive
+	}
+	return false
+}
+
+func (x *OrderTax) GetAmount() *pb.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type OrderDiscount struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId string                 `protobuf:"bytes,1,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	// code is empty for promotions that apply without a coupon.
+	Code        string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// product_id is the product discounted, or empty for discounts on several
+	// products together.
+	ProductId     string    `protobuf:"bytes,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Amount        *pb.Money `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderDiscount) Reset() {
+	*x = OrderDiscount{}
+	mi := &file_order_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderDiscount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderDiscount) ProtoMessage() {}
+
+func (x *OrderDiscount) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderDiscount.ProtoReflect.Descriptor instead.
+func (*OrderDiscount) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OrderDiscount) GetPromotionId() string {
+	if x != nil {
+		return x.PromotionId
+	}
+	return ""
+}
+
+func (x *OrderDiscount) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *OrderDiscount) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *OrderDiscount) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderDiscount) GetAmount() *pb.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// Promotion is a discount rule. Promotions with a code are coupons customers
+// apply to their orders; promotions without one apply to every eligible
+// order.
+type Promotion struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code        string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// type is percentage, fixed or buy_x_get_y.
+	Type string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// percent_off, from 1 to 100, is taken off eligible products by percentage
+	// promotions.
+	PercentOff uint32 `protobuf:"varint,5,opt,name=percent_off,json=percentOff,proto3" json:"percent_off,omitempty"`
+	// amount_off is taken off the eligible products together by fixed
+	// promotions, for orders in its currency.
+	AmountOff *pb.Money `protobuf:"bytes,6,opt,name=amount_off,json=amountOff,proto3" json:"amount_off,omitempty"`
+	// buy_x_get_y promotions make get_quantity units of an eligible product
+	// free for every buy_quantity units bought.
+	BuyQuantity uint32 `protobuf:"varint,7,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	GetQuantity uint32 `protobuf:"varint,8,opt,name=get_quantity,json=getQuantity,proto3" json:"get_quantity,omitempty"`
+	// product_ids and category_ids limit the products discounted; with neither
+	// every product is eligible. account_ids limits the accounts that can use
+	// the promotion.
+	ProductIds  []string `protobuf:"bytes,9,rep,name=product_ids,json=productIds,proto3" json:"product_ids,omitempty"`
+	CategoryIds []string `protobuf:"bytes,10,rep,name=category_ids,json=categoryIds,proto3" json:"category_ids,omitempty"`
+	AccountIds  []string `protobuf:"bytes,11,rep,name=account_ids,json=accountIds,proto3" json:"account_ids,omitempty"`
+	// stackable promotions combine with each other. Other promotions are only
+	// used alone, when no combination gives a bigger discount.
+	Stackable bool                   `protobuf:"varint,12,opt,name=stackable,proto3" json:"stackable,omitempty"`
+	StartsAt  *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	// usage_limit caps the orders that use the promotion, and
+	// usage_limit_per_account the orders of each account; 0 is no limit.
+	UsageLimit           uint64                 `protobuf:"varint,15,opt,name=usage_limit,json=usageLimit,proto3" json:"usage_limit,omitempty"`
+	UsageLimitPerAccount uint64                 `protobuf:"varint,16,opt,name=usage_limit_per_account,json=usageLimitPerAccount,proto3" json:"usage_limit_per_account,omitempty"`
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Promotion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Promotion) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Promotion) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Promotion) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Promotion) GetPercentOff() uint32 {
+	if x != nil {
+		return x.PercentOff
+	}
+	return 0
+}
+
+func (x *Promotion) GetAmountOff() *pb.Money {
+	if x != nil {
+		return x.AmountOff
+	}
+	return nil
+}
+
+func (x *Promotion) GetBuyQuantity() uint32 {
+	if x != nil {
+		return x.BuyQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetGetQuantity() uint32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
+}
+
+func (x *Promotion) GetProductIds() []string {
+	if x != nil {
+		return x.ProductIds
+	}
+	return nil
+}
+
+func (x *Promotion) GetCategoryIds() []string {
+	if x != nil {
+		return x.CategoryIds
+	}
+	return nil
+}
+
+func (x *Promotion) GetAccountIds() []string {
+	if x != nil {
+		return x.AccountIds
+	}
+	return nil
+}
+
+func (x *Promotion) GetStackable() bool {
+	if x != nil {
+		return x.Stackable
+	}
+	return false
+}
+
+func (x *Promotion) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetUsageLimit() uint64 {
+	if x != nil {
+		return x.UsageLimit
+	}
+	return 0
+}
+
+func (x *Promotion) GetUsageLimitPerAccount() uint64 {
+	if x != nil {
+		return x.UsageLimitPerAccount
+	}
+	return 0
+}
+
+func (x *Promotion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateOrUpdateOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Order *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	// currency the order is priced in; defaults to the products' own currency.
+	Currency string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	// coupon_codes are applied to the order and must each give it a discount.
+	CouponCodes []string `protobuf:"bytes,3,rep,name=coupon_codes,json=couponCodes,proto3" json:"coupon_codes,omitempty"`
+	// shipping_address_id is an address of the account to ship to; it
+	// defaults to the account's default shipping address. The order is taxed
+	// at the shipping address.
+	ShippingAddressId string `protobuf:"bytes,4,opt,name=shipping_address_id,json=shippingAddressId,proto3" json:"shipping_address_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateOrUpdateOrderRequest) Reset() {
+	*x = CreateOrUpdateOrderRequest{}
+	mi := &file_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrUpdateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrUpdateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrUpdateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrUpdateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrUpdateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateOrUpdateOrderRequest) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *CreateOrUpdateOrderRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateOrUpdateOrderRequest) GetCouponCodes() []string {
+	if x != nil {
+		return x.CouponCodes
+	}
+	return nil
+}
+
+func (x *CreateOrUpdateOrderRequest) GetShippingAddressId() string {
+	if x != nil {
+		return x.ShippingAddressId
+	}
+	return ""
+}
+
+type CreateOrUpdateOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrUpdateOrderResponse) Reset() {
+	*x = CreateOrUpdateOrderResponse{}
+	mi := &file_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrUpdateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrUpdateOrderResponse) ProtoMessage() {}
+
+func (x *CreateOrUpdateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrUpdateOrderResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrUpdateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateOrUpdateOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type GetOrderByIDRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// currency to display amounts in, converted at the rate in effect when the order was placed.
+	Currency      string `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderByIDRequest) Reset() {
+	*x = GetOrderByIDRequest{}
+	mi := &file_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderByIDRequest) ProtoMessage() {}
+
+func (x *GetOrderByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderByIDRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetOrderByIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetOrderByIDRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetOrderByIDResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderByIDResponse) Reset() {
+	*x = GetOrderByIDResponse{}
+	mi := &file_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderByIDResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderByIDResponse) ProtoMessage() {}
+
+func (x *GetOrderByIDResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderByIDResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderByIDResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOrderByIDResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+// GetOrdersForAccountRequest asks for take orders of an account, newest
+// first, after cursor, a cursor from an earlier response, or the first take
+// without one. take is 100 if 0 or more than 100. Only the account itself
+// and admins may list its orders.
+type GetOrdersForAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Take          uint64                 `protobuf:"varint,3,opt,name=take,proto3" json:"take,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersForAccountRequest) Reset() {
+	*x = GetOrdersForAccountRequest{}
+	mi := &file_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersForAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersForAccountRequest) ProtoMessage() {}
+
+func (x *GetOrdersForAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersForAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetOrdersForAccountRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetOrdersForAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetOrdersForAccountRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetOrdersForAccountRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *GetOrdersForAccountRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetOrdersForAccountResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// cursors[i] continues the listing after orders[i].
+	Cursors       []string `protobuf:"bytes,2,rep,name=cursors,proto3" json:"cursors,omitempty"`
+	NextCursor    string   `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalCount    uint64   `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrdersForAccountResponse) Reset() {
+	*x = GetOrdersForAccountResponse{}
+	mi := &file_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrdersForAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrdersForAccountResponse) ProtoMessage() {}
+
+func (x *GetOrdersForAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrdersForAccountResponse.ProtoReflect.Descriptor instead.
+func (*GetOrdersForAccountResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetOrdersForAccountResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *GetOrdersForAccountResponse) GetCursors() []string {
+	if x != nil {
+		return x.Cursors
+	}
+	return nil
+}
+
+func (x *GetOrdersForAccountResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *GetOrdersForAccountResponse) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// OrderFilter narrows an order search; unset fields match every order.
+// statuses matches orders in any of them. created_from is inclusive and
+// created_to exclusive. min_total and max_total are inclusive and only match
+// orders in their currency. sort is empty for newest first, oldest,
+// total_desc or total_asc; sorting by total requires currency.
+type OrderFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statuses      []string               `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ProductId     string                 `protobuf:"bytes,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	CreatedFrom   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	MinTotal      *pb.Money              `protobuf:"bytes,7,opt,name=min_total,json=minTotal,proto3" json:"min_total,omitempty"`
+	MaxTotal      *pb.Money              `protobuf:"bytes,8,opt,name=max_total,json=maxTotal,proto3" json:"max_total,omitempty"`
+	Sort          string                 `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderFilter) Reset() {
+	*x = OrderFilter{}
+	mi := &file_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderFilter) ProtoMessage() {}
+
+func (x *OrderFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderFilter.ProtoReflect.Descriptor instead.
+func (*OrderFilter) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *OrderFilter) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *OrderFilter) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *OrderFilter) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderFilter) GetCreatedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return nil
+}
+
+func (x *OrderFilter) GetCreatedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return nil
+}
+
+func (x *OrderFilter) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OrderFilter) GetMinTotal() *pb.Money {
+	if x != nil {
+		return x.MinTotal
+	}
+	return nil
+}
+
+func (x *OrderFilter) GetMaxTotal() *pb.Money {
+	if x != nil {
+		return x.MaxTotal
+	}
+	return nil
+}
+
+func (x *OrderFilter) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+// SearchOrdersRequest asks for take orders after cursor, a cursor from an
+// earlier response with the same filter, or the first take without one.
+// currency converts the orders for display.
+type SearchOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *OrderFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Take          uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersRequest) Reset() {
+	*x = SearchOrdersRequest{}
+	mi := &file_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersRequest) ProtoMessage() {}
+
+func (x *SearchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*SearchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SearchOrdersRequest) GetFilter() *OrderFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *SearchOrdersRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+func (x *SearchOrdersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type SearchOrdersResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// cursors[i] continues the search after orders[i].
+	Cursors       []string `protobuf:"bytes,2,rep,name=cursors,proto3" json:"cursors,omitempty"`
+	NextCursor    string   `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalCount    uint64   `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersResponse) Reset() {
+	*x = SearchOrdersResponse{}
+	mi := &file_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersResponse) ProtoMessage() {}
+
+func (x *SearchOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersResponse.ProtoReflect.Descriptor instead.
+func (*SearchOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *SearchOrdersResponse) GetCursors() []string {
+	if x != nil {
+		return x.Cursors
+	}
+	return nil
+}
+
+func (x *SearchOrdersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *SearchOrdersResponse) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type UpdateOrderItemsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// operation is replace, add or remove. replace makes products the order's
+	// only lines. add adds products, raising the quantity of lines already on
+	// the order. remove lowers the quantity of lines by the given quantities; a
+	// quantity of 0 removes the whole line.
+	Operation string `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	// products only need product_id and quantity.
+	Products      []*OrderProduct `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderItemsRequest) Reset() {
+	*x = UpdateOrderItemsRequest{}
+	mi := &file_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderItemsRequest) ProtoMessage() {}
+
+func (x *UpdateOrderItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderItemsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderItemsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateOrderItemsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UpdateOrderItemsRequest) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *UpdateOrderItemsRequest) GetProducts() []*OrderProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+type UpdateOrderItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderItemsResponse) Reset() {
+	*x = UpdateOrderItemsResponse{}
+	mi := &file_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderItemsResponse) ProtoMessage() {}
+
+func (x *UpdateOrderItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderItemsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderItemsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateOrderItemsResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ApplyCouponRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyCouponRequest) Reset() {
+	*x = ApplyCouponRequest{}
+	mi := &file_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyCouponRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyCouponRequest) ProtoMessage() {}
+
+func (x *ApplyCouponRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyCouponRequest.ProtoReflect.Descriptor instead.
+func (*ApplyCouponRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ApplyCouponRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ApplyCouponRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ApplyCouponResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyCouponResponse) Reset() {
+	*x = ApplyCouponResponse{}
+	mi := &file_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyCouponResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyCouponResponse) ProtoMessage() {}
+
+func (x *ApplyCouponResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyCouponResponse.ProtoReflect.Descriptor instead.
+func (*ApplyCouponResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ApplyCouponResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type CreateOrUpdatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrUpdatePromotionRequest) Reset() {
+	*x = CreateOrUpdatePromotionRequest{}
+	mi := &file_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrUpdatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrUpdatePromotionRequest) ProtoMessage() {}
+
+func (x *CreateOrUpdatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrUpdatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrUpdatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateOrUpdatePromotionRequest) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type CreateOrUpdatePromotionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrUpdatePromotionResponse) Reset() {
+	*x = CreateOrUpdatePromotionResponse{}
+	mi := &file_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrUpdatePromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrUpdatePromotionResponse) ProtoMessage() {}
+
+func (x *CreateOrUpdatePromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrUpdatePromotionResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrUpdatePromotionResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateOrUpdatePromotionResponse) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type ListPromotionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skip          uint64                 `protobuf:"varint,1,opt,name=skip,proto3" json:"skip,omitempty"`
+	Take          uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsRequest) Reset() {
+	*x = ListPromotionsRequest{}
+	mi := &file_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsRequest) ProtoMessage() {}
+
+func (x *ListPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListPromotionsRequest) GetSkip() uint64 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *ListPromotionsRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+type ListPromotionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsResponse) Reset() {
+	*x = ListPromotionsResponse{}
+	mi := &file_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsResponse) ProtoMessage() {}
+
+func (x *ListPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPromotionsResponse) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+type UpdateOrderStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusRequest) Reset() {
+	*x = UpdateOrderStatusRequest{}
+	mi := &file_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UpdateOrderStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type UpdateOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusResponse) Reset() {
+	*x = UpdateOrderStatusResponse{}
+	mi := &file_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusResponse) ProtoMessage() {}
+
+func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateOrderStatusResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type OrderItemChange struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	OrderId   string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Operation string                 `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	// old_quantity is 0 for added lines and new_quantity is 0 for removed ones.
+	OldQuantity int32 `protobuf:"varint,4,opt,name=old_quantity,json=oldQuantity,proto3" json:"old_quantity,omitempty"`
+	NewQuantity int32 `protobuf:"varint,5,opt,name=new_quantity,json=newQuantity,proto3" json:"new_quantity,omitempty"`
+	// price is the unit price after the change, or before it for removed lines.
+	Price         *pb.Money              `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderItemChange) Reset() {
+	*x = OrderItemChange{}
+	mi := &file_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderItemChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderItemChange) ProtoMessage() {}
+
+func (x *OrderItemChange) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderItemChange.ProtoReflect.Descriptor instead.
+func (*OrderItemChange) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *OrderItemChange) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *OrderItemChange) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *OrderItemChange) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *OrderItemChange) GetOldQuantity() int32 {
+	if x != nil {
+		return x.OldQuantity
+	}
+	return 0
+}
+
+func (x *OrderItemChange) GetNewQuantity() int32 {
+	if x != nil {
+		return x.NewQuantity
+	}
+	return 0
+}
+
+func (x *OrderItemChange) GetPrice() *pb.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *OrderItemChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+type ListOrderItemChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderItemChangesRequest) Reset() {
+	*x = ListOrderItemChangesRequest{}
+	mi := &file_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderItemChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderItemChangesRequest) ProtoMessage() {}
+
+func (x *ListOrderItemChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderItemChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListOrderItemChangesRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListOrderItemChangesRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListOrderItemChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*OrderItemChange     `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrderItemChangesResponse) Reset() {
+	*x = ListOrderItemChangesResponse{}
+	mi := &file_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderItemChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderItemChangesResponse) ProtoMessage() {}
+
+func (x *ListOrderItemChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderItemChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListOrderItemChangesResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListOrderItemChangesResponse) GetChanges() []*OrderItemChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type CoPurchase struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// orders is the number of orders that contained both products.
+	Orders        uint64 `protobuf:"varint,2,opt,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CoPurchase) Reset() {
+	*x = CoPurchase{}
+	mi := &file_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CoPurchase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CoPurchase) ProtoMessage() {}
+
+func (x *CoPurchase) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CoPurchase.ProtoReflect.Descriptor instead.
+func (*CoPurchase) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CoPurchase) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *CoPurchase) GetOrders() uint64 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+type ListCoPurchasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Take          uint64                 `protobuf:"varint,2,opt,name=take,proto3" json:"take,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoPurchasesRequest) Reset() {
+	*x = ListCoPurchasesRequest{}
+	mi := &file_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoPurchasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoPurchasesRequest) ProtoMessage() {}
+
+func (x *ListCoPurchasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoPurchasesRequest.ProtoReflect.Descriptor instead.
+func (*ListCoPurchasesRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListCoPurchasesRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ListCoPurchasesRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+type ListCoPurchasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Products      []*CoPurchase          `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCoPurchasesResponse) Reset() {
+	*x = ListCoPurchasesResponse{}
+	mi := &file_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCoPurchasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCoPurchasesResponse) ProtoMessage() {}
+
+func (x *ListCoPurchasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCoPurchasesResponse.ProtoReflect.Descriptor instead.
+func (*ListCoPurchasesResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListCoPurchasesResponse) GetProducts() []*CoPurchase {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+// Shipment is a parcel sent for an order. status is shipped or delivered.
+type Shipment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId        string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Carrier        string                 `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,4,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Items          []*ShipmentItem        `protobuf:"bytes,6,rep,name=items,proto3" json:"items,omitempty"`
+	ShippedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=shipped_at,json=shippedAt,proto3" json:"shipped_at,omitempty"`
+	DeliveredAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	// merchant_id is the merchant who sent the shipment, empty for shipments
+	// sent by admins.
+	MerchantId    string `protobuf:"bytes,9,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Shipment) Reset() {
+	*x = Shipment{}
+	mi := &file_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Shipment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Shipment) ProtoMessage() {}
+
+func (x *Shipment) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Shipment.ProtoReflect.Descriptor instead.
+func (*Shipment) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Shipment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Shipment) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Shipment) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *Shipment) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *Shipment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Shipment) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Shipment) GetShippedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ShippedAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+func (x *Shipment) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+type ShipmentItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShipmentItem) Reset() {
+	*x = ShipmentItem{}
+	mi := &file_order_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShipmentItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShipmentItem) ProtoMessage() {}
+
+func (x *ShipmentItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShipmentItem.ProtoReflect.Descriptor instead.
+func (*ShipmentItem) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ShipmentItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ShipmentItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+// CreateShipmentRequest is only accepted from admins, for paid or shipped
+// orders. Without items, every unit not shipped yet is shipped.
+type CreateShipmentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Carrier        string                 `protobuf:"bytes,2,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	TrackingNumber string                 `protobuf:"bytes,3,opt,name=tracking_number,json=trackingNumber,proto3" json:"tracking_number,omitempty"`
+	Items          []*ShipmentItem        `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateShipmentRequest) Reset() {
+	*x = CreateShipmentRequest{}
+	mi := &file_order_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentRequest) ProtoMessage() {}
+
+func (x *CreateShipmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateShipmentRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateShipmentRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetTrackingNumber() string {
+	if x != nil {
+		return x.TrackingNumber
+	}
+	return ""
+}
+
+func (x *CreateShipmentRequest) GetItems() []*ShipmentItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CreateShipmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShipmentResponse) Reset() {
+	*x = CreateShipmentResponse{}
+	mi := &file_order_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShipmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShipmentResponse) ProtoMessage() {}
+
+func (x *CreateShipmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShipmentResponse.ProtoReflect.Descriptor instead.
+func (*CreateShipmentResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CreateShipmentResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+// MarkDeliveredRequest is only accepted from admins. The order becomes
+// delivered once all of it has shipped and been delivered.
+type MarkDeliveredRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShipmentId    string                 `protobuf:"bytes,1,opt,name=shipment_id,json=shipmentId,proto3" json:"shipment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkDeliveredRequest) Reset() {
+	*x = MarkDeliveredRequest{}
+	mi := &file_order_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkDeliveredRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkDeliveredRequest) ProtoMessage() {}
+
+func (x *MarkDeliveredRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkDeliveredRequest.ProtoReflect.Descriptor instead.
+func (*MarkDeliveredRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MarkDeliveredRequest) GetShipmentId() string {
+	if x != nil {
+		return x.ShipmentId
+	}
+	return ""
+}
+
+type MarkDeliveredResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipment      *Shipment              `protobuf:"bytes,1,opt,name=shipment,proto3" json:"shipment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkDeliveredResponse) Reset() {
+	*x = MarkDeliveredResponse{}
+	mi := &file_order_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkDeliveredResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkDeliveredResponse) ProtoMessage() {}
+
+func (x *MarkDeliveredResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkDeliveredResponse.ProtoReflect.Descriptor instead.
+func (*MarkDeliveredResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *MarkDeliveredResponse) GetShipment() *Shipment {
+	if x != nil {
+		return x.Shipment
+	}
+	return nil
+}
+
+type ListShipmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShipmentsRequest) Reset() {
+	*x = ListShipmentsRequest{}
+	mi := &file_order_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShipmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShipmentsRequest) ProtoMessage() {}
+
+func (x *ListShipmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShipmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListShipmentsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListShipmentsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListShipmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shipments     []*Shipment            `protobuf:"bytes,1,rep,name=shipments,proto3" json:"shipments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShipmentsResponse) Reset() {
+	*x = ListShipmentsResponse{}
+	mi := &file_order_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShipmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShipmentsResponse) ProtoMessage() {}
+
+func (x *ListShipmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShipmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListShipmentsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListShipmentsResponse) GetShipments() []*Shipment {
+	if x != nil {
+		return x.Shipments
+	}
+	return nil
+}
+
+// ShippingQuote is what a shipping method charges to deliver an order.
+type ShippingQuote struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Method  string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Name    string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Carrier string                 `protobuf:"bytes,3,opt,name=carrier,proto3" json:"carrier,omitempty"`
+	Zone    string                 `protobuf:"bytes,4,opt,name=zone,proto3" json:"zone,omitempty"`
+	Fee     *pb.Money              `protobuf:"bytes,5,opt,name=fee,proto3" json:"fee,omitempty"`
+	// free is set when the fee is waived because the order reaches the
+	// method's free shipping threshold.
+	Free          bool `protobuf:"varint,6,opt,name=free,proto3" json:"free,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShippingQuote) Reset() {
+	*x = ShippingQuote{}
+	mi := &file_order_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShippingQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShippingQuote) ProtoMessage() {}
+
+func (x *ShippingQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShippingQuote.ProtoReflect.Descriptor instead.
+func (*ShippingQuote) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ShippingQuote) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ShippingQuote) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ShippingQuote) GetCarrier() string {
+	if x != nil {
+		return x.Carrier
+	}
+	return ""
+}
+
+func (x *ShippingQuote) GetZone() string {
+	if x != nil {
+		return x.Zone
+	}
+	return ""
+}
+
+func (x *ShippingQuote) GetFee() *pb.Money {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *ShippingQuote) GetFree() bool {
+	if x != nil {
+		return x.Free
+	}
+	return false
+}
+
+// GetShippingQuotesRequest quotes either an existing order, by order_id, or
+// a cart of products priced in currency. Orders are quoted on what they cost
+// after discounts and carts on what they cost before promotions. The address
+// is address if set, else the account's shipping_address_id, else the
+// order's shipping address or the account's default one.
+type GetShippingQuotesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	OrderId           string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Products          []*OrderProduct        `protobuf:"bytes,2,rep,name=products,proto3" json:"products,omitempty"`
+	Currency          string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	AccountId         string                 `protobuf:"bytes,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ShippingAddressId string                 `protobuf:"bytes,5,opt,name=shipping_address_id,json=shippingAddressId,proto3" json:"shipping_address_id,omitempty"`
+	Address           *ShippingAddress       `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetShippingQuotesRequest) Reset() {
+	*x = GetShippingQuotesRequest{}
+	mi := &file_order_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShippingQuotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShippingQuotesRequest) ProtoMessage() {}
+
+func (x *GetShippingQuotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShippingQuotesRequest.ProtoReflect.Descriptor instead.
+func (*GetShippingQuotesRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetShippingQuotesRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetShippingQuotesRequest) GetProducts() []*OrderProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *GetShippingQuotesRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GetShippingQuotesRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetShippingQuotesRequest) GetShippingAddressId() string {
+	if x != nil {
+		return x.ShippingAddressId
+	}
+	return ""
+}
+
+func (x *GetShippingQuotesRequest) GetAddress() *ShippingAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type GetShippingQuotesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// quotes are cheapest first.
+	Quotes        []*ShippingQuote `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetShippingQuotesResponse) Reset() {
+	*x = GetShippingQuotesResponse{}
+	mi := &file_order_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetShippingQuotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetShippingQuotesResponse) ProtoMessage() {}
+
+func (x *GetShippingQuotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetShippingQuotesResponse.ProtoReflect.Descriptor instead.
+func (*GetShippingQuotesResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetShippingQuotesResponse) GetQuotes() []*ShippingQuote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+// Return sends back units of the products of a delivered order. status is
+// requested, approved, rejected, received or inspected. refund is set when
+// the return is inspected and sums the refunds of its items.
+type Return struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId   string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	AccountId string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status    string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Items     []*ReturnItem          `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	Refund    *pb.Money              `protobuf:"bytes,6,opt,name=refund,proto3" json:"refund,omitempty"`
+	// note is the admin's note to the customer, e.g. why the return was
+	// rejected.
+	Note          string                 `protobuf:"bytes,7,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Return) Reset() {
+	*x = Return{}
+	mi := &file_order_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Return) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Return) ProtoMessage() {}
+
+func (x *Return) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Return.ProtoReflect.Descriptor instead.
+func (*Return) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Return) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Return) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Return) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Return) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Return) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Return) GetRefund() *pb.Money {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+func (x *Return) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Return) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Return) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// ReturnItem is the units of an order product in a return. reason is one of
+// damaged, defective, wrong_item, not_as_described, no_longer_needed or
+// other. accepted_quantity, restock and refund are set by inspection: the
+// accepted units are refunded from what the product cost after discounts,
+// with added taxes, and put back into stock if restock is set.
+type ReturnItem struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ProductId        string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity         int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Reason           string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Comment          string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	AcceptedQuantity int32                  `protobuf:"varint,5,opt,name=accepted_quantity,json=acceptedQuantity,proto3" json:"accepted_quantity,omitempty"`
+	Restock          bool                   `protobuf:"varint,6,opt,name=restock,proto3" json:"restock,omitempty"`
+	Refund           *pb.Money              `protobuf:"bytes,7,opt,name=refund,proto3" json:"refund,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ReturnItem) Reset() {
+	*x = ReturnItem{}
+	mi := &file_order_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnItem) ProtoMessage() {}
+
+func (x *ReturnItem) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnItem.ProtoReflect.Descriptor instead.
+func (*ReturnItem) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ReturnItem) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *ReturnItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReturnItem) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ReturnItem) GetAcceptedQuantity() int32 {
+	if x != nil {
+		return x.AcceptedQuantity
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetRestock() bool {
+	if x != nil {
+		return x.Restock
+	}
+	return false
+}
+
+func (x *ReturnItem) GetRefund() *pb.Money {
+	if x != nil {
+		return x.Refund
+	}
+	return nil
+}
+
+// RequestReturnRequest is accepted from the order's account and admins, for
+// delivered orders. items only need product_id, quantity, reason and
+// comment.
+type RequestReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Items         []*ReturnItem          `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestReturnRequest) Reset() {
+	*x = RequestReturnRequest{}
+	mi := &file_order_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestReturnRequest) ProtoMessage() {}
+
+func (x *RequestReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestReturnRequest.ProtoReflect.Descriptor instead.
+func (*RequestReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RequestReturnRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RequestReturnRequest) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RequestReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestReturnResponse) Reset() {
+	*x = RequestReturnResponse{}
+	mi := &file_order_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestReturnResponse) ProtoMessage() {}
+
+func (x *RequestReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestReturnResponse.ProtoReflect.Descriptor instead.
+func (*RequestReturnResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RequestReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+// UpdateReturnStatusRequest is only accepted from admins. status is approved
+// or rejected for requested returns and received for approved ones.
+type UpdateReturnStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateReturnStatusRequest) Reset() {
+	*x = UpdateReturnStatusRequest{}
+	mi := &file_order_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReturnStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReturnStatusRequest) ProtoMessage() {}
+
+func (x *UpdateReturnStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReturnStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateReturnStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UpdateReturnStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateReturnStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateReturnStatusRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type UpdateReturnStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateReturnStatusResponse) Reset() {
+	*x = UpdateReturnStatusResponse{}
+	mi := &file_order_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateReturnStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateReturnStatusResponse) ProtoMessage() {}
+
+func (x *UpdateReturnStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateReturnStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateReturnStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UpdateReturnStatusResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+// InspectReturnRequest is only accepted from admins, for received returns.
+// items give the accepted_quantity and restock of each product; products not
+// listed have no units accepted. Without items every unit is accepted and
+// restocked.
+type InspectReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Items         []*ReturnItem          `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectReturnRequest) Reset() {
+	*x = InspectReturnRequest{}
+	mi := &file_order_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectReturnRequest) ProtoMessage() {}
+
+func (x *InspectReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectReturnRequest.ProtoReflect.Descriptor instead.
+func (*InspectReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *InspectReturnRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *InspectReturnRequest) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *InspectReturnRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type InspectReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InspectReturnResponse) Reset() {
+	*x = InspectReturnResponse{}
+	mi := &file_order_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InspectReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectReturnResponse) ProtoMessage() {}
+
+func (x *InspectReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectReturnResponse.ProtoReflect.Descriptor instead.
+func (*InspectReturnResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *InspectReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+type GetReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReturnRequest) Reset() {
+	*x = GetReturnRequest{}
+	mi := &file_order_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnRequest) ProtoMessage() {}
+
+func (x *GetReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnRequest.ProtoReflect.Descriptor instead.
+func (*GetReturnRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetReturnRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetReturnResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Return        *Return                `protobuf:"bytes,1,opt,name=return,proto3" json:"return,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReturnResponse) Reset() {
+	*x = GetReturnResponse{}
+	mi := &file_order_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnResponse) ProtoMessage() {}
+
+func (x *GetReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnResponse.ProtoReflect.Descriptor instead.
+func (*GetReturnResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetReturnResponse) GetReturn() *Return {
+	if x != nil {
+		return x.Return
+	}
+	return nil
+}
+
+// ListReturnsRequest lists the returns of an order, or of every order for
+// admins, newest first. status, if set, only lists returns in it.
+type ListReturnsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Skip          uint64                 `protobuf:"varint,3,opt,name=skip,proto3" json:"skip,omitempty"`
+	Take          uint64                 `protobuf:"varint,4,opt,name=take,proto3" json:"take,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsRequest) Reset() {
+	*x = ListReturnsRequest{}
+	mi := &file_order_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsRequest) ProtoMessage() {}
+
+func (x *ListReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsRequest.ProtoReflect.Descriptor instead.
+func (*ListReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListReturnsRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *ListReturnsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListReturnsRequest) GetSkip() uint64 {
+	if x != nil {
+		return x.Skip
+	}
+	return 0
+}
+
+func (x *ListReturnsRequest) GetTake() uint64 {
+	if x != nil {
+		return x.Take
+	}
+	return 0
+}
+
+type ListReturnsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Returns       []*Return              `protobuf:"bytes,1,rep,name=returns,proto3" json:"returns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsResponse) Reset() {
+	*x = ListReturnsResponse{}
+	mi := &file_order_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsResponse) ProtoMessage() {}
+
+func (x *ListReturnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsResponse.ProtoReflect.Descriptor instead.
+func (*ListReturnsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListReturnsResponse) GetReturns() []*Return {
+	if x != nil {
+		return x.Returns
+	}
+	return nil
+}
+
+// Invoice bills the products of an order sold by one merchant, issued when
+// the order is paid. Credit notes, of kind credit_note, refund the units of
+// the merchant's products accepted by a return inspection; return_id is the
+// return and invoice_id the invoice they correct. Numbers are sequential and
+// gap-free per merchant and kind. total is subtotal less discount plus the
+// taxes that are not inclusive and shipping; tax sums every tax.
+type Invoice struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number     string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	Kind       string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
+	MerchantId string                 `protobuf:"bytes,4,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	OrderId    string                 `protobuf:"bytes,5,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	AccountId  string                 `protobuf:"bytes,6,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	ReturnId   string                 `protobuf:"bytes,7,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	InvoiceId  string                 `protobuf:"bytes,8,opt,name=invoice_id,json=invoiceId,proto3" json:"invoice_id,omitempty"`
+	// bill_to is the lines of the order's shipping address.
+	BillTo        []string               `protobuf:"bytes,9,rep,name=bill_to,json=billTo,proto3" json:"bill_to,omitempty"`
+	Lines         []*InvoiceLine         `protobuf:"bytes,10,rep,name=lines,proto3" json:"lines,omitempty"`
+	Subtotal      *pb.Money              `protobuf:"bytes,11,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount      *pb.Money              `protobuf:"bytes,12,opt,name=discount,proto3" json:"discount,omitempty"`
+	Tax           *pb.Money              `protobuf:"bytes,13,opt,name=tax,proto3" json:"tax,omitempty"`
+	Shipping      *pb.Money              `protobuf:"bytes,14,opt,name=shipping,proto3" json:"shipping,omitempty"`
+	Total         *pb.Money              `protobuf:"bytes,15,opt,name=total,proto3" json:"total,omitempty"`
+	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_order_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invoice) ProtoMessage() {}
+
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *Invoice) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Invoice) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Invoice) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Invoice) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *Invoice) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Invoice) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Invoice) GetReturnId() string {
+	if x != nil {
+		return x.ReturnId
+	}
+	return ""
+}
+
+func (x *Invoice) GetInvoiceId() string {
+	if x != nil {
+		return x.InvoiceId
+	}
+	return ""
+}
+
+func (x *Invoice) GetBillTo() []string {
+	if x != nil {
+		return x.BillTo
+	}
+	return nil
+}
+
+func (x *Invoice) GetLines() []*InvoiceLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *Invoice) GetSubtotal() *pb.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Invoice) GetDiscount() *pb.Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *Invoice) GetTax() *pb.Money {
+	if x != nil {
+		return x.Tax
+	}
+	return nil
+}
+
+func (x *Invoice) GetShipping() *pb.Money {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+func (x *Invoice) GetTotal() *pb.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *Invoice) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+// InvoiceLine is the units of an order product on an invoice. amount is
+// unit_price times quantity; total is amount less discount, plus tax unless
+// it is inclusive. tax_rate is a decimal fraction, e.g. "0.19".
+type InvoiceLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Quantity      int32                  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	UnitPrice     *pb.Money              `protobuf:"bytes,4,opt,name=unit_price,json=unitPrice,proto3" json:"unit_price,omitempty"`
+	Amount        *pb.Money              `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Discount      *pb.Money              `protobuf:"bytes,6,opt,name=discount,proto3" json:"discount,omitempty"`
+	Tax           *pb.Money              `protobuf:"bytes,7,opt,name=tax,proto3" json:"tax,omitempty"`
+	TaxRate       string                 `protobuf:"bytes,8,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
+	TaxInclusive  bool                   `protobuf:"varint,9,opt,name=tax_inclusive,json=taxInclusive,proto3" json:"tax_inclusive,omitempty"`
+	Total         *pb.Money              `protobuf:"bytes,10,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceLine) Reset() {
+	*x = InvoiceLine{}
+	mi := &file_order_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceLine) ProtoMessage() {}
+
+func (x *InvoiceLine) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceLine.ProtoReflect.Descriptor instead.
+func (*InvoiceLine) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *InvoiceLine) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *InvoiceLine) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *InvoiceLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *InvoiceLine) GetUnitPrice() *pb.Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *InvoiceLine) GetAmount() *pb.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *InvoiceLine) GetDiscount() *pb.Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *InvoiceLine) GetTax() *pb.Money {
+	if x != nil {
+		return x.Tax
+	}
+	return nil
+}
+
+func (x *InvoiceLine) GetTaxRate() string {
+	if x != nil {
+		return x.TaxRate
+	}
+	return ""
+}
+
+func (x *InvoiceLine) GetTaxInclusive() bool {
+	if x != nil {
+		return x.TaxInclusive
+	}
+	return false
+}
+
+func (x *InvoiceLine) GetTotal() *pb.Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+// GetInvoiceRequest is accepted from the order's account, the invoice's
+// merchant and admins. format, if set, is html or pdf and renders the
+// invoice into document.
+type GetInvoiceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Products      []*OrderProduct        `protobuf:"bytes,3,rep,name=products,proto3" json:"products,omitempty"`
-	TotalPrice    float64                `protobuf:"fixed64,4,opt,name=total_price,json=totalPrice,proto3" json:"total_price,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Order) Reset() {
-	*x = Order{}
-	mi := &file_order_proto_msgTypes[0]
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_order_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetInvoiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetInvoiceRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type GetInvoiceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *Invoice               `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	Document      []byte                 `protobuf:"bytes,2,opt,name=document,proto3" json:"document,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceResponse) Reset() {
+	*x = GetInvoiceResponse{}
+	mi := &file_order_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceResponse) ProtoMessage() {}
+
+func (x *GetInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetInvoiceResponse) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+func (x *GetInvoiceResponse) GetDocument() []byte {
+	if x != nil {
+		return x.Document
+	}
+	return nil
+}
+
+func (x *GetInvoiceResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// ListInvoicesRequest lists the invoices and credit notes of an order,
+// oldest first: all of them for its account and admins, and their own for
+// merchants.
+type ListInvoicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvoicesRequest) Reset() {
+	*x = ListInvoicesRequest{}
+	mi := &file_order_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvoicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvoicesRequest) ProtoMessage() {}
+
+func (x *ListInvoicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvoicesRequest.ProtoReflect.Descriptor instead.
+func (*ListInvoicesRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListInvoicesRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type ListInvoicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoices      []*Invoice             `protobuf:"bytes,1,rep,name=invoices,proto3" json:"invoices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvoicesResponse) Reset() {
+	*x = ListInvoicesResponse{}
+	mi := &file_order_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvoicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvoicesResponse) ProtoMessage() {}
+
+func (x *ListInvoicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvoicesResponse.ProtoReflect.Descriptor instead.
+func (*ListInvoicesResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListInvoicesResponse) GetInvoices() []*Invoice {
+	if x != nil {
+		return x.Invoices
+	}
+	return nil
+}
+
+// MerchantOrder is the part of an order sold by one merchant. status is the
+// order's until every unit of the merchant's products has shipped, then
+// shipped, and delivered once those shipments have been delivered. The
+// amounts are split out of the order's as its invoices are; refunded sums
+// the merchant's credit notes and payout is total less refunded, or zero
+// while the order is pending or if it was cancelled.
+type MerchantOrder struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MerchantId    string                 `protobuf:"bytes,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,3,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Products      []*OrderProduct        `protobuf:"bytes,5,rep,name=products,proto3" json:"products,omitempty"`
+	Subtotal      *pb.Money              `protobuf:"bytes,6,opt,name=subtotal,proto3" json:"subtotal,omitempty"`
+	Discount      *pb.Money              `protobuf:"bytes,7,opt,name=discount,proto3" json:"discount,omitempty"`
+	Tax           *pb.Money              `protobuf:"bytes,8,opt,name=tax,proto3" json:"tax,omitempty"`
+	Shipping      *pb.Money              `protobuf:"bytes,9,opt,name=shipping,proto3" json:"shipping,omitempty"`
+	Total         *pb.Money              `protobuf:"bytes,10,opt,name=total,proto3" json:"total,omitempty"`
+	Refunded      *pb.Money              `protobuf:"bytes,11,opt,name=refunded,proto3" json:"refunded,omitempty"`
+	Payout        *pb.Money              `protobuf:"bytes,12,opt,name=payout,proto3" json:"payout,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MerchantOrder) Reset() {
+	*x = MerchantOrder{}
+	mi := &file_order_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Order) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *MerchantOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MerchantOrder) ProtoMessage() {}
+
+func (x *MerchantOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MerchantOrder.ProtoReflect.Descriptor instead.
+func (*MerchantOrder) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *MerchantOrder) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *MerchantOrder) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *MerchantOrder) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *MerchantOrder) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MerchantOrder) GetProducts() []*OrderProduct {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *MerchantOrder) GetSubtotal() *pb.Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *MerchantOrder) GetDiscount() *pb.Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
 }
 
-func (*Order) ProtoMessage() {}
-
-func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[0]
+func (x *MerchantOrder) GetTax() *pb.Money {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Tax
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use Order.ProtoReflect.Descriptor instead.
-func (*Order) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{0}
+func (x *MerchantOrder) GetShipping() *pb.Money {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
 }
 
-func (x *Order) GetId() string {
+func (x *MerchantOrder) GetTotal() *pb.Money {
 	if x != nil {
-		return x.Id
+		return x.Total
 	}
-	return ""
+	return nil
 }
 
-func (x *Order) GetAccountId() string {
+func (x *MerchantOrder) GetRefunded() *pb.Money {
 	if x != nil {
-		return x.AccountId
+		return x.Refunded
 	}
-	return ""
+	return nil
 }
 
-func (x *Order) GetProducts() []*OrderProduct {
+func (x *MerchantOrder) GetPayout() *pb.Money {
 	if x != nil {
-		return x.Products
+		return x.Payout
 	}
 	return nil
 }
 
-func (x *Order) GetTotalPrice() float64 {
+func (x *MerchantOrder) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TotalPrice
+		return x.CreatedAt
 	}
-	return 0
+	return nil
 }
 
-func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+func (x *MerchantOrder) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CreatedAt
+		return x.UpdatedAt
 	}
 	return nil
 }
 
-type OrderProduct struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	OrderId            string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	ProductId          string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	ProductName        string                 `protobuf:"bytes,3,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
-	ProductDescription string                 `protobuf:"bytes,4,opt,name=product_description,json=productDescription,proto3" json:"product_description,omitempty"`
-	Price              float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity           int32                  `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+// ListMerchantOrdersRequest lists merchant orders, newest first, take at a
+// time after cursor, a cursor from an earlier response with the same
+// filters. Merchants list their own, and an order's account the merchant
+// orders of the order; admins list any.
+type ListMerchantOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MerchantId    string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Take          uint64                 `protobuf:"varint,4,opt,name=take,proto3" json:"take,omitempty"`
+	Cursor        string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderProduct) Reset() {
-	*x = OrderProduct{}
-	mi := &file_order_proto_msgTypes[1]
+func (x *ListMerchantOrdersRequest) Reset() {
+	*x = ListMerchantOrdersRequest{}
+	mi := &file_order_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderProduct) String() string {
+func (x *ListMerchantOrdersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderProduct) ProtoMessage() {}
+func (*ListMerchantOrdersRequest) ProtoMessage() {}
 
-func (x *OrderProduct) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[1]
+func (x *ListMerchantOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -137,75 +4328,72 @@ func (x *OrderProduct) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderProduct.ProtoReflect.Descriptor instead.
-func (*OrderProduct) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{1}
-}
-
-func (x *OrderProduct) GetOrderId() string {
-	if x != nil {
-		return x.OrderId
-	}
-	return ""
+// Deprecated: Use ListMerchantOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListMerchantOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *OrderProduct) GetProductId() string {
+func (x *ListMerchantOrdersRequest) GetMerchantId() string {
 	if x != nil {
-		return x.ProductId
+		return x.MerchantId
 	}
 	return ""
 }
 
-func (x *OrderProduct) GetProductName() string {
+func (x *ListMerchantOrdersRequest) GetOrderId() string {
 	if x != nil {
-		return x.ProductName
+		return x.OrderId
 	}
 	return ""
 }
 
-func (x *OrderProduct) GetProductDescription() string {
+func (x *ListMerchantOrdersRequest) GetStatus() string {
 	if x != nil {
-		return x.ProductDescription
+		return x.Status
 	}
 	return ""
 }
 
-func (x *OrderProduct) GetPrice() float64 {
+func (x *ListMerchantOrdersRequest) GetTake() uint64 {
 	if x != nil {
-		return x.Price
+		return x.Take
 	}
 	return 0
 }
 
-func (x *OrderProduct) GetQuantity() int32 {
+func (x *ListMerchantOrdersRequest) GetCursor() string {
 	if x != nil {
-		return x.Quantity
+		return x.Cursor
 	}
-	return 0
+	return ""
 }
 
-type CreateOrUpdateOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+type ListMerchantOrdersResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MerchantOrders []*MerchantOrder       `protobuf:"bytes,1,rep,name=merchant_orders,json=merchantOrders,proto3" json:"merchant_orders,omitempty"`
+	// cursors[i] continues the listing after merchant_orders[i].
+	Cursors       []string `protobuf:"bytes,2,rep,name=cursors,proto3" json:"cursors,omitempty"`
+	NextCursor    string   `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalCount    uint64   `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrUpdateOrderRequest) Reset() {
-	*x = CreateOrUpdateOrderRequest{}
-	mi := &file_order_proto_msgTypes[2]
+func (x *ListMerchantOrdersResponse) Reset() {
+	*x = ListMerchantOrdersResponse{}
+	mi := &file_order_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrUpdateOrderRequest) String() string {
+func (x *ListMerchantOrdersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrUpdateOrderRequest) ProtoMessage() {}
+func (*ListMerchantOrdersResponse) ProtoMessage() {}
 
-func (x *CreateOrUpdateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[2]
+func (x *ListMerchantOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -216,40 +4404,62 @@ func (x *CreateOrUpdateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrUpdateOrderRequest.ProtoReflect.Descriptor instead.
-func (*CreateOrUpdateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ListMerchantOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListMerchantOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *CreateOrUpdateOrderRequest) GetOrder() *Order {
+func (x *ListMerchantOrdersResponse) GetMerchantOrders() []*MerchantOrder {
 	if x != nil {
-		return x.Order
+		return x.MerchantOrders
 	}
 	return nil
 }
 
-type CreateOrUpdateOrderResponse struct {
+func (x *ListMerchantOrdersResponse) GetCursors() []string {
+	if x != nil {
+		return x.Cursors
+	}
+	return nil
+}
+
+func (x *ListMerchantOrdersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListMerchantOrdersResponse) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type GetMerchantOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	MerchantId    string                 `protobuf:"bytes,2,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrUpdateOrderResponse) Reset() {
-	*x = CreateOrUpdateOrderResponse{}
-	mi := &file_order_proto_msgTypes[3]
+func (x *GetMerchantOrderRequest) Reset() {
+	*x = GetMerchantOrderRequest{}
+	mi := &file_order_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrUpdateOrderResponse) String() string {
+func (x *GetMerchantOrderRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrUpdateOrderResponse) ProtoMessage() {}
+func (*GetMerchantOrderRequest) ProtoMessage() {}
 
-func (x *CreateOrUpdateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[3]
+func (x *GetMerchantOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -260,40 +4470,47 @@ func (x *CreateOrUpdateOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrUpdateOrderResponse.ProtoReflect.Descriptor instead.
-func (*CreateOrUpdateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use GetMerchantOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetMerchantOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *CreateOrUpdateOrderResponse) GetOrder() *Order {
+func (x *GetMerchantOrderRequest) GetOrderId() string {
 	if x != nil {
-		return x.Order
+		return x.OrderId
 	}
-	return nil
+	return ""
 }
 
-type GetOrderByIDRequest struct {
+func (x *GetMerchantOrderRequest) GetMerchantId() string {
+	if x != nil {
+		return x.MerchantId
+	}
+	return ""
+}
+
+type GetMerchantOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MerchantOrder *MerchantOrder         `protobuf:"bytes,1,opt,name=merchant_order,json=merchantOrder,proto3" json:"merchant_order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderByIDRequest) Reset() {
-	*x = GetOrderByIDRequest{}
-	mi := &file_order_proto_msgTypes[4]
+func (x *GetMerchantOrderResponse) Reset() {
+	*x = GetMerchantOrderResponse{}
+	mi := &file_order_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderByIDRequest) String() string {
+func (x *GetMerchantOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderByIDRequest) ProtoMessage() {}
+func (*GetMerchantOrderResponse) ProtoMessage() {}
 
-func (x *GetOrderByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[4]
+func (x *GetMerchantOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -304,40 +4521,47 @@ func (x *GetOrderByIDRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderByIDRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderByIDRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetMerchantOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetMerchantOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *GetOrderByIDRequest) GetId() string {
+func (x *GetMerchantOrderResponse) GetMerchantOrder() *MerchantOrder {
 	if x != nil {
-		return x.Id
+		return x.MerchantOrder
 	}
-	return ""
+	return nil
 }
 
-type GetOrderByIDResponse struct {
+// MerchantPayout sums the merchant orders of a merchant in one currency that
+// have been paid and not cancelled.
+type MerchantPayout struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	MerchantId    string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Orders        uint64                 `protobuf:"varint,3,opt,name=orders,proto3" json:"orders,omitempty"`
+	Total         *pb.Money              `protobuf:"bytes,4,opt,name=total,proto3" json:"total,omitempty"`
+	Refunded      *pb.Money              `protobuf:"bytes,5,opt,name=refunded,proto3" json:"refunded,omitempty"`
+	Payout        *pb.Money              `protobuf:"bytes,6,opt,name=payout,proto3" json:"payout,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderByIDResponse) Reset() {
-	*x = GetOrderByIDResponse{}
-	mi := &file_order_proto_msgTypes[5]
+func (x *MerchantPayout) Reset() {
+	*x = MerchantPayout{}
+	mi := &file_order_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderByIDResponse) String() string {
+func (x *MerchantPayout) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderByIDResponse) ProtoMessage() {}
+func (*MerchantPayout) ProtoMessage() {}
 
-func (x *GetOrderByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[5]
+func (x *MerchantPayout) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,40 +4572,79 @@ func (x *GetOrderByIDResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderByIDResponse.ProtoReflect.Descriptor instead.
-func (*GetOrderByIDResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use MerchantPayout.ProtoReflect.Descriptor instead.
+func (*MerchantPayout) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *GetOrderByIDResponse) GetOrder() *Order {
+func (x *MerchantPayout) GetMerchantId() string {
 	if x != nil {
-		return x.Order
+		return x.MerchantId
+	}
+	return ""
+}
+
+func (x *MerchantPayout) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *MerchantPayout) GetOrders() uint64 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+func (x *MerchantPayout) GetTotal() *pb.Money {
+	if x != nil {
+		return x.Total
 	}
 	return nil
 }
 
-type GetOrdersForAccountRequest struct {
+func (x *MerchantPayout) GetRefunded() *pb.Money {
+	if x != nil {
+		return x.Refunded
+	}
+	return nil
+}
+
+func (x *MerchantPayout) GetPayout() *pb.Money {
+	if x != nil {
+		return x.Payout
+	}
+	return nil
+}
+
+// GetMerchantPayoutsRequest sums the orders placed from from, inclusive, to
+// to, exclusive; unset bounds are open.
+type GetMerchantPayoutsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	MerchantId    string                 `protobuf:"bytes,1,opt,name=merchant_id,json=merchantId,proto3" json:"merchant_id,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersForAccountRequest) Reset() {
-	*x = GetOrdersForAccountRequest{}
-	mi := &file_order_proto_msgTypes[6]
+func (x *GetMerchantPayoutsRequest) Reset() {
+	*x = GetMerchantPayoutsRequest{}
+	mi := &file_order_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersForAccountRequest) String() string {
+func (x *GetMerchantPayoutsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersForAccountRequest) ProtoMessage() {}
+func (*GetMerchantPayoutsRequest) ProtoMessage() {}
 
-func (x *GetOrdersForAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[6]
+func (x *GetMerchantPayoutsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -392,40 +4655,54 @@ func (x *GetOrdersForAccountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersForAccountRequest.ProtoReflect.Descriptor instead.
-func (*GetOrdersForAccountRequest) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use GetMerchantPayoutsRequest.ProtoReflect.Descriptor instead.
+func (*GetMerchantPayoutsRequest) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *GetOrdersForAccountRequest) GetAccountId() string {
+func (x *GetMerchantPayoutsRequest) GetMerchantId() string {
 	if x != nil {
-		return x.AccountId
+		return x.MerchantId
 	}
 	return ""
 }
 
-type GetOrdersForAccountResponse struct {
+func (x *GetMerchantPayoutsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetMerchantPayoutsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type GetMerchantPayoutsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	Payouts       []*MerchantPayout      `protobuf:"bytes,1,rep,name=payouts,proto3" json:"payouts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrdersForAccountResponse) Reset() {
-	*x = GetOrdersForAccountResponse{}
-	mi := &file_order_proto_msgTypes[7]
+func (x *GetMerchantPayoutsResponse) Reset() {
+	*x = GetMerchantPayoutsResponse{}
+	mi := &file_order_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrdersForAccountResponse) String() string {
+func (x *GetMerchantPayoutsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrdersForAccountResponse) ProtoMessage() {}
+func (*GetMerchantPayoutsResponse) ProtoMessage() {}
 
-func (x *GetOrdersForAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_proto_msgTypes[7]
+func (x *GetMerchantPayoutsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -436,14 +4713,14 @@ func (x *GetOrdersForAccountResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrdersForAccountResponse.ProtoReflect.Descriptor instead.
-func (*GetOrdersForAccountResponse) Descriptor() ([]byte, []int) {
-	return file_order_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetMerchantPayoutsResponse.ProtoReflect.Descriptor instead.
+func (*GetMerchantPayoutsResponse) Descriptor() ([]byte, []int) {
+	return file_order_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *GetOrdersForAccountResponse) GetOrders() []*Order {
+func (x *GetMerchantPayoutsResponse) GetPayouts() []*MerchantPayout {
 	if x != nil {
-		return x.Orders
+		return x.Payouts
 	}
 	return nil
 }
@@ -452,41 +4729,434 @@ var File_order_proto protoreflect.FileDescriptor
 
 const file_order_proto_rawDesc = "" +
 	"\n" +
-	"\vorder.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc0\x01\n" +
+	"\vorder.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x11money/money.proto\"\xf9\x05\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x02 \x01(\tR\taccountId\x12,\n" +
-	"\bproducts\x18\x03 \x03(\v2\x10.pb.OrderProductR\bproducts\x12\x1f\n" +
-	"\vtotal_price\x18\x04 \x01(\x01R\n" +
+	"\bproducts\x18\x03 \x03(\v2\x10.pb.OrderProductR\bproducts\x12-\n" +
+	"\vtotal_price\x18\x04 \x01(\v2\f.money.MoneyR\n" +
 	"totalPrice\x129\n" +
 	"\n" +
-	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xce\x01\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12:\n" +
+	"\x0eexchange_rates\x18\x06 \x03(\v2\x13.money.ExchangeRateR\rexchangeRates\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12/\n" +
+	"\tdiscounts\x18\b \x03(\v2\x11.pb.OrderDiscountR\tdiscounts\x123\n" +
+	"\x0ediscount_total\x18\t \x01(\v2\f.money.MoneyR\rdiscountTotal\x12!\n" +
+	"\fcoupon_codes\x18\n" +
+	" \x03(\tR\vcouponCodes\x12(\n" +
+	"\bsubtotal\x18\v \x01(\v2\f.money.MoneyR\bsubtotal\x12)\n" +
+	"\ttax_total\x18\f \x01(\v2\f.money.MoneyR\btaxTotal\x12\x1f\n" +
+	"\vtax_country\x18\r \x01(\tR\n" +
+	"taxCountry\x12\x1d\n" +
+	"\n" +
+	"tax_region\x18\x0e \x01(\tR\ttaxRegion\x12\x1d\n" +
+	"\n" +
+	"tax_exempt\x18\x0f \x01(\bR\ttaxExempt\x12>\n" +
+	"\x10shipping_address\x18\x10 \x01(\v2\x13.pb.ShippingAddressR\x0fshippingAddress\x12'\n" +
+	"\x0fshipping_method\x18\x11 \x01(\tR\x0eshippingMethod\x12/\n" +
+	"\fshipping_fee\x18\x12 \x01(\v2\f.money.MoneyR\vshippingFee\"\xed\x01\n" +
+	"\x0fShippingAddress\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x01 \x01(\tR\taddressId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05line1\x18\x03 \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\x04 \x01(\tR\x05line2\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\x06 \x01(\tR\x06region\x12\x1f\n" +
+	"\vpostal_code\x18\a \x01(\tR\n" +
+	"postalCode\x12\x18\n" +
+	"\acountry\x18\b \x01(\tR\acountry\x12\x14\n" +
+	"\x05phone\x18\t \x01(\tR\x05phone\"\xe1\x02\n" +
 	"\fOrderProduct\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x02 \x01(\tR\tproductId\x12!\n" +
 	"\fproduct_name\x18\x03 \x01(\tR\vproductName\x12/\n" +
-	"\x13product_description\x18\x04 \x01(\tR\x12productDescription\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x06 \x01(\x05R\bquantity\"=\n" +
+	"\x13product_description\x18\x04 \x01(\tR\x12productDescription\x12\"\n" +
+	"\x05price\x18\x05 \x01(\v2\f.money.MoneyR\x05price\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vcategory_id\x18\a \x01(\tR\n" +
+	"categoryId\x12!\n" +
+	"\ftax_category\x18\b \x01(\tR\vtaxCategory\x12\x1e\n" +
+	"\x03tax\x18\t \x01(\v2\f.pb.OrderTaxR\x03tax\x12\x1f\n" +
+	"\vmerchant_id\x18\n" +
+	" \x01(\tR\n" +
+	"merchantId\"\xb0\x01\n" +
+	"\bOrderTax\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04rate\x18\x04 \x01(\tR\x04rate\x12\x1c\n" +
+	"\tinclusive\x18\x05 \x01(\bR\tinclusive\x12$\n" +
+	"\x06amount\x18\x06 \x01(\v2\f.money.MoneyR\x06amount\"\xad\x01\n" +
+	"\rOrderDiscount\x12!\n" +
+	"\fpromotion_id\x18\x01 \x01(\tR\vpromotionId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\tR\tproductId\x12$\n" +
+	"\x06amount\x18\x05 \x01(\v2\f.money.MoneyR\x06amount\"\xfd\x04\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1f\n" +
+	"\vpercent_off\x18\x05 \x01(\rR\n" +
+	"percentOff\x12+\n" +
+	"\n" +
+	"amount_off\x18\x06 \x01(\v2\f.money.MoneyR\tamountOff\x12!\n" +
+	"\fbuy_quantity\x18\a \x01(\rR\vbuyQuantity\x12!\n" +
+	"\fget_quantity\x18\b \x01(\rR\vgetQuantity\x12\x1f\n" +
+	"\vproduct_ids\x18\t \x03(\tR\n" +
+	"productIds\x12!\n" +
+	"\fcategory_ids\x18\n" +
+	" \x03(\tR\vcategoryIds\x12\x1f\n" +
+	"\vaccount_ids\x18\v \x03(\tR\n" +
+	"accountIds\x12\x1c\n" +
+	"\tstackable\x18\f \x01(\bR\tstackable\x127\n" +
+	"\tstarts_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12\x1f\n" +
+	"\vusage_limit\x18\x0f \x01(\x04R\n" +
+	"usageLimit\x125\n" +
+	"\x17usage_limit_per_account\x18\x10 \x01(\x04R\x14usageLimitPerAccount\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xac\x01\n" +
 	"\x1aCreateOrUpdateOrderRequest\x12\x1f\n" +
-	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\">\n" +
+	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12!\n" +
+	"\fcoupon_codes\x18\x03 \x03(\tR\vcouponCodes\x12.\n" +
+	"\x13shipping_address_id\x18\x04 \x01(\tR\x11shippingAddressId\">\n" +
 	"\x1bCreateOrUpdateOrderResponse\x12\x1f\n" +
-	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\"%\n" +
+	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\"A\n" +
 	"\x13GetOrderByIDRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"7\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"7\n" +
 	"\x14GetOrderByIDResponse\x12\x1f\n" +
-	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\";\n" +
+	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\"\x83\x01\n" +
 	"\x1aGetOrdersForAccountRequest\x12\x1d\n" +
 	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\"@\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04take\x18\x03 \x01(\x04R\x04take\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\"\x9c\x01\n" +
 	"\x1bGetOrdersForAccountResponse\x12!\n" +
-	"\x06orders\x18\x01 \x03(\v2\t.pb.OrderR\x06orders2\x81\x02\n" +
+	"\x06orders\x18\x01 \x03(\v2\t.pb.OrderR\x06orders\x12\x18\n" +
+	"\acursors\x18\x02 \x03(\tR\acursors\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vtotal_count\x18\x04 \x01(\x04R\n" +
+	"totalCount\"\xe7\x02\n" +
+	"\vOrderFilter\x12\x1a\n" +
+	"\bstatuses\x18\x01 \x03(\tR\bstatuses\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\tR\tproductId\x12=\n" +
+	"\fcreated_from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vcreatedFrom\x129\n" +
+	"\n" +
+	"created_to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedTo\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12)\n" +
+	"\tmin_total\x18\a \x01(\v2\f.money.MoneyR\bminTotal\x12)\n" +
+	"\tmax_total\x18\b \x01(\v2\f.money.MoneyR\bmaxTotal\x12\x12\n" +
+	"\x04sort\x18\t \x01(\tR\x04sort\"\x86\x01\n" +
+	"\x13SearchOrdersRequest\x12'\n" +
+	"\x06filter\x18\x01 \x01(\v2\x0f.pb.OrderFilterR\x06filter\x12\x12\n" +
+	"\x04take\x18\x02 \x01(\x04R\x04take\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"\x95\x01\n" +
+	"\x14SearchOrdersResponse\x12!\n" +
+	"\x06orders\x18\x01 \x03(\v2\t.pb.OrderR\x06orders\x12\x18\n" +
+	"\acursors\x18\x02 \x03(\tR\acursors\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vtotal_count\x18\x04 \x01(\x04R\n" +
+	"totalCount\"\x80\x01\n" +
+	"\x17UpdateOrderItemsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12,\n" +
+	"\bproducts\x18\x03 \x03(\v2\x10.pb.OrderProductR\bproducts\";\n" +
+	"\x18UpdateOrderItemsResponse\x12\x1f\n" +
+	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\"C\n" +
+	"\x12ApplyCouponRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"6\n" +
+	"\x13ApplyCouponResponse\x12\x1f\n" +
+	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\"M\n" +
+	"\x1eCreateOrUpdatePromotionRequest\x12+\n" +
+	"\tpromotion\x18\x01 \x01(\v2\r.pb.PromotionR\tpromotion\"N\n" +
+	"\x1fCreateOrUpdatePromotionResponse\x12+\n" +
+	"\tpromotion\x18\x01 \x01(\v2\r.pb.PromotionR\tpromotion\"?\n" +
+	"\x15ListPromotionsRequest\x12\x12\n" +
+	"\x04skip\x18\x01 \x01(\x04R\x04skip\x12\x12\n" +
+	"\x04take\x18\x02 \x01(\x04R\x04take\"G\n" +
+	"\x16ListPromotionsResponse\x12-\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\r.pb.PromotionR\n" +
+	"promotions\"B\n" +
+	"\x18UpdateOrderStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"<\n" +
+	"\x19UpdateOrderStatusResponse\x12\x1f\n" +
+	"\x05order\x18\x01 \x01(\v2\t.pb.OrderR\x05order\"\x8e\x02\n" +
+	"\x0fOrderItemChange\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId\x12\x1c\n" +
+	"\toperation\x18\x03 \x01(\tR\toperation\x12!\n" +
+	"\fold_quantity\x18\x04 \x01(\x05R\voldQuantity\x12!\n" +
+	"\fnew_quantity\x18\x05 \x01(\x05R\vnewQuantity\x12\"\n" +
+	"\x05price\x18\x06 \x01(\v2\f.money.MoneyR\x05price\x129\n" +
+	"\n" +
+	"changed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"8\n" +
+	"\x1bListOrderItemChangesRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"M\n" +
+	"\x1cListOrderItemChangesResponse\x12-\n" +
+	"\achanges\x18\x01 \x03(\v2\x13.pb.OrderItemChangeR\achanges\"C\n" +
+	"\n" +
+	"CoPurchase\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x16\n" +
+	"\x06orders\x18\x02 \x01(\x04R\x06orders\"K\n" +
+	"\x16ListCoPurchasesRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x12\n" +
+	"\x04take\x18\x02 \x01(\x04R\x04take\"E\n" +
+	"\x17ListCoPurchasesResponse\x12*\n" +
+	"\bproducts\x18\x01 \x03(\v2\x0e.pb.CoPurchaseR\bproducts\"\xd3\x02\n" +
+	"\bShipment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x18\n" +
+	"\acarrier\x18\x03 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x04 \x01(\tR\x0etrackingNumber\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12&\n" +
+	"\x05items\x18\x06 \x03(\v2\x10.pb.ShipmentItemR\x05items\x129\n" +
+	"\n" +
+	"shipped_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tshippedAt\x12=\n" +
+	"\fdelivered_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\x12\x1f\n" +
+	"\vmerchant_id\x18\t \x01(\tR\n" +
+	"merchantId\"I\n" +
+	"\fShipmentItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"\x9d\x01\n" +
+	"\x15CreateShipmentRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x18\n" +
+	"\acarrier\x18\x02 \x01(\tR\acarrier\x12'\n" +
+	"\x0ftracking_number\x18\x03 \x01(\tR\x0etrackingNumber\x12&\n" +
+	"\x05items\x18\x04 \x03(\v2\x10.pb.ShipmentItemR\x05items\"B\n" +
+	"\x16CreateShipmentResponse\x12(\n" +
+	"\bshipment\x18\x01 \x01(\v2\f.pb.ShipmentR\bshipment\"7\n" +
+	"\x14MarkDeliveredRequest\x12\x1f\n" +
+	"\vshipment_id\x18\x01 \x01(\tR\n" +
+	"shipmentId\"A\n" +
+	"\x15MarkDeliveredResponse\x12(\n" +
+	"\bshipment\x18\x01 \x01(\v2\f.pb.ShipmentR\bshipment\"1\n" +
+	"\x14ListShipmentsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"C\n" +
+	"\x15ListShipmentsResponse\x12*\n" +
+	"\tshipments\x18\x01 \x03(\v2\f.pb.ShipmentR\tshipments\"\x9d\x01\n" +
+	"\rShippingQuote\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acarrier\x18\x03 \x01(\tR\acarrier\x12\x12\n" +
+	"\x04zone\x18\x04 \x01(\tR\x04zone\x12\x1e\n" +
+	"\x03fee\x18\x05 \x01(\v2\f.money.MoneyR\x03fee\x12\x12\n" +
+	"\x04free\x18\x06 \x01(\bR\x04free\"\xfd\x01\n" +
+	"\x18GetShippingQuotesRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12,\n" +
+	"\bproducts\x18\x02 \x03(\v2\x10.pb.OrderProductR\bproducts\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x04 \x01(\tR\taccountId\x12.\n" +
+	"\x13shipping_address_id\x18\x05 \x01(\tR\x11shippingAddressId\x12-\n" +
+	"\aaddress\x18\x06 \x01(\v2\x13.pb.ShippingAddressR\aaddress\"F\n" +
+	"\x19GetShippingQuotesResponse\x12)\n" +
+	"\x06quotes\x18\x01 \x03(\v2\x11.pb.ShippingQuoteR\x06quotes\"\xc0\x02\n" +
+	"\x06Return\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12$\n" +
+	"\x05items\x18\x05 \x03(\v2\x0e.pb.ReturnItemR\x05items\x12$\n" +
+	"\x06refund\x18\x06 \x01(\v2\f.money.MoneyR\x06refund\x12\x12\n" +
+	"\x04note\x18\a \x01(\tR\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe6\x01\n" +
+	"\n" +
+	"ReturnItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\x12+\n" +
+	"\x11accepted_quantity\x18\x05 \x01(\x05R\x10acceptedQuantity\x12\x18\n" +
+	"\arestock\x18\x06 \x01(\bR\arestock\x12$\n" +
+	"\x06refund\x18\a \x01(\v2\f.money.MoneyR\x06refund\"W\n" +
+	"\x14RequestReturnRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12$\n" +
+	"\x05items\x18\x02 \x03(\v2\x0e.pb.ReturnItemR\x05items\";\n" +
+	"\x15RequestReturnResponse\x12\"\n" +
+	"\x06return\x18\x01 \x01(\v2\n" +
+	".pb.ReturnR\x06return\"W\n" +
+	"\x19UpdateReturnStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"@\n" +
+	"\x1aUpdateReturnStatusResponse\x12\"\n" +
+	"\x06return\x18\x01 \x01(\v2\n" +
+	".pb.ReturnR\x06return\"`\n" +
+	"\x14InspectReturnRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12$\n" +
+	"\x05items\x18\x02 \x03(\v2\x0e.pb.ReturnItemR\x05items\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\";\n" +
+	"\x15InspectReturnResponse\x12\"\n" +
+	"\x06return\x18\x01 \x01(\v2\n" +
+	".pb.ReturnR\x06return\"\"\n" +
+	"\x10GetReturnRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"7\n" +
+	"\x11GetReturnResponse\x12\"\n" +
+	"\x06return\x18\x01 \x01(\v2\n" +
+	".pb.ReturnR\x06return\"o\n" +
+	"\x12ListReturnsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04skip\x18\x03 \x01(\x04R\x04skip\x12\x12\n" +
+	"\x04take\x18\x04 \x01(\x04R\x04take\";\n" +
+	"\x13ListReturnsResponse\x12$\n" +
+	"\areturns\x18\x01 \x03(\v2\n" +
+	".pb.ReturnR\areturns\"\x97\x04\n" +
+	"\aInvoice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1f\n" +
+	"\vmerchant_id\x18\x04 \x01(\tR\n" +
+	"merchantId\x12\x19\n" +
+	"\border_id\x18\x05 \x01(\tR\aorderId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x06 \x01(\tR\taccountId\x12\x1b\n" +
+	"\treturn_id\x18\a \x01(\tR\breturnId\x12\x1d\n" +
+	"\n" +
+	"invoice_id\x18\b \x01(\tR\tinvoiceId\x12\x17\n" +
+	"\abill_to\x18\t \x03(\tR\x06billTo\x12%\n" +
+	"\x05lines\x18\n" +
+	" \x03(\v2\x0f.pb.InvoiceLineR\x05lines\x12(\n" +
+	"\bsubtotal\x18\v \x01(\v2\f.money.MoneyR\bsubtotal\x12(\n" +
+	"\bdiscount\x18\f \x01(\v2\f.money.MoneyR\bdiscount\x12\x1e\n" +
+	"\x03tax\x18\r \x01(\v2\f.money.MoneyR\x03tax\x12(\n" +
+	"\bshipping\x18\x0e \x01(\v2\f.money.MoneyR\bshipping\x12\"\n" +
+	"\x05total\x18\x0f \x01(\v2\f.money.MoneyR\x05total\x127\n" +
+	"\tissued_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\"\xeb\x02\n" +
+	"\vInvoiceLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tR\tproductId\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x05R\bquantity\x12+\n" +
+	"\n" +
+	"unit_price\x18\x04 \x01(\v2\f.money.MoneyR\tunitPrice\x12$\n" +
+	"\x06amount\x18\x05 \x01(\v2\f.money.MoneyR\x06amount\x12(\n" +
+	"\bdiscount\x18\x06 \x01(\v2\f.money.MoneyR\bdiscount\x12\x1e\n" +
+	"\x03tax\x18\a \x01(\v2\f.money.MoneyR\x03tax\x12\x19\n" +
+	"\btax_rate\x18\b \x01(\tR\ataxRate\x12#\n" +
+	"\rtax_inclusive\x18\t \x01(\bR\ftaxInclusive\x12\"\n" +
+	"\x05total\x18\n" +
+	" \x01(\v2\f.money.MoneyR\x05total\";\n" +
+	"\x11GetInvoiceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"z\n" +
+	"\x12GetInvoiceResponse\x12%\n" +
+	"\ainvoice\x18\x01 \x01(\v2\v.pb.InvoiceR\ainvoice\x12\x1a\n" +
+	"\bdocument\x18\x02 \x01(\fR\bdocument\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\"0\n" +
+	"\x13ListInvoicesRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\"?\n" +
+	"\x14ListInvoicesResponse\x12'\n" +
+	"\binvoices\x18\x01 \x03(\v2\v.pb.InvoiceR\binvoices\"\xb8\x04\n" +
+	"\rMerchantOrder\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\tR\n" +
+	"merchantId\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x03 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12,\n" +
+	"\bproducts\x18\x05 \x03(\v2\x10.pb.OrderProductR\bproducts\x12(\n" +
+	"\bsubtotal\x18\x06 \x01(\v2\f.money.MoneyR\bsubtotal\x12(\n" +
+	"\bdiscount\x18\a \x01(\v2\f.money.MoneyR\bdiscount\x12\x1e\n" +
+	"\x03tax\x18\b \x01(\v2\f.money.MoneyR\x03tax\x12(\n" +
+	"\bshipping\x18\t \x01(\v2\f.money.MoneyR\bshipping\x12\"\n" +
+	"\x05total\x18\n" +
+	" \x01(\v2\f.money.MoneyR\x05total\x12(\n" +
+	"\brefunded\x18\v \x01(\v2\f.money.MoneyR\brefunded\x12$\n" +
+	"\x06payout\x18\f \x01(\v2\f.money.MoneyR\x06payout\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x9b\x01\n" +
+	"\x19ListMerchantOrdersRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04take\x18\x04 \x01(\x04R\x04take\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\"\xb4\x01\n" +
+	"\x1aListMerchantOrdersResponse\x12:\n" +
+	"\x0fmerchant_orders\x18\x01 \x03(\v2\x11.pb.MerchantOrderR\x0emerchantOrders\x12\x18\n" +
+	"\acursors\x18\x02 \x03(\tR\acursors\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vtotal_count\x18\x04 \x01(\x04R\n" +
+	"totalCount\"U\n" +
+	"\x17GetMerchantOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x1f\n" +
+	"\vmerchant_id\x18\x02 \x01(\tR\n" +
+	"merchantId\"T\n" +
+	"\x18GetMerchantOrderResponse\x128\n" +
+	"\x0emerchant_order\x18\x01 \x01(\v2\x11.pb.MerchantOrderR\rmerchantOrder\"\xd9\x01\n" +
+	"\x0eMerchantPayout\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06orders\x18\x03 \x01(\x04R\x06orders\x12\"\n" +
+	"\x05total\x18\x04 \x01(\v2\f.money.MoneyR\x05total\x12(\n" +
+	"\brefunded\x18\x05 \x01(\v2\f.money.MoneyR\brefunded\x12$\n" +
+	"\x06payout\x18\x06 \x01(\v2\f.money.MoneyR\x06payout\"\x98\x01\n" +
+	"\x19GetMerchantPayoutsRequest\x12\x1f\n" +
+	"\vmerchant_id\x18\x01 \x01(\tR\n" +
+	"merchantId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"J\n" +
+	"\x1aGetMerchantPayoutsResponse\x12,\n" +
+	"\apayouts\x18\x01 \x03(\v2\x12.pb.MerchantPayoutR\apayouts2\xf4\x0e\n" +
 	"\fOrderService\x12V\n" +
-	"\x13CreateOrUpdateOrder\x12\x1e.pb.CreateOrUpdateOrderRequest\x1a\x1f.pb.CreateOrUpdateOrderResponse\x12A\n" +
+	"\x13CreateOrUpdateOrder\x12\x1e.pb.CreateOrUpdateOrderRequest\x1a\x1f.pb.CreateOrUpdateOrderResponse\x12M\n" +
+	"\x10UpdateOrderItems\x12\x1b.pb.UpdateOrderItemsRequest\x1a\x1c.pb.UpdateOrderItemsResponse\x12>\n" +
+	"\vApplyCoupon\x12\x16.pb.ApplyCouponRequest\x1a\x17.pb.ApplyCouponResponse\x12P\n" +
+	"\x11UpdateOrderStatus\x12\x1c.pb.UpdateOrderStatusRequest\x1a\x1d.pb.UpdateOrderStatusResponse\x12Y\n" +
+	"\x14ListOrderItemChanges\x12\x1f.pb.ListOrderItemChangesRequest\x1a .pb.ListOrderItemChangesResponse\x12A\n" +
 	"\fGetOrderByID\x12\x17.pb.GetOrderByIDRequest\x1a\x18.pb.GetOrderByIDResponse\x12V\n" +
-	"\x13GetOrdersForAccount\x12\x1e.pb.GetOrdersForAccountRequest\x1a\x1f.pb.GetOrdersForAccountResponseB\x06Z\x04./pbb\x06proto3"
+	"\x13GetOrdersForAccount\x12\x1e.pb.GetOrdersForAccountRequest\x1a\x1f.pb.GetOrdersForAccountResponse\x12A\n" +
+	"\fSearchOrders\x12\x17.pb.SearchOrdersRequest\x1a\x18.pb.SearchOrdersResponse\x12J\n" +
+	"\x0fListCoPurchases\x12\x1a.pb.ListCoPurchasesRequest\x1a\x1b.pb.ListCoPurchasesResponse\x12b\n" +
+	"\x17CreateOrUpdatePromotion\x12\".pb.CreateOrUpdatePromotionRequest\x1a#.pb.CreateOrUpdatePromotionResponse\x12G\n" +
+	"\x0eListPromotions\x12\x19.pb.ListPromotionsRequest\x1a\x1a.pb.ListPromotionsResponse\x12G\n" +
+	"\x0eCreateShipment\x12\x19.pb.CreateShipmentRequest\x1a\x1a.pb.CreateShipmentResponse\x12D\n" +
+	"\rMarkDelivered\x12\x18.pb.MarkDeliveredRequest\x1a\x19.pb.MarkDeliveredResponse\x12D\n" +
+	"\rListShipments\x12\x18.pb.ListShipmentsRequest\x1a\x19.pb.ListShipmentsResponse\x12P\n" +
+	"\x11GetShippingQuotes\x12\x1c.pb.GetShippingQuotesRequest\x1a\x1d.pb.GetShippingQuotesResponse\x12D\n" +
+	"\rRequestReturn\x12\x18.pb.RequestReturnRequest\x1a\x19.pb.RequestReturnResponse\x12S\n" +
+	"\x12UpdateReturnStatus\x12\x1d.pb.UpdateReturnStatusRequest\x1a\x1e.pb.UpdateReturnStatusResponse\x12D\n" +
+	"\rInspectReturn\x12\x18.pb.InspectReturnRequest\x1a\x19.pb.InspectReturnResponse\x128\n" +
+	"\tGetReturn\x12\x14.pb.GetReturnRequest\x1a\x15.pb.GetReturnResponse\x12>\n" +
+	"\vListReturns\x12\x16.pb.ListReturnsRequest\x1a\x17.pb.ListReturnsResponse\x12;\n" +
+	"\n" +
+	"GetInvoice\x12\x15.pb.GetInvoiceRequest\x1a\x16.pb.GetInvoiceResponse\x12A\n" +
+	"\fListInvoices\x12\x17.pb.ListInvoicesRequest\x1a\x18.pb.ListInvoicesResponse\x12S\n" +
+	"\x12ListMerchantOrders\x12\x1d.pb.ListMerchantOrdersRequest\x1a\x1e.pb.ListMerchantOrdersResponse\x12M\n" +
+	"\x10GetMerchantOrder\x12\x1b.pb.GetMerchantOrderRequest\x1a\x1c.pb.GetMerchantOrderResponse\x12S\n" +
+	"\x12GetMerchantPayouts\x12\x1d.pb.GetMerchantPayoutsRequest\x1a\x1e.pb.GetMerchantPayoutsResponseB\x06Z\x04./pbb\x06proto3"
 
 var (
 	file_order_proto_rawDescOnce sync.Once
@@ -500,36 +5170,230 @@ func file_order_proto_rawDescGZIP() []byte {
 	return file_order_proto_rawDescData
 }
 
-var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_order_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
 var file_order_proto_goTypes = []any{
-	(*Order)(nil),                       // 0: pb.Order
-	(*OrderProduct)(nil),                // 1: pb.OrderProduct
-	(*CreateOrUpdateOrderRequest)(nil),  // 2: pb.CreateOrUpdateOrderRequest
-	(*CreateOrUpdateOrderResponse)(nil), // 3: pb.CreateOrUpdateOrderResponse
-	(*GetOrderByIDRequest)(nil),         // 4: pb.GetOrderByIDRequest
-	(*GetOrderByIDResponse)(nil),        // 5: pb.GetOrderByIDResponse
-	(*GetOrdersForAccountRequest)(nil),  // 6: pb.GetOrdersForAccountRequest
-	(*GetOrdersForAccountResponse)(nil), // 7: pb.GetOrdersForAccountResponse
-	(*timestamppb.Timestamp)(nil),       // 8: google.protobuf.Timestamp
+	(*Order)(nil),                           // 0: pb.Order
+	(*ShippingAddress)(nil),                 // 1: pb.ShippingAddress
+	(*OrderProduct)(nil),                    // 2: pb.OrderProduct
+	(*OrderTax)(nil),                        // 3: pb.OrderTax
+	(*OrderDiscount)(nil),                   // 4: pb.OrderDiscount
+	(*Promotion)(nil),                       // 5: pb.Promotion
+	(*CreateOrUpdateOrderRequest)(nil),      // 6: pb.CreateOrUpdateOrderRequest
+	(*CreateOrUpdateOrderResponse)(nil),     // 7: pb.CreateOrUpdateOrderResponse
+	(*GetOrderByIDRequest)(nil),             // 8: pb.GetOrderByIDRequest
+	(*GetOrderByIDResponse)(nil),            // 9: pb.GetOrderByIDResponse
+	(*GetOrdersForAccountRequest)(nil),      // 10: pb.GetOrdersForAccountRequest
+	(*GetOrdersForAccountResponse)(nil),     // 11: pb.GetOrdersForAccountResponse
+	(*OrderFilter)(nil),                     // 12: pb.OrderFilter
+	(*SearchOrdersRequest)(nil),             // 13: pb.SearchOrdersRequest
+	(*SearchOrdersResponse)(nil),            // 14: pb.SearchOrdersResponse
+	(*UpdateOrderItemsRequest)(nil),         // 15: pb.UpdateOrderItemsRequest
+	(*UpdateOrderItemsResponse)(nil),        // 16: pb.UpdateOrderItemsResponse
+	(*ApplyCouponRequest)(nil),              // 17: pb.ApplyCouponRequest
+	(*ApplyCouponResponse)(nil),             // 18: pb.ApplyCouponResponse
+	(*CreateOrUpdatePromotionRequest)(nil),  // 19: pb.CreateOrUpdatePromotionRequest
+	(*CreateOrUpdatePromotionResponse)(nil), // 20: pb.CreateOrUpdatePromotionResponse
+	(*ListPromotionsRequest)(nil),           // 21: pb.ListPromotionsRequest
+	(*ListPromotionsResponse)(nil),          // 22: pb.ListPromotionsResponse
+	(*UpdateOrderStatusRequest)(nil),        // 23: pb.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),       // 24: pb.UpdateOrderStatusResponse
+	(*OrderItemChange)(nil),                 // 25: pb.OrderItemChange
+	(*ListOrderItemChangesRequest)(nil),     // 26: pb.ListOrderItemChangesRequest
+	(*ListOrderItemChangesResponse)(nil),    // 27: pb.ListOrderItemChangesResponse
+	(*CoPurchase)(nil),                      // 28: pb.CoPurchase
+	(*ListCoPurchasesRequest)(nil),          // 29: pb.ListCoPurchasesRequest
+	(*ListCoPurchasesResponse)(nil),         // 30: pb.ListCoPurchasesResponse
+	(*Shipment)(nil),                        // 31: pb.Shipment
+	(*ShipmentItem)(nil),                    // 32: pb.ShipmentItem
+	(*CreateShipmentRequest)(nil),           // 33: pb.CreateShipmentRequest
+	(*CreateShipmentResponse)(nil),          // 34: pb.CreateShipmentResponse
+	(*MarkDeliveredRequest)(nil),            // 35: pb.MarkDeliveredRequest
+	(*MarkDeliveredResponse)(nil),           // 36: pb.MarkDeliveredResponse
+	(*ListShipmentsRequest)(nil),            // 37: pb.ListShipmentsRequest
+	(*ListShipmentsResponse)(nil),           // 38: pb.ListShipmentsResponse
+	(*ShippingQuote)(nil),                   // 39: pb.ShippingQuote
+	(*GetShippingQuotesRequest)(nil),        // 40: pb.GetShippingQuotesRequest
+	(*GetShippingQuotesResponse)(nil),       // 41: pb.GetShippingQuotesResponse
+	(*Return)(nil),                          // 42: pb.Return
+	(*ReturnItem)(nil),                      // 43: pb.ReturnItem
+	(*RequestReturnRequest)(nil),            // 44: pb.RequestReturnRequest
+	(*RequestReturnResponse)(nil),           // 45: pb.RequestReturnResponse
+	(*UpdateReturnStatusRequest)(nil),       // 46: pb.UpdateReturnStatusRequest
+	(*UpdateReturnStatusResponse)(nil),      // 47: pb.UpdateReturnStatusResponse
+	(*InspectReturnRequest)(nil),            // 48: pb.InspectReturnRequest
+	(*InspectReturnResponse)(nil),           // 49: pb.InspectReturnResponse
+	(*GetReturnRequest)(nil),                // 50: pb.GetReturnRequest
+	(*GetReturnResponse)(nil),               // 51: pb.GetReturnResponse
+	(*ListReturnsRequest)(nil),              // 52: pb.ListReturnsRequest
+	(*ListReturnsResponse)(nil),             // 53: pb.ListReturnsResponse
+	(*Invoice)(nil),                         // 54: pb.Invoice
+	(*InvoiceLine)(nil),                     // 55: pb.InvoiceLine
+	(*GetInvoiceRequest)(nil),               // 56: pb.GetInvoiceRequest
+	(*GetInvoiceResponse)(nil),              // 57: pb.GetInvoiceResponse
+	(*ListInvoicesRequest)(nil),             // 58: pb.ListInvoicesRequest
+	(*ListInvoicesResponse)(nil),            // 59: pb.ListInvoicesResponse
+	(*MerchantOrder)(nil),                   // 60: pb.MerchantOrder
+	(*ListMerchantOrdersRequest)(nil),       // 61: pb.ListMerchantOrdersRequest
+	(*ListMerchantOrdersResponse)(nil),      // 62: pb.ListMerchantOrdersResponse
+	(*GetMerchantOrderRequest)(nil),         // 63: pb.GetMerchantOrderRequest
+	(*GetMerchantOrderResponse)(nil),        // 64: pb.GetMerchantOrderResponse
+	(*MerchantPayout)(nil),                  // 65: pb.MerchantPayout
+	(*GetMerchantPayoutsRequest)(nil),       // 66: pb.GetMerchantPayoutsRequest
+	(*GetMerchantPayoutsResponse)(nil),      // 67: pb.GetMerchantPayoutsResponse
+	(*pb.Money)(nil),                        // 68: money.Money
+	(*timestamppb.Timestamp)(nil),           // 69: google.protobuf.Timestamp
+	(*pb.ExchangeRate)(nil),                 // 70: money.ExchangeRate
 }
 var file_order_proto_depIdxs = []int32{
-	1, // 0: pb.Order.products:type_name -> pb.OrderProduct
-	8, // 1: pb.Order.created_at:type_name -> google.protobuf.Timestamp
-	0, // 2: pb.CreateOrUpdateOrderRequest.order:type_name -> pb.Order
-	0, // 3: pb.CreateOrUpdateOrderResponse.order:type_name -> pb.Order
-	0, // 4: pb.GetOrderByIDResponse.order:type_name -> pb.Order
-	0, // 5: pb.GetOrdersForAccountResponse.orders:type_name -> pb.Order
-	2, // 6: pb.OrderService.CreateOrUpdateOrder:input_type -> pb.CreateOrUpdateOrderRequest
-	4, // 7: pb.OrderService.GetOrderByID:input_type -> pb.GetOrderByIDRequest
-	6, // 8: pb.OrderService.GetOrdersForAccount:input_type -> pb.GetOrdersForAccountRequest
-	3, // 9: pb.OrderService.CreateOrUpdateOrder:output_type -> pb.CreateOrUpdateOrderResponse
-	5, // 10: pb.OrderService.GetOrderByID:output_type -> pb.GetOrderByIDResponse
-	7, // 11: pb.OrderService.GetOrdersForAccount:output_type -> pb.GetOrdersForAccountResponse
-	9, // [9:12] is the sub-list for method output_type
-	6, // [6:9] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	2,   // 0: pb.Order.products:type_name -> pb.OrderProduct
+	68,  // 1: pb.Order.total_price:type_name -> money.Money
+	69,  // 2: pb.Order.created_at:type_name -> google.protobuf.Timestamp
+	70,  // 3: pb.Order.exchange_rates:type_name -> money.ExchangeRate
+	4,   // 4: pb.Order.discounts:type_name -> pb.OrderDiscount
+	68,  // 5: pb.Order.discount_total:type_name -> money.Money
+	68,  // 6: pb.Order.subtotal:type_name -> money.Money
+	68,  // 7: pb.Order.tax_total:type_name -> money.Money
+	1,   // 8: pb.Order.shipping_address:type_name -> pb.ShippingAddress
+	68,  // 9: pb.Order.shipping_fee:type_name -> money.Money
+	68,  // 10: pb.OrderProduct.price:type_name -> money.Money
+	3,   // 11: pb.OrderProduct.tax:type_name -> pb.OrderTax
+	68,  // 12: pb.OrderTax.amount:type_name -> money.Money
+	68,  // 13: pb.OrderDiscount.amount:type_name -> money.Money
+	68,  // 14: pb.Promotion.amount_off:type_name -> money.Money
+	69,  // 15: pb.Promotion.starts_at:type_name -> google.protobuf.Timestamp
+	69,  // 16: pb.Promotion.ends_at:type_name -> google.protobuf.Timestamp
+	69,  // 17: pb.Promotion.created_at:type_name -> google.protobuf.Timestamp
+	0,   // 18: pb.CreateOrUpdateOrderRequest.order:type_name -> pb.Order
+	0,   // 19: pb.CreateOrUpdateOrderResponse.order:type_name -> pb.Order
+	0,   // 20: pb.GetOrderByIDResponse.order:type_name -> pb.Order
+	0,   // 21: pb.GetOrdersForAccountResponse.orders:type_name -> pb.Order
+	69,  // 22: pb.OrderFilter.created_from:type_name -> google.protobuf.Timestamp
+	69,  // 23: pb.OrderFilter.created_to:type_name -> google.protobuf.Timestamp
+	68,  // 24: pb.OrderFilter.min_total:type_name -> money.Money
+	68,  // 25: pb.OrderFilter.max_total:type_name -> money.Money
+	12,  // 26: pb.SearchOrdersRequest.filter:type_name -> pb.OrderFilter
+	0,   // 27: pb.SearchOrdersResponse.orders:type_name -> pb.Order
+	2,   // 28: pb.UpdateOrderItemsRequest.products:type_name -> pb.OrderProduct
+	0,   // 29: pb.UpdateOrderItemsResponse.order:type_name -> pb.Order
+	0,   // 30: pb.ApplyCouponResponse.order:type_name -> pb.Order
+	5,   // 31: pb.CreateOrUpdatePromotionRequest.promotion:type_name -> pb.Promotion
+	5,   // 32: pb.CreateOrUpdatePromotionResponse.promotion:type_name -> pb.Promotion
+	5,   // 33: pb.ListPromotionsResponse.promotions:type_name -> pb.Promotion
+	0,   // 34: pb.UpdateOrderStatusResponse.order:type_name -> pb.Order
+	68,  // 35: pb.OrderItemChange.price:type_name -> money.Money
+	69,  // 36: pb.OrderItemChange.changed_at:type_name -> google.protobuf.Timestamp
+	25,  // 37: pb.ListOrderItemChangesResponse.changes:type_name -> pb.OrderItemChange
+	28,  // 38: pb.ListCoPurchasesResponse.products:type_name -> pb.CoPurchase
+	32,  // 39: pb.Shipment.items:type_name -> pb.ShipmentItem
+	69,  // 40: pb.Shipment.shipped_at:type_name -> google.protobuf.Timestamp
+	69,  // 41: pb.Shipment.delivered_at:type_name -> google.protobuf.Timestamp
+	32,  // 42: pb.CreateShipmentRequest.items:type_name -> pb.ShipmentItem
+	31,  // 43: pb.CreateShipmentResponse.shipment:type_name -> pb.Shipment
+	31,  // 44: pb.MarkDeliveredResponse.shipment:type_name -> pb.Shipment
+	31,  // 45: pb.ListShipmentsResponse.shipments:type_name -> pb.Shipment
+	68,  // 46: pb.ShippingQuote.fee:type_name -> money.Money
+	2,   // 47: pb.GetShippingQuotesRequest.products:type_name -> pb.OrderProduct
+	1,   // 48: pb.GetShippingQuotesRequest.address:type_name -> pb.ShippingAddress
+	39,  // 49: pb.GetShippingQuotesResponse.quotes:type_name -> pb.ShippingQuote
+	43,  // 50: pb.Return.items:type_name -> pb.ReturnItem
+	68,  // 51: pb.Return.refund:type_name -> money.Money
+	69,  // 52: pb.Return.created_at:type_name -> google.protobuf.Timestamp
+	69,  // 53: pb.Return.updated_at:type_name -> google.protobuf.Timestamp
+	68,  // 54: pb.ReturnItem.refund:type_name -> money.Money
+	43,  // 55: pb.RequestReturnRequest.items:type_name -> pb.ReturnItem
+	42,  // 56: pb.RequestReturnResponse.return:type_name -> pb.Return
+	42,  // 57: pb.UpdateReturnStatusResponse.return:type_name -> pb.Return
+	43,  // 58: pb.InspectReturnRequest.items:type_name -> pb.ReturnItem
+	42,  // 59: pb.InspectReturnResponse.return:type_name -> pb.Return
+	42,  // 60: pb.GetReturnResponse.return:type_name -> pb.Return
+	42,  // 61: pb.ListReturnsResponse.returns:type_name -> pb.Return
+	55,  // 62: pb.Invoice.lines:type_name -> pb.InvoiceLine
+	68,  // 63: pb.Invoice.subtotal:type_name -> money.Money
+	68,  // 64: pb.Invoice.discount:type_name -> money.Money
+	68,  // 65: pb.Invoice.tax:type_name -> money.Money
+	68,  // 66: pb.Invoice.shipping:type_name -> money.Money
+	68,  // 67: pb.Invoice.total:type_name -> money.Money
+	69,  // 68: pb.Invoice.issued_at:type_name -> google.protobuf.Timestamp
+	68,  // 69: pb.InvoiceLine.unit_price:type_name -> money.Money
+	68,  // 70: pb.InvoiceLine.amount:type_name -> money.Money
+	68,  // 71: pb.InvoiceLine.discount:type_name -> money.Money
+	68,  // 72: pb.InvoiceLine.tax:type_name -> money.Money
+	68,  // 73: pb.InvoiceLine.total:type_name -> money.Money
+	54,  // 74: pb.GetInvoiceResponse.invoice:type_name -> pb.Invoice
+	54,  // 75: pb.ListInvoicesResponse.invoices:type_name -> pb.Invoice
+	2,   // 76: pb.MerchantOrder.products:type_name -> pb.OrderProduct
+	68,  // 77: pb.MerchantOrder.subtotal:type_name -> money.Money
+	68,  // 78: pb.MerchantOrder.discount:type_name -> money.Money
+	68,  // 79: pb.MerchantOrder.tax:type_name -> money.Money
+	68,  // 80: pb.MerchantOrder.shipping:type_name -> money.Money
+	68,  // 81: pb.MerchantOrder.total:type_name -> money.Money
+	68,  // 82: pb.MerchantOrder.refunded:type_name -> money.Money
+	68,  // 83: pb.MerchantOrder.payout:type_name -> money.Money
+	69,  // 84: pb.MerchantOrder.created_at:type_name -> google.protobuf.Timestamp
+	69,  // 85: pb.MerchantOrder.updated_at:type_name -> google.protobuf.Timestamp
+	60,  // 86: pb.ListMerchantOrdersResponse.merchant_orders:type_name -> pb.MerchantOrder
+	60,  // 87: pb.GetMerchantOrderResponse.merchant_order:type_name -> pb.MerchantOrder
+	68,  // 88: pb.MerchantPayout.total:type_name -> money.Money
+	68,  // 89: pb.MerchantPayout.refunded:type_name -> money.Money
+	68,  // 90: pb.MerchantPayout.payout:type_name -> money.Money
+	69,  // 91: pb.GetMerchantPayoutsRequest.from:type_name -> google.protobuf.Timestamp
+	69,  // 92: pb.GetMerchantPayoutsRequest.to:type_name -> google.protobuf.Timestamp
+	65,  // 93: pb.GetMerchantPayoutsResponse.payouts:type_name -> pb.MerchantPayout
+	6,   // 94: pb.OrderService.CreateOrUpdateOrder:input_type -> pb.CreateOrUpdateOrderRequest
+	15,  // 95: pb.OrderService.UpdateOrderItems:input_type -> pb.UpdateOrderItemsRequest
+	17,  // 96: pb.OrderService.ApplyCoupon:input_type -> pb.ApplyCouponRequest
+	23,  // 97: pb.OrderService.UpdateOrderStatus:input_type -> pb.UpdateOrderStatusRequest
+	26,  // 98: pb.OrderService.ListOrderItemChanges:input_type -> pb.ListOrderItemChangesRequest
+	8,   // 99: pb.OrderService.GetOrderByID:input_type -> pb.GetOrderByIDRequest
+	10,  // 100: pb.OrderService.GetOrdersForAccount:input_type -> pb.GetOrdersForAccountRequest
+	13,  // 101: pb.OrderService.SearchOrders:input_type -> pb.SearchOrdersRequest
+	29,  // 102: pb.OrderService.ListCoPurchases:input_type -> pb.ListCoPurchasesRequest
+	19,  // 103: pb.OrderService.CreateOrUpdatePromotion:input_type -> pb.CreateOrUpdatePromotionRequest
+	21,  // 104: pb.OrderService.ListPromotions:input_type -> pb.ListPromotionsRequest
+	33,  // 105: pb.OrderService.CreateShipment:input_type -> pb.CreateShipmentRequest
+	35,  // 106: pb.OrderService.MarkDelivered:input_type -> pb.MarkDeliveredRequest
+	37,  // 107: pb.OrderService.ListShipments:input_type -> pb.ListShipmentsRequest
+	40,  // 108: pb.OrderService.GetShippingQuotes:input_type -> pb.GetShippingQuotesRequest
+	44,  // 109: pb.OrderService.RequestReturn:input_type -> pb.RequestReturnRequest
+	46,  // 110: pb.OrderService.UpdateReturnStatus:input_type -> pb.UpdateReturnStatusRequest
+	48,  // 111: pb.OrderService.InspectReturn:input_type -> pb.InspectReturnRequest
+	50,  // 112: pb.OrderService.GetReturn:input_type -> pb.GetReturnRequest
+	52,  // 113: pb.OrderService.ListReturns:input_type -> pb.ListReturnsRequest
+	56,  // 114: pb.OrderService.GetInvoice:input_type -> pb.GetInvoiceRequest
+	58,  // 115: pb.OrderService.ListInvoices:input_type -> pb.ListInvoicesRequest
+	61,  // 116: pb.OrderService.ListMerchantOrders:input_type -> pb.ListMerchantOrdersRequest
+	63,  // 117: pb.OrderService.GetMerchantOrder:input_type -> pb.GetMerchantOrderRequest
+	66,  // 118: pb.OrderService.GetMerchantPayouts:input_type -> pb.GetMerchantPayoutsRequest
+	7,   // 119: pb.OrderService.CreateOrUpdateOrder:output_type -> pb.CreateOrUpdateOrderResponse
+	16,  // 120: pb.OrderService.UpdateOrderItems:output_type -> pb.UpdateOrderItemsResponse
+	18,  // 121: pb.OrderService.ApplyCoupon:output_type -> pb.ApplyCouponResponse
+	24,  // 122: pb.OrderService.UpdateOrderStatus:output_type -> pb.UpdateOrderStatusResponse
+	27,  // 123: pb.OrderService.ListOrderItemChanges:output_type -> pb.ListOrderItemChangesResponse
+	9,   // 124: pb.OrderService.GetOrderByID:output_type -> pb.GetOrderByIDResponse
+	11,  // 125: pb.OrderService.GetOrdersForAccount:output_type -> pb.GetOrdersForAccountResponse
+	14,  // 126: pb.OrderService.SearchOrders:output_type -> pb.SearchOrdersResponse
+	30,  // 127: pb.OrderService.ListCoPurchases:output_type -> pb.ListCoPurchasesResponse
+	20,  // 128: pb.OrderService.CreateOrUpdatePromotion:output_type -> pb.CreateOrUpdatePromotionResponse
+	22,  // 129: pb.OrderService.ListPromotions:output_type -> pb.ListPromotionsResponse
+	34,  // 130: pb.OrderService.CreateShipment:output_type -> pb.CreateShipmentResponse
+	36,  // 131: pb.OrderService.MarkDelivered:output_type -> pb.MarkDeliveredResponse
+	38,  // 132: pb.OrderService.ListShipments:output_type -> pb.ListShipmentsResponse
+	41,  // 133: pb.OrderService.GetShippingQuotes:output_type -> pb.GetShippingQuotesResponse
+	45,  // 134: pb.OrderService.RequestReturn:output_type -> pb.RequestReturnResponse
+	47,  // 135: pb.OrderService.UpdateReturnStatus:output_type -> pb.UpdateReturnStatusResponse
+	49,  // 136: pb.OrderService.InspectReturn:output_type -> pb.InspectReturnResponse
+	51,  // 137: pb.OrderService.GetReturn:output_type -> pb.GetReturnResponse
+	53,  // 138: pb.OrderService.ListReturns:output_type -> pb.ListReturnsResponse
+	57,  // 139: pb.OrderService.GetInvoice:output_type -> pb.GetInvoiceResponse
+	59,  // 140: pb.OrderService.ListInvoices:output_type -> pb.ListInvoicesResponse
+	62,  // 141: pb.OrderService.ListMerchantOrders:output_type -> pb.ListMerchantOrdersResponse
+	64,  // 142: pb.OrderService.GetMerchantOrder:output_type -> pb.GetMerchantOrderResponse
+	67,  // 143: pb.OrderService.GetMerchantPayouts:output_type -> pb.GetMerchantPayoutsResponse
+	119, // [119:144] is the sub-list for method output_type
+	94,  // [94:119] is the sub-list for method input_type
+	94,  // [94:94] is the sub-list for extension type_name
+	94,  // [94:94] is the sub-list for extension extendee
+	0,   // [0:94] is the sub-list for field type_name
 }
 
 func init() { file_order_proto_init() }
@@ -543,7 +5407,7 @@ func file_order_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_proto_rawDesc), len(file_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   68,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
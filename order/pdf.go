@@ -0,0 +1,107 @@
+package order
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Pages are A4 in points, with lines of pdfFontSize Courier pdfLeading
+// points apart, at most pdfColumns characters wide.
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 40
+	pdfFontSize   = 9
+	pdfLeading    = 11
+	pdfColumns    = 95
+	pdfPageLines  = (pdfPageHeight - 2*pdfMargin) / pdfLeading
+)
+
+// writeTextPDF writes text as a PDF in a monospaced font, one line of text
+// per line of the page, so that columns laid out with spaces stay aligned.
+// Longer lines are wrapped, and characters the font's encoding lacks are
+// written as '?'.
+func writeTextPDF(w io.Writer, title string, text string) error {
+	lines := []string{}
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		runes := []rune(strings.TrimRight(line, " "))
+		for len(runes) > pdfColumns {
+			lines = append(lines, string(runes[:pdfColumns]))
+			runes = runes[pdfColumns:]
+		}
+		lines = append(lines, string(runes))
+	}
+	pages := [][]string{}
+	for len(lines) > pdfPageLines {
+		pages = append(pages, lines[:pdfPageLines])
+		lines = lines[pdfPageLines:]
+	}
+	pages = append(pages, lines)
+
+	// Objects 1 to 4 are the catalog, the page tree, the font and the
+	// document information; each page is then a page object followed by
+	// its content stream.
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Title %s /Producer (Minimum Viable Shop) >>", pdfString(title)),
+	}
+	kids := []string{}
+	for _, page := range pages {
+		pageObject := len(objects) + 1
+		kids = append(kids, fmt.Sprintf("%d 0 R", pageObject))
+		var content strings.Builder
+		fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLeading, pdfMargin, pdfPageHeight-pdfMargin-pdfFontSize)
+		for _, line := range page {
+			fmt.Fprintf(&content, "%s Tj T*\n", pdfString(line))
+		}
+		content.WriteString("ET")
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, pageObject+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()),
+		)
+	}
+	objects[1] = fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages))
+
+	var buffer bytes.Buffer
+	buffer.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = buffer.Len()
+		fmt.Fprintf(&buffer, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := buffer.Len()
+	fmt.Fprintf(&buffer, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buffer, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buffer, "trailer\n<< /Size %d /Root 1 0 R /Info 4 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	_, err := buffer.WriteTo(w)
+	return err
+}
+
+// pdfString returns text as a PDF string literal in WinAnsiEncoding.
+func pdfString(text string) string {
+	var literal strings.Builder
+	literal.WriteByte('(')
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			literal.WriteByte('\\')
+			literal.WriteRune(r)
+		case r == '€':
+			literal.WriteByte(0x80)
+		case r >= 0x20 && r < 0x7f, r >= 0xa0 && r <= 0xff:
+			literal.WriteByte(byte(r))
+		case r < 0x20:
+			literal.WriteByte(' ')
+		default:
+			literal.WriteByte('?')
+		}
+	}
+	literal.WriteByte(')')
+	return literal.String()
+}
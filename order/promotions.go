@@ -0,0 +1,263 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+type PromotionType string
+
+const (
+	// PromotionTypePercentage takes PercentOff percent off eligible lines.
+	PromotionTypePercentage PromotionType = "percentage"
+	// PromotionTypeFixed takes AmountOff off the eligible lines together.
+	PromotionTypeFixed PromotionType = "fixed"
+	// PromotionTypeBuyXGetY makes GetQuantity units of an eligible line free
+	// for every BuyQuantity units bought.
+	PromotionTypeBuyXGetY PromotionType = "buy_x_get_y"
+)
+
+func (promotionType PromotionType) Valid() bool {
+	switch promotionType {
+	case PromotionTypePercentage, PromotionTypeFixed, PromotionTypeBuyXGetY:
+		return true
+	}
+	return false
+}
+
+var (
+	ErrCouponNotFound = errors.New("coupon not found")
+	// ErrPromotionNotApplicable is returned, with the reason, when a coupon
+	// cannot be used on an order.
+	ErrPromotionNotApplicable = errors.New("promotion does not apply")
+)
+
+// Promotion is a discount rule. Promotions with a Code are coupons that
+// customers apply to their orders; promotions without one apply to every
+// eligible order.
+type Promotion struct {
+	ID          string        `json:"id"`
+	Code        string        `json:"code"`
+	Description string        `json:"description"`
+	Type        PromotionType `json:"type"`
+	// PercentOff is from 1 to 100.
+	PercentOff uint32 `json:"percentOff"`
+	// AmountOff only applies to orders in its currency.
+	AmountOff   money.Money `json:"amountOff"`
+	BuyQuantity uint32      `json:"buyQuantity"`
+	GetQuantity uint32      `json:"getQuantity"`
+	// ProductIDs and CategoryIDs limit the lines a promotion discounts to
+	// those of the products, or in the categories, listed. With neither, every
+	// line is eligible.
+	ProductIDs  []string `json:"productIds"`
+	CategoryIDs []string `json:"categoryIds"`
+	// AccountIDs limits the promotion to orders of the accounts listed.
+	AccountIDs []string `json:"accountIds"`
+	// Stackable promotions combine with each other. Other promotions are only
+	// used alone, when no combination gives a bigger discount.
+	Stackable bool       `json:"stackable"`
+	StartsAt  *time.Time `json:"startsAt"`
+	EndsAt    *time.Time `json:"endsAt"`
+	// UsageLimit caps the orders that use the promotion, and
+	// UsageLimitPerAccount the orders of each account. Zero is no limit.
+	// Cancelled orders do not count.
+	UsageLimit           uint64    `json:"usageLimit"`
+	UsageLimitPerAccount uint64    `json:"usageLimitPerAccount"`
+	CreatedAt            time.Time `json:"createdAt"`
+}
+
+// PromotionUsage counts the orders, other than the one being priced, that
+// use a promotion: in all, and of the account placing the order.
+type PromotionUsage struct {
+	Orders        uint64
+	AccountOrders uint64
+}
+
+// OrderDiscount is an amount a promotion takes off an order. ProductID is
+// the line discounted, or empty for discounts on several lines together.
+type OrderDiscount struct {
+	PromotionID string      `json:"promotionId"`
+	Code        string      `json:"code"`
+	Description string      `json:"description"`
+	ProductID   string      `json:"productId"`
+	Amount      money.Money `json:"amount"`
+}
+
+// NormalizeCouponCode makes codes case insensitive.
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (promotion *Promotion) name() string {
+	if promotion.Code != "" {
+		return "coupon " + promotion.Code
+	}
+	return "promotion " + promotion.ID
+}
+
+func (promotion *Promotion) eligible(product *OrderProduct) bool {
+	if len(promotion.ProductIDs) == 0 && len(promotion.CategoryIDs) == 0 {
+		return true
+	}
+	return slices.Contains(promotion.ProductIDs, product.ProductID) ||
+		(product.CategoryID != "" && slices.Contains(promotion.CategoryIDs, product.CategoryID))
+}
+
+// promotionDiscounts returns what promotion takes off order, or an error
+// wrapping ErrPromotionNotApplicable that says why it does not apply.
+func promotionDiscounts(order *Order, promotion *Promotion, usage PromotionUsage, now time.Time) ([]*OrderDiscount, error) {
+	switch {
+	case promotion.StartsAt != nil && now.Before(*promotion.StartsAt):
+		return nil, fmt.Errorf("%w: %s has not started yet", ErrPromotionNotApplicable, promotion.name())
+	case promotion.EndsAt != nil && !now.Before(*promotion.EndsAt):
+		return nil, fmt.Errorf("%w: %s has expired", ErrPromotionNotApplicable, promotion.name())
+	case promotion.UsageLimit > 0 && usage.Orders >= promotion.UsageLimit:
+		return nil, fmt.Errorf("%w: %s has been used up", ErrPromotionNotApplicable, promotion.name())
+	case promotion.UsageLimitPerAccount > 0 && usage.AccountOrders >= promotion.UsageLimitPerAccount:
+		return nil, fmt.Errorf("%w: %s has already been used by this account", ErrPromotionNotApplicable, promotion.name())
+	case len(promotion.AccountIDs) > 0 && !slices.Contains(promotion.AccountIDs, order.AccountID):
+		return nil, fmt.Errorf("%w: %s is not available to this account", ErrPromotionNotApplicable, promotion.name())
+	}
+	lines := []*OrderProduct{}
+	for _, product := range order.Products {
+		if promotion.eligible(product) {
+			lines = append(lines, product)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: %s does not apply to any product of the order", ErrPromotionNotApplicable, promotion.name())
+	}
+
+	discounts := []*OrderDiscount{}
+	discount := func(productID string, amount money.Money) {
+		if amount.Amount > 0 {
+			discounts = append(discounts, &OrderDiscount{
+				PromotionID: promotion.ID,
+				Code:        promotion.Code,
+				Description: promotion.Description,
+				ProductID:   productID,
+				Amount:      amount,
+			})
+		}
+	}
+	switch promotion.Type {
+	case PromotionTypePercentage:
+		share := big.NewRat(int64(promotion.PercentOff), 100)
+		for _, line := range lines {
+			total, err := line.Price.Mul(int64(line.Quantity))
+			if err != nil {
+				return nil, err
+			}
+			amount, err := money.RoundToMinor(new(big.Rat).Mul(total.Rat(), share), total.Currency)
+			if err != nil {
+				return nil, err
+			}
+			discount(line.ProductID, money.New(amount, total.Currency))
+		}
+	case PromotionTypeFixed:
+		subtotal, err := orderTotal(lines)
+		if err != nil {
+			return nil, err
+		}
+		if promotion.AmountOff.Currency != subtotal.Currency {
+			return nil, fmt.Errorf("%w: %s is only available for orders in %s", ErrPromotionNotApplicable, promotion.name(), promotion.AmountOff.Currency)
+		}
+		amount := promotion.AmountOff
+		if amount.Amount > subtotal.Amount {
+			amount = subtotal
+		}
+		productID := ""
+		if len(lines) == 1 {
+			productID = lines[0].ProductID
+		}
+		discount(productID, amount)
+	case PromotionTypeBuyXGetY:
+		group := int64(promotion.BuyQuantity + promotion.GetQuantity)
+		for _, line := range lines {
+			free := int64(line.Quantity) / group * int64(promotion.GetQuantity)
+			amount, err := line.Price.Mul(free)
+			if err != nil {
+				return nil, err
+			}
+			discount(line.ProductID, amount)
+		}
+		if len(discounts) == 0 {
+			return nil, fmt.Errorf("%w: %s needs %d units of a product to give %d free", ErrPromotionNotApplicable, promotion.name(), group, promotion.GetQuantity)
+		}
+	default:
+		return nil, fmt.Errorf("invalid promotion type %q", promotion.Type)
+	}
+	if len(discounts) == 0 {
+		return nil, fmt.Errorf("%w: %s gives no discount on this order", ErrPromotionNotApplicable, promotion.name())
+	}
+	return discounts, nil
+}
+
+// applyPromotions returns the discounts of the promotions that take the most
+// off order: either every applicable stackable promotion together, or the
+// best other promotion alone. Together, discounts never exceed the order's
+// lines.
+func applyPromotions(order *Order, promotions []*Promotion, usage map[string]PromotionUsage, now time.Time) ([]*OrderDiscount, error) {
+	subtotal, err := orderTotal(order.Products)
+	if err != nil {
+		return nil, err
+	}
+	stacked := []*OrderDiscount{}
+	best := []*OrderDiscount{}
+	bestAmount := int64(0)
+	for _, promotion := range promotions {
+		discounts, err := promotionDiscounts(order, promotion, usage[promotion.ID], now)
+		if errors.Is(err, ErrPromotionNotApplicable) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if promotion.Stackable {
+			stacked = append(stacked, discounts...)
+			continue
+		}
+		if amount := discountAmount(discounts); amount > bestAmount {
+			best, bestAmount = discounts, amount
+		}
+	}
+	if discountAmount(stacked) >= bestAmount {
+		best = stacked
+	}
+
+	// Stacked discounts may add up to more than the order, so the last ones
+	// are cut down to what is left.
+	left := subtotal.Amount
+	discounts := []*OrderDiscount{}
+	for _, discount := range best {
+		if left == 0 {
+			break
+		}
+		if discount.Amount.Amount > left {
+			discount.Amount.Amount = left
+		}
+		left -= discount.Amount.Amount
+		discounts = append(discounts, discount)
+	}
+	return discounts, nil
+}
+
+func discountAmount(discounts []*OrderDiscount) int64 {
+	amount := int64(0)
+	for _, discount := range discounts {
+		amount += discount.Amount.Amount
+	}
+	return amount
+}
+
+// setDiscounts sets the discounts of order and recomputes its totals.
+func setDiscounts(order *Order, discounts []*OrderDiscount) error {
+	order.Discounts = discounts
+	return updateTotals(order)
+}
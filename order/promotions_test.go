@@ -0,0 +1,220 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+var promotionTestTime = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// promotionTestOrder has a line of 2 lamps at 10.00 USD in category lights
+// and a line of 3 bulbs at 5.00 USD in category parts: 35.00 USD in all.
+func promotionTestOrder() *Order {
+	return &Order{
+		ID:        "order",
+		AccountID: "customer",
+		Products: []*OrderProduct{
+			{ProductID: "lamp", Price: money.New(1000, "USD"), Quantity: 2, CategoryID: "lights"},
+			{ProductID: "bulb", Price: money.New(500, "USD"), Quantity: 3, CategoryID: "parts"},
+		},
+	}
+}
+
+// describeDiscounts lists discounts as product:amount, with "*" for
+// discounts on several lines.
+func describeDiscounts(discounts []*OrderDiscount) []string {
+	described := []string{}
+	for _, discount := range discounts {
+		productID := discount.ProductID
+		if productID == "" {
+			productID = "*"
+		}
+		described = append(described, fmt.Sprintf("%s:%d", productID, discount.Amount.Amount))
+	}
+	return described
+}
+
+func TestPromotionDiscounts(t *testing.T) {
+	before := promotionTestTime.Add(-time.Hour)
+	after := promotionTestTime.Add(time.Hour)
+	tests := []struct {
+		name      string
+		promotion Promotion
+		usage     PromotionUsage
+		want      []string
+	}{
+		{
+			name:      "percentage of every line",
+			promotion: Promotion{Type: PromotionTypePercentage, PercentOff: 10},
+			want:      []string{"lamp:200", "bulb:150"},
+		},
+		{
+			name:      "percentage of a product",
+			promotion: Promotion{Type: PromotionTypePercentage, PercentOff: 15, ProductIDs: []string{"bulb"}},
+			want:      []string{"bulb:225"},
+		},
+		{
+			name:      "percentage of a category",
+			promotion: Promotion{Type: PromotionTypePercentage, PercentOff: 33, CategoryIDs: []string{"parts"}},
+			want:      []string{"bulb:495"},
+		},
+		{
+			name:      "fixed amount off several lines",
+			promotion: Promotion{Type: PromotionTypeFixed, AmountOff: money.New(500, "USD")},
+			want:      []string{"*:500"},
+		},
+		{
+			name:      "fixed amount off one category",
+			promotion: Promotion{Type: PromotionTypeFixed, AmountOff: money.New(500, "USD"), CategoryIDs: []string{"lights"}},
+			want:      []string{"lamp:500"},
+		},
+		{
+			name:      "fixed amount capped at the lines",
+			promotion: Promotion{Type: PromotionTypeFixed, AmountOff: money.New(5000, "USD"), ProductIDs: []string{"bulb"}},
+			want:      []string{"bulb:1500"},
+		},
+		{
+			name:      "buy 2 get 1 only discounts lines with enough units",
+			promotion: Promotion{Type: PromotionTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1},
+			want:      []string{"bulb:500"},
+		},
+		{
+			name:      "buy 1 get 1",
+			promotion: Promotion{Type: PromotionTypeBuyXGetY, BuyQuantity: 1, GetQuantity: 1},
+			want:      []string{"lamp:1000", "bulb:500"},
+		},
+		{
+			name:      "within its dates and limits",
+			promotion: Promotion{Type: PromotionTypePercentage, PercentOff: 10, ProductIDs: []string{"lamp"}, StartsAt: &before, EndsAt: &after, UsageLimit: 2, UsageLimitPerAccount: 1, AccountIDs: []string{"customer"}},
+			usage:     PromotionUsage{Orders: 1},
+			want:      []string{"lamp:200"},
+		},
+	}
+	for _, test := range tests {
+		promotion := test.promotion
+		discounts, err := promotionDiscounts(promotionTestOrder(), &promotion, test.usage, promotionTestTime)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if got := describeDiscounts(discounts); !slices.Equal(got, test.want) {
+			t.Errorf("%s: discounts = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestPercentageDiscountRoundsHalfAwayFromZero(t *testing.T) {
+	order := &Order{Products: []*OrderProduct{
+		{ProductID: "pen", Price: money.New(333, "USD"), Quantity: 1},
+		{ProductID: "pad", Price: money.New(101, "USD"), Quantity: 1},
+	}}
+	promotion := &Promotion{Type: PromotionTypePercentage, PercentOff: 50}
+	discounts, err := promotionDiscounts(order, promotion, PromotionUsage{}, promotionTestTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"pen:167", "pad:51"}
+	if got := describeDiscounts(discounts); !slices.Equal(got, want) {
+		t.Errorf("discounts = %v, want %v", got, want)
+	}
+}
+
+func TestPromotionDiscountsNotApplicable(t *testing.T) {
+	before := promotionTestTime.Add(-time.Hour)
+	after := promotionTestTime.Add(time.Hour)
+	tests := []struct {
+		name      string
+		promotion Promotion
+		usage     PromotionUsage
+	}{
+		{"not started", Promotion{Type: PromotionTypePercentage, PercentOff: 10, StartsAt: &after}, PromotionUsage{}},
+		{"expired", Promotion{Type: PromotionTypePercentage, PercentOff: 10, EndsAt: &before}, PromotionUsage{}},
+		{"used up", Promotion{Type: PromotionTypePercentage, PercentOff: 10, UsageLimit: 5}, PromotionUsage{Orders: 5}},
+		{"used up by the account", Promotion{Type: PromotionTypePercentage, PercentOff: 10, UsageLimitPerAccount: 1}, PromotionUsage{Orders: 1, AccountOrders: 1}},
+		{"other accounts only", Promotion{Type: PromotionTypePercentage, PercentOff: 10, AccountIDs: []string{"someone else"}}, PromotionUsage{}},
+		{"no eligible products", Promotion{Type: PromotionTypePercentage, PercentOff: 10, CategoryIDs: []string{"furniture"}}, PromotionUsage{}},
+		{"other currency", Promotion{Type: PromotionTypeFixed, AmountOff: money.New(500, "EUR")}, PromotionUsage{}},
+		{"not enough units", Promotion{Type: PromotionTypeBuyXGetY, BuyQuantity: 3, GetQuantity: 1, ProductIDs: []string{"lamp"}}, PromotionUsage{}},
+	}
+	for _, test := range tests {
+		promotion := test.promotion
+		discounts, err := promotionDiscounts(promotionTestOrder(), &promotion, test.usage, promotionTestTime)
+		if !errors.Is(err, ErrPromotionNotApplicable) {
+			t.Errorf("%s: discounts = %v, error = %v, want %v", test.name, describeDiscounts(discounts), err, ErrPromotionNotApplicable)
+		}
+	}
+}
+
+func TestApplyPromotions(t *testing.T) {
+	expired := promotionTestTime.Add(-time.Hour)
+	tests := []struct {
+		name       string
+		promotions []*Promotion
+		usage      map[string]PromotionUsage
+		want       []string
+	}{
+		{
+			name: "stacked promotions beat a bigger single one",
+			promotions: []*Promotion{
+				{ID: "ten", Type: PromotionTypePercentage, PercentOff: 10, Stackable: true},
+				{ID: "five off", Type: PromotionTypeFixed, AmountOff: money.New(500, "USD"), Stackable: true},
+				{ID: "twenty", Type: PromotionTypePercentage, PercentOff: 20},
+			},
+			want: []string{"lamp:200", "bulb:150", "*:500"},
+		},
+		{
+			name: "a single promotion beats smaller stacked ones",
+			promotions: []*Promotion{
+				{ID: "ten", Type: PromotionTypePercentage, PercentOff: 10, Stackable: true},
+				{ID: "twenty", Type: PromotionTypePercentage, PercentOff: 20},
+				{ID: "fifteen", Type: PromotionTypePercentage, PercentOff: 15},
+			},
+			want: []string{"lamp:400", "bulb:300"},
+		},
+		{
+			name: "stacked discounts are cut down to the order",
+			promotions: []*Promotion{
+				{ID: "thirty off", Type: PromotionTypeFixed, AmountOff: money.New(3000, "USD"), Stackable: true},
+				{ID: "half", Type: PromotionTypePercentage, PercentOff: 50, Stackable: true},
+			},
+			want: []string{"*:3000", "lamp:500"},
+		},
+		{
+			name: "promotions that do not apply are skipped",
+			promotions: []*Promotion{
+				{ID: "expired", Type: PromotionTypePercentage, PercentOff: 90, EndsAt: &expired},
+				{ID: "used", Type: PromotionTypePercentage, PercentOff: 80, UsageLimit: 1},
+				{ID: "bulbs", Type: PromotionTypePercentage, PercentOff: 10, ProductIDs: []string{"bulb"}},
+			},
+			usage: map[string]PromotionUsage{"used": {Orders: 1}},
+			want:  []string{"bulb:150"},
+		},
+		{
+			name: "no promotions",
+			want: []string{},
+		},
+	}
+	for _, test := range tests {
+		order := promotionTestOrder()
+		discounts, err := applyPromotions(order, test.promotions, test.usage, promotionTestTime)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if got := describeDiscounts(discounts); !slices.Equal(got, test.want) {
+			t.Errorf("%s: discounts = %v, want %v", test.name, got, test.want)
+		}
+		if err := setDiscounts(order, discounts); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if order.Subtotal.Amount != 3500 || order.TotalPrice.Amount != 3500-order.DiscountTotal.Amount || order.TotalPrice.Amount < 0 {
+			t.Errorf("%s: subtotal %v, discount %v, total %v do not add up", test.name, order.Subtotal, order.DiscountTotal, order.TotalPrice)
+		}
+	}
+}
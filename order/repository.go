@@ -3,24 +3,111 @@ package order
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/pricing"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/shipping"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/tax"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	"github.com/lib/pq"
 )
 
 type Repository interface {
 	Close()
+	// CreateOrUpdateOrder stores a new order, or replaces every line of an
+	// existing pending order.
 	CreateOrUpdateOrder(ctx context.Context, order *Order) (*Order, error)
+	// UpdateOrderItems applies operation with products to the lines of a
+	// pending order in one transaction, keeping its total in step, and
+	// records the changes. rates are added for currencies the order has no
+	// rate for yet.
+	UpdateOrderItems(ctx context.Context, id string, operation ItemOperation, products []*OrderProduct, rates []*pricing.Rate) (*Order, error)
+	// ApplyCoupon adds a coupon to a pending order and reprices it. It fails
+	// unless the coupon gives the order a discount.
+	ApplyCoupon(ctx context.Context, orderID string, code string) (*Order, error)
+	// UpdateOrderStatus moves an order to status. An order that becomes paid
+	// is invoiced, with an invoice for each merchant that sold some of its
+	// lines.
+	UpdateOrderStatus(ctx context.Context, id string, status OrderStatus) (*Order, error)
+	// ListOrderItemChanges returns the changes made to an order's lines,
+	// oldest first.
+	ListOrderItemChanges(ctx context.Context, orderID string) ([]*OrderItemChange, error)
 	GetOrderById(ctx context.Context, id string) (*Order, error)
-	GetOrdersForAccount(ctx context.Context, accountId string) ([]*Order, error)
+	// GetOrdersForAccount returns the page of take orders of an account that
+	// follows cursor, or the first page without one, newest first.
+	GetOrdersForAccount(ctx context.Context, accountId string, take uint64, cursor string) (*OrderPage, error)
+	// SearchOrders returns the page of take orders matching filter that
+	// follows cursor, or the first page without one, sorted by filter.Sort.
+	SearchOrders(ctx context.Context, filter OrderFilter, take uint64, cursor string) (*OrderPage, error)
+	// RefreshCoPurchases recounts, from every order, how often each pair of
+	// products was bought together and returns the number of pairs.
+	RefreshCoPurchases(ctx context.Context) (int64, error)
+	// ListCoPurchases returns the products most often bought with productID,
+	// most frequent first.
+	ListCoPurchases(ctx context.Context, productID string, take uint64) ([]*CoPurchase, error)
+	CreateOrUpdatePromotion(ctx context.Context, promotion *Promotion) (*Promotion, error)
+	// ListPromotions returns promotions, newest first.
+	ListPromotions(ctx context.Context, skip uint64, take uint64) ([]*Promotion, error)
+	// CreateShipment stores a shipment of a paid or shipped order, which
+	// becomes shipped. Without items, the shipment holds every unit not
+	// shipped yet; a merchant's shipment only holds units of the merchant's
+	// lines.
+	CreateShipment(ctx context.Context, shipment *Shipment) (*Shipment, error)
+	// MarkDelivered marks a shipment as delivered at deliveredAt. Once every
+	// unit of its order has shipped and been delivered, the order becomes
+	// delivered.
+	MarkDelivered(ctx context.Context, shipmentID string, deliveredAt time.Time) (*Shipment, error)
+	// ListShipments returns the shipments of an order, oldest first.
+	ListShipments(ctx context.Context, orderID string) ([]*Shipment, error)
+	// CreateReturn stores a return requested for a delivered order. It
+	// fails if the return asks for more units of a line than are left to
+	// return.
+	CreateReturn(ctx context.Context, orderReturn *Return) (*Return, error)
+	// UpdateReturnStatus moves a return to approved, rejected or received at
+	// at, keeping its note unless a new one is given.
+	UpdateReturnStatus(ctx context.Context, id string, status ReturnStatus, note string, at time.Time) (*Return, error)
+	// InspectReturn records the units of a received return accepted and
+	// restocked, as inspectItems does, refunds the accepted units, issuing a
+	// credit note for each merchant, and hands the restocked ones to the
+	// repository's restocker.
+	InspectReturn(ctx context.Context, id string, items []*ReturnItem, note string, at time.Time) (*Return, error)
+	GetReturn(ctx context.Context, id string) (*Return, error)
+	// ListReturns returns the returns of an order, or of every order if
+	// orderID is empty, newest first. A status only returns those in it.
+	ListReturns(ctx context.Context, orderID string, status ReturnStatus, skip uint64, take uint64) ([]*Return, error)
+	GetInvoice(ctx context.Context, id string) (*Invoice, error)
+	// ListInvoices returns the invoices and credit notes of an order, oldest
+	// first.
+	ListInvoices(ctx context.Context, orderID string) ([]*Invoice, error)
+	// ListMerchantOrders returns the page of take merchant orders matching
+	// filter that follows cursor, or the first page without one, newest
+	// first.
+	ListMerchantOrders(ctx context.Context, filter MerchantOrderFilter, take uint64, cursor string) (*MerchantOrderPage, error)
+	// GetMerchantPayouts sums the payable merchant orders of a merchant
+	// placed from from, inclusive, to to, exclusive, for each currency. Nil
+	// bounds are open.
+	GetMerchantPayouts(ctx context.Context, merchantID string, from *time.Time, to *time.Time) ([]*MerchantPayout, error)
 }
 
 type PostgresRepository struct {
-	db     *sql.DB
-	logger util.Logger
+	db        *sql.DB
+	taxes     tax.TaxCalculator
+	shipping  shipping.ShippingRateProvider
+	restocker Restocker
+	logger    util.Logger
 }
 
-func NewPostgresRepository(url string, logger util.Logger) (Repository, error) {
+// NewPostgresRepository returns a repository that taxes orders with taxes
+// and quotes their shipping method with shipping whenever it prices them,
+// and restocks the units of inspected returns with restocker.
+func NewPostgresRepository(url string, taxes tax.TaxCalculator, shipping shipping.ShippingRateProvider, restocker Restocker, logger util.Logger) (Repository, error) {
 	db, err := sql.Open("postgres", url)
 	if err != nil {
 		return nil, err
@@ -29,14 +116,17 @@ func NewPostgresRepository(url string, logger util.Logger) (Repository, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &PostgresRepository{db, logger}, nil
+	return &PostgresRepository{db, taxes, shipping, restocker, logger}, nil
 }
 
 func (repository *PostgresRepository) Close() {
 	repository.db.Close()
 }
 
-func (repository *PostgresRepository) CreateOrUpdateOrder(ctx context.Context, order *Order) (*Order, error) {
+// CreateOrUpdateOrder inserts order, or replaces the lines of the existing
+// order with its id as UpdateOrderItems does. Either way the order's coupon
+// codes must all give it a discount.
+func (repository *PostgresRepository) CreateOrUpdateOrder(ctx context.Context, order *Order) (result *Order, err error) {
 	tx, err := repository.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -48,159 +138,1787 @@ func (repository *PostgresRepository) CreateOrUpdateOrder(ctx context.Context, o
 		}
 		err = tx.Commit()
 	}()
-	_, err = tx.ExecContext(ctx, "INSERT INTO orders (id, accountId,createdAt, totalPrice) VALUES ($1, $2, $3, $4) ON CONFLICT (id) DO UPDATE SET accountId = $2, totalPrice = $4", order.ID, order.AccountID, order.CreatedAt, order.TotalPrice)
+	existing, err := lockOrder(ctx, tx, order.ID)
+	if err == nil {
+		existing.TaxLocation = order.TaxLocation
+		existing.TaxExempt = order.TaxExempt
+		existing.ShippingAddress = order.ShippingAddress
+		existing.ShippingMethod = order.ShippingMethod
+		return repository.updateOrderItems(ctx, tx, existing, ItemOperationReplace, order.Products, order.ExchangeRates, order.CouponCodes)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	err = repository.priceOrder(ctx, tx, order, order.CouponCodes)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO orders (id, accountId, createdAt, totalPrice, currency, status, discount, taxCountry, taxRegion, taxExempt, shippingMethod, shippingFee) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)", order.ID, order.AccountID, order.CreatedAt, order.TotalPrice.Amount, order.TotalPrice.Currency, order.Status, order.DiscountTotal.Amount, order.TaxLocation.Country, order.TaxLocation.Region, order.TaxExempt, order.ShippingMethod, order.ShippingFee.Amount)
+	if err != nil {
+		return nil, err
+	}
+	for _, rate := range order.ExchangeRates {
+		_, err = tx.ExecContext(ctx, "INSERT INTO order_exchange_rates (orderId, base, quote, rate, effectiveFrom) VALUES ($1, $2, $3, $4, $5)", order.ID, rate.Base, rate.Quote, rate.Decimal(), rate.EffectiveFrom)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, product := range order.Products {
+		_, err = tx.ExecContext(ctx, "INSERT INTO order_products (orderId, productId, quantity, name, description, price, categoryId, taxCategory, weight, merchantId) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)", order.ID, product.ProductID, product.Quantity, product.ProductName, product.ProductDescription, product.Price.Amount, product.CategoryID, product.TaxCategory, product.Weight, product.MerchantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = writeOrderPromotions(ctx, tx, order)
+	if err != nil {
+		return nil, err
+	}
+	err = writeOrderTaxes(ctx, tx, order)
+	if err != nil {
+		return nil, err
+	}
+	err = writeShippingAddress(ctx, tx, order)
+	if err != nil {
+		return nil, err
+	}
+	err = writeMerchantOrders(ctx, tx, order, nil)
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (repository *PostgresRepository) UpdateOrderItems(ctx context.Context, id string, operation ItemOperation, products []*OrderProduct, rates []*pricing.Rate) (result *Order, err error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	order, err := lockOrder(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	return repository.updateOrderItems(ctx, tx, order, operation, products, rates, nil)
+}
+
+func (repository *PostgresRepository) ApplyCoupon(ctx context.Context, orderID string, code string) (result *Order, err error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	order, err := lockOrder(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != OrderStatusPending {
+		return nil, fmt.Errorf("%w: order %s is %s", ErrOrderNotPending, order.ID, order.Status)
+	}
+	err = repository.priceOrder(ctx, tx, order, []string{code})
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE orders SET totalPrice = $2, discount = $3, shippingFee = $4 WHERE id = $1", order.ID, order.TotalPrice.Amount, order.DiscountTotal.Amount, order.ShippingFee.Amount)
+	if err != nil {
+		return nil, err
+	}
+	err = writeOrderPromotions(ctx, tx, order)
+	if err != nil {
+		return nil, err
+	}
+	err = writeOrderTaxes(ctx, tx, order)
+	if err != nil {
+		return nil, err
+	}
+	err = writeMerchantOrders(ctx, tx, order, nil)
+	if err != nil {
+		return nil, err
+	}
+	rates, err := getExchangeRates(ctx, tx, []string{order.ID})
+	if err != nil {
+		return nil, err
+	}
+	order.ExchangeRates = rates[order.ID]
+	return order, nil
+}
+
+func (repository *PostgresRepository) UpdateOrderStatus(ctx context.Context, id string, status OrderStatus) (result *Order, err error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	order, err := lockOrder(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !order.Status.CanBecome(status) {
+		return nil, fmt.Errorf("order %s cannot change from %s to %s", id, order.Status, status)
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE orders SET status = $2 WHERE id = $1", id, status)
+	if err != nil {
+		return nil, err
+	}
+	order.Status = status
+	if status == OrderStatusPaid {
+		invoices, err := orderInvoices(order, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		if err = writeInvoices(ctx, tx, invoices); err != nil {
+			return nil, err
+		}
+	}
+	shipments, err := getShipments(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeMerchantOrders(ctx, tx, order, shipments); err != nil {
+		return nil, err
+	}
+	rates, err := getExchangeRates(ctx, tx, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	order.ExchangeRates = rates[id]
+	return order, nil
+}
+
+// lockOrder loads an order, its lines, discounts, coupons, taxes and shipping
+// address, locking the order until tx ends.
+func lockOrder(ctx context.Context, tx *sql.Tx, id string) (*Order, error) {
+	order := &Order{ID: id, Products: []*OrderProduct{}}
+	var totalPrice, discount, shippingFee int64
+	var currency string
+	err := tx.QueryRowContext(ctx, "SELECT createdAt, accountId, totalPrice, currency, status, discount, taxCountry, taxRegion, taxExempt, shippingMethod, shippingFee FROM orders WHERE id = $1 FOR UPDATE", id).
+		Scan(&order.CreatedAt, &order.AccountID, &totalPrice, &currency, &order.Status, &discount, &order.TaxLocation.Country, &order.TaxLocation.Region, &order.TaxExempt, &order.ShippingMethod, &shippingFee)
+	if err != nil {
+		return nil, err
+	}
+	order.TotalPrice = money.New(totalPrice, currency)
+	order.DiscountTotal = money.New(discount, currency)
+	order.ShippingFee = money.New(shippingFee, currency)
+	rows, err := tx.QueryContext(ctx, "SELECT productId, quantity, name, description, price, categoryId, taxCategory, weight, merchantId FROM order_products WHERE orderId = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		product := &OrderProduct{OrderID: id}
+		var description sql.NullString
+		var price int64
+		if err := rows.Scan(&product.ProductID, &product.Quantity, &product.ProductName, &description, &price, &product.CategoryID, &product.TaxCategory, &product.Weight, &product.MerchantID); err != nil {
+			return nil, err
+		}
+		product.ProductDescription = description.String
+		product.Price = money.New(price, currency)
+		order.Products = append(order.Products, product)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := getOrderPromotions(ctx, tx, []*Order{order}); err != nil {
+		return nil, err
+	}
+	if err := getOrderTaxes(ctx, tx, []*Order{order}); err != nil {
+		return nil, err
+	}
+	if err := getShippingAddresses(ctx, tx, []*Order{order}); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// updateOrderItems applies operation to the lines of an order locked in tx,
+// reprices it with its promotions and coupons, including the coupons given,
+// writes the lines and totals that result and records the changes. rates
+// are added to the order's rates, or replace them if every line was replaced.
+func (repository *PostgresRepository) updateOrderItems(ctx context.Context, tx *sql.Tx, order *Order, operation ItemOperation, products []*OrderProduct, rates []*pricing.Rate, coupons []string) (*Order, error) {
+	changes, err := applyItemChanges(order, operation, products, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	err = repository.priceOrder(ctx, tx, order, coupons)
+	if err != nil {
+		return nil, err
+	}
+	productIDs := []string{}
+	for _, product := range order.Products {
+		productIDs = append(productIDs, product.ProductID)
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM order_products WHERE orderId = $1 AND NOT (productId = ANY($2))", order.ID, pq.Array(productIDs))
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range order.Products {
+		_, err = tx.ExecContext(ctx, "INSERT INTO order_products (orderId, productId, quantity, name, description, price, categoryId, taxCategory, weight, merchantId) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (orderId, productId) DO UPDATE SET quantity = $3, name = $4, description = $5, price = $6, categoryId = $7, taxCategory = $8, weight = $9, merchantId = $10", order.ID, product.ProductID, product.Quantity, product.ProductName, product.ProductDescription, product.Price.Amount, product.CategoryID, product.TaxCategory, product.Weight, product.MerchantID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE orders SET accountId = $2, totalPrice = $3, currency = $4, discount = $5, taxCountry = $6, taxRegion = $7, taxExempt = $8, shippingMethod = $9, shippingFee = $10 WHERE id = $1", order.ID, order.AccountID, order.TotalPrice.Amount, order.TotalPrice.Currency, order.DiscountTotal.Amount, order.TaxLocation.Country, order.TaxLocation.Region, order.TaxExempt, order.ShippingMethod, order.ShippingFee.Amount)
+	if err != nil {
+		return nil, err
+	}
+	err = writeOrderPromotions(ctx, tx, order)
+	if err != nil {
+		return nil, err
+	}
+	err = writeOrderTaxes(ctx, tx, order)
+	if err != nil {
+		return nil, err
+	}
+	err = writeShippingAddress(ctx, tx, order)
+	if err != nil {
+		return nil, err
+	}
+	err = writeMerchantOrders(ctx, tx, order, nil)
+	if err != nil {
+		return nil, err
+	}
+	if operation == ItemOperationReplace {
+		_, err = tx.ExecContext(ctx, "DELETE FROM order_exchange_rates WHERE orderId = $1", order.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, rate := range rates {
+		_, err = tx.ExecContext(ctx, "INSERT INTO order_exchange_rates (orderId, base, quote, rate, effectiveFrom) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (orderId, base) DO NOTHING", order.ID, rate.Base, rate.Quote, rate.Decimal(), rate.EffectiveFrom)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, change := range changes {
+		_, err = tx.ExecContext(ctx, "INSERT INTO order_item_changes (orderId, productId, operation, oldQuantity, newQuantity, price, currency, changedAt) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", change.OrderID, change.ProductID, change.Operation, change.OldQuantity, change.NewQuantity, change.Price.Amount, change.Price.Currency, change.ChangedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	orderRates, err := getExchangeRates(ctx, tx, []string{order.ID})
+	if err != nil {
+		return nil, err
+	}
+	order.ExchangeRates = orderRates[order.ID]
+	return order, nil
+}
+
+// priceOrder applies the order's promotions, with the coupons given, then
+// its taxes, which depend on its discounts, and then its shipping fee, which
+// depends on what it costs after them.
+func (repository *PostgresRepository) priceOrder(ctx context.Context, tx *sql.Tx, order *Order, coupons []string) error {
+	if err := applyOrderPromotions(ctx, tx, order, coupons); err != nil {
+		return err
+	}
+	if err := applyTaxes(order, repository.taxes); err != nil {
+		return err
+	}
+	return applyShipping(order, repository.shipping)
+}
+
+// applyOrderPromotions adds the coupons given to order and sets its
+// discounts to those of the best promotions it is eligible for. Every coupon
+// given must be part of them. The promotions considered are locked until tx
+// ends, so concurrent orders cannot exceed their usage limits.
+func applyOrderPromotions(ctx context.Context, tx *sql.Tx, order *Order, coupons []string) error {
+	for _, code := range coupons {
+		if !slices.Contains(order.CouponCodes, code) {
+			order.CouponCodes = append(order.CouponCodes, code)
+		}
+	}
+	now := time.Now().UTC()
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+promotionColumns+`,
+			(SELECT COUNT(DISTINCT d.orderId) FROM order_discounts d JOIN orders o ON (o.id = d.orderId)
+				WHERE d.promotionId = p.id AND d.orderId <> $1 AND o.status <> 'cancelled'),
+			(SELECT COUNT(DISTINCT d.orderId) FROM order_discounts d JOIN orders o ON (o.id = d.orderId)
+				WHERE d.promotionId = p.id AND d.orderId <> $1 AND o.status <> 'cancelled' AND o.accountId = $2)
+		FROM promotions p
+		WHERE (p.code = '' AND (p.endsAt IS NULL OR p.endsAt > $3)) OR p.code = ANY($4)
+		ORDER BY p.createdAt, p.id
+		FOR UPDATE OF p`, order.ID, order.AccountID, now, pq.Array(order.CouponCodes))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	promotions := []*Promotion{}
+	usage := map[string]PromotionUsage{}
+	for rows.Next() {
+		var promotionUsage PromotionUsage
+		promotion, err := scanPromotion(rows, &promotionUsage.Orders, &promotionUsage.AccountOrders)
+		if err != nil {
+			return err
+		}
+		promotions = append(promotions, promotion)
+		usage[promotion.ID] = promotionUsage
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, code := range coupons {
+		index := slices.IndexFunc(promotions, func(promotion *Promotion) bool { return promotion.Code == code })
+		if index < 0 {
+			return fmt.Errorf("%w: %s", ErrCouponNotFound, code)
+		}
+		if _, err := promotionDiscounts(order, promotions[index], usage[promotions[index].ID], now); err != nil {
+			return err
+		}
+	}
+	discounts, err := applyPromotions(order, promotions, usage, now)
+	if err != nil {
+		return err
+	}
+	for _, code := range coupons {
+		if !slices.ContainsFunc(discounts, func(discount *OrderDiscount) bool { return discount.Code == code }) {
+			return fmt.Errorf("%w: coupon %s cannot be combined with the order's other promotions", ErrPromotionNotApplicable, code)
+		}
+	}
+	return setDiscounts(order, discounts)
+}
+
+// writeOrderPromotions stores the coupons and discounts of order.
+func writeOrderPromotions(ctx context.Context, tx *sql.Tx, order *Order) error {
+	for _, code := range order.CouponCodes {
+		_, err := tx.ExecContext(ctx, "INSERT INTO order_coupons (orderId, code) VALUES ($1, $2) ON CONFLICT DO NOTHING", order.ID, code)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := tx.ExecContext(ctx, "DELETE FROM order_discounts WHERE orderId = $1", order.ID)
+	if err != nil {
+		return err
+	}
+	for position, discount := range order.Discounts {
+		_, err = tx.ExecContext(ctx, "INSERT INTO order_discounts (orderId, position, promotionId, code, description, productId, amount, currency) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", order.ID, position, discount.PromotionID, discount.Code, discount.Description, discount.ProductID, discount.Amount.Amount, discount.Amount.Currency)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOrderPromotions loads the discounts and coupon codes of orders.
+func getOrderPromotions(ctx context.Context, db queryer, orders []*Order) error {
+	byID := map[string]*Order{}
+	orderIDs := []string{}
+	for _, order := range orders {
+		order.Discounts = []*OrderDiscount{}
+		order.CouponCodes = []string{}
+		byID[order.ID] = order
+		orderIDs = append(orderIDs, order.ID)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT orderId, promotionId, code, description, productId, amount, currency FROM order_discounts WHERE orderId = ANY($1) ORDER BY orderId, position", pq.Array(orderIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		discount := &OrderDiscount{}
+		var orderID, currency string
+		var amount int64
+		if err := rows.Scan(&orderID, &discount.PromotionID, &discount.Code, &discount.Description, &discount.ProductID, &amount, &currency); err != nil {
+			return err
+		}
+		discount.Amount = money.New(amount, currency)
+		byID[orderID].Discounts = append(byID[orderID].Discounts, discount)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	codeRows, err := db.QueryContext(ctx, "SELECT orderId, code FROM order_coupons WHERE orderId = ANY($1) ORDER BY orderId, code", pq.Array(orderIDs))
+	if err != nil {
+		return err
+	}
+	defer codeRows.Close()
+	for codeRows.Next() {
+		var orderID, code string
+		if err := codeRows.Scan(&orderID, &code); err != nil {
+			return err
+		}
+		byID[orderID].CouponCodes = append(byID[orderID].CouponCodes, code)
+	}
+	return codeRows.Err()
+}
+
+// writeOrderTaxes replaces the stored taxes of the lines of order.
+func writeOrderTaxes(ctx context.Context, tx *sql.Tx, order *Order) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM order_taxes WHERE orderId = $1", order.ID)
+	if err != nil {
+		return err
+	}
+	for _, product := range order.Products {
+		if product.Tax == nil {
+			continue
+		}
+		_, err = tx.ExecContext(ctx, "INSERT INTO order_taxes (orderId, productId, country, region, category, rate, inclusive, amount, currency) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)", order.ID, product.ProductID, product.Tax.Country, product.Tax.Region, product.Tax.Category, tax.FormatRate(product.Tax.Rate), product.Tax.Inclusive, product.Tax.Amount.Amount, product.Tax.Amount.Currency)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOrderTaxes loads the taxes of the lines of orders, whose discounts must
+// already be loaded, and recomputes their totals.
+func getOrderTaxes(ctx context.Context, db queryer, orders []*Order) error {
+	lines := map[string]*OrderProduct{}
+	orderIDs := []string{}
+	for _, order := range orders {
+		for _, product := range order.Products {
+			lines[order.ID+"/"+product.ProductID] = product
+		}
+		orderIDs = append(orderIDs, order.ID)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT orderId, productId, country, region, category, rate, inclusive, amount, currency FROM order_taxes WHERE orderId = ANY($1)", pq.Array(orderIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		orderTax := &OrderTax{}
+		var orderID, productID, rate, currency string
+		var amount int64
+		if err := rows.Scan(&orderID, &productID, &orderTax.Country, &orderTax.Region, &orderTax.Category, &rate, &orderTax.Inclusive, &amount, &currency); err != nil {
+			return err
+		}
+		value, ok := new(big.Rat).SetString(rate)
+		if !ok {
+			return fmt.Errorf("invalid tax rate %q on order %s", rate, orderID)
+		}
+		orderTax.Rate = value
+		orderTax.Amount = money.New(amount, currency)
+		if product, ok := lines[orderID+"/"+productID]; ok {
+			product.Tax = orderTax
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, order := range orders {
+		if len(order.Products) == 0 {
+			continue
+		}
+		if err := updateTotals(order); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeShippingAddress replaces the stored shipping address of order.
+func writeShippingAddress(ctx context.Context, tx *sql.Tx, order *Order) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM order_shipping_addresses WHERE orderId = $1", order.ID)
+	if err != nil || order.ShippingAddress == nil {
+		return err
+	}
+	address := order.ShippingAddress
+	_, err = tx.ExecContext(ctx, "INSERT INTO order_shipping_addresses (orderId, addressId, name, line1, line2, city, region, postalCode, country, phone) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)", order.ID, address.AddressID, address.Name, address.Line1, address.Line2, address.City, address.Region, address.PostalCode, address.Country, address.Phone)
+	return err
+}
+
+// getShippingAddresses loads the shipping addresses of orders.
+func getShippingAddresses(ctx context.Context, db queryer, orders []*Order) error {
+	byID := map[string]*Order{}
+	orderIDs := []string{}
+	for _, order := range orders {
+		byID[order.ID] = order
+		orderIDs = append(orderIDs, order.ID)
+	}
+	rows, err := db.QueryContext(ctx, "SELECT orderId, addressId, name, line1, line2, city, region, postalCode, country, phone FROM order_shipping_addresses WHERE orderId = ANY($1)", pq.Array(orderIDs))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		address := &ShippingAddress{}
+		var orderID string
+		if err := rows.Scan(&orderID, &address.AddressID, &address.Name, &address.Line1, &address.Line2, &address.City, &address.Region, &address.PostalCode, &address.Country, &address.Phone); err != nil {
+			return err
+		}
+		byID[orderID].ShippingAddress = address
+	}
+	return rows.Err()
+}
+
+func (repository *PostgresRepository) GetOrderById(ctx context.Context, id string) (*Order, error) {
+	rows, err := repository.db.QueryContext(ctx, `
+		SELECT
+			o.id, o.createdAt, o.accountId, o.totalPrice, o.currency, o.status, o.discount,
+			o.taxCountry, o.taxRegion, o.taxExempt, o.shippingMethod, o.shippingFee,
+			op.productId, op.quantity, op.name, op.description, op.price, op.categoryId, op.taxCategory, op.weight, op.merchantId
+		FROM orders o
+		LEFT JOIN order_products op ON (o.id = op.orderId)
+		WHERE o.id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var order *Order
+	for rows.Next() {
+		var p OrderProduct
+		var productId sql.NullString
+		var quantity sql.NullInt32
+		var name sql.NullString
+		var description sql.NullString
+		var price sql.NullInt64
+		var categoryID sql.NullString
+		var taxCategory sql.NullString
+		var weight sql.NullInt32
+		var merchantID sql.NullString
+
+		var oID string
+		var oCreatedAt time.Time
+		var oAccountID string
+		var oTotalPrice int64
+		var oCurrency string
+		var oStatus OrderStatus
+		var oDiscount int64
+		var oTaxLocation tax.Location
+		var oTaxExempt bool
+		var oShippingMethod string
+		var oShippingFee int64
+
+		if err := rows.Scan(
+			&oID, &oCreatedAt, &oAccountID, &oTotalPrice, &oCurrency, &oStatus, &oDiscount,
+			&oTaxLocation.Country, &oTaxLocation.Region, &oTaxExempt, &oShippingMethod, &oShippingFee,
+			&productId, &quantity, &name, &description, &price, &categoryID, &taxCategory, &weight, &merchantID,
+		); err != nil {
+			return nil, err
+		}
+
+		if order == nil {
+			order = &Order{
+				ID:             oID,
+				CreatedAt:      oCreatedAt,
+				AccountID:      oAccountID,
+				TotalPrice:     money.New(oTotalPrice, oCurrency),
+				Products:       []*OrderProduct{},
+				Status:         oStatus,
+				DiscountTotal:  money.New(oDiscount, oCurrency),
+				TaxLocation:    oTaxLocation,
+				TaxExempt:      oTaxExempt,
+				ShippingMethod: oShippingMethod,
+				ShippingFee:    money.New(oShippingFee, oCurrency),
+			}
+		}
+
+		if productId.Valid {
+			p.OrderID = oID
+			p.ProductID = productId.String
+			p.Quantity = quantity.Int32
+			p.ProductName = name.String
+			p.ProductDescription = description.String
+			p.Price = money.New(price.Int64, oCurrency)
+			p.CategoryID = categoryID.String
+			p.TaxCategory = taxCategory.String
+			p.Weight = uint32(weight.Int32)
+			p.MerchantID = merchantID.String
+			order.Products = append(order.Products, &p)
+		}
+	}
+
+	if order == nil {
+		return nil, sql.ErrNoRows
+	}
+
+	rates, err := getExchangeRates(ctx, repository.db, []string{order.ID})
+	if err != nil {
+		return nil, err
+	}
+	order.ExchangeRates = rates[order.ID]
+
+	if err := getOrderPromotions(ctx, repository.db, []*Order{order}); err != nil {
+		return nil, err
+	}
+	if err := getOrderTaxes(ctx, repository.db, []*Order{order}); err != nil {
+		return nil, err
+	}
+	if err := getShippingAddresses(ctx, repository.db, []*Order{order}); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (repository *PostgresRepository) GetOrdersForAccount(ctx context.Context, accountId string, take uint64, cursor string) (*OrderPage, error) {
+	return repository.SearchOrders(ctx, OrderFilter{AccountID: accountId}, take, cursor)
+}
+
+func (repository *PostgresRepository) SearchOrders(ctx context.Context, filter OrderFilter, take uint64, cursor string) (*OrderPage, error) {
+	listing, err := listingKey("orders", filter)
+	if err != nil {
+		return nil, err
+	}
+	state := &keysetCursor{}
+	if cursor != "" {
+		state, err = decodeKeysetCursor(cursor, listing)
+		if err != nil {
+			return nil, err
+		}
+	}
+	query := &orderQuery{}
+	query.filter(filter)
+	page := &OrderPage{Orders: []*Order{}, Cursors: []string{}}
+	err = repository.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM orders o WHERE "+query.conditionSQL(), query.args...).Scan(&page.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	var terms []orderTerm
+	switch filter.Sort {
+	case OrderSortOldest:
+		terms = []orderTerm{{column: "o.createdAt", value: state.CreatedAt}, {column: "o.id", value: state.ID}}
+	case OrderSortTotalDesc:
+		terms = []orderTerm{{column: "o.totalPrice", descending: true, value: state.TotalPrice}, {column: "o.id", descending: true, value: state.ID}}
+	case OrderSortTotalAsc:
+		terms = []orderTerm{{column: "o.totalPrice", value: state.TotalPrice}, {column: "o.id", value: state.ID}}
+	default:
+		terms = []orderTerm{{column: "o.createdAt", descending: true, value: state.CreatedAt}, {column: "o.id", descending: true, value: state.ID}}
+	}
+	if cursor != "" {
+		query.where(query.after(terms))
+	}
+	rows, err := repository.db.QueryContext(ctx,
+		"SELECT o.id, o.createdAt, o.totalPrice FROM orders o WHERE "+query.conditionSQL()+
+			" ORDER BY "+orderBy(terms)+" LIMIT "+query.arg(take),
+		query.args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := []string{}
+	offset := state.Offset
+	for rows.Next() {
+		key := &keysetCursor{Listing: listing}
+		if err := rows.Scan(&key.ID, &key.CreatedAt, &key.TotalPrice); err != nil {
+			return nil, err
+		}
+		offset++
+		key.Offset = offset
+		orderCursor, err := encodeKeysetCursor(key)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, key.ID)
+		page.Cursors = append(page.Cursors, orderCursor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if page.Orders, err = getOrders(ctx, repository.db, ids); err != nil {
+		return nil, err
+	}
+	if len(page.Cursors) > 0 && offset < page.TotalCount {
+		page.NextCursor = page.Cursors[len(page.Cursors)-1]
+	}
+	return page, nil
+}
+
+// getOrders loads the orders with the given ids, in that order, with their
+// lines, rates, discounts, taxes and shipping addresses. Lines are loaded by
+// a query of their own rather than joined to the orders.
+func getOrders(ctx context.Context, db queryer, ids []string) ([]*Order, error) {
+	orders := []*Order{}
+	if len(ids) == 0 {
+		return orders, nil
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, createdAt, accountId, totalPrice, currency, status, discount,
+			taxCountry, taxRegion, taxExempt, shippingMethod, shippingFee
+		FROM orders
+		WHERE id = ANY($1)`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	byID := map[string]*Order{}
+	for rows.Next() {
+		order := &Order{Products: []*OrderProduct{}}
+		var totalPrice, discount, shippingFee int64
+		var currency string
+		if err := rows.Scan(
+			&order.ID, &order.CreatedAt, &order.AccountID, &totalPrice, &currency, &order.Status, &discount,
+			&order.TaxLocation.Country, &order.TaxLocation.Region, &order.TaxExempt, &order.ShippingMethod, &shippingFee,
+		); err != nil {
+			return nil, err
+		}
+		order.TotalPrice = money.New(totalPrice, currency)
+		order.DiscountTotal = money.New(discount, currency)
+		order.ShippingFee = money.New(shippingFee, currency)
+		byID[order.ID] = order
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if order, ok := byID[id]; ok {
+			orders = append(orders, order)
+		}
+	}
+
+	lines, err := db.QueryContext(ctx, `
+		SELECT orderId, productId, quantity, name, COALESCE(description, ''), price, categoryId, taxCategory, weight, merchantId
+		FROM order_products
+		WHERE orderId = ANY($1)`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer lines.Close()
+	for lines.Next() {
+		product := &OrderProduct{}
+		var price int64
+		if err := lines.Scan(
+			&product.OrderID, &product.ProductID, &product.Quantity, &product.ProductName, &product.ProductDescription,
+			&price, &product.CategoryID, &product.TaxCategory, &product.Weight, &product.MerchantID,
+		); err != nil {
+			return nil, err
+		}
+		order := byID[product.OrderID]
+		product.Price = money.New(price, order.TotalPrice.Currency)
+		order.Products = append(order.Products, product)
+	}
+	if err := lines.Err(); err != nil {
+		return nil, err
+	}
+
+	rates, err := getExchangeRates(ctx, db, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		order.ExchangeRates = rates[order.ID]
+	}
+	if err := getOrderPromotions(ctx, db, orders); err != nil {
+		return nil, err
+	}
+	if err := getOrderTaxes(ctx, db, orders); err != nil {
+		return nil, err
+	}
+	if err := getShippingAddresses(ctx, db, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// orderQuery collects the conditions of an orders query and the arguments
+// they refer to.
+type orderQuery struct {
+	conditions []string
+	args       []any
+}
+
+// arg adds an argument and returns its placeholder.
+func (query *orderQuery) arg(value any) string {
+	query.args = append(query.args, value)
+	return "$" + strconv.Itoa(len(query.args))
+}
+
+func (query *orderQuery) where(condition string) {
+	query.conditions = append(query.conditions, condition)
+}
+
+func (query *orderQuery) conditionSQL() string {
+	if len(query.conditions) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(query.conditions, " AND ")
+}
+
+// filter adds the conditions of filter on orders aliased o.
+func (query *orderQuery) filter(filter OrderFilter) {
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, 0, len(filter.Statuses))
+		for _, status := range filter.Statuses {
+			statuses = append(statuses, string(status))
+		}
+		query.where("o.status = ANY(" + query.arg(pq.Array(statuses)) + ")")
+	}
+	if filter.AccountID != "" {
+		query.where("o.accountId = " + query.arg(filter.AccountID))
+	}
+	if filter.ProductID != "" {
+		query.where("EXISTS (SELECT 1 FROM order_products op WHERE op.productId = " + query.arg(filter.ProductID) + " AND op.orderId = o.id)")
+	}
+	if filter.CreatedFrom != nil {
+		query.where("o.createdAt >= " + query.arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		query.where("o.createdAt < " + query.arg(*filter.CreatedTo))
+	}
+	if filter.Currency != "" {
+		query.where("o.currency = " + query.arg(filter.Currency))
+	}
+	if filter.MinTotal != nil {
+		query.where("o.currency = " + query.arg(filter.MinTotal.Currency) + " AND o.totalPrice >= " + query.arg(filter.MinTotal.Amount))
+	}
+	if filter.MaxTotal != nil {
+		query.where("o.currency = " + query.arg(filter.MaxTotal.Currency) + " AND o.totalPrice <= " + query.arg(filter.MaxTotal.Amount))
+	}
+}
+
+// orderTerm is a column a page is sorted by and its value in the cursor the
+// page starts after.
+type orderTerm struct {
+	column     string
+	descending bool
+	value      any
+}
+
+// orderBy returns the ORDER BY list of terms.
+func orderBy(terms []orderTerm) string {
+	columns := []string{}
+	for _, term := range terms {
+		if term.descending {
+			columns = append(columns, term.column+" DESC")
+		} else {
+			columns = append(columns, term.column)
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+// after returns the condition that a row sorts after the cursor's values.
+func (query *orderQuery) after(terms []orderTerm) string {
+	alternatives := []string{}
+	equal := []string{}
+	for _, term := range terms {
+		operator := " > "
+		if term.descending {
+			operator = " < "
+		}
+		value := query.arg(term.value)
+		alternative := append(append([]string{}, equal...), term.column+operator+value)
+		alternatives = append(alternatives, "("+strings.Join(alternative, " AND ")+")")
+		equal = append(equal, term.column+" = "+value)
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")"
+}
+
+// queryer is implemented by *sql.DB and *sql.Tx.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// getExchangeRates loads the rate snapshots of the given orders keyed by order id.
+func getExchangeRates(ctx context.Context, db queryer, orderIDs []string) (map[string][]*pricing.Rate, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		"SELECT orderId, base, quote, rate, effectiveFrom FROM order_exchange_rates WHERE orderId = ANY($1)",
+		pq.Array(orderIDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := make(map[string][]*pricing.Rate)
+	for rows.Next() {
+		var orderID, base, quote, value string
+		var effectiveFrom time.Time
+		if err = rows.Scan(&orderID, &base, &quote, &value, &effectiveFrom); err != nil {
+			return nil, err
+		}
+		rate, err := pricing.ParseRate(base, quote, value, effectiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		rates[orderID] = append(rates[orderID], rate)
+	}
+	return rates, rows.Err()
+}
+
+func (repository *PostgresRepository) ListOrderItemChanges(ctx context.Context, orderID string) ([]*OrderItemChange, error) {
+	rows, err := repository.db.QueryContext(ctx, `
+		SELECT orderId, productId, operation, oldQuantity, newQuantity, price, currency, changedAt
+		FROM order_item_changes
+		WHERE orderId = $1
+		ORDER BY changedAt, id`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	changes := []*OrderItemChange{}
+	for rows.Next() {
+		change := &OrderItemChange{}
+		var price int64
+		var currency string
+		if err := rows.Scan(&change.OrderID, &change.ProductID, &change.Operation, &change.OldQuantity, &change.NewQuantity, &price, &currency, &change.ChangedAt); err != nil {
+			return nil, err
+		}
+		change.Price = money.New(price, currency)
+		changes = append(changes, change)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (repository *PostgresRepository) RefreshCoPurchases(ctx context.Context) (count int64, err error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	_, err = tx.ExecContext(ctx, "DELETE FROM product_co_purchases")
+	if err != nil {
+		return 0, err
+	}
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO product_co_purchases (productId, relatedProductId, orderCount)
+		SELECT a.productId, b.productId, COUNT(*)
+		FROM order_products a
+		JOIN order_products b ON (a.orderId = b.orderId AND a.productId <> b.productId)
+		GROUP BY a.productId, b.productId`)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (repository *PostgresRepository) ListCoPurchases(ctx context.Context, productID string, take uint64) ([]*CoPurchase, error) {
+	rows, err := repository.db.QueryContext(ctx, `
+		SELECT relatedProductId, orderCount
+		FROM product_co_purchases
+		WHERE productId = $1
+		ORDER BY orderCount DESC, relatedProductId
+		LIMIT $2`, productID, take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	coPurchases := []*CoPurchase{}
+	for rows.Next() {
+		coPurchase := &CoPurchase{}
+		if err := rows.Scan(&coPurchase.ProductID, &coPurchase.Orders); err != nil {
+			return nil, err
+		}
+		coPurchases = append(coPurchases, coPurchase)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return coPurchases, nil
+}
+
+const promotionColumns = "p.id, p.code, p.description, p.type, p.percentOff, p.amountOff, p.currency, p.buyQuantity, p.getQuantity, p.productIds, p.categoryIds, p.accountIds, p.stackable, p.startsAt, p.endsAt, p.usageLimit, p.usageLimitPerAccount, p.createdAt"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPromotion scans the promotionColumns of a row, followed by extra.
+func scanPromotion(row rowScanner, extra ...any) (*Promotion, error) {
+	promotion := &Promotion{}
+	var amountOff int64
+	var currency string
+	var startsAt, endsAt sql.NullTime
+	dest := []any{
+		&promotion.ID, &promotion.Code, &promotion.Description, &promotion.Type, &promotion.PercentOff,
+		&amountOff, &currency, &promotion.BuyQuantity, &promotion.GetQuantity,
+		pq.Array(&promotion.ProductIDs), pq.Array(&promotion.CategoryIDs), pq.Array(&promotion.AccountIDs),
+		&promotion.Stackable, &startsAt, &endsAt, &promotion.UsageLimit, &promotion.UsageLimitPerAccount, &promotion.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if currency != "" {
+		promotion.AmountOff = money.New(amountOff, currency)
+	}
+	if startsAt.Valid {
+		promotion.StartsAt = &startsAt.Time
+	}
+	if endsAt.Valid {
+		promotion.EndsAt = &endsAt.Time
+	}
+	return promotion, nil
+}
+
+func (repository *PostgresRepository) CreateOrUpdatePromotion(ctx context.Context, promotion *Promotion) (*Promotion, error) {
+	row := repository.db.QueryRowContext(ctx, `
+		INSERT INTO promotions AS p (id, code, description, type, percentOff, amountOff, currency, buyQuantity, getQuantity, productIds, categoryIds, accountIds, stackable, startsAt, endsAt, usageLimit, usageLimitPerAccount, createdAt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10, '{}'), COALESCE($11, '{}'), COALESCE($12, '{}'), $13, $14, $15, $16, $17, $18)
+		ON CONFLICT (id) DO UPDATE SET
+			code = $2, description = $3, type = $4, percentOff = $5, amountOff = $6, currency = $7, buyQuantity = $8, getQuantity = $9,
+			productIds = COALESCE($10, '{}'), categoryIds = COALESCE($11, '{}'), accountIds = COALESCE($12, '{}'),
+			stackable = $13, startsAt = $14, endsAt = $15, usageLimit = $16, usageLimitPerAccount = $17
+		RETURNING `+promotionColumns,
+		promotion.ID, promotion.Code, promotion.Description, promotion.Type, promotion.PercentOff,
+		promotion.AmountOff.Amount, promotion.AmountOff.Currency, promotion.BuyQuantity, promotion.GetQuantity,
+		pq.Array(promotion.ProductIDs), pq.Array(promotion.CategoryIDs), pq.Array(promotion.AccountIDs),
+		promotion.Stackable, promotion.StartsAt, promotion.EndsAt, promotion.UsageLimit, promotion.UsageLimitPerAccount, promotion.CreatedAt,
+	)
+	return scanPromotion(row)
+}
+
+func (repository *PostgresRepository) ListPromotions(ctx context.Context, skip uint64, take uint64) ([]*Promotion, error) {
+	rows, err := repository.db.QueryContext(ctx, "SELECT "+promotionColumns+" FROM promotions p ORDER BY p.createdAt DESC, p.id OFFSET $1 LIMIT $2", skip, take)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	promotions := []*Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+func (repository *PostgresRepository) CreateShipment(ctx context.Context, shipment *Shipment) (result *Shipment, err error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	order, err := lockOrder(ctx, tx, shipment.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != OrderStatusPaid && order.Status != OrderStatusShipped {
+		return nil, fmt.Errorf("order %s is %s and cannot ship", order.ID, order.Status)
+	}
+	if order.ShippingAddress == nil {
+		return nil, fmt.Errorf("order %s has no shipping address", order.ID)
+	}
+	shipments, err := getShipments(ctx, tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := shipmentItems(order, shipments, shipment.Items, shipment.MerchantID)
+	if err != nil {
+		return nil, err
+	}
+	newShipment := *shipment
+	newShipment.Items = items
+	newShipment.Status = ShipmentStatusShipped
+	newShipment.DeliveredAt = nil
+	_, err = tx.ExecContext(ctx, "INSERT INTO shipments (id, orderId, merchantId, carrier, trackingNumber, status, shippedAt) VALUES ($1, $2, $3, $4, $5, $6, $7)", newShipment.ID, newShipment.OrderID, newShipment.MerchantID, newShipment.Carrier, newShipment.TrackingNumber, newShipment.Status, newShipment.ShippedAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		_, err = tx.ExecContext(ctx, "INSERT INTO shipment_items (shipmentId, productId, quantity) VALUES ($1, $2, $3)", newShipment.ID, item.ProductID, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if order.Status == OrderStatusPaid {
+		_, err = tx.ExecContext(ctx, "UPDATE orders SET status = $2 WHERE id = $1", order.ID, OrderStatusShipped)
+		if err != nil {
+			return nil, err
+		}
+		order.Status = OrderStatusShipped
+	}
+	if err = writeMerchantOrders(ctx, tx, order, append(shipments, &newShipment)); err != nil {
+		return nil, err
+	}
+	return &newShipment, nil
+}
+
+func (repository *PostgresRepository) MarkDelivered(ctx context.Context, shipmentID string, deliveredAt time.Time) (result *Shipment, err error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	var orderID string
+	err = tx.QueryRowContext(ctx, "SELECT orderId FROM shipments WHERE id = $1", shipmentID).Scan(&orderID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := lockOrder(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	shipments, err := getShipments(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	index := slices.IndexFunc(shipments, func(shipment *Shipment) bool { return shipment.ID == shipmentID })
+	if index < 0 {
+		return nil, sql.ErrNoRows
+	}
+	shipment := shipments[index]
+	if shipment.Status == ShipmentStatusDelivered {
+		return nil, fmt.Errorf("shipment %s has already been delivered", shipmentID)
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE shipments SET status = $2, deliveredAt = $3 WHERE id = $1", shipmentID, ShipmentStatusDelivered, deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	shipment.Status = ShipmentStatusDelivered
+	shipment.DeliveredAt = &deliveredAt
+	if order.Status == OrderStatusShipped && fulfilled(order, shipments) {
+		_, err = tx.ExecContext(ctx, "UPDATE orders SET status = $2 WHERE id = $1", orderID, OrderStatusDelivered)
+		if err != nil {
+			return nil, err
+		}
+		order.Status = OrderStatusDelivered
+	}
+	if err = writeMerchantOrders(ctx, tx, order, shipments); err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+func (repository *PostgresRepository) ListShipments(ctx context.Context, orderID string) ([]*Shipment, error) {
+	return getShipments(ctx, repository.db, orderID)
+}
+
+// getShipments loads the shipments of an order and their items, oldest first.
+func getShipments(ctx context.Context, db queryer, orderID string) ([]*Shipment, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, orderId, merchantId, carrier, trackingNumber, status, shippedAt, deliveredAt
+		FROM shipments
+		WHERE orderId = $1
+		ORDER BY shippedAt, id`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	shipments := []*Shipment{}
+	byID := map[string]*Shipment{}
+	for rows.Next() {
+		shipment := &Shipment{Items: []*ShipmentItem{}}
+		var deliveredAt sql.NullTime
+		if err := rows.Scan(&shipment.ID, &shipment.OrderID, &shipment.MerchantID, &shipment.Carrier, &shipment.TrackingNumber, &shipment.Status, &shipment.ShippedAt, &deliveredAt); err != nil {
+			return nil, err
+		}
+		if deliveredAt.Valid {
+			shipment.DeliveredAt = &deliveredAt.Time
+		}
+		shipments = append(shipments, shipment)
+		byID[shipment.ID] = shipment
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	itemRows, err := db.QueryContext(ctx, `
+		SELECT i.shipmentId, i.productId, i.quantity
+		FROM shipment_items i
+		JOIN shipments s ON (s.id = i.shipmentId)
+		WHERE s.orderId = $1
+		ORDER BY i.shipmentId, i.productId`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer itemRows.Close()
+	for itemRows.Next() {
+		item := &ShipmentItem{}
+		var shipmentID string
+		if err := itemRows.Scan(&shipmentID, &item.ProductID, &item.Quantity); err != nil {
+			return nil, err
+		}
+		if shipment, ok := byID[shipmentID]; ok {
+			shipment.Items = append(shipment.Items, item)
+		}
+	}
+	if err := itemRows.Err(); err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
+
+func (repository *PostgresRepository) CreateReturn(ctx context.Context, orderReturn *Return) (result *Return, err error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
-	for _, product := range order.Products {
-		_, err = tx.ExecContext(ctx, "INSERT INTO order_products (orderId, productId, quantity, name, description, price) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT (orderId, productId) DO UPDATE SET quantity = $3, name = $4, description = $5, price = $6", order.ID, product.ProductID, product.Quantity, product.ProductName, product.ProductDescription, product.Price)
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	order, err := lockOrder(ctx, tx, orderReturn.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != OrderStatusDelivered {
+		return nil, fmt.Errorf("order %s is %s; only delivered orders can be returned", order.ID, order.Status)
+	}
+	returns, err := getOrderReturns(ctx, tx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := returnItems(order, returns, orderReturn.Items)
+	if err != nil {
+		return nil, err
+	}
+	newReturn := *orderReturn
+	newReturn.AccountID = order.AccountID
+	newReturn.Status = ReturnStatusRequested
+	newReturn.Items = items
+	newReturn.Refund = money.Zero(order.TotalPrice.Currency)
+	_, err = tx.ExecContext(ctx, "INSERT INTO order_returns (id, orderId, accountId, status, refund, currency, note, createdAt, updatedAt) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)", newReturn.ID, newReturn.OrderID, newReturn.AccountID, newReturn.Status, newReturn.Refund.Amount, newReturn.Refund.Currency, newReturn.Note, newReturn.CreatedAt, newReturn.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		_, err = tx.ExecContext(ctx, "INSERT INTO order_return_items (returnId, productId, quantity, reason, comment) VALUES ($1, $2, $3, $4, $5)", newReturn.ID, item.ProductID, item.Quantity, item.Reason, item.Comment)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return order, nil
+	return &newReturn, nil
 }
 
-func (repository *PostgresRepository) GetOrderById(ctx context.Context, id string) (*Order, error) {
-	rows, err := repository.db.QueryContext(ctx, `
-		SELECT
-			o.id, o.createdAt, o.accountId, o.totalPrice,
-			op.productId, op.quantity, op.name, op.description, op.price
-		FROM orders o
-		LEFT JOIN order_products op ON (o.id = op.orderId)
-		WHERE o.id = $1`, id)
+func (repository *PostgresRepository) UpdateReturnStatus(ctx context.Context, id string, status ReturnStatus, note string, at time.Time) (result *Return, err error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var order *Order
-	for rows.Next() {
-		var p OrderProduct
-		var productId sql.NullString
-		var quantity sql.NullInt32
-		var name sql.NullString
-		var description sql.NullString
-		var price sql.NullFloat64
-
-		var oID string
-		var oCreatedAt time.Time
-		var oAccountID string
-		var oTotalPrice float64
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	orderReturn, err := lockReturn(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if status == ReturnStatusInspected || !orderReturn.Status.CanBecome(status) {
+		return nil, fmt.Errorf("return %s cannot change from %s to %s", id, orderReturn.Status, status)
+	}
+	orderReturn.Status = status
+	orderReturn.UpdatedAt = at
+	if note != "" {
+		orderReturn.Note = note
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE order_returns SET status = $2, note = $3, updatedAt = $4 WHERE id = $1", id, orderReturn.Status, orderReturn.Note, orderReturn.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return orderReturn, nil
+}
 
-		if err := rows.Scan(
-			&oID, &oCreatedAt, &oAccountID, &oTotalPrice,
-			&productId, &quantity, &name, &description, &price,
-		); err != nil {
+func (repository *PostgresRepository) InspectReturn(ctx context.Context, id string, items []*ReturnItem, note string, at time.Time) (result *Return, err error) {
+	tx, err := repository.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	var orderID string
+	err = tx.QueryRowContext(ctx, "SELECT orderId FROM order_returns WHERE id = $1", id).Scan(&orderID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := lockOrder(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	orderReturn, err := lockReturn(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !orderReturn.Status.CanBecome(ReturnStatusInspected) {
+		return nil, fmt.Errorf("return %s is %s and cannot be inspected", id, orderReturn.Status)
+	}
+	returns, err := getOrderReturns(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := inspectItems(orderReturn, items); err != nil {
+		return nil, err
+	}
+	if err := refundReturn(order, returns, orderReturn); err != nil {
+		return nil, err
+	}
+	orderReturn.Status = ReturnStatusInspected
+	orderReturn.UpdatedAt = at
+	if note != "" {
+		orderReturn.Note = note
+	}
+	_, err = tx.ExecContext(ctx, "UPDATE order_returns SET status = $2, refund = $3, note = $4, updatedAt = $5 WHERE id = $1", id, orderReturn.Status, orderReturn.Refund.Amount, orderReturn.Note, orderReturn.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	restock := []*ReturnItem{}
+	for _, item := range orderReturn.Items {
+		_, err = tx.ExecContext(ctx, "UPDATE order_return_items SET acceptedQuantity = $3, restock = $4, refund = $5 WHERE returnId = $1 AND productId = $2", id, item.ProductID, item.AcceptedQuantity, item.Restock, item.Refund.Amount)
+		if err != nil {
 			return nil, err
 		}
-
-		if order == nil {
-			order = &Order{
-				ID:         oID,
-				CreatedAt:  oCreatedAt,
-				AccountID:  oAccountID,
-				TotalPrice: oTotalPrice,
-				Products:   []*OrderProduct{},
+		if item.Restock {
+			restock = append(restock, item)
+		}
+	}
+	if orderReturn.Refund.Amount > 0 {
+		invoices, err := queryInvoices(ctx, tx, "SELECT "+invoiceColumns+" FROM invoices i WHERE i.orderId = $1 AND i.kind = $2", orderID, InvoiceKindInvoice)
+		if err != nil {
+			return nil, err
+		}
+		notes, err := creditNotes(order, orderReturn, invoices, at)
+		if err != nil {
+			return nil, err
+		}
+		if err = writeInvoices(ctx, tx, notes); err != nil {
+			return nil, err
+		}
+		for _, note := range notes {
+			_, err = tx.ExecContext(ctx, "UPDATE merchant_orders SET refunded = refunded + $3, updatedAt = $4 WHERE orderId = $1 AND merchantId = $2", orderID, note.MerchantID, note.Total.Amount, at)
+			if err != nil {
+				return nil, err
 			}
 		}
-
-		if productId.Valid {
-			p.OrderID = oID
-			p.ProductID = productId.String
-			p.Quantity = quantity.Int32
-			p.ProductName = name.String
-			p.ProductDescription = description.String
-			p.Price = price.Float64
-			order.Products = append(order.Products, &p)
+	}
+	if len(restock) > 0 && repository.restocker != nil {
+		if err = repository.restocker.Restock(ctx, orderReturn, restock); err != nil {
+			return nil, fmt.Errorf("failed to restock return %s: %w", id, err)
 		}
 	}
+	return orderReturn, nil
+}
 
-	if order == nil {
+func (repository *PostgresRepository) GetReturn(ctx context.Context, id string) (*Return, error) {
+	returns, err := queryReturns(ctx, repository.db, "SELECT "+returnColumns+" FROM order_returns r WHERE r.id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(returns) == 0 {
 		return nil, sql.ErrNoRows
 	}
+	return returns[0], nil
+}
 
-	return order, nil
+func (repository *PostgresRepository) ListReturns(ctx context.Context, orderID string, status ReturnStatus, skip uint64, take uint64) ([]*Return, error) {
+	return queryReturns(ctx, repository.db, `
+		SELECT `+returnColumns+`
+		FROM order_returns r
+		WHERE ($1 = '' OR r.orderId = $1) AND ($2 = '' OR r.status = $2)
+		ORDER BY r.createdAt DESC, r.id
+		OFFSET $3 LIMIT $4`, orderID, status, skip, take)
 }
 
-func (repository *PostgresRepository) GetOrdersForAccount(ctx context.Context, accountId string) ([]*Order, error) {
-	rows, err := repository.db.QueryContext(
-		ctx,
-		`SELECT
-			o.id,
-			o.createdAt,
-			o.accountId,
-			o.totalPrice,
-			op.productId,
-			op.quantity,
-			op.name,
-			op.description,
-			op.price
-		FROM orders o
-		JOIN order_products op ON (o.id = op.orderId)
-		WHERE o.accountId = $1
-		ORDER BY o.createdAt DESC`,
-		accountId,
-	)
+// lockReturn loads a return and its items, locking the return until tx ends.
+func lockReturn(ctx context.Context, tx *sql.Tx, id string) (*Return, error) {
+	returns, err := queryReturns(ctx, tx, "SELECT "+returnColumns+" FROM order_returns r WHERE r.id = $1 FOR UPDATE", id)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	if len(returns) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return returns[0], nil
+}
+
+// getOrderReturns loads the returns of an order and their items, oldest first.
+func getOrderReturns(ctx context.Context, db queryer, orderID string) ([]*Return, error) {
+	return queryReturns(ctx, db, "SELECT "+returnColumns+" FROM order_returns r WHERE r.orderId = $1 ORDER BY r.createdAt, r.id", orderID)
+}
 
-	ordersMap := make(map[string]*Order)
-	var orders []*Order
+const returnColumns = "r.id, r.orderId, r.accountId, r.status, r.refund, r.currency, r.note, r.createdAt, r.updatedAt"
 
+// queryReturns loads the returns query selects, as returnColumns, and their
+// items.
+func queryReturns(ctx context.Context, db queryer, query string, args ...any) ([]*Return, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	returns := []*Return{}
+	byID := map[string]*Return{}
+	returnIDs := []string{}
 	for rows.Next() {
-		var orderID string
-		var createdAt time.Time
-		var accountID string
-		var totalPrice float64
-		var productID string
-		var quantity int32
-		var productName string
-		var productDescription string
-		var productPrice float64
-
-		if err = rows.Scan(
-			&orderID,
-			&createdAt,
-			&accountID,
-			&totalPrice,
-			&productID,
-			&quantity,
-			&productName,
-			&productDescription,
-			&productPrice,
-		); err != nil {
+		orderReturn := &Return{Items: []*ReturnItem{}}
+		var refund int64
+		var currency string
+		if err := rows.Scan(&orderReturn.ID, &orderReturn.OrderID, &orderReturn.AccountID, &orderReturn.Status, &refund, &currency, &orderReturn.Note, &orderReturn.CreatedAt, &orderReturn.UpdatedAt); err != nil {
+			return nil, err
+		}
+		orderReturn.Refund = money.New(refund, currency)
+		returns = append(returns, orderReturn)
+		byID[orderReturn.ID] = orderReturn
+		returnIDs = append(returnIDs, orderReturn.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(returns) == 0 {
+		return returns, nil
+	}
+	itemRows, err := db.QueryContext(ctx, `
+		SELECT returnId, productId, quantity, reason, comment, acceptedQuantity, restock, refund
+		FROM order_return_items
+		WHERE returnId = ANY($1)
+		ORDER BY returnId, productId`, pq.Array(returnIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer itemRows.Close()
+	for itemRows.Next() {
+		item := &ReturnItem{}
+		var returnID string
+		var refund int64
+		if err := itemRows.Scan(&returnID, &item.ProductID, &item.Quantity, &item.Reason, &item.Comment, &item.AcceptedQuantity, &item.Restock, &refund); err != nil {
 			return nil, err
 		}
+		if orderReturn, ok := byID[returnID]; ok {
+			item.Refund = money.New(refund, orderReturn.Refund.Currency)
+			orderReturn.Items = append(orderReturn.Items, item)
+		}
+	}
+	if err := itemRows.Err(); err != nil {
+		return nil, err
+	}
+	return returns, nil
+}
 
-		order, ok := ordersMap[orderID]
-		if !ok {
-			order = &Order{
-				ID:         orderID,
-				CreatedAt:  createdAt,
-				AccountID:  accountID,
-				TotalPrice: totalPrice,
-				Products:   []*OrderProduct{},
+func (repository *PostgresRepository) GetInvoice(ctx context.Context, id string) (*Invoice, error) {
+	invoices, err := queryInvoices(ctx, repository.db, "SELECT "+invoiceColumns+" FROM invoices i WHERE i.id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(invoices) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return invoices[0], nil
+}
+
+func (repository *PostgresRepository) ListInvoices(ctx context.Context, orderID string) ([]*Invoice, error) {
+	return queryInvoices(ctx, repository.db, "SELECT "+invoiceColumns+" FROM invoices i WHERE i.orderId = $1 ORDER BY i.issuedAt, i.kind DESC, i.number", orderID)
+}
+
+// writeInvoices numbers invoices and stores them with their lines. Each
+// number is the next in its merchant's sequence for the invoice's kind,
+// whose row stays locked until tx ends, so concurrent invoices cannot take
+// the same number and a rolled back invoice does not use one up.
+func writeInvoices(ctx context.Context, tx *sql.Tx, invoices []*Invoice) error {
+	for _, invoice := range invoices {
+		var sequence int64
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO invoice_sequences (merchantId, kind, lastNumber) VALUES ($1, $2, 1)
+			ON CONFLICT (merchantId, kind) DO UPDATE SET lastNumber = invoice_sequences.lastNumber + 1
+			RETURNING lastNumber`, invoice.MerchantID, invoice.Kind).Scan(&sequence)
+		if err != nil {
+			return err
+		}
+		invoice.Number = invoiceNumber(invoice.Kind, sequence)
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO invoices (id, number, sequence, kind, merchantId, orderId, accountId, returnId, invoiceId, billTo, subtotal, discount, tax, shipping, total, currency, issuedAt)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)`,
+			invoice.ID, invoice.Number, sequence, invoice.Kind, invoice.MerchantID, invoice.OrderID, invoice.AccountID, invoice.ReturnID, invoice.InvoiceID, pq.Array(invoice.BillTo),
+			invoice.Subtotal.Amount, invoice.Discount.Amount, invoice.Tax.Amount, invoice.Shipping.Amount, invoice.Total.Amount, invoice.Total.Currency, invoice.IssuedAt)
+		if err != nil {
+			return err
+		}
+		for i, line := range invoice.Lines {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO invoice_lines (invoiceId, position, productId, description, quantity, unitPrice, amount, discount, tax, taxRate, taxInclusive, total)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+				invoice.ID, i, line.ProductID, line.Description, line.Quantity, line.UnitPrice.Amount, line.Amount.Amount, line.Discount.Amount, line.Tax.Amount, line.TaxRate, line.TaxInclusive, line.Total.Amount)
+			if err != nil {
+				return err
 			}
-			ordersMap[orderID] = order
-			orders = append(orders, order)
 		}
+	}
+	return nil
+}
+
+const invoiceColumns = "i.id, i.number, i.kind, i.merchantId, i.orderId, i.accountId, i.returnId, i.invoiceId, i.billTo, i.subtotal, i.discount, i.tax, i.shipping, i.total, i.currency, i.issuedAt"
+
+// queryInvoices loads the invoices query selects, as invoiceColumns, and
+// their lines.
+func queryInvoices(ctx context.Context, db queryer, query string, args ...any) ([]*Invoice, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	invoices := []*Invoice{}
+	byID := map[string]*Invoice{}
+	invoiceIDs := []string{}
+	for rows.Next() {
+		invoice := &Invoice{Lines: []*InvoiceLine{}}
+		var subtotal, discount, taxAmount, shippingFee, total int64
+		var currency string
+		if err := rows.Scan(&invoice.ID, &invoice.Number, &invoice.Kind, &invoice.MerchantID, &invoice.OrderID, &invoice.AccountID, &invoice.ReturnID, &invoice.InvoiceID, pq.Array(&invoice.BillTo), &subtotal, &discount, &taxAmount, &shippingFee, &total, &currency, &invoice.IssuedAt); err != nil {
+			return nil, err
+		}
+		invoice.Subtotal = money.New(subtotal, currency)
+		invoice.Discount = money.New(discount, currency)
+		invoice.Tax = money.New(taxAmount, currency)
+		invoice.Shipping = money.New(shippingFee, currency)
+		invoice.Total = money.New(total, currency)
+		invoices = append(invoices, invoice)
+		byID[invoice.ID] = invoice
+		invoiceIDs = append(invoiceIDs, invoice.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(invoices) == 0 {
+		return invoices, nil
+	}
+	lineRows, err := db.QueryContext(ctx, `
+		SELECT invoiceId, productId, description, quantity, unitPrice, amount, discount, tax, taxRate, taxInclusive, total
+		FROM invoice_lines
+		WHERE invoiceId = ANY($1)
+		ORDER BY invoiceId, position`, pq.Array(invoiceIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer lineRows.Close()
+	for lineRows.Next() {
+		line := &InvoiceLine{}
+		var invoiceID string
+		var unitPrice, amount, discount, taxAmount, total int64
+		if err := lineRows.Scan(&invoiceID, &line.ProductID, &line.Description, &line.Quantity, &unitPrice, &amount, &discount, &taxAmount, &line.TaxRate, &line.TaxInclusive, &total); err != nil {
+			return nil, err
+		}
+		invoice, ok := byID[invoiceID]
+		if !ok {
+			continue
+		}
+		currency := invoice.Total.Currency
+		line.UnitPrice = money.New(unitPrice, currency)
+		line.Amount = money.New(amount, currency)
+		line.Discount = money.New(discount, currency)
+		line.Tax = money.New(taxAmount, currency)
+		line.Total = money.New(total, currency)
+		invoice.Lines = append(invoice.Lines, line)
+	}
+	if err := lineRows.Err(); err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
 
-		order.Products = append(order.Products, &OrderProduct{
-			OrderID:            orderID,
-			ProductID:          productID,
-			ProductName:        productName,
-			ProductDescription: productDescription,
-			Price:              productPrice,
-			Quantity:           quantity,
+func (repository *PostgresRepository) ListMerchantOrders(ctx context.Context, filter MerchantOrderFilter, take uint64, cursor string) (*MerchantOrderPage, error) {
+	listing, err := listingKey("merchant orders", filter)
+	if err != nil {
+		return nil, err
+	}
+	state := &keysetCursor{}
+	if cursor != "" {
+		state, err = decodeKeysetCursor(cursor, listing)
+		if err != nil {
+			return nil, err
+		}
+	}
+	query := &orderQuery{}
+	if filter.MerchantID != "" {
+		query.where("m.merchantId = " + query.arg(filter.MerchantID))
+	}
+	if filter.OrderID != "" {
+		query.where("m.orderId = " + query.arg(filter.OrderID))
+	}
+	if filter.Status != "" {
+		query.where("m.status = " + query.arg(filter.Status))
+	}
+	page := &MerchantOrderPage{MerchantOrders: []*MerchantOrder{}, Cursors: []string{}}
+	err = repository.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM merchant_orders m WHERE "+query.conditionSQL(), query.args...).Scan(&page.TotalCount)
+	if err != nil {
+		return nil, err
+	}
+	terms := []orderTerm{
+		{column: "m.createdAt", descending: true, value: state.CreatedAt},
+		{column: "m.orderId", descending: true, value: state.ID},
+		{column: "m.merchantId", value: state.MerchantID},
+	}
+	if cursor != "" {
+		query.where(query.after(terms))
+	}
+	merchantOrders, err := queryMerchantOrders(ctx, repository.db,
+		"SELECT "+merchantOrderColumns+" FROM merchant_orders m WHERE "+query.conditionSQL()+
+			" ORDER BY "+orderBy(terms)+" LIMIT "+query.arg(take),
+		query.args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	offset := state.Offset
+	for _, merchantOrder := range merchantOrders {
+		offset++
+		merchantOrderCursor, err := encodeKeysetCursor(&keysetCursor{
+			Listing:    listing,
+			CreatedAt:  merchantOrder.CreatedAt,
+			ID:         merchantOrder.OrderID,
+			MerchantID: merchantOrder.MerchantID,
+			Offset:     offset,
 		})
+		if err != nil {
+			return nil, err
+		}
+		page.MerchantOrders = append(page.MerchantOrders, merchantOrder)
+		page.Cursors = append(page.Cursors, merchantOrderCursor)
+	}
+	if len(page.Cursors) > 0 && offset < page.TotalCount {
+		page.NextCursor = page.Cursors[len(page.Cursors)-1]
 	}
+	return page, nil
+}
 
-	if err = rows.Err(); err != nil {
+func (repository *PostgresRepository) GetMerchantPayouts(ctx context.Context, merchantID string, from *time.Time, to *time.Time) ([]*MerchantPayout, error) {
+	query := &orderQuery{}
+	query.where("merchantId = " + query.arg(merchantID))
+	query.where("status = ANY(" + query.arg(pq.Array([]string{string(OrderStatusPaid), string(OrderStatusShipped), string(OrderStatusDelivered)})) + ")")
+	if from != nil {
+		query.where("createdAt >= " + query.arg(*from))
+	}
+	if to != nil {
+		query.where("createdAt < " + query.arg(*to))
+	}
+	rows, err := repository.db.QueryContext(ctx,
+		"SELECT currency, COUNT(*), SUM(total), SUM(refunded) FROM merchant_orders WHERE "+query.conditionSQL()+
+			" GROUP BY currency ORDER BY currency",
+		query.args...,
+	)
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+	payouts := []*MerchantPayout{}
+	for rows.Next() {
+		payout := &MerchantPayout{MerchantID: merchantID}
+		var total, refunded int64
+		if err := rows.Scan(&payout.Currency, &payout.Orders, &total, &refunded); err != nil {
+			return nil, err
+		}
+		payout.Total = money.New(total, payout.Currency)
+		payout.Refunded = money.New(refunded, payout.Currency)
+		payout.Payout = money.New(total-refunded, payout.Currency)
+		payouts = append(payouts, payout)
+	}
+	return payouts, rows.Err()
+}
 
-	return orders, nil
+// writeMerchantOrders splits order into its merchant orders, as
+// merchantOrders does, and stores them, keeping their refunds. Merchant
+// orders of merchants the order no longer has lines of are deleted.
+func writeMerchantOrders(ctx context.Context, tx *sql.Tx, order *Order, shipments []*Shipment) error {
+	split, err := merchantOrders(order, shipments, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	merchantIDs := []string{}
+	for _, merchantOrder := range split {
+		merchantIDs = append(merchantIDs, merchantOrder.MerchantID)
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO merchant_orders (orderId, merchantId, accountId, status, subtotal, discount, tax, shipping, total, currency, createdAt, updatedAt)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			ON CONFLICT (orderId, merchantId) DO UPDATE SET accountId = $3, status = $4, subtotal = $5, discount = $6, tax = $7, shipping = $8, total = $9, currency = $10, updatedAt = $12`,
+			merchantOrder.OrderID, merchantOrder.MerchantID, merchantOrder.AccountID, merchantOrder.Status,
+			merchantOrder.Subtotal.Amount, merchantOrder.Discount.Amount, merchantOrder.Tax.Amount, merchantOrder.Shipping.Amount, merchantOrder.Total.Amount,
+			merchantOrder.Total.Currency, merchantOrder.CreatedAt, merchantOrder.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM merchant_orders WHERE orderId = $1 AND NOT (merchantId = ANY($2))", order.ID, pq.Array(merchantIDs))
+	return err
+}
+
+const merchantOrderColumns = "m.orderId, m.merchantId, m.accountId, m.status, m.subtotal, m.discount, m.tax, m.shipping, m.total, m.refunded, m.currency, m.createdAt, m.updatedAt"
+
+// queryMerchantOrders runs a query selecting merchantOrderColumns and loads
+// the lines of the merchant orders, with their taxes.
+func queryMerchantOrders(ctx context.Context, db queryer, query string, args ...any) ([]*MerchantOrder, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	merchantOrders := []*MerchantOrder{}
+	orderIDs := []string{}
+	for rows.Next() {
+		merchantOrder := &MerchantOrder{Products: []*OrderProduct{}}
+		var subtotal, discount, taxAmount, shippingFee, total, refunded int64
+		var currency string
+		if err := rows.Scan(&merchantOrder.OrderID, &merchantOrder.MerchantID, &merchantOrder.AccountID, &merchantOrder.Status, &subtotal, &discount, &taxAmount, &shippingFee, &total, &refunded, &currency, &merchantOrder.CreatedAt, &merchantOrder.UpdatedAt); err != nil {
+			return nil, err
+		}
+		merchantOrder.Subtotal = money.New(subtotal, currency)
+		merchantOrder.Discount = money.New(discount, currency)
+		merchantOrder.Tax = money.New(taxAmount, currency)
+		merchantOrder.Shipping = money.New(shippingFee, currency)
+		merchantOrder.Total = money.New(total, currency)
+		merchantOrder.Refunded = money.New(refunded, currency)
+		merchantOrders = append(merchantOrders, merchantOrder)
+		if !slices.Contains(orderIDs, merchantOrder.OrderID) {
+			orderIDs = append(orderIDs, merchantOrder.OrderID)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	orders, err := getOrders(ctx, db, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]*Order{}
+	for _, order := range orders {
+		byID[order.ID] = order
+	}
+	for _, merchantOrder := range merchantOrders {
+		order, ok := byID[merchantOrder.OrderID]
+		if !ok {
+			continue
+		}
+		for _, product := range order.Products {
+			if product.MerchantID == merchantOrder.MerchantID {
+				merchantOrder.Products = append(merchantOrder.Products, product)
+			}
+		}
+	}
+	return merchantOrders, nil
 }
@@ -0,0 +1,194 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+)
+
+// ErrNotReturnable is returned when a return asks for more units of a line
+// than are left to return.
+var ErrNotReturnable = errors.New("nothing left to return")
+
+// Restocker puts returned units back into stock. InspectReturn calls it with
+// the items of a return whose accepted units are restocked, before the
+// inspection is stored; if it fails, the inspection is not stored.
+type Restocker interface {
+	Restock(ctx context.Context, orderReturn *Return, items []*ReturnItem) error
+}
+
+// LogRestocker only logs the units it is given to restock, for shops that
+// keep stock elsewhere.
+type LogRestocker struct {
+	logger util.Logger
+}
+
+func NewLogRestocker(logger util.Logger) *LogRestocker {
+	return &LogRestocker{logger: logger}
+}
+
+func (restocker *LogRestocker) Restock(ctx context.Context, orderReturn *Return, items []*ReturnItem) error {
+	for _, item := range items {
+		restocker.logger.Service().Info().
+			Str("return", orderReturn.ID).
+			Str("order", orderReturn.OrderID).
+			Str("product", item.ProductID).
+			Int32("quantity", item.AcceptedQuantity).
+			Msg("restocking returned units")
+	}
+	return nil
+}
+
+// returnItems returns the items of a new return of order, whose earlier
+// returns are given. Each item must be a line of the order with enough units
+// that are not part of another return, unless that return was rejected.
+func returnItems(order *Order, returns []*Return, requested []*ReturnItem) ([]*ReturnItem, error) {
+	if len(requested) == 0 {
+		return nil, errors.New("a return needs at least one item")
+	}
+	left := unreturnedQuantities(order, returns)
+	items := []*ReturnItem{}
+	seen := map[string]bool{}
+	for _, item := range requested {
+		quantity, ok := left[item.ProductID]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("product %s is not part of order %s", item.ProductID, order.ID)
+		case seen[item.ProductID]:
+			return nil, fmt.Errorf("product %s is listed more than once", item.ProductID)
+		case item.Quantity <= 0:
+			return nil, fmt.Errorf("quantity of product %s must be positive", item.ProductID)
+		case item.Quantity > quantity:
+			return nil, fmt.Errorf("%w: %d of product %s left, not %d", ErrNotReturnable, quantity, item.ProductID, item.Quantity)
+		}
+		seen[item.ProductID] = true
+		items = append(items, &ReturnItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Reason:    item.Reason,
+			Comment:   item.Comment,
+			Refund:    money.Zero(order.TotalPrice.Currency),
+		})
+	}
+	return items, nil
+}
+
+// unreturnedQuantities returns, for each line of order, the units not in any
+// of its returns that were not rejected.
+func unreturnedQuantities(order *Order, returns []*Return) map[string]int32 {
+	left := map[string]int32{}
+	for _, product := range order.Products {
+		left[product.ProductID] = product.Quantity
+	}
+	for _, orderReturn := range returns {
+		if orderReturn.Status == ReturnStatusRejected {
+			continue
+		}
+		for _, item := range orderReturn.Items {
+			left[item.ProductID] -= item.Quantity
+		}
+	}
+	return left
+}
+
+// inspectItems sets the units accepted and restocked of each item of
+// orderReturn from the inspected items given. Without inspected items, every
+// unit is accepted and restocked; otherwise items that are not listed have
+// none accepted.
+func inspectItems(orderReturn *Return, inspected []*ReturnItem) error {
+	if len(inspected) == 0 {
+		for _, item := range orderReturn.Items {
+			item.AcceptedQuantity = item.Quantity
+			item.Restock = true
+		}
+		return nil
+	}
+	byProduct := map[string]*ReturnItem{}
+	for _, item := range orderReturn.Items {
+		item.AcceptedQuantity = 0
+		item.Restock = false
+		byProduct[item.ProductID] = item
+	}
+	seen := map[string]bool{}
+	for _, inspection := range inspected {
+		item, ok := byProduct[inspection.ProductID]
+		switch {
+		case !ok:
+			return fmt.Errorf("product %s is not part of return %s", inspection.ProductID, orderReturn.ID)
+		case seen[inspection.ProductID]:
+			return fmt.Errorf("product %s is listed more than once", inspection.ProductID)
+		case inspection.AcceptedQuantity < 0 || inspection.AcceptedQuantity > item.Quantity:
+			return fmt.Errorf("accepted quantity of product %s must be between 0 and %d", inspection.ProductID, item.Quantity)
+		}
+		seen[inspection.ProductID] = true
+		item.AcceptedQuantity = inspection.AcceptedQuantity
+		item.Restock = inspection.Restock && inspection.AcceptedQuantity > 0
+	}
+	return nil
+}
+
+// refundReturn sets the refund of each item of orderReturn, and of the
+// return, for the units accepted. A line's units are refunded from what the
+// line cost: its price after its discounts plus any tax added to it, shared
+// evenly among its units. returns are the order's other returns; those
+// already inspected count towards the refunds of their lines, so that
+// refunding every unit of a line refunds what it cost, to the minor unit.
+// Shipping fees are not refunded.
+func refundReturn(order *Order, returns []*Return, orderReturn *Return) error {
+	amounts, err := taxableAmounts(order)
+	if err != nil {
+		return err
+	}
+	currency := order.TotalPrice.Currency
+	paid := map[string]int64{}
+	quantities := map[string]int32{}
+	for i, product := range order.Products {
+		paid[product.ProductID] = amounts[i].Amount
+		if product.Tax != nil && !product.Tax.Inclusive {
+			paid[product.ProductID] += product.Tax.Amount.Amount
+		}
+		quantities[product.ProductID] = product.Quantity
+	}
+	refunded := map[string]int32{}
+	for _, other := range returns {
+		if other.ID == orderReturn.ID || other.Status != ReturnStatusInspected {
+			continue
+		}
+		for _, item := range other.Items {
+			refunded[item.ProductID] += item.AcceptedQuantity
+		}
+	}
+
+	total := money.Zero(currency)
+	for _, item := range orderReturn.Items {
+		quantity, ok := quantities[item.ProductID]
+		if !ok || quantity <= 0 {
+			return fmt.Errorf("product %s is no longer part of order %s", item.ProductID, order.ID)
+		}
+		before := refunded[item.ProductID]
+		after := before + item.AcceptedQuantity
+		if after > quantity {
+			return fmt.Errorf("%w: %d of product %s refunded already", ErrNotReturnable, before, item.ProductID)
+		}
+		amount := unitsShare(paid[item.ProductID], after, quantity) - unitsShare(paid[item.ProductID], before, quantity)
+		item.Refund = money.New(amount, currency)
+		refunded[item.ProductID] = after
+		total, err = total.Add(item.Refund)
+		if err != nil {
+			return fmt.Errorf("failed to compute refund: %w", err)
+		}
+	}
+	orderReturn.Refund = total
+	return nil
+}
+
+// unitsShare returns the part of amount that units of quantity units make
+// up, rounded down.
+func unitsShare(amount int64, units int32, quantity int32) int64 {
+	share := new(big.Int).Mul(big.NewInt(amount), big.NewInt(int64(units)))
+	return share.Quo(share, big.NewInt(int64(quantity))).Int64()
+}
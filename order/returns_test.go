@@ -0,0 +1,203 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+// returnTestOrder has a lamp line that cost 3 × 10.00 - 1.00 discount + 2.33
+// tax added = 31.33, and a bulb line with the tax included that cost
+// 7 × 3.33 = 23.31; neither divides evenly among its units.
+func returnTestOrder() *Order {
+	return &Order{
+		ID:         "order",
+		AccountID:  "customer",
+		TotalPrice: money.New(5464, "USD"),
+		Products: []*OrderProduct{
+			{ProductID: "lamp", Price: money.New(1000, "USD"), Quantity: 3, Tax: &OrderTax{Amount: money.New(233, "USD")}},
+			{ProductID: "bulb", Price: money.New(333, "USD"), Quantity: 7, Tax: &OrderTax{Amount: money.New(352, "USD"), Inclusive: true}},
+		},
+		Discounts: []*OrderDiscount{{ProductID: "lamp", Amount: money.New(100, "USD")}},
+	}
+}
+
+// inspectReturn requests, inspects and refunds a return of quantity units of
+// product, accepting every unit, and adds it to returns.
+func inspectReturn(order *Order, returns []*Return, product string, quantity int32) ([]*Return, error) {
+	items, err := returnItems(order, returns, []*ReturnItem{{ProductID: product, Quantity: quantity, Reason: ReturnReasonDamaged}})
+	if err != nil {
+		return returns, err
+	}
+	orderReturn := &Return{ID: fmt.Sprintf("return-%d", len(returns)+1), OrderID: order.ID, Status: ReturnStatusReceived, Items: items}
+	if err := inspectItems(orderReturn, nil); err != nil {
+		return returns, err
+	}
+	if err := refundReturn(order, returns, orderReturn); err != nil {
+		return returns, err
+	}
+	orderReturn.Status = ReturnStatusInspected
+	return append(returns, orderReturn), nil
+}
+
+func TestRefundingEveryUnitRefundsWhatTheLineCost(t *testing.T) {
+	tests := []struct {
+		product string
+		returns []int32
+		want    int64
+	}{
+		{"lamp", []int32{3}, 3133},
+		{"lamp", []int32{1, 1, 1}, 3133},
+		{"lamp", []int32{2, 1}, 3133},
+		{"lamp", []int32{1, 2}, 3133},
+		{"bulb", []int32{7}, 2331},
+		{"bulb", []int32{3, 2, 2}, 2331},
+		{"bulb", []int32{1, 1, 1, 1, 1, 1, 1}, 2331},
+	}
+	for _, test := range tests {
+		order := returnTestOrder()
+		returns := []*Return{}
+		var err error
+		for _, quantity := range test.returns {
+			if returns, err = inspectReturn(order, returns, test.product, quantity); err != nil {
+				t.Fatalf("returning %v of %s: %v", test.returns, test.product, err)
+			}
+		}
+		refunded := int64(0)
+		for _, orderReturn := range returns {
+			if orderReturn.Refund.Amount != orderReturn.Items[0].Refund.Amount {
+				t.Errorf("returning %v of %s: return refunds %v, its item %v", test.returns, test.product, orderReturn.Refund, orderReturn.Items[0].Refund)
+			}
+			refunded += orderReturn.Refund.Amount
+		}
+		if refunded != test.want {
+			t.Errorf("returning %v of %s refunded %d, want %d", test.returns, test.product, refunded, test.want)
+		}
+		if _, err := inspectReturn(order, returns, test.product, 1); !errors.Is(err, ErrNotReturnable) {
+			t.Errorf("returning %v of %s, then one more: %v, want %v", test.returns, test.product, err, ErrNotReturnable)
+		}
+	}
+}
+
+func TestRefundCountsOnlyInspectedReturns(t *testing.T) {
+	order := returnTestOrder()
+	pending := &Return{ID: "pending", Status: ReturnStatusApproved, Items: []*ReturnItem{{ProductID: "lamp", Quantity: 2, AcceptedQuantity: 2}}}
+	orderReturn := &Return{ID: "return", Status: ReturnStatusReceived, Items: []*ReturnItem{{ProductID: "lamp", Quantity: 1, AcceptedQuantity: 1}}}
+	if err := refundReturn(order, []*Return{pending, orderReturn}, orderReturn); err != nil {
+		t.Fatal(err)
+	}
+	// The approved return is not refunded yet, so this is the line's first
+	// refunded unit: 31.33 / 3 rounded down.
+	if orderReturn.Refund != money.New(1044, "USD") {
+		t.Errorf("refund %v, want 10.44 USD", orderReturn.Refund)
+	}
+}
+
+func TestRejectedReturnsFreeTheirUnits(t *testing.T) {
+	order := returnTestOrder()
+	request := func(returns []*Return, quantity int32) ([]*ReturnItem, error) {
+		return returnItems(order, returns, []*ReturnItem{{ProductID: "lamp", Quantity: quantity}})
+	}
+	first, err := request(nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := request([]*Return{{ID: "first", Status: ReturnStatusRequested, Items: first}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	returns := []*Return{
+		{ID: "first", Status: ReturnStatusRequested, Items: first},
+		{ID: "second", Status: ReturnStatusApproved, Items: second},
+	}
+	if _, err := request(returns, 1); !errors.Is(err, ErrNotReturnable) {
+		t.Errorf("returning a fourth lamp: %v, want %v", err, ErrNotReturnable)
+	}
+
+	returns[0].Status = ReturnStatusRejected
+	if _, err := request(returns, 3); !errors.Is(err, ErrNotReturnable) {
+		t.Errorf("returning three lamps after rejecting two: %v, want %v", err, ErrNotReturnable)
+	}
+	items, err := request(returns, 2)
+	if err != nil {
+		t.Fatalf("returning the two lamps of the rejected return: %v", err)
+	}
+	if len(items) != 1 || items[0].ProductID != "lamp" || items[0].Quantity != 2 || items[0].Refund != money.Zero("USD") {
+		t.Errorf("returned items %+v, want 2 lamps without a refund yet", items[0])
+	}
+}
+
+func TestReturnItemsRejectsInvalidItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []*ReturnItem
+	}{
+		{"no items", nil},
+		{"product not in the order", []*ReturnItem{{ProductID: "shade", Quantity: 1}}},
+		{"product listed twice", []*ReturnItem{{ProductID: "lamp", Quantity: 1}, {ProductID: "lamp", Quantity: 1}}},
+		{"no units", []*ReturnItem{{ProductID: "lamp", Quantity: 0}}},
+		{"negative units", []*ReturnItem{{ProductID: "lamp", Quantity: -1}}},
+		{"more units than ordered", []*ReturnItem{{ProductID: "lamp", Quantity: 4}}},
+	}
+	for _, test := range tests {
+		if _, err := returnItems(returnTestOrder(), nil, test.items); err == nil {
+			t.Errorf("%s: returnItems succeeded, want an error", test.name)
+		}
+	}
+}
+
+func TestInspectItems(t *testing.T) {
+	tests := []struct {
+		name      string
+		inspected []*ReturnItem
+		// want lists each item as product:accepted, with "+restock" for
+		// restocked units.
+		want []string
+	}{
+		{"everything accepted without inspected items", nil, []string{"lamp:2+restock", "bulb:3+restock"}},
+		{"items not listed accept nothing", []*ReturnItem{{ProductID: "lamp", AcceptedQuantity: 1, Restock: true}}, []string{"lamp:1+restock", "bulb:0"}},
+		{"accepted without restocking", []*ReturnItem{{ProductID: "bulb", AcceptedQuantity: 3}}, []string{"lamp:0", "bulb:3"}},
+		{"nothing accepted is not restocked", []*ReturnItem{{ProductID: "lamp", AcceptedQuantity: 0, Restock: true}}, []string{"lamp:0", "bulb:0"}},
+	}
+	for _, test := range tests {
+		orderReturn := &Return{ID: "return", Items: []*ReturnItem{
+			{ProductID: "lamp", Quantity: 2, AcceptedQuantity: 2, Restock: true},
+			{ProductID: "bulb", Quantity: 3, AcceptedQuantity: 3, Restock: true},
+		}}
+		if err := inspectItems(orderReturn, test.inspected); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		got := []string{}
+		for _, item := range orderReturn.Items {
+			description := fmt.Sprintf("%s:%d", item.ProductID, item.AcceptedQuantity)
+			if item.Restock {
+				description += "+restock"
+			}
+			got = append(got, description)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(test.want) {
+			t.Errorf("%s: inspected %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestInspectItemsRejectsInvalidInspections(t *testing.T) {
+	tests := []struct {
+		name      string
+		inspected []*ReturnItem
+	}{
+		{"product not in the return", []*ReturnItem{{ProductID: "shade", AcceptedQuantity: 1}}},
+		{"product listed twice", []*ReturnItem{{ProductID: "lamp", AcceptedQuantity: 1}, {ProductID: "lamp", AcceptedQuantity: 1}}},
+		{"negative units accepted", []*ReturnItem{{ProductID: "lamp", AcceptedQuantity: -1}}},
+		{"more units accepted than returned", []*ReturnItem{{ProductID: "lamp", AcceptedQuantity: 3}}},
+	}
+	for _, test := range tests {
+		orderReturn := &Return{ID: "return", Items: []*ReturnItem{{ProductID: "lamp", Quantity: 2}}}
+		if err := inspectItems(orderReturn, test.inspected); err == nil {
+			t.Errorf("%s: inspectItems succeeded, want an error", test.name)
+		}
+	}
+}
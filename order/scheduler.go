@@ -0,0 +1,28 @@
+package order
+
+import (
+	"context"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+)
+
+// RunCoPurchaseScheduler recounts co-purchased products every interval until
+// ctx is cancelled.
+func RunCoPurchaseScheduler(ctx context.Context, service Service, interval time.Duration, logger util.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		pairs, err := service.RefreshCoPurchases(ctx)
+		if err != nil {
+			logger.Service().Error().Err(err).Msg("failed to refresh co-purchases")
+		} else {
+			logger.Service().Info().Int64("pairs", pairs).Msg("refreshed co-purchases")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
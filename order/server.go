@@ -4,10 +4,17 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/account"
+	accountpb "github.com/Asif-Faizal/Minimum-Viable-Shop/account/pb"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	moneypb "github.com/Asif-Faizal/Minimum-Viable-Shop/money/pb"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/order/pb/pb"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/pricing"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/shipping"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/tax"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"google.golang.org/grpc"
@@ -23,7 +30,7 @@ type GrpcServer struct {
 	pb.UnimplementedOrderServiceServer
 }
 
-func ListenGrpcServer(service Service, accountUrl string, catalogUrl string, logger util.Logger, port int) error {
+func ListenGrpcServer(service Service, accountUrl string, catalogUrl string, jwtSecret string, logger util.Logger, port int) error {
 	accountClient, err := account.NewAccountClient(accountUrl)
 	if err != nil {
 		return err
@@ -36,6 +43,7 @@ func ListenGrpcServer(service Service, accountUrl string, catalogUrl string, log
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			util.UnaryServerInterceptor(logger),
+			util.AuthUnaryServerInterceptor(jwtSecret),
 		)),
 	)
 	server := &GrpcServer{
@@ -66,6 +74,11 @@ func (server *GrpcServer) CreateOrUpdateOrder(ctx context.Context, request *pb.C
 	if len(request.Order.Products) == 0 {
 		return nil, fmt.Errorf("invalid request: at least one product is required")
 	}
+	if request.Currency != "" {
+		if err := money.ValidateCurrency(request.Currency); err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+	}
 
 	// 1. Check if account exists
 	accountResponse, err := server.accountClient.GetAccountByID(ctx, request.Order.AccountId)
@@ -76,47 +89,45 @@ func (server *GrpcServer) CreateOrUpdateOrder(ctx context.Context, request *pb.C
 		return nil, fmt.Errorf("account not found: %s", request.Order.AccountId)
 	}
 
-	// 2. Get ordered products from catalog
-	productIDs := []string{}
-	for _, p := range request.Order.Products {
-		productIDs = append(productIDs, p.ProductId)
-	}
-	catalogResp, err := server.catalogClient.ListProductsWithIDs(ctx, productIDs)
+	// 2. Copy the shipping address, which is also where the order is taxed.
+	// Only admins may tax an order elsewhere or exempt it from tax
+	shippingAddress, err := server.shippingAddress(ctx, request.Order.AccountId, request.ShippingAddressId)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch products from catalog: %w", err)
+		return nil, err
 	}
-
-	// 3. Construct domain products
-	products := []*OrderProduct{}
-	for _, p := range catalogResp.Products {
-		product := &OrderProduct{
-			ProductID:          p.Id,
-			ProductName:        p.Name,
-			ProductDescription: p.Description,
-			Price:              float64(p.Price),
-			Quantity:           0,
-		}
-		for _, rp := range request.Order.Products {
-			if rp.ProductId == p.Id {
-				product.Quantity = rp.Quantity
-				break
-			}
+	taxLocation := tax.Location{}
+	if shippingAddress != nil {
+		taxLocation = tax.Location{Country: shippingAddress.Country, Region: shippingAddress.Region}
+	}
+	taxExempt := accountResponse.Account.TaxExempt
+	if request.Order.TaxCountry != "" || request.Order.TaxRegion != "" || request.Order.TaxExempt {
+		claims, ok := util.ClaimsFromContext(ctx)
+		if !ok || !claims.IsAdmin() {
+			return nil, fmt.Errorf("%w: only admins can choose where an order is taxed", ErrForbidden)
 		}
-
-		if product.Quantity != 0 {
-			products = append(products, product)
+		if request.Order.TaxCountry != "" {
+			taxLocation = tax.Location{Country: request.Order.TaxCountry, Region: request.Order.TaxRegion}
 		}
+		taxExempt = taxExempt || request.Order.TaxExempt
 	}
 
-	if len(products) != len(request.Order.Products) {
-		return nil, fmt.Errorf("validation error: one or more products not found in catalog")
+	// 3. Get ordered products from catalog, priced in the order currency
+	products, exchangeRates, err := server.priceProducts(ctx, request.Order.Products, request.Currency)
+	if err != nil {
+		return nil, err
 	}
 
-	// 4. Call service implementation
+	// 4. Call service implementation, taxing the order unless it is exempt
 	domainOrder := &Order{
-		ID:        request.Order.Id,
-		AccountID: request.Order.AccountId,
-		Products:  products,
+		ID:              request.Order.Id,
+		AccountID:       request.Order.AccountId,
+		Products:        products,
+		ExchangeRates:   exchangeRates,
+		CouponCodes:     request.CouponCodes,
+		TaxLocation:     taxLocation,
+		TaxExempt:       taxExempt,
+		ShippingAddress: shippingAddress,
+		ShippingMethod:  request.Order.ShippingMethod,
 	}
 	if request.Order.CreatedAt != nil {
 		domainOrder.CreatedAt = request.Order.CreatedAt.AsTime()
@@ -128,55 +139,104 @@ func (server *GrpcServer) CreateOrUpdateOrder(ctx context.Context, request *pb.C
 	}
 
 	// 5. Make response order
-	pbProducts := []*pb.OrderProduct{}
-	for _, p := range order.Products {
-		pbProducts = append(pbProducts, &pb.OrderProduct{
-			OrderId:            p.OrderID,
-			ProductId:          p.ProductID,
-			ProductName:        p.ProductName,
-			ProductDescription: p.ProductDescription,
-			Price:              p.Price,
-			Quantity:           p.Quantity,
-		})
+	return &pb.CreateOrUpdateOrderResponse{
+		Order: toProtoOrder(order),
+	}, nil
+}
+
+func (server *GrpcServer) UpdateOrderItems(ctx context.Context, request *pb.UpdateOrderItemsRequest) (*pb.UpdateOrderItemsResponse, error) {
+	// Validate request
+	if request == nil || request.OrderId == "" {
+		return nil, fmt.Errorf("invalid request: order_id is required")
+	}
+	if len(request.Products) == 0 {
+		return nil, fmt.Errorf("invalid request: at least one product is required")
 	}
 
-	return &pb.CreateOrUpdateOrderResponse{
-		Order: &pb.Order{
-			Id:         order.ID,
-			AccountId:  order.AccountID,
-			TotalPrice: order.TotalPrice,
-			Products:   pbProducts,
-			CreatedAt:  timestamppb.New(order.CreatedAt),
-		},
+	// 1. Removed lines need no price; other lines are priced in the order currency
+	operation := ItemOperation(request.Operation)
+	products := []*OrderProduct{}
+	exchangeRates := []*pricing.Rate{}
+	if operation == ItemOperationRemove {
+		for _, p := range request.Products {
+			products = append(products, &OrderProduct{ProductID: p.ProductId, Quantity: p.Quantity})
+		}
+	} else {
+		order, err := server.orderService.GetOrderById(ctx, request.OrderId, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch order: %w", err)
+		}
+		products, exchangeRates, err = server.priceProducts(ctx, request.Products, order.TotalPrice.Currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// 2. Apply the change
+	order, err := server.orderService.UpdateOrderItems(ctx, request.OrderId, operation, products, exchangeRates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order items: %w", err)
+	}
+
+	return &pb.UpdateOrderItemsResponse{
+		Order: toProtoOrder(order),
 	}, nil
 }
 
-func (server *GrpcServer) GetOrderByID(ctx context.Context, request *pb.GetOrderByIDRequest) (*pb.GetOrderByIDResponse, error) {
-	order, err := server.orderService.GetOrderById(ctx, request.Id)
+func (server *GrpcServer) ApplyCoupon(ctx context.Context, request *pb.ApplyCouponRequest) (*pb.ApplyCouponResponse, error) {
+	order, err := server.orderService.ApplyCoupon(ctx, request.OrderId, request.Code)
 	if err != nil {
 		return nil, err
 	}
 
-	pbProducts := []*pb.OrderProduct{}
-	for _, p := range order.Products {
-		pbProducts = append(pbProducts, &pb.OrderProduct{
-			OrderId:            p.OrderID,
-			ProductId:          p.ProductID,
-			ProductName:        p.ProductName,
-			ProductDescription: p.ProductDescription,
-			Price:              p.Price,
-			Quantity:           p.Quantity,
+	return &pb.ApplyCouponResponse{
+		Order: toProtoOrder(order),
+	}, nil
+}
+
+func (server *GrpcServer) UpdateOrderStatus(ctx context.Context, request *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
+	order, err := server.orderService.UpdateOrderStatus(ctx, request.Id, OrderStatus(request.Status))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateOrderStatusResponse{
+		Order: toProtoOrder(order),
+	}, nil
+}
+
+func (server *GrpcServer) ListOrderItemChanges(ctx context.Context, request *pb.ListOrderItemChangesRequest) (*pb.ListOrderItemChangesResponse, error) {
+	changes, err := server.orderService.ListOrderItemChanges(ctx, request.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	pbChanges := []*pb.OrderItemChange{}
+	for _, change := range changes {
+		pbChanges = append(pbChanges, &pb.OrderItemChange{
+			OrderId:     change.OrderID,
+			ProductId:   change.ProductID,
+			Operation:   string(change.Operation),
+			OldQuantity: change.OldQuantity,
+			NewQuantity: change.NewQuantity,
+			Price:       change.Price.ToProto(),
+			ChangedAt:   timestamppb.New(change.ChangedAt),
 		})
 	}
 
+	return &pb.ListOrderItemChangesResponse{
+		Changes: pbChanges,
+	}, nil
+}
+
+func (server *GrpcServer) GetOrderByID(ctx context.Context, request *pb.GetOrderByIDRequest) (*pb.GetOrderByIDResponse, error) {
+	order, err := server.orderService.GetOrderById(ctx, request.Id, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	return &pb.GetOrderByIDResponse{
-		Order: &pb.Order{
-			Id:         order.ID,
-			AccountId:  order.AccountID,
-			TotalPrice: order.TotalPrice,
-			Products:   pbProducts,
-			CreatedAt:  timestamppb.New(order.CreatedAt),
-		},
+		Order: toProtoOrder(order),
 	}, nil
 }
 
@@ -195,34 +255,809 @@ func (server *GrpcServer) GetOrdersForAccount(ctx context.Context, request *pb.G
 		return nil, fmt.Errorf("account not found: %s", request.AccountId)
 	}
 
-	orders, err := server.orderService.GetOrdersForAccount(ctx, request.AccountId)
+	page, err := server.orderService.GetOrdersForAccount(ctx, request.AccountId, request.Take, request.Cursor, request.Currency)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch orders: %w", err)
 	}
 
 	pbOrders := []*pb.Order{}
-	for _, o := range orders {
-		pbProducts := []*pb.OrderProduct{}
-		for _, p := range o.Products {
-			pbProducts = append(pbProducts, &pb.OrderProduct{
-				OrderId:            p.OrderID,
-				ProductId:          p.ProductID,
-				ProductName:        p.ProductName,
-				ProductDescription: p.ProductDescription,
-				Price:              p.Price,
-				Quantity:           p.Quantity,
-			})
-		}
-		pbOrders = append(pbOrders, &pb.Order{
-			Id:         o.ID,
-			AccountId:  o.AccountID,
-			TotalPrice: o.TotalPrice,
-			Products:   pbProducts,
-			CreatedAt:  timestamppb.New(o.CreatedAt),
-		})
+	for _, o := range page.Orders {
+		pbOrders = append(pbOrders, toProtoOrder(o))
 	}
 
 	return &pb.GetOrdersForAccountResponse{
-		Orders: pbOrders,
+		Orders:     pbOrders,
+		Cursors:    page.Cursors,
+		NextCursor: page.NextCursor,
+		TotalCount: page.TotalCount,
+	}, nil
+}
+
+func (server *GrpcServer) SearchOrders(ctx context.Context, request *pb.SearchOrdersRequest) (*pb.SearchOrdersResponse, error) {
+	page, err := server.orderService.SearchOrders(ctx, orderFilterFromProto(request.Filter), request.Take, request.Cursor, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	pbOrders := []*pb.Order{}
+	for _, o := range page.Orders {
+		pbOrders = append(pbOrders, toProtoOrder(o))
+	}
+
+	return &pb.SearchOrdersResponse{
+		Orders:     pbOrders,
+		Cursors:    page.Cursors,
+		NextCursor: page.NextCursor,
+		TotalCount: page.TotalCount,
+	}, nil
+}
+
+func (server *GrpcServer) ListCoPurchases(ctx context.Context, request *pb.ListCoPurchasesRequest) (*pb.ListCoPurchasesResponse, error) {
+	coPurchases, err := server.orderService.ListCoPurchases(ctx, request.ProductId, request.Take)
+	if err != nil {
+		return nil, err
+	}
+
+	pbCoPurchases := []*pb.CoPurchase{}
+	for _, coPurchase := range coPurchases {
+		pbCoPurchases = append(pbCoPurchases, &pb.CoPurchase{
+			ProductId: coPurchase.ProductID,
+			Orders:    coPurchase.Orders,
+		})
+	}
+
+	return &pb.ListCoPurchasesResponse{
+		Products: pbCoPurchases,
 	}, nil
 }
+
+// shippingAddress copies the address of the account with addressID, or the
+// account's default shipping address if addressID is empty. Defaults are
+// only looked up for callers who may read the address book; for others, and
+// for accounts without a default, it returns nil.
+func (server *GrpcServer) shippingAddress(ctx context.Context, accountID string, addressID string) (*ShippingAddress, error) {
+	var address *accountpb.Address
+	if addressID != "" {
+		response, err := server.accountClient.GetAddress(ctx, addressID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch shipping address: %w", err)
+		}
+		if response.Address == nil || response.Address.AccountId != accountID {
+			return nil, fmt.Errorf("invalid request: address %s does not belong to account %s", addressID, accountID)
+		}
+		address = response.Address
+	} else {
+		claims, ok := util.ClaimsFromContext(ctx)
+		if !ok || (!claims.IsAdmin() && claims.AccountID != accountID) {
+			return nil, nil
+		}
+		response, err := server.accountClient.ListAddresses(ctx, accountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch addresses: %w", err)
+		}
+		for _, candidate := range response.Addresses {
+			if candidate.DefaultShipping {
+				address = candidate
+			}
+		}
+		if address == nil {
+			return nil, nil
+		}
+	}
+	return &ShippingAddress{
+		AddressID:  address.Id,
+		Name:       address.Name,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     address.Region,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	}, nil
+}
+
+// priceProducts looks the requested products up in the catalog and returns
+// them priced in currency, along with the exchange rates used. An empty
+// currency keeps each product's own currency.
+func (server *GrpcServer) priceProducts(ctx context.Context, requested []*pb.OrderProduct, currency string) ([]*OrderProduct, []*pricing.Rate, error) {
+	productIDs := []string{}
+	for _, p := range requested {
+		productIDs = append(productIDs, p.ProductId)
+	}
+	catalogResp, err := server.catalogClient.ListProductsWithIDs(ctx, productIDs, currency)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch products from catalog: %w", err)
+	}
+
+	products := []*OrderProduct{}
+	exchangeRates := []*pricing.Rate{}
+	seenRates := map[string]bool{}
+	for _, p := range catalogResp.Products {
+		product := &OrderProduct{
+			ProductID:          p.Id,
+			ProductName:        p.Name,
+			ProductDescription: p.Description,
+			Price:              money.FromProto(p.DisplayPrice),
+			Quantity:           0,
+			CategoryID:         p.CategoryId,
+			TaxCategory:        p.TaxCategory,
+			Weight:             p.Weight,
+			MerchantID:         p.MerchantId,
+		}
+		if p.ExchangeRate != nil && !seenRates[p.ExchangeRate.Base] {
+			rate, err := pricing.RateFromProto(p.ExchangeRate)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid exchange rate from catalog: %w", err)
+			}
+			seenRates[rate.Base] = true
+			exchangeRates = append(exchangeRates, rate)
+		}
+		for _, rp := range requested {
+			if rp.ProductId == p.Id {
+				product.Quantity = rp.Quantity
+				break
+			}
+		}
+		products = append(products, product)
+	}
+
+	if len(products) != len(requested) {
+		return nil, nil, fmt.Errorf("validation error: one or more products not found in catalog")
+	}
+	return products, exchangeRates, nil
+}
+
+func (server *GrpcServer) CreateOrUpdatePromotion(ctx context.Context, request *pb.CreateOrUpdatePromotionRequest) (*pb.CreateOrUpdatePromotionResponse, error) {
+	if request == nil || request.Promotion == nil {
+		return nil, fmt.Errorf("invalid request: promotion cannot be nil")
+	}
+
+	promotion, err := server.orderService.CreateOrUpdatePromotion(ctx, promotionFromProto(request.Promotion))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateOrUpdatePromotionResponse{
+		Promotion: toProtoPromotion(promotion),
+	}, nil
+}
+
+func (server *GrpcServer) ListPromotions(ctx context.Context, request *pb.ListPromotionsRequest) (*pb.ListPromotionsResponse, error) {
+	promotions, err := server.orderService.ListPromotions(ctx, request.Skip, request.Take)
+	if err != nil {
+		return nil, err
+	}
+
+	pbPromotions := []*pb.Promotion{}
+	for _, promotion := range promotions {
+		pbPromotions = append(pbPromotions, toProtoPromotion(promotion))
+	}
+
+	return &pb.ListPromotionsResponse{
+		Promotions: pbPromotions,
+	}, nil
+}
+
+func (server *GrpcServer) CreateShipment(ctx context.Context, request *pb.CreateShipmentRequest) (*pb.CreateShipmentResponse, error) {
+	items := []*ShipmentItem{}
+	for _, item := range request.Items {
+		items = append(items, &ShipmentItem{ProductID: item.ProductId, Quantity: item.Quantity})
+	}
+	shipment, err := server.orderService.CreateShipment(ctx, &Shipment{
+		OrderID:        request.OrderId,
+		Carrier:        request.Carrier,
+		TrackingNumber: request.TrackingNumber,
+		Items:          items,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateShipmentResponse{
+		Shipment: toProtoShipment(shipment),
+	}, nil
+}
+
+func (server *GrpcServer) MarkDelivered(ctx context.Context, request *pb.MarkDeliveredRequest) (*pb.MarkDeliveredResponse, error) {
+	shipment, err := server.orderService.MarkDelivered(ctx, request.ShipmentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.MarkDeliveredResponse{
+		Shipment: toProtoShipment(shipment),
+	}, nil
+}
+
+func (server *GrpcServer) ListShipments(ctx context.Context, request *pb.ListShipmentsRequest) (*pb.ListShipmentsResponse, error) {
+	shipments, err := server.orderService.ListShipments(ctx, request.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	pbShipments := []*pb.Shipment{}
+	for _, shipment := range shipments {
+		pbShipments = append(pbShipments, toProtoShipment(shipment))
+	}
+
+	return &pb.ListShipmentsResponse{
+		Shipments: pbShipments,
+	}, nil
+}
+
+func (server *GrpcServer) GetShippingQuotes(ctx context.Context, request *pb.GetShippingQuotesRequest) (*pb.GetShippingQuotesResponse, error) {
+	if request == nil || (request.OrderId == "" && len(request.Products) == 0) {
+		return nil, fmt.Errorf("invalid request: order_id or products are required")
+	}
+
+	// 1. Quote the order, or the cart priced in the requested currency
+	var order *Order
+	accountID := request.AccountId
+	if request.OrderId != "" {
+		var err error
+		order, err = server.orderService.GetOrderById(ctx, request.OrderId, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch order: %w", err)
+		}
+		accountID = order.AccountID
+	} else {
+		if request.Currency != "" {
+			if err := money.ValidateCurrency(request.Currency); err != nil {
+				return nil, fmt.Errorf("invalid request: %w", err)
+			}
+		}
+		products, _, err := server.priceProducts(ctx, request.Products, request.Currency)
+		if err != nil {
+			return nil, err
+		}
+		order = &Order{AccountID: accountID, Products: products}
+	}
+
+	// 2. Ship to the given address, else the order's or the account's
+	switch {
+	case request.Address != nil:
+		order.ShippingAddress = shippingAddressFromProto(request.Address)
+	case request.ShippingAddressId != "" || (order.ShippingAddress == nil && accountID != ""):
+		address, err := server.shippingAddress(ctx, accountID, request.ShippingAddressId)
+		if err != nil {
+			return nil, err
+		}
+		order.ShippingAddress = address
+	}
+
+	quotes, err := server.orderService.GetShippingQuotes(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+
+	pbQuotes := []*pb.ShippingQuote{}
+	for _, quote := range quotes {
+		pbQuotes = append(pbQuotes, toProtoShippingQuote(quote))
+	}
+
+	return &pb.GetShippingQuotesResponse{
+		Quotes: pbQuotes,
+	}, nil
+}
+
+func (server *GrpcServer) RequestReturn(ctx context.Context, request *pb.RequestReturnRequest) (*pb.RequestReturnResponse, error) {
+	orderReturn, err := server.orderService.RequestReturn(ctx, &Return{
+		OrderID: request.OrderId,
+		Items:   returnItemsFromProto(request.Items),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RequestReturnResponse{
+		Return: toProtoReturn(orderReturn),
+	}, nil
+}
+
+func (server *GrpcServer) UpdateReturnStatus(ctx context.Context, request *pb.UpdateReturnStatusRequest) (*pb.UpdateReturnStatusResponse, error) {
+	orderReturn, err := server.orderService.UpdateReturnStatus(ctx, request.Id, ReturnStatus(request.Status), request.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateReturnStatusResponse{
+		Return: toProtoReturn(orderReturn),
+	}, nil
+}
+
+func (server *GrpcServer) InspectReturn(ctx context.Context, request *pb.InspectReturnRequest) (*pb.InspectReturnResponse, error) {
+	orderReturn, err := server.orderService.InspectReturn(ctx, request.Id, returnItemsFromProto(request.Items), request.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.InspectReturnResponse{
+		Return: toProtoReturn(orderReturn),
+	}, nil
+}
+
+func (server *GrpcServer) GetReturn(ctx context.Context, request *pb.GetReturnRequest) (*pb.GetReturnResponse, error) {
+	orderReturn, err := server.orderService.GetReturn(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetReturnResponse{
+		Return: toProtoReturn(orderReturn),
+	}, nil
+}
+
+func (server *GrpcServer) ListReturns(ctx context.Context, request *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	returns, err := server.orderService.ListReturns(ctx, request.OrderId, ReturnStatus(request.Status), request.Skip, request.Take)
+	if err != nil {
+		return nil, err
+	}
+
+	pbReturns := []*pb.Return{}
+	for _, orderReturn := range returns {
+		pbReturns = append(pbReturns, toProtoReturn(orderReturn))
+	}
+
+	return &pb.ListReturnsResponse{
+		Returns: pbReturns,
+	}, nil
+}
+
+// GetInvoice returns an invoice, rendered into a document if a format is
+// given.
+func (server *GrpcServer) GetInvoice(ctx context.Context, request *pb.GetInvoiceRequest) (*pb.GetInvoiceResponse, error) {
+	invoice, err := server.orderService.GetInvoice(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	response := &pb.GetInvoiceResponse{
+		Invoice: toProtoInvoice(invoice),
+	}
+	if request.Format == "" {
+		return response, nil
+	}
+
+	document := &InvoiceDocument{
+		Invoice:      invoice,
+		MerchantName: server.accountName(ctx, invoice.MerchantID),
+		CustomerName: server.accountName(ctx, invoice.AccountID),
+	}
+	if invoice.InvoiceID != "" {
+		corrected, err := server.orderService.GetInvoice(ctx, invoice.InvoiceID)
+		if err != nil {
+			return nil, err
+		}
+		document.CorrectedNumber = corrected.Number
+	}
+	response.Document, response.ContentType, err = RenderInvoice(document, request.Format)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (server *GrpcServer) ListInvoices(ctx context.Context, request *pb.ListInvoicesRequest) (*pb.ListInvoicesResponse, error) {
+	invoices, err := server.orderService.ListInvoices(ctx, request.OrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	pbInvoices := []*pb.Invoice{}
+	for _, invoice := range invoices {
+		pbInvoices = append(pbInvoices, toProtoInvoice(invoice))
+	}
+
+	return &pb.ListInvoicesResponse{
+		Invoices: pbInvoices,
+	}, nil
+}
+
+func (server *GrpcServer) ListMerchantOrders(ctx context.Context, request *pb.ListMerchantOrdersRequest) (*pb.ListMerchantOrdersResponse, error) {
+	filter := MerchantOrderFilter{
+		MerchantID: request.MerchantId,
+		OrderID:    request.OrderId,
+		Status:     OrderStatus(request.Status),
+	}
+	page, err := server.orderService.ListMerchantOrders(ctx, filter, request.Take, request.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	pbMerchantOrders := []*pb.MerchantOrder{}
+	for _, merchantOrder := range page.MerchantOrders {
+		pbMerchantOrders = append(pbMerchantOrders, toProtoMerchantOrder(merchantOrder))
+	}
+
+	return &pb.ListMerchantOrdersResponse{
+		MerchantOrders: pbMerchantOrders,
+		Cursors:        page.Cursors,
+		NextCursor:     page.NextCursor,
+		TotalCount:     page.TotalCount,
+	}, nil
+}
+
+func (server *GrpcServer) GetMerchantOrder(ctx context.Context, request *pb.GetMerchantOrderRequest) (*pb.GetMerchantOrderResponse, error) {
+	merchantOrder, err := server.orderService.GetMerchantOrder(ctx, request.OrderId, request.MerchantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetMerchantOrderResponse{
+		MerchantOrder: toProtoMerchantOrder(merchantOrder),
+	}, nil
+}
+
+func (server *GrpcServer) GetMerchantPayouts(ctx context.Context, request *pb.GetMerchantPayoutsRequest) (*pb.GetMerchantPayoutsResponse, error) {
+	var from, to *time.Time
+	if request.From != nil {
+		fromTime := request.From.AsTime()
+		from = &fromTime
+	}
+	if request.To != nil {
+		toTime := request.To.AsTime()
+		to = &toTime
+	}
+	payouts, err := server.orderService.GetMerchantPayouts(ctx, request.MerchantId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	pbPayouts := []*pb.MerchantPayout{}
+	for _, payout := range payouts {
+		pbPayouts = append(pbPayouts, &pb.MerchantPayout{
+			MerchantId: payout.MerchantID,
+			Currency:   payout.Currency,
+			Orders:     payout.Orders,
+			Total:      payout.Total.ToProto(),
+			Refunded:   payout.Refunded.ToProto(),
+			Payout:     payout.Payout.ToProto(),
+		})
+	}
+
+	return &pb.GetMerchantPayoutsResponse{
+		Payouts: pbPayouts,
+	}, nil
+}
+
+// accountName returns the name of an account for documents, or an empty
+// name if it cannot be looked up.
+func (server *GrpcServer) accountName(ctx context.Context, accountID string) string {
+	if accountID == "" {
+		return ""
+	}
+	response, err := server.accountClient.GetAccountByID(ctx, accountID)
+	if err != nil || response.Account == nil {
+		server.logger.Service().Warn().Err(err).Str("account", accountID).Msg("failed to look up account name for invoice")
+		return ""
+	}
+	return response.Account.Name
+}
+
+func toProtoInvoice(invoice *Invoice) *pb.Invoice {
+	pbLines := []*pb.InvoiceLine{}
+	for _, line := range invoice.Lines {
+		pbLines = append(pbLines, &pb.InvoiceLine{
+			ProductId:    line.ProductID,
+			Description:  line.Description,
+			Quantity:     line.Quantity,
+			UnitPrice:    line.UnitPrice.ToProto(),
+			Amount:       line.Amount.ToProto(),
+			Discount:     line.Discount.ToProto(),
+			Tax:          line.Tax.ToProto(),
+			TaxRate:      line.TaxRate,
+			TaxInclusive: line.TaxInclusive,
+			Total:        line.Total.ToProto(),
+		})
+	}
+	return &pb.Invoice{
+		Id:         invoice.ID,
+		Number:     invoice.Number,
+		Kind:       string(invoice.Kind),
+		MerchantId: invoice.MerchantID,
+		OrderId:    invoice.OrderID,
+		AccountId:  invoice.AccountID,
+		ReturnId:   invoice.ReturnID,
+		InvoiceId:  invoice.InvoiceID,
+		BillTo:     invoice.BillTo,
+		Lines:      pbLines,
+		Subtotal:   invoice.Subtotal.ToProto(),
+		Discount:   invoice.Discount.ToProto(),
+		Tax:        invoice.Tax.ToProto(),
+		Shipping:   invoice.Shipping.ToProto(),
+		Total:      invoice.Total.ToProto(),
+		IssuedAt:   timestamppb.New(invoice.IssuedAt),
+	}
+}
+
+func returnItemsFromProto(items []*pb.ReturnItem) []*ReturnItem {
+	result := []*ReturnItem{}
+	for _, item := range items {
+		result = append(result, &ReturnItem{
+			ProductID:        item.ProductId,
+			Quantity:         item.Quantity,
+			Reason:           ReturnReason(item.Reason),
+			Comment:          item.Comment,
+			AcceptedQuantity: item.AcceptedQuantity,
+			Restock:          item.Restock,
+		})
+	}
+	return result
+}
+
+func toProtoReturn(orderReturn *Return) *pb.Return {
+	pbItems := []*pb.ReturnItem{}
+	for _, item := range orderReturn.Items {
+		pbItems = append(pbItems, &pb.ReturnItem{
+			ProductId:        item.ProductID,
+			Quantity:         item.Quantity,
+			Reason:           string(item.Reason),
+			Comment:          item.Comment,
+			AcceptedQuantity: item.AcceptedQuantity,
+			Restock:          item.Restock,
+			Refund:           item.Refund.ToProto(),
+		})
+	}
+	return &pb.Return{
+		Id:        orderReturn.ID,
+		OrderId:   orderReturn.OrderID,
+		AccountId: orderReturn.AccountID,
+		Status:    string(orderReturn.Status),
+		Items:     pbItems,
+		Refund:    orderReturn.Refund.ToProto(),
+		Note:      orderReturn.Note,
+		CreatedAt: timestamppb.New(orderReturn.CreatedAt),
+		UpdatedAt: timestamppb.New(orderReturn.UpdatedAt),
+	}
+}
+
+func toProtoShippingQuote(quote *shipping.Quote) *pb.ShippingQuote {
+	return &pb.ShippingQuote{
+		Method:  quote.Method,
+		Name:    quote.Name,
+		Carrier: quote.Carrier,
+		Zone:    quote.Zone,
+		Fee:     quote.Fee.ToProto(),
+		Free:    quote.Free,
+	}
+}
+
+func toProtoShipment(shipment *Shipment) *pb.Shipment {
+	pbItems := []*pb.ShipmentItem{}
+	for _, item := range shipment.Items {
+		pbItems = append(pbItems, &pb.ShipmentItem{
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+	result := &pb.Shipment{
+		Id:             shipment.ID,
+		OrderId:        shipment.OrderID,
+		MerchantId:     shipment.MerchantID,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		Status:         string(shipment.Status),
+		Items:          pbItems,
+		ShippedAt:      timestamppb.New(shipment.ShippedAt),
+	}
+	if shipment.DeliveredAt != nil {
+		result.DeliveredAt = timestamppb.New(*shipment.DeliveredAt)
+	}
+	return result
+}
+
+func orderFilterFromProto(filter *pb.OrderFilter) OrderFilter {
+	if filter == nil {
+		return OrderFilter{}
+	}
+	result := OrderFilter{
+		AccountID: filter.AccountId,
+		ProductID: filter.ProductId,
+		Currency:  filter.Currency,
+		Sort:      OrderSort(filter.Sort),
+	}
+	for _, status := range filter.Statuses {
+		result.Statuses = append(result.Statuses, OrderStatus(status))
+	}
+	if filter.CreatedFrom != nil {
+		createdFrom := filter.CreatedFrom.AsTime()
+		result.CreatedFrom = &createdFrom
+	}
+	if filter.CreatedTo != nil {
+		createdTo := filter.CreatedTo.AsTime()
+		result.CreatedTo = &createdTo
+	}
+	if filter.MinTotal != nil {
+		minTotal := money.FromProto(filter.MinTotal)
+		result.MinTotal = &minTotal
+	}
+	if filter.MaxTotal != nil {
+		maxTotal := money.FromProto(filter.MaxTotal)
+		result.MaxTotal = &maxTotal
+	}
+	return result
+}
+
+func promotionFromProto(promotion *pb.Promotion) *Promotion {
+	result := &Promotion{
+		ID:                   promotion.Id,
+		Code:                 promotion.Code,
+		Description:          promotion.Description,
+		Type:                 PromotionType(promotion.Type),
+		PercentOff:           promotion.PercentOff,
+		AmountOff:            money.FromProto(promotion.AmountOff),
+		BuyQuantity:          promotion.BuyQuantity,
+		GetQuantity:          promotion.GetQuantity,
+		ProductIDs:           promotion.ProductIds,
+		CategoryIDs:          promotion.CategoryIds,
+		AccountIDs:           promotion.AccountIds,
+		Stackable:            promotion.Stackable,
+		UsageLimit:           promotion.UsageLimit,
+		UsageLimitPerAccount: promotion.UsageLimitPerAccount,
+	}
+	if promotion.StartsAt != nil {
+		startsAt := promotion.StartsAt.AsTime()
+		result.StartsAt = &startsAt
+	}
+	if promotion.EndsAt != nil {
+		endsAt := promotion.EndsAt.AsTime()
+		result.EndsAt = &endsAt
+	}
+	return result
+}
+
+func toProtoPromotion(promotion *Promotion) *pb.Promotion {
+	result := &pb.Promotion{
+		Id:                   promotion.ID,
+		Code:                 promotion.Code,
+		Description:          promotion.Description,
+		Type:                 string(promotion.Type),
+		PercentOff:           promotion.PercentOff,
+		BuyQuantity:          promotion.BuyQuantity,
+		GetQuantity:          promotion.GetQuantity,
+		ProductIds:           promotion.ProductIDs,
+		CategoryIds:          promotion.CategoryIDs,
+		AccountIds:           promotion.AccountIDs,
+		Stackable:            promotion.Stackable,
+		UsageLimit:           promotion.UsageLimit,
+		UsageLimitPerAccount: promotion.UsageLimitPerAccount,
+		CreatedAt:            timestamppb.New(promotion.CreatedAt),
+	}
+	if promotion.AmountOff.Currency != "" {
+		result.AmountOff = promotion.AmountOff.ToProto()
+	}
+	if promotion.StartsAt != nil {
+		result.StartsAt = timestamppb.New(*promotion.StartsAt)
+	}
+	if promotion.EndsAt != nil {
+		result.EndsAt = timestamppb.New(*promotion.EndsAt)
+	}
+	return result
+}
+
+func toProtoOrder(order *Order) *pb.Order {
+	pbProducts := []*pb.OrderProduct{}
+	for _, p := range order.Products {
+		pbProducts = append(pbProducts, toProtoOrderProduct(p))
+	}
+	pbDiscounts := []*pb.OrderDiscount{}
+	for _, discount := range order.Discounts {
+		pbDiscounts = append(pbDiscounts, &pb.OrderDiscount{
+			PromotionId: discount.PromotionID,
+			Code:        discount.Code,
+			Description: discount.Description,
+			ProductId:   discount.ProductID,
+			Amount:      discount.Amount.ToProto(),
+		})
+	}
+	pbRates := []*moneypb.ExchangeRate{}
+	for _, rate := range order.ExchangeRates {
+		pbRates = append(pbRates, rate.ToProto())
+	}
+	result := &pb.Order{
+		Id:              order.ID,
+		AccountId:       order.AccountID,
+		TotalPrice:      order.TotalPrice.ToProto(),
+		Products:        pbProducts,
+		CreatedAt:       timestamppb.New(order.CreatedAt),
+		ExchangeRates:   pbRates,
+		Status:          string(order.Status),
+		Discounts:       pbDiscounts,
+		DiscountTotal:   order.DiscountTotal.ToProto(),
+		CouponCodes:     order.CouponCodes,
+		Subtotal:        order.Subtotal.ToProto(),
+		TaxTotal:        order.TaxTotal.ToProto(),
+		TaxCountry:      order.TaxLocation.Country,
+		TaxRegion:       order.TaxLocation.Region,
+		TaxExempt:       order.TaxExempt,
+		ShippingAddress: toProtoShippingAddress(order.ShippingAddress),
+		ShippingMethod:  order.ShippingMethod,
+	}
+	if order.ShippingMethod != "" {
+		result.ShippingFee = order.ShippingFee.ToProto()
+	}
+	return result
+}
+
+func toProtoOrderProduct(p *OrderProduct) *pb.OrderProduct {
+	return &pb.OrderProduct{
+		OrderId:            p.OrderID,
+		ProductId:          p.ProductID,
+		ProductName:        p.ProductName,
+		ProductDescription: p.ProductDescription,
+		Price:              p.Price.ToProto(),
+		Quantity:           p.Quantity,
+		CategoryId:         p.CategoryID,
+		TaxCategory:        p.TaxCategory,
+		Tax:                toProtoOrderTax(p.Tax),
+		MerchantId:         p.MerchantID,
+	}
+}
+
+func toProtoMerchantOrder(merchantOrder *MerchantOrder) *pb.MerchantOrder {
+	pbProducts := []*pb.OrderProduct{}
+	for _, p := range merchantOrder.Products {
+		pbProducts = append(pbProducts, toProtoOrderProduct(p))
+	}
+	return &pb.MerchantOrder{
+		OrderId:    merchantOrder.OrderID,
+		MerchantId: merchantOrder.MerchantID,
+		AccountId:  merchantOrder.AccountID,
+		Status:     string(merchantOrder.Status),
+		Products:   pbProducts,
+		Subtotal:   merchantOrder.Subtotal.ToProto(),
+		Discount:   merchantOrder.Discount.ToProto(),
+		Tax:        merchantOrder.Tax.ToProto(),
+		Shipping:   merchantOrder.Shipping.ToProto(),
+		Total:      merchantOrder.Total.ToProto(),
+		Refunded:   merchantOrder.Refunded.ToProto(),
+		Payout:     merchantOrder.Payout().ToProto(),
+		CreatedAt:  timestamppb.New(merchantOrder.CreatedAt),
+		UpdatedAt:  timestamppb.New(merchantOrder.UpdatedAt),
+	}
+}
+
+func toProtoShippingAddress(address *ShippingAddress) *pb.ShippingAddress {
+	if address == nil {
+		return nil
+	}
+	return &pb.ShippingAddress{
+		AddressId:  address.AddressID,
+		Name:       address.Name,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     address.Region,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	}
+}
+
+func shippingAddressFromProto(address *pb.ShippingAddress) *ShippingAddress {
+	return &ShippingAddress{
+		AddressID:  address.AddressId,
+		Name:       address.Name,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     address.Region,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	}
+}
+
+func toProtoOrderTax(orderTax *OrderTax) *pb.OrderTax {
+	if orderTax == nil {
+		return nil
+	}
+	return &pb.OrderTax{
+		Country:   orderTax.Country,
+		Region:    orderTax.Region,
+		Category:  orderTax.Category,
+		Rate:      tax.FormatRate(orderTax.Rate),
+		Inclusive: orderTax.Inclusive,
+		Amount:    orderTax.Amount.ToProto(),
+	}
+}
@@ -2,61 +2,870 @@ package order
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/pricing"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/shipping"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/tax"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 	"github.com/segmentio/ksuid"
 )
 
 type Service interface {
 	CreateOrUpdateOrder(ctx context.Context, order *Order) (*Order, error)
-	GetOrderById(ctx context.Context, id string) (*Order, error)
-	GetOrdersForAccount(ctx context.Context, accountID string) ([]*Order, error)
+	UpdateOrderItems(ctx context.Context, id string, operation ItemOperation, products []*OrderProduct, rates []*pricing.Rate) (*Order, error)
+	UpdateOrderStatus(ctx context.Context, id string, status OrderStatus) (*Order, error)
+	ListOrderItemChanges(ctx context.Context, orderID string) ([]*OrderItemChange, error)
+	ApplyCoupon(ctx context.Context, orderID string, code string) (*Order, error)
+	GetOrderById(ctx context.Context, id string, currency string) (*Order, error)
+	GetOrdersForAccount(ctx context.Context, accountID string, take uint64, cursor string, currency string) (*OrderPage, error)
+	SearchOrders(ctx context.Context, filter OrderFilter, take uint64, cursor string, currency string) (*OrderPage, error)
+	RefreshCoPurchases(ctx context.Context) (int64, error)
+	ListCoPurchases(ctx context.Context, productID string, take uint64) ([]*CoPurchase, error)
+	CreateOrUpdatePromotion(ctx context.Context, promotion *Promotion) (*Promotion, error)
+	ListPromotions(ctx context.Context, skip uint64, take uint64) ([]*Promotion, error)
+	CreateShipment(ctx context.Context, shipment *Shipment) (*Shipment, error)
+	MarkDelivered(ctx context.Context, shipmentID string) (*Shipment, error)
+	ListShipments(ctx context.Context, orderID string) ([]*Shipment, error)
+	GetShippingQuotes(ctx context.Context, order *Order) ([]*shipping.Quote, error)
+	RequestReturn(ctx context.Context, orderReturn *Return) (*Return, error)
+	UpdateReturnStatus(ctx context.Context, id string, status ReturnStatus, note string) (*Return, error)
+	InspectReturn(ctx context.Context, id string, items []*ReturnItem, note string) (*Return, error)
+	GetReturn(ctx context.Context, id string) (*Return, error)
+	ListReturns(ctx context.Context, orderID string, status ReturnStatus, skip uint64, take uint64) ([]*Return, error)
+	GetInvoice(ctx context.Context, id string) (*Invoice, error)
+	ListInvoices(ctx context.Context, orderID string) ([]*Invoice, error)
+	ListMerchantOrders(ctx context.Context, filter MerchantOrderFilter, take uint64, cursor string) (*MerchantOrderPage, error)
+	GetMerchantOrder(ctx context.Context, orderID string, merchantID string) (*MerchantOrder, error)
+	GetMerchantPayouts(ctx context.Context, merchantID string, from *time.Time, to *time.Time) ([]*MerchantPayout, error)
 }
 
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("permission denied")
+	// ErrOrderNotPending is returned when changing the products of an order
+	// that has been paid or cancelled.
+	ErrOrderNotPending = errors.New("order is no longer pending")
+)
+
 type OrderService struct {
 	repository Repository
+	pricing    pricing.Service
+	shipping   shipping.ShippingRateProvider
 }
 
-func NewOrderService(repository Repository) *OrderService {
-	return &OrderService{repository: repository}
+func NewOrderService(repository Repository, pricing pricing.Service, shipping shipping.ShippingRateProvider) *OrderService {
+	return &OrderService{repository: repository, pricing: pricing, shipping: shipping}
 }
 
+// CreateOrUpdateOrder places a new order, or replaces every product of an
+// existing one, which must still be pending and stays with its account. Only
+// the account itself and admins may place or change an order, and every
+// product must have a positive quantity. The order's coupon codes are added
+// to those already applied and must each give it a discount. The order is
+// taxed at its tax location unless it is tax exempt, and ships to its
+// shipping address with its shipping method, if it has one.
 func (service *OrderService) CreateOrUpdateOrder(ctx context.Context, order *Order) (*Order, error) {
+	taxLocation, err := tax.ParseLocation(order.TaxLocation.Country, order.TaxLocation.Region)
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range order.Products {
+		if product.ProductID == "" {
+			return nil, errors.New("product id is required")
+		}
+		if product.Quantity <= 0 {
+			return nil, fmt.Errorf("quantity of product %s must be positive", product.ProductID)
+		}
+	}
 	id := order.ID
 	createdAt := order.CreatedAt
+	exists := false
+	if id != "" {
+		stored, err := service.authorizeOrder(ctx, id)
+		switch {
+		case err == nil:
+			if order.AccountID != stored.AccountID {
+				return nil, errors.New("an order cannot be moved to another account")
+			}
+			exists = true
+		case !errors.Is(err, sql.ErrNoRows):
+			return nil, err
+		}
+	}
+	if !exists {
+		if err := authorizeAccount(ctx, order.AccountID); err != nil {
+			return nil, err
+		}
+	}
 	if id == "" {
 		id = ksuid.New().String()
 		createdAt = time.Now().UTC()
 	}
 	newOrder := &Order{
-		ID:         id,
-		CreatedAt:  createdAt,
-		AccountID:  order.AccountID,
-		TotalPrice: order.TotalPrice,
-		Products:   order.Products,
+		ID:             id,
+		CreatedAt:      createdAt,
+		AccountID:      order.AccountID,
+		TotalPrice:     order.TotalPrice,
+		Products:       order.Products,
+		ExchangeRates:  order.ExchangeRates,
+		Status:         OrderStatusPending,
+		CouponCodes:    []string{},
+		TaxLocation:    taxLocation,
+		TaxExempt:      order.TaxExempt,
+		ShippingMethod: strings.ToLower(strings.TrimSpace(order.ShippingMethod)),
+	}
+	if order.ShippingAddress != nil {
+		address := *order.ShippingAddress
+		newOrder.ShippingAddress = &address
+	}
+	for _, code := range order.CouponCodes {
+		code = NormalizeCouponCode(code)
+		if code == "" {
+			return nil, errors.New("coupon code must not be empty")
+		}
+		if !slices.Contains(newOrder.CouponCodes, code) {
+			newOrder.CouponCodes = append(newOrder.CouponCodes, code)
+		}
+	}
+	totalPrice, err := orderTotal(newOrder.Products)
+	if err != nil {
+		return nil, err
+	}
+	newOrder.TotalPrice = totalPrice
+	return service.repository.CreateOrUpdateOrder(ctx, newOrder)
+}
+
+// UpdateOrderItems changes the products of a pending order, for its account
+// or an admin. Lines added or replaced must be priced in the order currency;
+// removed lines only need a product id and the quantity to remove.
+func (service *OrderService) UpdateOrderItems(ctx context.Context, id string, operation ItemOperation, products []*OrderProduct, rates []*pricing.Rate) (*Order, error) {
+	if id == "" {
+		return nil, errors.New("order id is required")
+	}
+	if !operation.Valid() {
+		return nil, fmt.Errorf("invalid item operation %q", operation)
+	}
+	if len(products) == 0 {
+		return nil, errors.New("at least one product is required")
+	}
+	seen := map[string]bool{}
+	for _, product := range products {
+		if product.ProductID == "" {
+			return nil, errors.New("product id is required")
+		}
+		if seen[product.ProductID] {
+			return nil, fmt.Errorf("product %s is listed more than once", product.ProductID)
+		}
+		seen[product.ProductID] = true
+		if product.Quantity < 0 || (product.Quantity == 0 && operation != ItemOperationRemove) {
+			return nil, fmt.Errorf("quantity of product %s must be positive", product.ProductID)
+		}
+	}
+	if _, err := service.authorizeOrder(ctx, id); err != nil {
+		return nil, err
+	}
+	return service.repository.UpdateOrderItems(ctx, id, operation, products, rates)
+}
+
+// UpdateOrderStatus moves an order along its lifecycle. Only admins change
+// statuses.
+func (service *OrderService) UpdateOrderStatus(ctx context.Context, id string, status OrderStatus) (*Order, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can change order statuses", ErrForbidden)
+	}
+	if !status.Valid() {
+		return nil, fmt.Errorf("invalid status %q", status)
+	}
+	return service.repository.UpdateOrderStatus(ctx, id, status)
+}
+
+// ApplyCoupon applies a coupon to a pending order, which is repriced with
+// it, for the order's account or an admin. It fails, saying why, if the
+// coupon gives the order no discount.
+func (service *OrderService) ApplyCoupon(ctx context.Context, orderID string, code string) (*Order, error) {
+	if orderID == "" {
+		return nil, errors.New("order id is required")
+	}
+	code = NormalizeCouponCode(code)
+	if code == "" {
+		return nil, errors.New("coupon code is required")
+	}
+	if _, err := service.authorizeOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return service.repository.ApplyCoupon(ctx, orderID, code)
+}
+
+// ListOrderItemChanges lists the changes made to the products of an order,
+// oldest first, for its account or an admin.
+func (service *OrderService) ListOrderItemChanges(ctx context.Context, orderID string) ([]*OrderItemChange, error) {
+	if orderID == "" {
+		return nil, errors.New("order id is required")
+	}
+	if _, err := service.authorizeOrder(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return service.repository.ListOrderItemChanges(ctx, orderID)
+}
+
+// GetOrderById returns an order to its account, an admin or a merchant with
+// lines in it.
+func (service *OrderService) GetOrderById(ctx context.Context, id string, currency string) (*Order, error) {
+	order, err := service.authorizeOrderView(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := service.convertOrder(order, currency); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// GetOrdersForAccount lists the orders of an account to the account itself
+// or an admin, newest first, a page of at most take orders at a time, 100 if
+// take is 0 or more than 100. The page after one starts at its cursor.
+func (service *OrderService) GetOrdersForAccount(ctx context.Context, accountID string, take uint64, cursor string, currency string) (*OrderPage, error) {
+	if err := authorizeAccount(ctx, accountID); err != nil {
+		return nil, err
+	}
+	if take == 0 || take > 100 {
+		take = 100
+	}
+	page, err := service.repository.GetOrdersForAccount(ctx, accountID, take, cursor)
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range page.Orders {
+		if err := service.convertOrder(order, currency); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// SearchOrders finds the orders matching filter for admins, a page of at
+// most take orders at a time, 100 if take is 0 or more than 100. The page
+// after one starts at its cursor.
+func (service *OrderService) SearchOrders(ctx context.Context, filter OrderFilter, take uint64, cursor string, currency string) (*OrderPage, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can search orders", ErrForbidden)
+	}
+	for _, status := range filter.Statuses {
+		if !status.Valid() {
+			return nil, fmt.Errorf("invalid order status %q", status)
+		}
+	}
+	if !filter.Sort.Valid() {
+		return nil, fmt.Errorf("invalid order sort %q", filter.Sort)
+	}
+	if filter.Currency != "" {
+		if err := money.ValidateCurrency(filter.Currency); err != nil {
+			return nil, err
+		}
+	}
+	if (filter.Sort == OrderSortTotalDesc || filter.Sort == OrderSortTotalAsc) && filter.Currency == "" {
+		return nil, errors.New("sorting orders by total requires a currency filter")
+	}
+	for _, bound := range []*money.Money{filter.MinTotal, filter.MaxTotal} {
+		if bound == nil {
+			continue
+		}
+		if err := bound.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid total bound: %w", err)
+		}
+		if filter.Currency != "" && bound.Currency != filter.Currency {
+			return nil, fmt.Errorf("total bounds must be in %s", filter.Currency)
+		}
+	}
+	if filter.MinTotal != nil && filter.MaxTotal != nil {
+		if filter.MinTotal.Currency != filter.MaxTotal.Currency {
+			return nil, errors.New("minimum and maximum totals must share a currency")
+		}
+		if filter.MinTotal.Amount > filter.MaxTotal.Amount {
+			return nil, errors.New("minimum total must not exceed the maximum")
+		}
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedTo.After(*filter.CreatedFrom) {
+		return nil, errors.New("date range must end after it starts")
+	}
+	if take == 0 || take > 100 {
+		take = 100
+	}
+	page, err := service.repository.SearchOrders(ctx, filter, take, cursor)
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range page.Orders {
+		if err := service.convertOrder(order, currency); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (service *OrderService) RefreshCoPurchases(ctx context.Context) (int64, error) {
+	return service.repository.RefreshCoPurchases(ctx)
+}
+
+func (service *OrderService) ListCoPurchases(ctx context.Context, productID string, take uint64) ([]*CoPurchase, error) {
+	if productID == "" {
+		return nil, errors.New("product id is required")
+	}
+	if take == 0 || take > 100 {
+		take = 100
+	}
+	return service.repository.ListCoPurchases(ctx, productID, take)
+}
+
+// CreateOrUpdatePromotion validates and stores a promotion. Only admins
+// manage promotions.
+func (service *OrderService) CreateOrUpdatePromotion(ctx context.Context, promotion *Promotion) (*Promotion, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can manage promotions", ErrForbidden)
+	}
+	newPromotion := *promotion
+	if newPromotion.ID == "" {
+		newPromotion.ID = ksuid.New().String()
+	}
+	if newPromotion.CreatedAt.IsZero() {
+		newPromotion.CreatedAt = time.Now().UTC()
+	}
+	newPromotion.Code = NormalizeCouponCode(newPromotion.Code)
+	newPromotion.Description = strings.TrimSpace(newPromotion.Description)
+	switch newPromotion.Type {
+	case PromotionTypePercentage:
+		if newPromotion.PercentOff < 1 || newPromotion.PercentOff > 100 {
+			return nil, errors.New("percent off must be between 1 and 100")
+		}
+	case PromotionTypeFixed:
+		if err := newPromotion.AmountOff.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid amount off: %w", err)
+		}
+		if newPromotion.AmountOff.IsZero() {
+			return nil, errors.New("amount off must be positive")
+		}
+	case PromotionTypeBuyXGetY:
+		if newPromotion.BuyQuantity == 0 || newPromotion.GetQuantity == 0 {
+			return nil, errors.New("buy and get quantities must be positive")
+		}
+	default:
+		return nil, fmt.Errorf("invalid promotion type %q", newPromotion.Type)
+	}
+	if newPromotion.Type != PromotionTypePercentage {
+		newPromotion.PercentOff = 0
+	}
+	if newPromotion.Type != PromotionTypeFixed {
+		newPromotion.AmountOff = money.Money{}
+	}
+	if newPromotion.Type != PromotionTypeBuyXGetY {
+		newPromotion.BuyQuantity, newPromotion.GetQuantity = 0, 0
+	}
+	if newPromotion.StartsAt != nil && newPromotion.EndsAt != nil && !newPromotion.EndsAt.After(*newPromotion.StartsAt) {
+		return nil, errors.New("promotion must end after it starts")
+	}
+	return service.repository.CreateOrUpdatePromotion(ctx, &newPromotion)
+}
+
+// ListPromotions lists promotions, newest first. Only admins see them, as
+// they include coupon codes.
+func (service *OrderService) ListPromotions(ctx context.Context, skip uint64, take uint64) ([]*Promotion, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can list promotions", ErrForbidden)
+	}
+	if take > 100 || (skip == 0 && take == 0) {
+		take = 100
+	}
+	return service.repository.ListPromotions(ctx, skip, take)
+}
+
+// CreateShipment records a shipment of a paid or shipped order. Admins ship
+// any of its lines, and merchants the lines of their own products.
+func (service *OrderService) CreateShipment(ctx context.Context, shipment *Shipment) (*Shipment, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	merchantID := ""
+	if !claims.IsAdmin() {
+		if claims.UserType != util.UserTypeMerchant {
+			return nil, fmt.Errorf("%w: only merchants and admins can ship orders", ErrForbidden)
+		}
+		merchantID = claims.AccountID
+	}
+	newShipment := &Shipment{
+		ID:             ksuid.New().String(),
+		OrderID:        shipment.OrderID,
+		MerchantID:     merchantID,
+		Carrier:        strings.TrimSpace(shipment.Carrier),
+		TrackingNumber: strings.TrimSpace(shipment.TrackingNumber),
+		Items:          shipment.Items,
+		ShippedAt:      time.Now().UTC(),
+	}
+	if newShipment.OrderID == "" {
+		return nil, errors.New("order id is required")
+	}
+	if newShipment.Carrier == "" {
+		return nil, errors.New("carrier is required")
+	}
+	return service.repository.CreateShipment(ctx, newShipment)
+}
+
+// MarkDelivered records that a shipment has been delivered. Only admins mark
+// deliveries.
+func (service *OrderService) MarkDelivered(ctx context.Context, shipmentID string) (*Shipment, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can mark deliveries", ErrForbidden)
+	}
+	if shipmentID == "" {
+		return nil, errors.New("shipment id is required")
+	}
+	return service.repository.MarkDelivered(ctx, shipmentID, time.Now().UTC())
+}
+
+// ListShipments lists the shipments of an order: all of them to the order's
+// account and admins, and their own to merchants with lines in it.
+func (service *OrderService) ListShipments(ctx context.Context, orderID string) ([]*Shipment, error) {
+	if orderID == "" {
+		return nil, errors.New("order id is required")
+	}
+	order, err := service.authorizeOrderView(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	shipments, err := service.repository.ListShipments(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	claims, _ := util.ClaimsFromContext(ctx)
+	if claims.IsAdmin() || claims.AccountID == order.AccountID {
+		return shipments, nil
+	}
+	visible := []*Shipment{}
+	for _, shipment := range shipments {
+		if shipment.MerchantID == claims.AccountID {
+			visible = append(visible, shipment)
+		}
+	}
+	return visible, nil
+}
+
+// GetShippingQuotes quotes every shipping method that delivers the products
+// of order to its shipping address, cheapest first. They are quoted on what
+// they cost after the order's discounts.
+func (service *OrderService) GetShippingQuotes(ctx context.Context, order *Order) ([]*shipping.Quote, error) {
+	if order.ShippingAddress == nil || order.ShippingAddress.Country == "" {
+		return nil, errors.New("a shipping address with a country is required")
+	}
+	if service.shipping == nil {
+		return []*shipping.Quote{}, nil
+	}
+	parcel, err := orderParcel(order)
+	if err != nil {
+		return nil, err
+	}
+	return service.shipping.Quotes(parcel)
+}
+
+// RequestReturn asks to send back units of the lines of a delivered order,
+// giving a reason for each line. Customers return their own orders; admins
+// can return any order.
+func (service *OrderService) RequestReturn(ctx context.Context, orderReturn *Return) (*Return, error) {
+	if orderReturn.OrderID == "" {
+		return nil, errors.New("order id is required")
+	}
+	order, err := service.repository.GetOrderById(ctx, orderReturn.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeAccount(ctx, order.AccountID); err != nil {
+		return nil, err
+	}
+	items := []*ReturnItem{}
+	for _, item := range orderReturn.Items {
+		if item.ProductID == "" {
+			return nil, errors.New("product id is required")
+		}
+		reason := ReturnReason(strings.ToLower(strings.TrimSpace(string(item.Reason))))
+		if !reason.Valid() {
+			return nil, fmt.Errorf("invalid return reason %q", item.Reason)
+		}
+		items = append(items, &ReturnItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Reason:    reason,
+			Comment:   strings.TrimSpace(item.Comment),
+		})
+	}
+	now := time.Now().UTC()
+	return service.repository.CreateReturn(ctx, &Return{
+		ID:        ksuid.New().String(),
+		OrderID:   order.ID,
+		Items:     items,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+// UpdateReturnStatus approves or rejects a requested return, or marks an
+// approved one received. Only admins process returns.
+func (service *OrderService) UpdateReturnStatus(ctx context.Context, id string, status ReturnStatus, note string) (*Return, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can process returns", ErrForbidden)
+	}
+	if id == "" {
+		return nil, errors.New("return id is required")
+	}
+	if !status.Valid() {
+		return nil, fmt.Errorf("invalid return status %q", status)
+	}
+	return service.repository.UpdateReturnStatus(ctx, id, status, strings.TrimSpace(note), time.Now().UTC())
+}
+
+// InspectReturn records how many units of each line of a received return
+// are accepted, and which are restocked, and refunds the accepted units.
+// Without items every unit is accepted and restocked. Only admins process
+// returns.
+func (service *OrderService) InspectReturn(ctx context.Context, id string, items []*ReturnItem, note string) (*Return, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can process returns", ErrForbidden)
+	}
+	if id == "" {
+		return nil, errors.New("return id is required")
+	}
+	return service.repository.InspectReturn(ctx, id, items, strings.TrimSpace(note), time.Now().UTC())
+}
+
+// GetReturn returns a return to the account that requested it or an admin.
+func (service *OrderService) GetReturn(ctx context.Context, id string) (*Return, error) {
+	if id == "" {
+		return nil, errors.New("return id is required")
+	}
+	orderReturn, err := service.repository.GetReturn(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeAccount(ctx, orderReturn.AccountID); err != nil {
+		return nil, err
+	}
+	return orderReturn, nil
+}
+
+// ListReturns lists the returns of an order to its account or an admin, or
+// the returns of every order to admins, newest first.
+func (service *OrderService) ListReturns(ctx context.Context, orderID string, status ReturnStatus, skip uint64, take uint64) ([]*Return, error) {
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("invalid return status %q", status)
+	}
+	if orderID == "" {
+		claims, ok := util.ClaimsFromContext(ctx)
+		if !ok {
+			return nil, ErrUnauthenticated
+		}
+		if !claims.IsAdmin() {
+			return nil, fmt.Errorf("%w: only admins can list the returns of every order", ErrForbidden)
+		}
+	} else {
+		order, err := service.repository.GetOrderById(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		if err := authorizeAccount(ctx, order.AccountID); err != nil {
+			return nil, err
+		}
+	}
+	if take > 100 || (skip == 0 && take == 0) {
+		take = 100
+	}
+	return service.repository.ListReturns(ctx, orderID, status, skip, take)
+}
+
+// GetInvoice returns an invoice or credit note to the order's account, the
+// invoice's merchant or an admin.
+func (service *OrderService) GetInvoice(ctx context.Context, id string) (*Invoice, error) {
+	if id == "" {
+		return nil, errors.New("invoice id is required")
+	}
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	invoice, err := service.repository.GetInvoice(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrInvoiceNotFound, id)
+	}
+	if err != nil {
+		return nil, err
 	}
-	newOrder.TotalPrice = 0.0
-	for _, product := range newOrder.Products {
-		newOrder.TotalPrice += product.Price * float64(product.Quantity)
+	if !canViewInvoice(claims, invoice) {
+		return nil, ErrForbidden
 	}
-	if _, err := service.repository.CreateOrUpdateOrder(ctx, newOrder); err != nil {
+	return invoice, nil
+}
+
+// ListInvoices lists the invoices and credit notes of an order, oldest
+// first: all of them to the order's account and admins, and their own to
+// merchants.
+func (service *OrderService) ListInvoices(ctx context.Context, orderID string) ([]*Invoice, error) {
+	if orderID == "" {
+		return nil, errors.New("order id is required")
+	}
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	invoices, err := service.repository.ListInvoices(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	visible := []*Invoice{}
+	for _, invoice := range invoices {
+		if canViewInvoice(claims, invoice) {
+			visible = append(visible, invoice)
+		}
+	}
+	if len(visible) == 0 && len(invoices) > 0 {
+		return nil, ErrForbidden
+	}
+	return visible, nil
+}
+
+// ListMerchantOrders lists merchant orders, newest first, a page of at most
+// take at a time, 100 if take is 0 or more than 100. Merchants list their own
+// and admins those of any merchant; the merchant orders of one order are also
+// listed to its account, and to other merchants only their own. Only admins
+// list them without a merchant or order.
+func (service *OrderService) ListMerchantOrders(ctx context.Context, filter MerchantOrderFilter, take uint64, cursor string) (*MerchantOrderPage, error) {
+	if filter.Status != "" && !filter.Status.Valid() {
+		return nil, fmt.Errorf("invalid order status %q", filter.Status)
+	}
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		switch {
+		case filter.MerchantID != "":
+			if claims.AccountID != filter.MerchantID {
+				return nil, fmt.Errorf("%w: merchants can only list their own orders", ErrForbidden)
+			}
+		case filter.OrderID != "":
+			order, err := service.repository.GetOrderById(ctx, filter.OrderID)
+			if err != nil {
+				return nil, err
+			}
+			if claims.AccountID != order.AccountID {
+				if claims.UserType != util.UserTypeMerchant {
+					return nil, ErrForbidden
+				}
+				filter.MerchantID = claims.AccountID
+			}
+		default:
+			return nil, fmt.Errorf("%w: only admins can list the merchant orders of every merchant", ErrForbidden)
+		}
+	}
+	if take == 0 || take > 100 {
+		take = 100
+	}
+	return service.repository.ListMerchantOrders(ctx, filter, take, cursor)
+}
+
+// GetMerchantOrder returns the part of an order sold by a merchant to the
+// merchant, the order's account or an admin.
+func (service *OrderService) GetMerchantOrder(ctx context.Context, orderID string, merchantID string) (*MerchantOrder, error) {
+	if orderID == "" {
+		return nil, errors.New("order id is required")
+	}
+	if merchantID == "" {
+		return nil, errors.New("merchant id is required")
+	}
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	page, err := service.repository.ListMerchantOrders(ctx, MerchantOrderFilter{MerchantID: merchantID, OrderID: orderID}, 1, "")
+	if err != nil {
 		return nil, err
 	}
-	return newOrder, nil
+	if len(page.MerchantOrders) == 0 {
+		return nil, fmt.Errorf("%w: order %s has no products of merchant %s", ErrMerchantOrderNotFound, orderID, merchantID)
+	}
+	merchantOrder := page.MerchantOrders[0]
+	if !claims.IsAdmin() && claims.AccountID != merchantOrder.MerchantID && claims.AccountID != merchantOrder.AccountID {
+		return nil, ErrForbidden
+	}
+	return merchantOrder, nil
+}
+
+// GetMerchantPayouts sums what a merchant is owed for the orders placed from
+// from to to, in each currency, for the merchant or an admin.
+func (service *OrderService) GetMerchantPayouts(ctx context.Context, merchantID string, from *time.Time, to *time.Time) ([]*MerchantPayout, error) {
+	if merchantID == "" {
+		return nil, errors.New("merchant id is required")
+	}
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() && claims.AccountID != merchantID {
+		return nil, fmt.Errorf("%w: merchants can only see their own payouts", ErrForbidden)
+	}
+	if from != nil && to != nil && !to.After(*from) {
+		return nil, errors.New("date range must end after it starts")
+	}
+	return service.repository.GetMerchantPayouts(ctx, merchantID, from, to)
+}
+
+// canViewInvoice reports whether invoice may be shown to the account of
+// claims: the order's account, the invoice's merchant or an admin.
+func canViewInvoice(claims *util.JWTClaims, invoice *Invoice) bool {
+	return claims.IsAdmin() || claims.AccountID == invoice.AccountID || (invoice.MerchantID != "" && claims.AccountID == invoice.MerchantID)
+}
+
+// canViewOrder reports whether order may be shown to the account of claims:
+// the order's account, an admin or a merchant with lines in it.
+func canViewOrder(claims *util.JWTClaims, order *Order) bool {
+	if claims.IsAdmin() || claims.AccountID == order.AccountID {
+		return true
+	}
+	if claims.UserType != util.UserTypeMerchant {
+		return false
+	}
+	return slices.ContainsFunc(order.Products, func(product *OrderProduct) bool {
+		return product.MerchantID == claims.AccountID
+	})
 }
 
-func (service *OrderService) GetOrderById(ctx context.Context, id string) (*Order, error) {
+// authorizeOrderView returns the order with id if the caller may see it.
+func (service *OrderService) authorizeOrderView(ctx context.Context, id string) (*Order, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
 	order, err := service.repository.GetOrderById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if !canViewOrder(claims, order) {
+		return nil, ErrForbidden
+	}
 	return order, nil
 }
 
-func (service *OrderService) GetOrdersForAccount(ctx context.Context, accountID string) ([]*Order, error) {
-	orders, err := service.repository.GetOrdersForAccount(ctx, accountID)
+// authorizeOrder returns the order with id if the caller is its account or
+// an admin.
+func (service *OrderService) authorizeOrder(ctx context.Context, id string) (*Order, error) {
+	order, err := service.repository.GetOrderById(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	return orders, nil
+	if err := authorizeAccount(ctx, order.AccountID); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// authorizeAccount lets the account itself and admins through.
+func authorizeAccount(ctx context.Context, accountID string) error {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return ErrUnauthenticated
+	}
+	if !claims.IsAdmin() && claims.AccountID != accountID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// convertOrder re-expresses an order's amounts in currency for display, using
+// the rates in effect when it was placed. The stored order and its rate
+// snapshot are left untouched.
+func (service *OrderService) convertOrder(order *Order, currency string) error {
+	if currency == "" || currency == order.TotalPrice.Currency {
+		return nil
+	}
+	for _, product := range order.Products {
+		quote, err := service.pricing.Convert(product.Price, currency, order.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to convert order %s to %s: %w", order.ID, currency, err)
+		}
+		product.Price = quote.Price
+	}
+	for _, discount := range order.Discounts {
+		quote, err := service.pricing.Convert(discount.Amount, currency, order.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to convert order %s to %s: %w", order.ID, currency, err)
+		}
+		discount.Amount = quote.Price
+	}
+	for _, product := range order.Products {
+		if product.Tax == nil {
+			continue
+		}
+		quote, err := service.pricing.Convert(product.Tax.Amount, currency, order.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to convert order %s to %s: %w", order.ID, currency, err)
+		}
+		product.Tax.Amount = quote.Price
+	}
+	if order.ShippingMethod != "" {
+		quote, err := service.pricing.Convert(order.ShippingFee, currency, order.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to convert order %s to %s: %w", order.ID, currency, err)
+		}
+		order.ShippingFee = quote.Price
+	}
+	return updateTotals(order)
+}
+
+// orderTotal sums line prices in minor units. All lines must share a currency.
+func orderTotal(products []*OrderProduct) (money.Money, error) {
+	if len(products) == 0 {
+		return money.Money{}, errors.New("order must contain at least one product")
+	}
+	total := money.Zero(products[0].Price.Currency)
+	for _, product := range products {
+		amount, err := product.Price.Mul(int64(product.Quantity))
+		if err == nil {
+			total, err = total.Add(amount)
+		}
+		if err != nil {
+			return money.Money{}, fmt.Errorf("failed to compute order total: %w", err)
+		}
+	}
+	return total, nil
 }
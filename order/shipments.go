@@ -0,0 +1,93 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNothingToShip is returned when a shipment would contain no units, or
+// more units than are left to ship.
+var ErrNothingToShip = errors.New("nothing left to ship")
+
+// shipmentItems returns the items of a new shipment of order, whose earlier
+// shipments are given. Each requested item must be a line of the order with
+// enough units left to ship; without requested items, every unit left is
+// shipped. A merchant's shipment only ships the merchant's lines.
+func shipmentItems(order *Order, shipments []*Shipment, requested []*ShipmentItem, merchantID string) ([]*ShipmentItem, error) {
+	left := unshippedQuantities(order, shipments)
+	if merchantID != "" {
+		for _, product := range order.Products {
+			if product.MerchantID != merchantID {
+				delete(left, product.ProductID)
+			}
+		}
+		if len(left) == 0 {
+			return nil, fmt.Errorf("%w: order %s has no products of merchant %s", ErrForbidden, order.ID, merchantID)
+		}
+	}
+	items := []*ShipmentItem{}
+	if len(requested) == 0 {
+		for _, product := range order.Products {
+			if left[product.ProductID] > 0 {
+				items = append(items, &ShipmentItem{ProductID: product.ProductID, Quantity: left[product.ProductID]})
+			}
+		}
+		if len(items) == 0 {
+			if merchantID != "" {
+				return nil, fmt.Errorf("%w: the products of merchant %s in order %s have shipped in full", ErrNothingToShip, merchantID, order.ID)
+			}
+			return nil, fmt.Errorf("%w: order %s has shipped in full", ErrNothingToShip, order.ID)
+		}
+		return items, nil
+	}
+	seen := map[string]bool{}
+	for _, item := range requested {
+		quantity, ok := left[item.ProductID]
+		switch {
+		case !ok && merchantID != "":
+			return nil, fmt.Errorf("product %s is not a product of merchant %s in order %s", item.ProductID, merchantID, order.ID)
+		case !ok:
+			return nil, fmt.Errorf("product %s is not part of order %s", item.ProductID, order.ID)
+		case seen[item.ProductID]:
+			return nil, fmt.Errorf("product %s is listed more than once", item.ProductID)
+		case item.Quantity <= 0:
+			return nil, fmt.Errorf("quantity of product %s must be positive", item.ProductID)
+		case item.Quantity > quantity:
+			return nil, fmt.Errorf("%w: %d of product %s left, not %d", ErrNothingToShip, quantity, item.ProductID, item.Quantity)
+		}
+		seen[item.ProductID] = true
+		items = append(items, &ShipmentItem{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+	return items, nil
+}
+
+// unshippedQuantities returns, for each line of order, the units not in any
+// of its shipments.
+func unshippedQuantities(order *Order, shipments []*Shipment) map[string]int32 {
+	left := map[string]int32{}
+	for _, product := range order.Products {
+		left[product.ProductID] = product.Quantity
+	}
+	for _, shipment := range shipments {
+		for _, item := range shipment.Items {
+			left[item.ProductID] -= item.Quantity
+		}
+	}
+	return left
+}
+
+// fulfilled reports whether every unit of order has shipped and every
+// shipment has been delivered.
+func fulfilled(order *Order, shipments []*Shipment) bool {
+	for _, quantity := range unshippedQuantities(order, shipments) {
+		if quantity > 0 {
+			return false
+		}
+	}
+	for _, shipment := range shipments {
+		if shipment.Status != ShipmentStatusDelivered {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/shipping"
+)
+
+// applyShipping requotes the shipping method of order with provider and
+// recomputes its totals. Orders without a method have no shipping fee.
+func applyShipping(order *Order, provider shipping.ShippingRateProvider) error {
+	if order.ShippingMethod == "" {
+		order.ShippingFee = money.Money{}
+		return updateTotals(order)
+	}
+	if order.ShippingAddress == nil {
+		return errors.New("orders with a shipping method need a shipping address")
+	}
+	if provider == nil {
+		return fmt.Errorf("%w: no shipping rates are configured", shipping.ErrMethodUnavailable)
+	}
+	parcel, err := orderParcel(order)
+	if err != nil {
+		return err
+	}
+	quote, err := shipping.QuoteMethod(provider, parcel, order.ShippingMethod)
+	if err != nil {
+		return err
+	}
+	order.ShippingFee = quote.Fee
+	return updateTotals(order)
+}
+
+// orderParcel returns the parcel order ships as: its lines, to its shipping
+// address, worth what they cost after discounts.
+func orderParcel(order *Order) (shipping.Parcel, error) {
+	subtotal, err := orderTotal(order.Products)
+	if err != nil {
+		return shipping.Parcel{}, err
+	}
+	value := subtotal
+	for _, discount := range order.Discounts {
+		value, err = value.Sub(discount.Amount)
+		if err != nil {
+			return shipping.Parcel{}, fmt.Errorf("failed to compute order value: %w", err)
+		}
+	}
+	if value.Amount < 0 {
+		value = money.Zero(subtotal.Currency)
+	}
+	weight := uint64(0)
+	for _, product := range order.Products {
+		weight += uint64(product.Weight) * uint64(product.Quantity)
+	}
+	parcel := shipping.Parcel{Weight: weight, Value: value}
+	if order.ShippingAddress != nil {
+		parcel.Destination = shipping.Destination{
+			Country:    order.ShippingAddress.Country,
+			Region:     order.ShippingAddress.Region,
+			PostalCode: order.ShippingAddress.PostalCode,
+		}
+	}
+	return parcel, nil
+}
@@ -0,0 +1,171 @@
+package order
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/tax"
+)
+
+// applyTaxes sets the tax of every line of order with calculator and
+// recomputes its totals. Lines are taxed on what they cost after their
+// discounts; discounts on several lines together are shared out among them
+// in proportion to what they cost.
+func applyTaxes(order *Order, calculator tax.TaxCalculator) error {
+	for _, product := range order.Products {
+		product.Tax = nil
+	}
+	if order.TaxExempt || order.TaxLocation.IsZero() || calculator == nil {
+		return updateTotals(order)
+	}
+	amounts, err := taxableAmounts(order)
+	if err != nil {
+		return err
+	}
+	items := []tax.Item{}
+	for i, product := range order.Products {
+		items = append(items, tax.Item{ID: product.ProductID, Category: product.TaxCategory, Amount: amounts[i]})
+	}
+	lines, err := calculator.Calculate(order.TaxLocation, items)
+	if err != nil {
+		return fmt.Errorf("failed to compute taxes of order %s: %w", order.ID, err)
+	}
+	byProduct := map[string]*tax.Line{}
+	for _, line := range lines {
+		byProduct[line.ItemID] = line
+	}
+	for _, product := range order.Products {
+		line, ok := byProduct[product.ProductID]
+		if !ok {
+			continue
+		}
+		product.Tax = &OrderTax{
+			Country:   line.Rate.Country,
+			Region:    line.Rate.Region,
+			Category:  line.Rate.Category,
+			Rate:      line.Rate.Rate,
+			Inclusive: line.Rate.Inclusive,
+			Amount:    line.Amount,
+		}
+	}
+	return updateTotals(order)
+}
+
+// taxableAmounts returns what each line of order costs after its discounts.
+func taxableAmounts(order *Order) ([]money.Money, error) {
+	subtotal, err := orderTotal(order.Products)
+	if err != nil {
+		return nil, err
+	}
+	amounts := make([]int64, len(order.Products))
+	lines := map[string]int{}
+	for i, product := range order.Products {
+		amount, err := product.Price.Mul(int64(product.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = amount.Amount
+		lines[product.ProductID] = i
+	}
+	shared := int64(0)
+	for _, discount := range order.Discounts {
+		if i, ok := lines[discount.ProductID]; ok {
+			amounts[i] -= discount.Amount.Amount
+			continue
+		}
+		shared += discount.Amount.Amount
+	}
+	// Discounts stacked on a line may take off more than it costs; the rest
+	// comes off the other lines.
+	total := int64(0)
+	for i := range amounts {
+		if amounts[i] < 0 {
+			shared -= amounts[i]
+			amounts[i] = 0
+		}
+		total += amounts[i]
+	}
+	if shared > total {
+		shared = total
+	}
+
+	// Each line takes its share rounded down, and what rounding leaves over
+	// is taken a minor unit at a time off the lines in order.
+	if shared > 0 {
+		left := shared
+		shares := make([]int64, len(amounts))
+		for i, amount := range amounts {
+			share := new(big.Int).Mul(big.NewInt(shared), big.NewInt(amount))
+			shares[i] = share.Quo(share, big.NewInt(total)).Int64()
+			left -= shares[i]
+		}
+		for i := 0; left > 0; i = (i + 1) % len(amounts) {
+			if shares[i] < amounts[i] {
+				shares[i]++
+				left--
+			}
+		}
+		for i := range amounts {
+			amounts[i] -= shares[i]
+		}
+	}
+
+	taxable := []money.Money{}
+	for _, amount := range amounts {
+		taxable = append(taxable, money.New(amount, subtotal.Currency))
+	}
+	return taxable, nil
+}
+
+// updateTotals recomputes the subtotal, discount, tax and total of order from
+// its lines, discounts, taxes and shipping fee.
+func updateTotals(order *Order) error {
+	subtotal, err := orderTotal(order.Products)
+	if err != nil {
+		return err
+	}
+	discountTotal := money.Zero(subtotal.Currency)
+	for _, discount := range order.Discounts {
+		discountTotal, err = discountTotal.Add(discount.Amount)
+		if err != nil {
+			return fmt.Errorf("failed to compute order discount: %w", err)
+		}
+	}
+	taxTotal := money.Zero(subtotal.Currency)
+	addedTax := money.Zero(subtotal.Currency)
+	for _, product := range order.Products {
+		if product.Tax == nil {
+			continue
+		}
+		taxTotal, err = taxTotal.Add(product.Tax.Amount)
+		if err != nil {
+			return fmt.Errorf("failed to compute order tax: %w", err)
+		}
+		if !product.Tax.Inclusive {
+			addedTax, err = addedTax.Add(product.Tax.Amount)
+			if err != nil {
+				return fmt.Errorf("failed to compute order tax: %w", err)
+			}
+		}
+	}
+	totalPrice, err := subtotal.Sub(discountTotal)
+	if err != nil {
+		return err
+	}
+	totalPrice, err = totalPrice.Add(addedTax)
+	if err != nil {
+		return err
+	}
+	if order.ShippingMethod != "" {
+		totalPrice, err = totalPrice.Add(order.ShippingFee)
+		if err != nil {
+			return fmt.Errorf("failed to add shipping fee: %w", err)
+		}
+	}
+	order.Subtotal = subtotal
+	order.DiscountTotal = discountTotal
+	order.TaxTotal = taxTotal
+	order.TotalPrice = totalPrice
+	return nil
+}
@@ -0,0 +1,126 @@
+package order
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/tax"
+)
+
+func TestTaxableAmounts(t *testing.T) {
+	tests := []struct {
+		name      string
+		products  []*OrderProduct
+		discounts []*OrderDiscount
+		want      []int64
+	}{
+		{
+			name: "no discounts",
+			want: []int64{2000, 1500},
+		},
+		{
+			name:      "line discount",
+			discounts: []*OrderDiscount{{ProductID: "lamp", Amount: money.New(300, "USD")}},
+			want:      []int64{1700, 1500},
+		},
+		{
+			name:      "shared discount in proportion to the lines",
+			discounts: []*OrderDiscount{{Amount: money.New(700, "USD")}},
+			want:      []int64{1600, 1200},
+		},
+		{
+			name:      "line and shared discounts",
+			discounts: []*OrderDiscount{{ProductID: "lamp", Amount: money.New(500, "USD")}, {Amount: money.New(300, "USD")}},
+			want:      []int64{1350, 1350},
+		},
+		{
+			name:      "line discount over the line comes off the others",
+			discounts: []*OrderDiscount{{ProductID: "lamp", Amount: money.New(2500, "USD")}},
+			want:      []int64{0, 1000},
+		},
+		{
+			name:      "shared discount over the order",
+			discounts: []*OrderDiscount{{Amount: money.New(5000, "USD")}},
+			want:      []int64{0, 0},
+		},
+		{
+			name: "rounding leftovers go to the first lines",
+			products: []*OrderProduct{
+				{ProductID: "a", Price: money.New(1000, "USD"), Quantity: 1},
+				{ProductID: "b", Price: money.New(1000, "USD"), Quantity: 1},
+				{ProductID: "c", Price: money.New(1000, "USD"), Quantity: 1},
+			},
+			discounts: []*OrderDiscount{{Amount: money.New(100, "USD")}},
+			want:      []int64{966, 967, 967},
+		},
+	}
+	for _, test := range tests {
+		order := promotionTestOrder()
+		if test.products != nil {
+			order.Products = test.products
+		}
+		order.Discounts = test.discounts
+		amounts, err := taxableAmounts(order)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		got := []int64{}
+		for _, amount := range amounts {
+			got = append(got, amount.Amount)
+		}
+		if !slices.Equal(got, test.want) {
+			t.Errorf("%s: taxable amounts = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestApplyTaxes(t *testing.T) {
+	rates, err := tax.ReadRateTable(strings.NewReader("DE,,standard,0.19,true\nUS,CA,standard,0.0725,false\nUS,CA,food,0,false\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		location    tax.Location
+		exempt      bool
+		taxCategory string
+		taxes       []int64
+		taxTotal    int64
+		total       int64
+	}{
+		{"exclusive taxes are added", tax.Location{Country: "US", Region: "CA"}, false, "", []int64{116, 87}, 203, 3003},
+		{"inclusive taxes are not added", tax.Location{Country: "DE"}, false, "", []int64{255, 192}, 447, 2800},
+		{"category rate", tax.Location{Country: "US", Region: "CA"}, false, "food", []int64{0, 87}, 87, 2887},
+		{"exempt orders are not taxed", tax.Location{Country: "US", Region: "CA"}, true, "", []int64{-1, -1}, 0, 2800},
+		{"orders without a location are not taxed", tax.Location{}, false, "", []int64{-1, -1}, 0, 2800},
+		{"locations without rates are not taxed", tax.Location{Country: "FR"}, false, "", []int64{-1, -1}, 0, 2800},
+	}
+	for _, test := range tests {
+		order := promotionTestOrder()
+		order.Discounts = []*OrderDiscount{{Amount: money.New(700, "USD")}}
+		order.TaxLocation = test.location
+		order.TaxExempt = test.exempt
+		order.Products[0].TaxCategory = test.taxCategory
+		if err := applyTaxes(order, rates); err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		taxes := []int64{}
+		for _, product := range order.Products {
+			if product.Tax == nil {
+				taxes = append(taxes, -1)
+				continue
+			}
+			taxes = append(taxes, product.Tax.Amount.Amount)
+		}
+		if !slices.Equal(taxes, test.taxes) {
+			t.Errorf("%s: line taxes = %v, want %v", test.name, taxes, test.taxes)
+		}
+		if order.TaxTotal.Amount != test.taxTotal || order.TotalPrice.Amount != test.total || order.DiscountTotal.Amount != 700 || order.Subtotal.Amount != 3500 {
+			t.Errorf("%s: subtotal %v, discount %v, tax %v, total %v, want tax %d and total %d", test.name, order.Subtotal, order.DiscountTotal, order.TaxTotal, order.TotalPrice, test.taxTotal, test.total)
+		}
+	}
+}
@@ -1,16 +1,308 @@
+-- Prices are stored as integer minor units (e.g. cents) of the order currency.
 CREATE TABLE IF NOT EXISTS orders (
     id CHAR(27) PRIMARY KEY,
     createdAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
     accountId CHAR(27) NOT NULL,
-    totalPrice NUMERIC(19,4) NOT NULL
+    totalPrice BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    -- status is one of pending, paid, shipped, delivered or cancelled.
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    -- discount is the sum of the order's discounts, already taken off totalPrice.
+    discount BIGINT NOT NULL DEFAULT 0,
+    -- The order is taxed at taxCountry and taxRegion unless taxExempt is set;
+    -- orders without a taxCountry are not taxed.
+    taxCountry VARCHAR(2) NOT NULL DEFAULT '',
+    taxRegion VARCHAR(64) NOT NULL DEFAULT '',
+    taxExempt BOOLEAN NOT NULL DEFAULT FALSE,
+    -- shippingFee, already added to totalPrice, is what shippingMethod
+    -- charges; orders without a shippingMethod have no shipping fee.
+    shippingMethod VARCHAR(64) NOT NULL DEFAULT '',
+    shippingFee BIGINT NOT NULL DEFAULT 0
 );
 
+-- Order search sorts by createdAt or totalPrice, then id, and filters by
+-- status, account and currency. Searching by product uses the primary key of
+-- order_products, which leads with productId.
+CREATE INDEX IF NOT EXISTS orders_created_idx ON orders (createdAt, id);
+CREATE INDEX IF NOT EXISTS orders_status_idx ON orders (status, createdAt, id);
+CREATE INDEX IF NOT EXISTS orders_account_idx ON orders (accountId, createdAt, id);
+CREATE INDEX IF NOT EXISTS orders_total_idx ON orders (currency, totalPrice, id);
+
 CREATE TABLE IF NOT EXISTS order_products (
   orderId CHAR(27) REFERENCES orders (id) ON DELETE CASCADE,
   productId CHAR(27),
   quantity INT NOT NULL,
   name VARCHAR(255) NOT NULL,
   description TEXT,
-  price NUMERIC(19,4) NOT NULL,
+  price BIGINT NOT NULL,
+  categoryId VARCHAR(64) NOT NULL DEFAULT '',
+  taxCategory VARCHAR(32) NOT NULL DEFAULT '',
+  -- weight is the product's shipping weight in grams.
+  weight INT NOT NULL DEFAULT 0,
+  -- merchantId is the account that sold the product; empty for products
+  -- without a merchant.
+  merchantId VARCHAR(27) NOT NULL DEFAULT '',
   PRIMARY KEY (productId, orderId)
-);
\ No newline at end of file
+);
+
+-- Lines are loaded by order, which the primary key cannot look up.
+CREATE INDEX IF NOT EXISTS order_products_order_idx ON order_products (orderId);
+
+-- Exchange rates used to price an order, one per source currency.
+CREATE TABLE IF NOT EXISTS order_exchange_rates (
+  orderId CHAR(27) REFERENCES orders (id) ON DELETE CASCADE,
+  base CHAR(3) NOT NULL,
+  quote CHAR(3) NOT NULL,
+  rate NUMERIC(24,12) NOT NULL,
+  effectiveFrom TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (orderId, base)
+);
+
+-- Co-purchase counts, rebuilt on a schedule from order_products: orderCount is
+-- the number of orders that contain both products. Each pair is stored in
+-- both directions.
+CREATE TABLE IF NOT EXISTS product_co_purchases (
+  productId CHAR(27) NOT NULL,
+  relatedProductId CHAR(27) NOT NULL,
+  orderCount INT NOT NULL,
+  PRIMARY KEY (productId, relatedProductId)
+);
+
+CREATE INDEX IF NOT EXISTS product_co_purchases_product_idx ON product_co_purchases (productId, orderCount DESC);
+
+-- Changes made to the lines of orders while they were pending, one row per
+-- line changed. price is the line's unit price after the change, or before
+-- it if the line was removed.
+CREATE TABLE IF NOT EXISTS order_item_changes (
+  id BIGSERIAL PRIMARY KEY,
+  orderId CHAR(27) REFERENCES orders (id) ON DELETE CASCADE,
+  productId CHAR(27) NOT NULL,
+  operation VARCHAR(16) NOT NULL,
+  oldQuantity INT NOT NULL,
+  newQuantity INT NOT NULL,
+  price BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  changedAt TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_item_changes_order_idx ON order_item_changes (orderId, changedAt);
+
+-- Promotions and coupons. Promotions with an empty code apply to every
+-- eligible order; the others are coupons customers apply to their orders.
+-- amountOff is in minor units of currency, which is empty unless type is
+-- fixed.
+CREATE TABLE IF NOT EXISTS promotions (
+  id CHAR(27) PRIMARY KEY,
+  code VARCHAR(64) NOT NULL DEFAULT '',
+  description TEXT NOT NULL DEFAULT '',
+  type VARCHAR(16) NOT NULL,
+  percentOff INT NOT NULL DEFAULT 0,
+  amountOff BIGINT NOT NULL DEFAULT 0,
+  currency VARCHAR(3) NOT NULL DEFAULT '',
+  buyQuantity INT NOT NULL DEFAULT 0,
+  getQuantity INT NOT NULL DEFAULT 0,
+  productIds TEXT[] NOT NULL DEFAULT '{}',
+  categoryIds TEXT[] NOT NULL DEFAULT '{}',
+  accountIds TEXT[] NOT NULL DEFAULT '{}',
+  stackable BOOLEAN NOT NULL DEFAULT FALSE,
+  startsAt TIMESTAMP WITH TIME ZONE,
+  endsAt TIMESTAMP WITH TIME ZONE,
+  usageLimit BIGINT NOT NULL DEFAULT 0,
+  usageLimitPerAccount BIGINT NOT NULL DEFAULT 0,
+  createdAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS promotions_code_idx ON promotions (code) WHERE code <> '';
+
+-- Coupons applied to orders, whether or not they currently give a discount.
+CREATE TABLE IF NOT EXISTS order_coupons (
+  orderId CHAR(27) REFERENCES orders (id) ON DELETE CASCADE,
+  code VARCHAR(64) NOT NULL,
+  PRIMARY KEY (orderId, code)
+);
+
+-- Discounts taken off orders, in the order currency. productId is empty for
+-- discounts on several lines together. The orders a promotion has been used
+-- on are the orders with its discounts.
+CREATE TABLE IF NOT EXISTS order_discounts (
+  orderId CHAR(27) REFERENCES orders (id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  promotionId CHAR(27) NOT NULL,
+  code VARCHAR(64) NOT NULL,
+  description TEXT NOT NULL,
+  productId VARCHAR(27) NOT NULL,
+  amount BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  PRIMARY KEY (orderId, position)
+);
+
+CREATE INDEX IF NOT EXISTS order_discounts_promotion_idx ON order_discounts (promotionId);
+
+-- Taxes of order lines, in the order currency. rate is a fraction of what the
+-- line costs after its discounts; inclusive taxes are part of the line's
+-- price and the others are added to the order's totalPrice.
+CREATE TABLE IF NOT EXISTS order_taxes (
+  orderId CHAR(27) REFERENCES orders (id) ON DELETE CASCADE,
+  productId CHAR(27) NOT NULL,
+  country VARCHAR(2) NOT NULL,
+  region VARCHAR(16) NOT NULL,
+  category VARCHAR(32) NOT NULL,
+  rate NUMERIC(10,6) NOT NULL,
+  inclusive BOOLEAN NOT NULL,
+  amount BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  PRIMARY KEY (orderId, productId)
+);
+
+-- Where orders ship to: a copy of an address from the account's address
+-- book, taken when the order was placed.
+CREATE TABLE IF NOT EXISTS order_shipping_addresses (
+  orderId CHAR(27) PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+  addressId CHAR(27) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  line1 VARCHAR(255) NOT NULL,
+  line2 VARCHAR(255) NOT NULL,
+  city VARCHAR(255) NOT NULL,
+  region VARCHAR(64) NOT NULL,
+  postalCode VARCHAR(32) NOT NULL,
+  country CHAR(2) NOT NULL,
+  phone VARCHAR(32) NOT NULL
+);
+
+-- Shipments of orders. status is shipped or delivered; shipment_items holds
+-- the units of each order line in a shipment. merchantId is the merchant
+-- who sent a shipment of their own lines, empty for shipments sent by
+-- admins.
+CREATE TABLE IF NOT EXISTS shipments (
+  id CHAR(27) PRIMARY KEY,
+  orderId CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  merchantId VARCHAR(27) NOT NULL DEFAULT '',
+  carrier VARCHAR(64) NOT NULL,
+  trackingNumber VARCHAR(128) NOT NULL,
+  status VARCHAR(16) NOT NULL,
+  shippedAt TIMESTAMP WITH TIME ZONE NOT NULL,
+  deliveredAt TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS shipments_order_idx ON shipments (orderId, shippedAt);
+
+CREATE TABLE IF NOT EXISTS shipment_items (
+  shipmentId CHAR(27) REFERENCES shipments (id) ON DELETE CASCADE,
+  productId CHAR(27) NOT NULL,
+  quantity INT NOT NULL,
+  PRIMARY KEY (shipmentId, productId)
+);
+
+-- Returns of units of delivered orders. status is requested, approved,
+-- rejected, received or inspected. refund, in minor units of currency, is
+-- set when the return is inspected; it is the sum of the refunds of the
+-- return's items.
+CREATE TABLE IF NOT EXISTS order_returns (
+  id CHAR(27) PRIMARY KEY,
+  orderId CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  accountId CHAR(27) NOT NULL,
+  status VARCHAR(16) NOT NULL,
+  refund BIGINT NOT NULL DEFAULT 0,
+  currency CHAR(3) NOT NULL,
+  note TEXT NOT NULL DEFAULT '',
+  createdAt TIMESTAMP WITH TIME ZONE NOT NULL,
+  updatedAt TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_returns_order_idx ON order_returns (orderId, createdAt);
+CREATE INDEX IF NOT EXISTS order_returns_status_idx ON order_returns (status, createdAt);
+
+-- The units of each order line in a return. acceptedQuantity, restock and
+-- refund are set when the return is inspected.
+CREATE TABLE IF NOT EXISTS order_return_items (
+  returnId CHAR(27) REFERENCES order_returns (id) ON DELETE CASCADE,
+  productId CHAR(27) NOT NULL,
+  quantity INT NOT NULL,
+  reason VARCHAR(32) NOT NULL,
+  comment TEXT NOT NULL DEFAULT '',
+  acceptedQuantity INT NOT NULL DEFAULT 0,
+  restock BOOLEAN NOT NULL DEFAULT FALSE,
+  refund BIGINT NOT NULL DEFAULT 0,
+  PRIMARY KEY (returnId, productId)
+);
+
+-- The last number issued to each merchant for each kind of invoice. Numbers
+-- are taken in the transaction that stores the invoice, so an invoice that
+-- is rolled back leaves no gap.
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+  merchantId VARCHAR(27) NOT NULL,
+  kind VARCHAR(16) NOT NULL,
+  lastNumber BIGINT NOT NULL,
+  PRIMARY KEY (merchantId, kind)
+);
+
+-- Invoices of a merchant's lines of paid orders, and credit notes, of kind
+-- credit_note, for the units of them refunded by returns. Amounts are in
+-- minor units of currency; total is subtotal less discount plus the taxes
+-- that are not inclusive and shipping. billTo holds the lines of the
+-- order's shipping address.
+CREATE TABLE IF NOT EXISTS invoices (
+  id CHAR(27) PRIMARY KEY,
+  number VARCHAR(32) NOT NULL,
+  sequence BIGINT NOT NULL,
+  kind VARCHAR(16) NOT NULL,
+  merchantId VARCHAR(27) NOT NULL,
+  orderId CHAR(27) NOT NULL REFERENCES orders (id),
+  accountId CHAR(27) NOT NULL,
+  returnId VARCHAR(27) NOT NULL DEFAULT '',
+  invoiceId VARCHAR(27) NOT NULL DEFAULT '',
+  billTo TEXT[] NOT NULL DEFAULT '{}',
+  subtotal BIGINT NOT NULL,
+  discount BIGINT NOT NULL,
+  tax BIGINT NOT NULL,
+  shipping BIGINT NOT NULL,
+  total BIGINT NOT NULL,
+  currency CHAR(3) NOT NULL,
+  issuedAt TIMESTAMP WITH TIME ZONE NOT NULL,
+  UNIQUE (merchantId, kind, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS invoices_order_idx ON invoices (orderId, issuedAt);
+
+-- The lines of invoices, as they were billed. rate is a decimal fraction.
+CREATE TABLE IF NOT EXISTS invoice_lines (
+  invoiceId CHAR(27) REFERENCES invoices (id) ON DELETE CASCADE,
+  position INT NOT NULL,
+  productId CHAR(27) NOT NULL,
+  description TEXT NOT NULL,
+  quantity INT NOT NULL,
+  unitPrice BIGINT NOT NULL,
+  amount BIGINT NOT NULL,
+  discount BIGINT NOT NULL,
+  tax BIGINT NOT NULL,
+  taxRate VARCHAR(16) NOT NULL DEFAULT '',
+  taxInclusive BOOLEAN NOT NULL DEFAULT FALSE,
+  total BIGINT NOT NULL,
+  PRIMARY KEY (invoiceId, position)
+);
+
+-- The part of each order sold by one merchant, split out when the order is
+-- placed and kept in step with it. status follows the order's until every
+-- unit of the merchant's lines has shipped. Amounts are in minor units of
+-- currency and split as the order's invoices are: total is subtotal less
+-- discount plus the taxes that are not inclusive and shipping. refunded sums
+-- the merchant's credit notes; createdAt is when the order was placed.
+CREATE TABLE IF NOT EXISTS merchant_orders (
+  orderId CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+  merchantId VARCHAR(27) NOT NULL,
+  accountId CHAR(27) NOT NULL,
+  status VARCHAR(16) NOT NULL,
+  subtotal BIGINT NOT NULL,
+  discount BIGINT NOT NULL,
+  tax BIGINT NOT NULL,
+  shipping BIGINT NOT NULL,
+  total BIGINT NOT NULL,
+  refunded BIGINT NOT NULL DEFAULT 0,
+  currency CHAR(3) NOT NULL,
+  createdAt TIMESTAMP WITH TIME ZONE NOT NULL,
+  updatedAt TIMESTAMP WITH TIME ZONE NOT NULL,
+  PRIMARY KEY (orderId, merchantId)
+);
+
+CREATE INDEX IF NOT EXISTS merchant_orders_merchant_idx ON merchant_orders (merchantId, createdAt, orderId);
+CREATE INDEX IF NOT EXISTS merchant_orders_status_idx ON merchant_orders (merchantId, status, createdAt, orderId);
@@ -0,0 +1,186 @@
+package pricing
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+	moneypb "github.com/Asif-Faizal/Minimum-Viable-Shop/money/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// Rate converts amounts between currencies: 1 Base = Rate Quote, starting at EffectiveFrom.
+type Rate struct {
+	Base          string    `json:"base"`
+	Quote         string    `json:"quote"`
+	Rate          *big.Rat  `json:"rate"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+}
+
+// Decimal formats the rate with up to 12 decimal places and no trailing zeros.
+func (rate *Rate) Decimal() string {
+	decimal := rate.Rate.FloatString(12)
+	decimal = strings.TrimRight(decimal, "0")
+	return strings.TrimSuffix(decimal, ".")
+}
+
+// Convert converts an amount in the rate's base currency to its quote currency.
+func (rate *Rate) Convert(amount money.Money) (money.Money, error) {
+	if amount.Currency != rate.Base {
+		return money.Money{}, fmt.Errorf("%w: %s and %s", money.ErrCurrencyMismatch, amount.Currency, rate.Base)
+	}
+	minor, err := money.RoundToMinor(new(big.Rat).Mul(amount.Rat(), rate.Rate), rate.Quote)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return money.New(minor, rate.Quote), nil
+}
+
+// inverse returns the rate converting Quote back to Base.
+func (rate *Rate) inverse() *Rate {
+	return &Rate{
+		Base:          rate.Quote,
+		Quote:         rate.Base,
+		Rate:          new(big.Rat).Inv(rate.Rate),
+		EffectiveFrom: rate.EffectiveFrom,
+	}
+}
+
+func ParseRate(base, quote, rate string, effectiveFrom time.Time) (*Rate, error) {
+	if err := money.ValidateCurrency(base); err != nil {
+		return nil, err
+	}
+	if err := money.ValidateCurrency(quote); err != nil {
+		return nil, err
+	}
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(rate))
+	if !ok || value.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid exchange rate %q for %s/%s", rate, base, quote)
+	}
+	return &Rate{Base: base, Quote: quote, Rate: value, EffectiveFrom: effectiveFrom.UTC()}, nil
+}
+
+func RateFromProto(rate *moneypb.ExchangeRate) (*Rate, error) {
+	if rate == nil {
+		return nil, nil
+	}
+	return ParseRate(rate.Base, rate.Quote, rate.Rate, rate.EffectiveFrom.AsTime())
+}
+
+func (rate *Rate) ToProto() *moneypb.ExchangeRate {
+	if rate == nil {
+		return nil
+	}
+	return &moneypb.ExchangeRate{
+		Base:          rate.Base,
+		Quote:         rate.Quote,
+		Rate:          rate.Decimal(),
+		EffectiveFrom: timestamppb.New(rate.EffectiveFrom),
+	}
+}
+
+// RateTable holds exchange rates by currency pair, each pair sorted by effective date.
+type RateTable struct {
+	rates map[string][]*Rate
+}
+
+func NewRateTable(rates []*Rate) *RateTable {
+	table := &RateTable{rates: map[string][]*Rate{}}
+	for _, rate := range rates {
+		key := pairKey(rate.Base, rate.Quote)
+		table.rates[key] = append(table.rates[key], rate)
+	}
+	for _, pairRates := range table.rates {
+		sort.Slice(pairRates, func(i, j int) bool {
+			return pairRates[i].EffectiveFrom.Before(pairRates[j].EffectiveFrom)
+		})
+	}
+	return table
+}
+
+// LoadRateTable reads exchange rates from a CSV file with the header
+// base,quote,rate,effective_from where effective_from is a date (2006-01-02)
+// or an RFC 3339 timestamp. An empty path yields an empty table.
+func LoadRateTable(path string) (*RateTable, error) {
+	if path == "" {
+		return NewRateTable(nil), nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadRateTable(file)
+}
+
+func ReadRateTable(reader io.Reader) (*RateTable, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	csvReader.TrimLeadingSpace = true
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rates := []*Rate{}
+	for i, record := range records {
+		if len(record) != 4 {
+			return nil, fmt.Errorf("exchange rates line %d: expected 4 fields, got %d", i+1, len(record))
+		}
+		if i == 0 && strings.EqualFold(record[0], "base") {
+			continue
+		}
+		effectiveFrom, err := parseEffectiveFrom(record[3])
+		if err != nil {
+			return nil, fmt.Errorf("exchange rates line %d: %w", i+1, err)
+		}
+		rate, err := ParseRate(record[0], record[1], record[2], effectiveFrom)
+		if err != nil {
+			return nil, fmt.Errorf("exchange rates line %d: %w", i+1, err)
+		}
+		rates = append(rates, rate)
+	}
+	return NewRateTable(rates), nil
+}
+
+func parseEffectiveFrom(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// Lookup returns the rate converting base to quote that was in effect at the
+// given time. If only the opposite pair is listed, its inverse is returned.
+func (table *RateTable) Lookup(base, quote string, at time.Time) (*Rate, error) {
+	if rate := table.latest(pairKey(base, quote), at); rate != nil {
+		return rate, nil
+	}
+	if rate := table.latest(pairKey(quote, base), at); rate != nil {
+		return rate.inverse(), nil
+	}
+	return nil, fmt.Errorf("%w: %s/%s at %s", ErrRateNotFound, base, quote, at.Format(time.RFC3339))
+}
+
+func (table *RateTable) latest(key string, at time.Time) *Rate {
+	pairRates := table.rates[key]
+	i := sort.Search(len(pairRates), func(i int) bool {
+		return pairRates[i].EffectiveFrom.After(at)
+	})
+	if i == 0 {
+		return nil
+	}
+	return pairRates[i-1]
+}
+
+func pairKey(base, quote string) string {
+	return base + "/" + quote
+}
@@ -0,0 +1,61 @@
+package pricing
+
+import (
+	"time"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+type Service interface {
+	Resolve(price money.Money, prices []money.Money, currency string, at time.Time) (*Quote, error)
+	Convert(amount money.Money, currency string, at time.Time) (*Quote, error)
+}
+
+// Quote is a price resolved into a requested currency. Rate is set only when
+// the price was converted with an exchange rate.
+type Quote struct {
+	Price money.Money
+	Rate  *Rate
+}
+
+type PricingService struct {
+	rates *RateTable
+}
+
+func NewPricingService(rates *RateTable) *PricingService {
+	return &PricingService{rates: rates}
+}
+
+// Resolve picks the display price for currency: the base price if it is
+// already in that currency, then an explicit price list entry, and finally
+// the base price converted at the exchange rate in effect at the given time.
+// An empty currency resolves to the base price.
+func (service *PricingService) Resolve(price money.Money, prices []money.Money, currency string, at time.Time) (*Quote, error) {
+	if currency == "" || currency == price.Currency {
+		return &Quote{Price: price}, nil
+	}
+	for _, listed := range prices {
+		if listed.Currency == currency {
+			return &Quote{Price: listed}, nil
+		}
+	}
+	return service.Convert(price, currency, at)
+}
+
+func (service *PricingService) Convert(amount money.Money, currency string, at time.Time) (*Quote, error) {
+	if currency == "" || currency == amount.Currency {
+		return &Quote{Price: amount}, nil
+	}
+	if err := money.ValidateCurrency(currency); err != nil {
+		return nil, err
+	}
+	rate, err := service.rates.Lookup(amount.Currency, currency, at)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := rate.Convert(amount)
+	if err != nil {
+		return nil, err
+	}
+	return &Quote{Price: converted, Rate: rate}, nil
+}
@@ -10,6 +10,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/blob"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/rest"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 	"github.com/kelseyhightower/envconfig"
@@ -17,9 +18,22 @@ import (
 
 type AppConfig struct {
 	AccountGrpcURL string `envconfig:"ACCOUNT_GRPC_URL" default:"localhost:50051"`
+	CatalogGrpcURL string `envconfig:"CATALOG_GRPC_URL" default:"localhost:50052"`
+	OrderGrpcURL   string `envconfig:"ORDER_GRPC_URL" default:"localhost:50053"`
 	Port           int    `envconfig:"PORT" default:"8082"`
 	Env            string `envconfig:"ENVIRONMENT" default:"development"`
 	LogLevel       string `envconfig:"LOG_LEVEL" default:"info"`
+
+	// BlobStore selects where product images are kept: "local" or "s3".
+	BlobStore         string `envconfig:"BLOB_STORE" default:"local"`
+	MediaDir          string `envconfig:"MEDIA_DIR" default:"./media"`
+	MediaBaseURL      string `envconfig:"MEDIA_BASE_URL" default:"http://localhost:8082/media"`
+	S3Endpoint        string `envconfig:"S3_ENDPOINT"`
+	S3Region          string `envconfig:"S3_REGION" default:"us-east-1"`
+	S3Bucket          string `envconfig:"S3_BUCKET"`
+	S3AccessKeyID     string `envconfig:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `envconfig:"S3_SECRET_ACCESS_KEY"`
+	S3PublicURL       string `envconfig:"S3_PUBLIC_URL"`
 }
 
 func main() {
@@ -29,7 +43,12 @@ func main() {
 	}
 	logger := util.NewLogger(cfg.LogLevel)
 
-	server, err := rest.NewServer(cfg.AccountGrpcURL, logger)
+	blobStore, err := newBlobStore(cfg)
+	if err != nil {
+		logger.Service().Fatal().Err(err).Msg("failed to initialize blob store")
+	}
+
+	server, err := rest.NewServer(cfg.AccountGrpcURL, cfg.CatalogGrpcURL, cfg.OrderGrpcURL, blobStore, logger)
 	if err != nil {
 		logger.Service().Fatal().Err(err).Msg("failed to initialize REST gateway")
 	}
@@ -68,3 +87,20 @@ func main() {
 
 	logger.Service().Info().Msg("server stopped")
 }
+
+func newBlobStore(cfg AppConfig) (blob.BlobStore, error) {
+	switch cfg.BlobStore {
+	case "local":
+		return blob.NewLocalStore(cfg.MediaDir, cfg.MediaBaseURL)
+	case "s3":
+		return blob.NewS3Store(blob.S3Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PublicURL:       cfg.S3PublicURL,
+		})
+	}
+	return nil, fmt.Errorf("unknown BLOB_STORE %q, expected local or s3", cfg.BlobStore)
+}
@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/order"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// handleDownloadInvoice serves an invoice or credit note as a PDF, or as
+// HTML with ?format=html, to the order's account, the invoice's merchant or
+// an admin.
+func (s *Server) handleDownloadInvoice(w http.ResponseWriter, r *http.Request) {
+	ctx, ok := authenticatedContext(r)
+	if !ok {
+		util.WriteJSONResponse(w, http.StatusUnauthorized, false, "unauthorized", nil)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = order.InvoiceFormatPDF
+	}
+	if format != order.InvoiceFormatPDF && format != order.InvoiceFormatHTML {
+		util.WriteJSONResponse(w, http.StatusBadRequest, false, "format must be pdf or html", nil)
+		return
+	}
+
+	resp, err := s.orderClient.GetInvoice(ctx, r.PathValue("id"), format)
+	if err != nil {
+		util.WriteJSONResponse(w, orderErrorStatus(err), false, status.Convert(err).Message(), nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", resp.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", resp.Invoice.Number+"."+format))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.Write(resp.Document)
+}
+
+func orderErrorStatus(err error) int {
+	if status.Code(err) == codes.Unauthenticated {
+		return http.StatusUnauthorized
+	}
+	message := status.Convert(err).Message()
+	switch {
+	case strings.Contains(message, order.ErrUnauthenticated.Error()):
+		return http.StatusUnauthorized
+	case strings.Contains(message, order.ErrForbidden.Error()):
+		return http.StatusForbidden
+	case strings.Contains(message, order.ErrInvoiceNotFound.Error()):
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	// maxUploadSize limits uploaded image files.
+	maxUploadSize = 10 << 20
+	// maxImagePixels guards against images that are small on disk but huge
+	// once decoded.
+	maxImagePixels = 40_000_000
+	// thumbnailSize is the largest width or height of a thumbnail.
+	thumbnailSize = 320
+)
+
+// imageExtensions lists the accepted image content types.
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+type processedImage struct {
+	ContentType          string
+	Extension            string
+	Width                int
+	Height               int
+	Thumbnail            []byte
+	ThumbnailContentType string
+	ThumbnailExtension   string
+}
+
+// processImage checks that data is a supported image whose content matches
+// the declared content type, and renders its thumbnail.
+func processImage(data []byte, declaredType string) (*processedImage, error) {
+	contentType := http.DetectContentType(data)
+	extension, ok := imageExtensions[contentType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported image type %q, expected JPEG, PNG or GIF", contentType)
+	}
+	if declaredType != "" && declaredType != "application/octet-stream" && declaredType != contentType {
+		return nil, fmt.Errorf("declared content type %q does not match image content %q", declaredType, contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image: %w", err)
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("image is too large: %dx%d", config.Width, config.Height)
+	}
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image: %w", err)
+	}
+
+	thumbnail := scaleToFit(source, thumbnailSize)
+	var encoded bytes.Buffer
+	processed := &processedImage{
+		ContentType: contentType,
+		Extension:   extension,
+		Width:       config.Width,
+		Height:      config.Height,
+	}
+	// JPEG thumbnails are smaller; PNG keeps the transparency of PNG and GIF sources.
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&encoded, thumbnail, &jpeg.Options{Quality: 85})
+		processed.ThumbnailContentType = "image/jpeg"
+		processed.ThumbnailExtension = ".jpg"
+	} else {
+		err = png.Encode(&encoded, thumbnail)
+		processed.ThumbnailContentType = "image/png"
+		processed.ThumbnailExtension = ".png"
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	processed.Thumbnail = encoded.Bytes()
+	return processed, nil
+}
+
+// scaleToFit shrinks source to fit in a size x size square, keeping its aspect
+// ratio. Images that already fit are copied unscaled.
+func scaleToFit(source image.Image, size int) image.Image {
+	bounds := source.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > size || height > size {
+		if width >= height {
+			height = max(1, height*size/width)
+			width = size
+		} else {
+			width = max(1, width*size/height)
+			height = size
+		}
+	}
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+	xdraw.CatmullRom.Scale(thumbnail, thumbnail.Bounds(), source, bounds, xdraw.Src, nil)
+	return thumbnail
+}
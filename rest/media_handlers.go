@@ -0,0 +1,173 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/blob"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// handleUploadProductMedia stores an image from the multipart "file" field and
+// its thumbnail in the blob store, then adds it to the product's media.
+func (s *Server) handleUploadProductMedia(w http.ResponseWriter, r *http.Request) {
+	ctx, ok := authenticatedContext(r)
+	if !ok {
+		util.WriteJSONResponse(w, http.StatusUnauthorized, false, "unauthorized", nil)
+		return
+	}
+	productID := r.PathValue("id")
+
+	// Leave room for the multipart framing and the other form fields.
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadSize+1<<20)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			util.WriteJSONResponse(w, http.StatusRequestEntityTooLarge, false, "file is too large", nil)
+			return
+		}
+		util.WriteJSONResponse(w, http.StatusBadRequest, false, "file is required", nil)
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxUploadSize+1))
+	if err != nil {
+		util.WriteJSONResponse(w, http.StatusBadRequest, false, "failed to read file", nil)
+		return
+	}
+	if len(data) > maxUploadSize {
+		util.WriteJSONResponse(w, http.StatusRequestEntityTooLarge, false, "file is too large", nil)
+		return
+	}
+
+	processed, err := processImage(data, header.Header.Get("Content-Type"))
+	if err != nil {
+		util.WriteJSONResponse(w, http.StatusUnsupportedMediaType, false, err.Error(), nil)
+		return
+	}
+
+	name := ksuid.New().String()
+	key := "products/" + productID + "/" + name + processed.Extension
+	thumbnailKey := "products/" + productID + "/" + name + "_thumb" + processed.ThumbnailExtension
+	if err := s.blobStore.Put(ctx, key, bytes.NewReader(data), int64(len(data)), processed.ContentType); err != nil {
+		s.logger.Service().Error().Err(err).Str("key", key).Msg("failed to store product image")
+		util.WriteJSONResponse(w, http.StatusInternalServerError, false, "failed to store image", nil)
+		return
+	}
+	if err := s.blobStore.Put(ctx, thumbnailKey, bytes.NewReader(processed.Thumbnail), int64(len(processed.Thumbnail)), processed.ThumbnailContentType); err != nil {
+		s.logger.Service().Error().Err(err).Str("key", thumbnailKey).Msg("failed to store product thumbnail")
+		s.deleteBlobs(key)
+		util.WriteJSONResponse(w, http.StatusInternalServerError, false, "failed to store image", nil)
+		return
+	}
+
+	resp, err := s.catalogClient.AddProductMedia(ctx, productID, &catalog.ProductMedia{
+		Key:          key,
+		URL:          s.blobStore.URL(key),
+		ThumbnailKey: thumbnailKey,
+		ThumbnailURL: s.blobStore.URL(thumbnailKey),
+		ContentType:  processed.ContentType,
+		AltText:      r.FormValue("alt_text"),
+		Width:        uint32(processed.Width),
+		Height:       uint32(processed.Height),
+	})
+	if err != nil {
+		s.deleteBlobs(key, thumbnailKey)
+		util.WriteJSONResponse(w, catalogErrorStatus(err), false, status.Convert(err).Message(), nil)
+		return
+	}
+
+	util.WriteJSONResponse(w, http.StatusCreated, true, "Image uploaded", toProductMedia(resp.Media))
+}
+
+// handleDeleteProductMedia removes an image from the product and deletes its
+// blobs.
+func (s *Server) handleDeleteProductMedia(w http.ResponseWriter, r *http.Request) {
+	ctx, ok := authenticatedContext(r)
+	if !ok {
+		util.WriteJSONResponse(w, http.StatusUnauthorized, false, "unauthorized", nil)
+		return
+	}
+
+	resp, err := s.catalogClient.RemoveProductMedia(ctx, r.PathValue("id"), r.PathValue("mediaId"))
+	if err != nil {
+		util.WriteJSONResponse(w, catalogErrorStatus(err), false, status.Convert(err).Message(), nil)
+		return
+	}
+	s.deleteBlobs(resp.Media.Key, resp.Media.ThumbnailKey)
+
+	util.WriteJSONResponse(w, http.StatusOK, true, "Image deleted", nil)
+}
+
+// handleGetMedia serves blobs for stores that are not publicly reachable,
+// such as the local filesystem store.
+func (s *Server) handleGetMedia(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	body, err := s.blobStore.Get(r.Context(), key)
+	if errors.Is(err, blob.ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		s.logger.Service().Error().Err(err).Str("key", key).Msg("failed to read blob")
+		http.Error(w, "failed to read media", http.StatusInternalServerError)
+		return
+	}
+	defer body.Close()
+	if contentType := mime.TypeByExtension(path.Ext(key)); contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	io.Copy(w, body)
+}
+
+// deleteBlobs removes blobs that are no longer referenced, logging failures
+// rather than failing the request.
+func (s *Server) deleteBlobs(keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := s.blobStore.Delete(context.Background(), key); err != nil {
+			s.logger.Service().Error().Err(err).Str("key", key).Msg("failed to delete blob")
+		}
+	}
+}
+
+// authenticatedContext returns the request context carrying the bearer token,
+// which the catalog client forwards to the catalog service.
+func authenticatedContext(r *http.Request) (context.Context, bool) {
+	accessToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || accessToken == "" {
+		return nil, false
+	}
+	return util.ContextWithAccessToken(r.Context(), accessToken), true
+}
+
+func catalogErrorStatus(err error) int {
+	if status.Code(err) == codes.Unauthenticated {
+		return http.StatusUnauthorized
+	}
+	message := status.Convert(err).Message()
+	switch {
+	case strings.Contains(message, catalog.ErrForbidden.Error()):
+		return http.StatusForbidden
+	case strings.Contains(message, catalog.ErrProductNotFound.Error()),
+		strings.Contains(message, catalog.ErrMediaNotFound.Error()):
+		return http.StatusNotFound
+	case strings.Contains(message, catalog.ErrVersionConflict.Error()):
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
@@ -1,6 +1,9 @@
 package rest
 
-import pb "github.com/Asif-Faizal/Minimum-Viable-Shop/account/pb"
+import (
+	pb "github.com/Asif-Faizal/Minimum-Viable-Shop/account/pb"
+	catalogpb "github.com/Asif-Faizal/Minimum-Viable-Shop/catalog/pb"
+)
 
 type LoginRequest struct {
 	Email    string `json:"email"`
@@ -24,6 +27,16 @@ type AuthenticatedResponse struct {
 	RefreshToken string   `json:"refresh_token"`
 }
 
+type ProductMedia struct {
+	ID           string `json:"id"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	ContentType  string `json:"content_type"`
+	AltText      string `json:"alt_text"`
+	Width        uint32 `json:"width"`
+	Height       uint32 `json:"height"`
+}
+
 func toAccount(a *pb.Account) *Account {
 	if a == nil {
 		return nil
@@ -35,3 +48,18 @@ func toAccount(a *pb.Account) *Account {
 		Email:    a.Email,
 	}
 }
+
+func toProductMedia(m *catalogpb.ProductMedia) *ProductMedia {
+	if m == nil {
+		return nil
+	}
+	return &ProductMedia{
+		ID:           m.Id,
+		URL:          m.Url,
+		ThumbnailURL: m.ThumbnailUrl,
+		ContentType:  m.ContentType,
+		AltText:      m.AltText,
+		Width:        m.Width,
+		Height:       m.Height,
+	}
+}
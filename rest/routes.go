@@ -9,5 +9,9 @@ func NewHandler(server *Server) http.Handler {
 	mux.HandleFunc("/accounts/login", server.handleLogin)
 	mux.HandleFunc("/accounts/logout", server.handleLogout)
 	mux.HandleFunc("/accounts/refresh", server.handleRefreshToken)
+	mux.HandleFunc("POST /products/{id}/media", server.handleUploadProductMedia)
+	mux.HandleFunc("DELETE /products/{id}/media/{mediaId}", server.handleDeleteProductMedia)
+	mux.HandleFunc("GET /media/{key...}", server.handleGetMedia)
+	mux.HandleFunc("GET /invoices/{id}", server.handleDownloadInvoice)
 	return mux
 }
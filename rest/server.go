@@ -4,26 +4,54 @@ import (
 	"fmt"
 
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/account"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/blob"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/order"
 	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
 )
 
 type Server struct {
 	accountClient *account.AccountClient
+	catalogClient *catalog.CatalogClient
+	orderClient   *order.OrderClient
+	blobStore     blob.BlobStore
 	logger        util.Logger
 }
 
-func NewServer(accountGrpcURL string, logger util.Logger) (*Server, error) {
+func NewServer(accountGrpcURL string, catalogGrpcURL string, orderGrpcURL string, blobStore blob.BlobStore, logger util.Logger) (*Server, error) {
 	if accountGrpcURL == "" {
 		return nil, fmt.Errorf("ACCOUNT_GRPC_URL must be provided")
 	}
+	if catalogGrpcURL == "" {
+		return nil, fmt.Errorf("CATALOG_GRPC_URL must be provided")
+	}
+	if orderGrpcURL == "" {
+		return nil, fmt.Errorf("ORDER_GRPC_URL must be provided")
+	}
 
 	accountClient, err := account.NewAccountClient(accountGrpcURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to account service: %w", err)
 	}
 
+	catalogClient, err := catalog.NewCatalogClient(catalogGrpcURL)
+	if err != nil {
+		accountClient.Close()
+		return nil, fmt.Errorf("failed to connect to catalog service: %w", err)
+	}
+
+	orderClient, err := order.NewOrderClient(orderGrpcURL)
+	if err != nil {
+		accountClient.Close()
+		catalogClient.Close()
+		return nil, fmt.Errorf("failed to connect to order service: %w", err)
+	}
+
 	return &Server{
 		accountClient: accountClient,
+		catalogClient: catalogClient,
+		orderClient:   orderClient,
+		blobStore:     blobStore,
 		logger:        logger,
 	}, nil
 }
@@ -32,5 +60,11 @@ func (s *Server) Close() error {
 	if s.accountClient != nil {
 		s.accountClient.Close()
 	}
+	if s.catalogClient != nil {
+		s.catalogClient.Close()
+	}
+	if s.orderClient != nil {
+		s.orderClient.Close()
+	}
 	return nil
 }
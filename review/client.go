@@ -0,0 +1,73 @@
+package review
+
+import (
+	"context"
+
+	pb "github.com/Asif-Faizal/Minimum-Viable-Shop/review/pb"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	"google.golang.org/grpc"
+)
+
+type ReviewClient struct {
+	connection *grpc.ClientConn
+	client     pb.ReviewServiceClient
+}
+
+func NewReviewClient(url string) (*ReviewClient, error) {
+	connection, err := grpc.Dial(
+		url,
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(util.AccessTokenClientInterceptor()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ReviewClient{
+		connection: connection,
+		client:     pb.NewReviewServiceClient(connection),
+	}, nil
+}
+
+func (client *ReviewClient) Close() {
+	client.connection.Close()
+}
+
+// CreateOrUpdate Review
+func (client *ReviewClient) CreateOrUpdateReview(ctx context.Context, productID string, rating uint32, title string, body string) (*pb.CreateOrUpdateReviewResponse, error) {
+	response, err := client.client.CreateOrUpdateReview(ctx, &pb.CreateOrUpdateReviewRequest{
+		ProductId: productID,
+		Rating:    rating,
+		Title:     title,
+		Body:      body,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// Moderate Review
+func (client *ReviewClient) ModerateReview(ctx context.Context, id string, status string) (*pb.ModerateReviewResponse, error) {
+	response, err := client.client.ModerateReview(ctx, &pb.ModerateReviewRequest{
+		Id:     id,
+		Status: status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// List Reviews
+func (client *ReviewClient) ListReviews(ctx context.Context, productID string, status string, skip uint64, take uint64) (*pb.ListReviewsResponse, error) {
+	response, err := client.client.ListReviews(ctx, &pb.ListReviewsRequest{
+		ProductId: productID,
+		Status:    status,
+		Skip:      skip,
+		Take:      take,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,37 @@
+package review
+
+import "time"
+
+type ReviewStatus string
+
+const (
+	ReviewStatusPending  ReviewStatus = "pending"
+	ReviewStatusApproved ReviewStatus = "approved"
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+func (status ReviewStatus) Valid() bool {
+	switch status {
+	case ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+		return true
+	}
+	return false
+}
+
+type Review struct {
+	ID        string       `json:"id"`
+	ProductID string       `json:"productId"`
+	AccountID string       `json:"accountId"`
+	Rating    uint32       `json:"rating"`
+	Title     string       `json:"title"`
+	Body      string       `json:"body"`
+	Status    ReviewStatus `json:"status"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+// Rating aggregates the approved reviews of a product.
+type Rating struct {
+	Average float64
+	Count   uint64
+}
@@ -0,0 +1,138 @@
+package review
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	_ "github.com/lib/pq"
+)
+
+var ErrReviewNotFound = errors.New("review not found")
+
+type Repository interface {
+	Close()
+	// CreateOrUpdateReview stores the review, replacing the account's earlier
+	// review of the product if there is one, and returns it with the id and
+	// creation time of the stored review.
+	CreateOrUpdateReview(ctx context.Context, review *Review) (*Review, error)
+	GetReviewById(ctx context.Context, id string) (*Review, error)
+	// ListReviews returns the newest reviews first. An empty productID or
+	// status matches any.
+	ListReviews(ctx context.Context, productID string, status ReviewStatus, skip uint64, take uint64) ([]*Review, error)
+	// GetProductRating aggregates the approved reviews of a product.
+	GetProductRating(ctx context.Context, productID string) (*Rating, error)
+}
+
+type PostgresRepository struct {
+	db     *sql.DB
+	logger util.Logger
+}
+
+func NewPostgresRepository(url string, logger util.Logger) (Repository, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+	return &PostgresRepository{db: db, logger: logger}, nil
+}
+
+func (repository *PostgresRepository) Close() {
+	repository.db.Close()
+}
+
+func (repository *PostgresRepository) CreateOrUpdateReview(ctx context.Context, review *Review) (*Review, error) {
+	row := repository.db.QueryRowContext(ctx, `
+		INSERT INTO reviews (id, product_id, account_id, rating, title, body, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (product_id, account_id) DO UPDATE
+		SET rating = $4, title = $5, body = $6, status = $7, updated_at = $9
+		RETURNING id, created_at`,
+		review.ID, review.ProductID, review.AccountID, review.Rating, review.Title, review.Body,
+		review.Status, review.CreatedAt, review.UpdatedAt,
+	)
+	if err := row.Scan(&review.ID, &review.CreatedAt); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (repository *PostgresRepository) GetReviewById(ctx context.Context, id string) (*Review, error) {
+	row := repository.db.QueryRowContext(ctx, `
+		SELECT id, product_id, account_id, rating, title, body, status, created_at, updated_at
+		FROM reviews
+		WHERE id = $1`, id)
+	review, err := scanReview(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func (repository *PostgresRepository) ListReviews(ctx context.Context, productID string, status ReviewStatus, skip uint64, take uint64) ([]*Review, error) {
+	rows, err := repository.db.QueryContext(ctx, `
+		SELECT id, product_id, account_id, rating, title, body, status, created_at, updated_at
+		FROM reviews
+		WHERE ($1 = '' OR product_id = $1) AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id
+		OFFSET $3 LIMIT $4`,
+		productID, string(status), skip, take,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := []*Review{}
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+func (repository *PostgresRepository) GetProductRating(ctx context.Context, productID string) (*Rating, error) {
+	row := repository.db.QueryRowContext(ctx, `
+		SELECT COALESCE(AVG(rating), 0), COUNT(*)
+		FROM reviews
+		WHERE product_id = $1 AND status = $2`,
+		productID, string(ReviewStatusApproved),
+	)
+	rating := &Rating{}
+	if err := row.Scan(&rating.Average, &rating.Count); err != nil {
+		return nil, err
+	}
+	return rating, nil
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReview(row scanner) (*Review, error) {
+	review := &Review{}
+	var status string
+	err := row.Scan(
+		&review.ID, &review.ProductID, &review.AccountID, &review.Rating, &review.Title, &review.Body,
+		&status, &review.CreatedAt, &review.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	review.Status = ReviewStatus(status)
+	return review, nil
+}
@@ -0,0 +1,172 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/catalog"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/order"
+	pb "github.com/Asif-Faizal/Minimum-Viable-Shop/review/pb"
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type GrpcServer struct {
+	reviewService Service
+	orderClient   *order.OrderClient
+	catalogClient *catalog.CatalogClient
+	jwtSecret     string
+	logger        util.Logger
+	pb.UnimplementedReviewServiceServer
+}
+
+func ListenGrpcServer(service Service, orderUrl string, catalogUrl string, jwtSecret string, logger util.Logger, port int) error {
+	orderClient, err := order.NewOrderClient(orderUrl)
+	if err != nil {
+		return err
+	}
+	catalogClient, err := catalog.NewCatalogClient(catalogUrl)
+	if err != nil {
+		orderClient.Close()
+		return err
+	}
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+			util.UnaryServerInterceptor(logger),
+			util.AuthUnaryServerInterceptor(jwtSecret),
+		)),
+	)
+	server := &GrpcServer{
+		reviewService: service,
+		orderClient:   orderClient,
+		catalogClient: catalogClient,
+		jwtSecret:     jwtSecret,
+		logger:        logger,
+	}
+	pb.RegisterReviewServiceServer(grpcServer, server)
+	reflection.Register(grpcServer)
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		orderClient.Close()
+		catalogClient.Close()
+		return err
+	}
+	return grpcServer.Serve(lis)
+}
+
+func (server *GrpcServer) CreateOrUpdateReview(ctx context.Context, request *pb.CreateOrUpdateReviewRequest) (*pb.CreateOrUpdateReviewResponse, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	// 1. Only accounts that ordered the product may review it
+	ordered, err := server.hasOrdered(ctx, claims.AccountID, request.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	if !ordered {
+		return nil, fmt.Errorf("%w: only customers who ordered the product can review it", ErrForbidden)
+	}
+
+	// 2. Store the review
+	review, err := server.reviewService.CreateOrUpdateReview(ctx, &Review{
+		ProductID: request.ProductId,
+		Rating:    request.Rating,
+		Title:     request.Title,
+		Body:      request.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. An edited review leaves the rating until it is approved again
+	server.pushRating(ctx, review.ProductID)
+
+	return &pb.CreateOrUpdateReviewResponse{
+		Review: toProtoReview(review),
+	}, nil
+}
+
+func (server *GrpcServer) ModerateReview(ctx context.Context, request *pb.ModerateReviewRequest) (*pb.ModerateReviewResponse, error) {
+	review, err := server.reviewService.ModerateReview(ctx, request.Id, ReviewStatus(request.Status))
+	if err != nil {
+		return nil, err
+	}
+	server.pushRating(ctx, review.ProductID)
+	return &pb.ModerateReviewResponse{
+		Review: toProtoReview(review),
+	}, nil
+}
+
+func (server *GrpcServer) ListReviews(ctx context.Context, request *pb.ListReviewsRequest) (*pb.ListReviewsResponse, error) {
+	reviews, err := server.reviewService.ListReviews(ctx, request.ProductId, ReviewStatus(request.Status), request.Skip, request.Take)
+	if err != nil {
+		return nil, err
+	}
+	pbReviews := []*pb.Review{}
+	for _, review := range reviews {
+		pbReviews = append(pbReviews, toProtoReview(review))
+	}
+	return &pb.ListReviewsResponse{Reviews: pbReviews}, nil
+}
+
+// hasOrdered reports whether any order of the account contains the product,
+// reading the account's orders a page at a time.
+func (server *GrpcServer) hasOrdered(ctx context.Context, accountID string, productID string) (bool, error) {
+	cursor := ""
+	for {
+		ordersResp, err := server.orderClient.ListOrdersForAccount(ctx, accountID, 100, cursor, "")
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch orders: %w", err)
+		}
+		for _, o := range ordersResp.Orders {
+			for _, p := range o.Products {
+				if p.ProductId == productID {
+					return true, nil
+				}
+			}
+		}
+		if ordersResp.NextCursor == "" {
+			return false, nil
+		}
+		cursor = ordersResp.NextCursor
+	}
+}
+
+// pushRating recomputes the product's rating and stores it in the catalog so
+// products can be sorted by rating. Failures are logged rather than returned:
+// the rating is recomputed in full on the next change.
+func (server *GrpcServer) pushRating(ctx context.Context, productID string) {
+	rating, err := server.reviewService.GetProductRating(ctx, productID)
+	if err != nil {
+		server.logger.Service().Error().Err(err).Str("product_id", productID).Msg("failed to compute product rating")
+		return
+	}
+	serviceCtx, err := util.ServiceContext(ctx, "review", server.jwtSecret)
+	if err != nil {
+		server.logger.Service().Error().Err(err).Msg("failed to create service token")
+		return
+	}
+	if err := server.catalogClient.UpdateProductRating(serviceCtx, productID, rating.Average, rating.Count); err != nil {
+		server.logger.Service().Error().Err(err).Str("product_id", productID).Msg("failed to update product rating in catalog")
+	}
+}
+
+func toProtoReview(review *Review) *pb.Review {
+	return &pb.Review{
+		Id:        review.ID,
+		ProductId: review.ProductID,
+		AccountId: review.AccountID,
+		Rating:    review.Rating,
+		Title:     review.Title,
+		Body:      review.Body,
+		Status:    string(review.Status),
+		CreatedAt: timestamppb.New(review.CreatedAt),
+		UpdatedAt: timestamppb.New(review.UpdatedAt),
+	}
+}
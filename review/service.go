@@ -0,0 +1,115 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/util"
+	"github.com/segmentio/ksuid"
+)
+
+type Service interface {
+	CreateOrUpdateReview(ctx context.Context, review *Review) (*Review, error)
+	ModerateReview(ctx context.Context, id string, status ReviewStatus) (*Review, error)
+	ListReviews(ctx context.Context, productID string, status ReviewStatus, skip uint64, take uint64) ([]*Review, error)
+	GetProductRating(ctx context.Context, productID string) (*Rating, error)
+}
+
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("permission denied")
+)
+
+const (
+	maxTitleLength = 255
+	maxBodyLength  = 5000
+)
+
+type ReviewService struct {
+	repository Repository
+}
+
+func NewReviewService(repository Repository) *ReviewService {
+	return &ReviewService{repository: repository}
+}
+
+// CreateOrUpdateReview stores the caller's review of a product. The caller is
+// expected to have checked that the account ordered the product. New and
+// edited reviews wait for moderation.
+func (service *ReviewService) CreateOrUpdateReview(ctx context.Context, review *Review) (*Review, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if review.ProductID == "" {
+		return nil, fmt.Errorf("product id is required")
+	}
+	if review.Rating < 1 || review.Rating > 5 {
+		return nil, fmt.Errorf("rating must be between 1 and 5")
+	}
+	title := strings.TrimSpace(review.Title)
+	body := strings.TrimSpace(review.Body)
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return nil, fmt.Errorf("title must be at most %d characters", maxTitleLength)
+	}
+	if utf8.RuneCountInString(body) > maxBodyLength {
+		return nil, fmt.Errorf("review must be at most %d characters", maxBodyLength)
+	}
+	now := time.Now().UTC()
+	return service.repository.CreateOrUpdateReview(ctx, &Review{
+		ID:        ksuid.New().String(),
+		ProductID: review.ProductID,
+		AccountID: claims.AccountID,
+		Rating:    review.Rating,
+		Title:     title,
+		Body:      body,
+		Status:    ReviewStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+// ModerateReview sets the moderation state of a review. Only admins moderate.
+func (service *ReviewService) ModerateReview(ctx context.Context, id string, status ReviewStatus) (*Review, error) {
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+	if !claims.IsAdmin() {
+		return nil, fmt.Errorf("%w: only admins can moderate reviews", ErrForbidden)
+	}
+	if !status.Valid() {
+		return nil, fmt.Errorf("invalid status %q", status)
+	}
+	review, err := service.repository.GetReviewById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	review.Status = status
+	review.UpdatedAt = time.Now().UTC()
+	return service.repository.CreateOrUpdateReview(ctx, review)
+}
+
+// ListReviews lists reviews, newest first. Only admins may list reviews that
+// are not approved.
+func (service *ReviewService) ListReviews(ctx context.Context, productID string, status ReviewStatus, skip uint64, take uint64) ([]*Review, error) {
+	if take > 100 || (skip == 0 && take == 0) {
+		take = 100
+	}
+	if status != "" && !status.Valid() {
+		return nil, fmt.Errorf("invalid status %q", status)
+	}
+	claims, ok := util.ClaimsFromContext(ctx)
+	if !ok || !claims.IsAdmin() {
+		status = ReviewStatusApproved
+	}
+	return service.repository.ListReviews(ctx, productID, status, skip, take)
+}
+
+func (service *ReviewService) GetProductRating(ctx context.Context, productID string) (*Rating, error) {
+	return service.repository.GetProductRating(ctx, productID)
+}
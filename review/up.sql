@@ -0,0 +1,16 @@
+-- One review per account and product; reviewing again replaces it.
+CREATE TABLE IF NOT EXISTS reviews (
+    id CHAR(27) PRIMARY KEY,
+    product_id CHAR(27) NOT NULL,
+    account_id CHAR(27) NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, account_id)
+);
+
+CREATE INDEX IF NOT EXISTS reviews_product_status_idx ON reviews (product_id, status, created_at DESC);
+CREATE INDEX IF NOT EXISTS reviews_status_idx ON reviews (status, created_at);
@@ -0,0 +1,66 @@
+package shipping
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+// ErrMethodUnavailable is returned when a shipping method does not deliver a
+// parcel, for instance because it is too heavy or goes to a country the
+// method does not serve.
+var ErrMethodUnavailable = errors.New("shipping method unavailable")
+
+// Destination is where a parcel is delivered.
+type Destination struct {
+	// Country is an ISO 3166-1 alpha-2 code such as "DE" or "US".
+	Country    string `json:"country"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postalCode"`
+}
+
+// Parcel is what is quoted for: the contents of a cart or order.
+type Parcel struct {
+	Destination Destination
+	// Weight is in grams.
+	Weight uint64
+	// Value is what the contents cost after discounts and before taxes. Its
+	// currency is the currency quotes are given in.
+	Value money.Money
+}
+
+// Quote is what a shipping method charges to deliver a parcel.
+type Quote struct {
+	// Method identifies the shipping method, such as "standard".
+	Method  string `json:"method"`
+	Name    string `json:"name"`
+	Carrier string `json:"carrier"`
+	// Zone is the destination zone the fee is for.
+	Zone string      `json:"zone"`
+	Fee  money.Money `json:"fee"`
+	// Free is set when the fee is waived because the parcel's value reaches
+	// the method's free shipping threshold.
+	Free bool `json:"free"`
+}
+
+type ShippingRateProvider interface {
+	// Quotes returns a quote for each method that delivers parcel, cheapest
+	// first.
+	Quotes(parcel Parcel) ([]*Quote, error)
+}
+
+// QuoteMethod returns the quote of method for parcel, or an error wrapping
+// ErrMethodUnavailable if method does not deliver it.
+func QuoteMethod(provider ShippingRateProvider, parcel Parcel, method string) (*Quote, error) {
+	quotes, err := provider.Quotes(parcel)
+	if err != nil {
+		return nil, err
+	}
+	for _, quote := range quotes {
+		if quote.Method == method {
+			return quote, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s does not deliver this parcel to %s", ErrMethodUnavailable, method, parcel.Destination.Country)
+}
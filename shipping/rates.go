@@ -0,0 +1,228 @@
+package shipping
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+// AnyCountry in a rate's countries matches every country the method has no
+// rate of its own for.
+const AnyCountry = "*"
+
+// TableRate is the fee of a shipping method for parcels to a zone, up to a
+// weight and from an order value.
+type TableRate struct {
+	Method  string
+	Name    string
+	Carrier string
+	Zone    string
+	// Countries are the ISO 3166-1 alpha-2 codes of the zone, or AnyCountry.
+	Countries []string
+	// MaxWeight is in grams; 0 is no limit.
+	MaxWeight uint64
+	// MinOrderValue is the least a parcel must be worth for the rate to
+	// apply. Rates only apply to parcels in the currency of their fee.
+	MinOrderValue money.Money
+	Fee           money.Money
+	// FreeAbove waives the fee for parcels worth at least it; nil never
+	// waives it.
+	FreeAbove *money.Money
+}
+
+// TableRateProvider quotes parcels from a table of rates.
+type TableRateProvider struct {
+	rates []*TableRate
+}
+
+func NewTableRateProvider(rates []*TableRate) *TableRateProvider {
+	return &TableRateProvider{rates: rates}
+}
+
+// LoadTableRateProvider reads rates from a CSV file with the header
+// method,name,carrier,zone,countries,max_weight,min_order_value,fee,free_above,currency
+// where countries are separated by spaces, max_weight is in grams and
+// amounts are decimals in currency. max_weight, min_order_value and
+// free_above may be empty. An empty path yields a provider without rates,
+// which quotes nothing.
+func LoadTableRateProvider(path string) (*TableRateProvider, error) {
+	if path == "" {
+		return NewTableRateProvider(nil), nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadTableRateProvider(file)
+}
+
+func ReadTableRateProvider(reader io.Reader) (*TableRateProvider, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	csvReader.TrimLeadingSpace = true
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rates := []*TableRate{}
+	for i, record := range records {
+		if len(record) != 10 {
+			return nil, fmt.Errorf("shipping rates line %d: expected 10 fields, got %d", i+1, len(record))
+		}
+		if i == 0 && strings.EqualFold(record[0], "method") {
+			continue
+		}
+		rate, err := parseTableRate(record)
+		if err != nil {
+			return nil, fmt.Errorf("shipping rates line %d: %w", i+1, err)
+		}
+		rates = append(rates, rate)
+	}
+	return NewTableRateProvider(rates), nil
+}
+
+func parseTableRate(record []string) (*TableRate, error) {
+	field := func(i int) string { return strings.TrimSpace(record[i]) }
+	rate := &TableRate{
+		Method:    strings.ToLower(field(0)),
+		Name:      field(1),
+		Carrier:   field(2),
+		Zone:      field(3),
+		Countries: strings.Fields(strings.ToUpper(field(4))),
+	}
+	if rate.Method == "" {
+		return nil, errors.New("shipping rates need a method")
+	}
+	if len(rate.Countries) == 0 {
+		return nil, fmt.Errorf("rate of %s has no countries", rate.Method)
+	}
+	for _, country := range rate.Countries {
+		if country != AnyCountry && (len(country) != 2 || strings.Trim(country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "") {
+			return nil, fmt.Errorf("country %q of %s is not a two letter code", country, rate.Method)
+		}
+	}
+	if field(5) != "" {
+		weight, err := strconv.ParseUint(field(5), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_weight %q of %s", field(5), rate.Method)
+		}
+		rate.MaxWeight = weight
+	}
+	currency := strings.ToUpper(field(9))
+	amount := func(name string, value string) (money.Money, error) {
+		if value == "" {
+			return money.Zero(currency), nil
+		}
+		parsed, err := money.Parse(value, currency)
+		if err != nil {
+			return money.Money{}, fmt.Errorf("invalid %s of %s: %w", name, rate.Method, err)
+		}
+		if err := parsed.Validate(); err != nil {
+			return money.Money{}, fmt.Errorf("invalid %s of %s: %w", name, rate.Method, err)
+		}
+		return parsed, nil
+	}
+	var err error
+	if rate.MinOrderValue, err = amount("min_order_value", field(6)); err != nil {
+		return nil, err
+	}
+	if field(7) == "" {
+		return nil, fmt.Errorf("rate of %s has no fee", rate.Method)
+	}
+	if rate.Fee, err = amount("fee", field(7)); err != nil {
+		return nil, err
+	}
+	if field(8) != "" {
+		freeAbove, err := amount("free_above", field(8))
+		if err != nil {
+			return nil, err
+		}
+		rate.FreeAbove = &freeAbove
+	}
+	return rate, nil
+}
+
+// Quotes only uses rates in the parcel's currency. For each method it picks
+// the rates naming the parcel's destination country, or the method's
+// AnyCountry rates if none do. Of those, the rate with the lowest weight limit
+// the parcel is within applies, and among rates with the same limit the one
+// with the highest minimum order value the parcel reaches.
+func (provider *TableRateProvider) Quotes(parcel Parcel) ([]*Quote, error) {
+	country := strings.ToUpper(parcel.Destination.Country)
+	methods := []string{}
+	byMethod := map[string][]*TableRate{}
+	for _, rate := range provider.rates {
+		if rate.Fee.Currency != parcel.Value.Currency {
+			continue
+		}
+		if !slices.Contains(methods, rate.Method) {
+			methods = append(methods, rate.Method)
+		}
+		byMethod[rate.Method] = append(byMethod[rate.Method], rate)
+	}
+
+	quotes := []*Quote{}
+	for _, method := range methods {
+		zone := []*TableRate{}
+		for _, rate := range byMethod[method] {
+			if slices.Contains(rate.Countries, country) {
+				zone = append(zone, rate)
+			}
+		}
+		if len(zone) == 0 {
+			for _, rate := range byMethod[method] {
+				if slices.Contains(rate.Countries, AnyCountry) {
+					zone = append(zone, rate)
+				}
+			}
+		}
+		var best *TableRate
+		for _, rate := range zone {
+			if !rate.applies(parcel) {
+				continue
+			}
+			if best == nil || rate.weightLimit() < best.weightLimit() ||
+				(rate.weightLimit() == best.weightLimit() && rate.MinOrderValue.Amount > best.MinOrderValue.Amount) {
+				best = rate
+			}
+		}
+		if best == nil {
+			continue
+		}
+		quote := &Quote{
+			Method:  best.Method,
+			Name:    best.Name,
+			Carrier: best.Carrier,
+			Zone:    best.Zone,
+			Fee:     best.Fee,
+		}
+		if best.FreeAbove != nil && parcel.Value.Amount >= best.FreeAbove.Amount {
+			quote.Fee = money.Zero(best.Fee.Currency)
+			quote.Free = true
+		}
+		quotes = append(quotes, quote)
+	}
+	sort.SliceStable(quotes, func(i, j int) bool { return quotes[i].Fee.Amount < quotes[j].Fee.Amount })
+	return quotes, nil
+}
+
+func (rate *TableRate) applies(parcel Parcel) bool {
+	return parcel.Weight <= rate.weightLimit() &&
+		parcel.Value.Amount >= rate.MinOrderValue.Amount
+}
+
+func (rate *TableRate) weightLimit() uint64 {
+	if rate.MaxWeight == 0 {
+		return ^uint64(0)
+	}
+	return rate.MaxWeight
+}
@@ -0,0 +1,141 @@
+package shipping
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+const testRates = `method,name,carrier,zone,countries,max_weight,min_order_value,fee,free_above,currency
+# Standard shipping in the US gets dearer with weight and is free from $50
+standard,Standard,USPS,domestic,US,1000,,4.99,50.00,USD
+standard,Standard,USPS,domestic,US,5000,,9.99,50.00,USD
+standard,Standard,USPS,domestic,US,,,19.99,,USD
+standard,Standard,USPS,world,*,2000,,3.99,,USD
+express,Express,UPS,domestic,US,5000,,14.99,,USD
+express,Express,UPS,domestic,US,5000,200.00,9.99,,USD
+express,Express,UPS,domestic,US,5000,100.00,11.99,,USD
+standard,Standard,DHL,domestic,DE,,,5.90,,EUR
+standard,Standard,DHL,world,*,,,12.00,,EUR
+`
+
+func readTestRates(t *testing.T) *TableRateProvider {
+	t.Helper()
+	provider, err := ReadTableRateProvider(strings.NewReader(testRates))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func TestQuotes(t *testing.T) {
+	provider := readTestRates(t)
+	tests := []struct {
+		name    string
+		country string
+		weight  uint64
+		value   string
+		// currency defaults to USD.
+		currency string
+		want     []string
+	}{
+		{"lowest weight limit the parcel is within", "US", 500, "20.00", "", []string{"standard 4.99", "express 14.99"}},
+		{"next weight limit for a heavier parcel", "US", 3000, "20.00", "", []string{"standard 9.99", "express 14.99"}},
+		{"rate without a weight limit", "US", 8000, "20.00", "", []string{"standard 19.99"}},
+		{"exact country before any country", "us", 500, "20.00", "", []string{"standard 4.99", "express 14.99"}},
+		{"any country for a country without rates", "FR", 500, "20.00", "", []string{"standard 3.99"}},
+		{"any country within its weight limit only", "FR", 3000, "20.00", "", []string{}},
+		{"highest minimum order value the parcel reaches", "US", 500, "150.00", "", []string{"standard 0.00 free", "express 11.99"}},
+		{"highest of several minimum order values", "US", 500, "250.00", "", []string{"standard 0.00 free", "express 9.99"}},
+		{"free at the threshold", "US", 500, "50.00", "", []string{"standard 0.00 free", "express 14.99"}},
+		{"not free below the threshold", "US", 500, "49.99", "", []string{"standard 4.99", "express 14.99"}},
+		{"rates in the parcel's currency", "DE", 500, "20.00", "EUR", []string{"standard 5.90"}},
+		{"any country in the parcel's currency", "US", 500, "20.00", "EUR", []string{"standard 12.00"}},
+		{"no rates in the parcel's currency", "US", 500, "20.00", "GBP", []string{}},
+	}
+	for _, test := range tests {
+		currency := test.currency
+		if currency == "" {
+			currency = "USD"
+		}
+		value, err := money.Parse(test.value, currency)
+		if err != nil {
+			t.Fatal(err)
+		}
+		quotes, err := provider.Quotes(Parcel{Destination: Destination{Country: test.country}, Weight: test.weight, Value: value})
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		got := []string{}
+		for _, quote := range quotes {
+			description := fmt.Sprintf("%s %s", quote.Method, quote.Fee.Decimal())
+			if quote.Free {
+				description += " free"
+			}
+			got = append(got, description)
+		}
+		if !slices.Equal(got, test.want) {
+			t.Errorf("%s: quotes %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestReadTableRateProvider(t *testing.T) {
+	provider, err := ReadTableRateProvider(strings.NewReader("Express, Express , UPS, domestic, us ca, 5000, 100, 11.99, 200, usd\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(provider.rates) != 1 {
+		t.Fatalf("read %d rates, want 1", len(provider.rates))
+	}
+	rate := provider.rates[0]
+	if rate.Method != "express" || rate.Name != "Express" || rate.Carrier != "UPS" || rate.Zone != "domestic" {
+		t.Errorf("read rate %+v, want express by UPS in domestic", rate)
+	}
+	if !slices.Equal(rate.Countries, []string{"US", "CA"}) || rate.MaxWeight != 5000 {
+		t.Errorf("read countries %v up to %d grams, want [US CA] up to 5000", rate.Countries, rate.MaxWeight)
+	}
+	if rate.MinOrderValue != money.New(10000, "USD") || rate.Fee != money.New(1199, "USD") ||
+		rate.FreeAbove == nil || *rate.FreeAbove != money.New(20000, "USD") {
+		t.Errorf("read minimum %v, fee %v and free above %v, want 100.00, 11.99 and 200.00 USD", rate.MinOrderValue, rate.Fee, rate.FreeAbove)
+	}
+}
+
+func TestReadTableRateProviderRejectsInvalidRates(t *testing.T) {
+	tests := []string{
+		"standard,Standard,USPS,domestic,US,1000,,4.99,\n",
+		",Standard,USPS,domestic,US,1000,,4.99,,USD\n",
+		"standard,Standard,USPS,domestic,,1000,,4.99,,USD\n",
+		"standard,Standard,USPS,domestic,USA,1000,,4.99,,USD\n",
+		"standard,Standard,USPS,domestic,U1,1000,,4.99,,USD\n",
+		"standard,Standard,USPS,domestic,US,heavy,,4.99,,USD\n",
+		"standard,Standard,USPS,domestic,US,-1,,4.99,,USD\n",
+		"standard,Standard,USPS,domestic,US,1000,abc,4.99,,USD\n",
+		"standard,Standard,USPS,domestic,US,1000,,,,USD\n",
+		"standard,Standard,USPS,domestic,US,1000,,-4.99,,USD\n",
+		"standard,Standard,USPS,domestic,US,1000,,abc,,USD\n",
+		"standard,Standard,USPS,domestic,US,1000,,4.99,abc,USD\n",
+		"standard,Standard,USPS,domestic,US,1000,,4.99,,\n",
+		"standard,Standard,USPS,domestic,US,1000,,4.99,,DOLLARS\n",
+	}
+	for _, rates := range tests {
+		if _, err := ReadTableRateProvider(strings.NewReader(rates)); err == nil {
+			t.Errorf("ReadTableRateProvider(%q) succeeded, want an error", rates)
+		}
+	}
+}
+
+func TestLoadTableRateProviderWithoutPathQuotesNothing(t *testing.T) {
+	provider, err := LoadTableRateProvider("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	quotes, err := provider.Quotes(Parcel{Destination: Destination{Country: "US"}, Weight: 500, Value: money.New(2000, "USD")})
+	if err != nil || len(quotes) != 0 {
+		t.Errorf("Quotes() = %v, %v, want no quotes", quotes, err)
+	}
+}
@@ -0,0 +1,70 @@
+package tax
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+// Item is an amount to tax, such as an order line after its discounts.
+type Item struct {
+	ID string
+	// Category is the item's tax category; empty is CategoryStandard.
+	Category string
+	Amount   money.Money
+}
+
+// Line is the tax on an item at the rate that applies to it.
+type Line struct {
+	ItemID string
+	Rate   *Rate
+	// Amount is included in the item's amount if the rate is inclusive, and
+	// due on top of it otherwise.
+	Amount money.Money
+}
+
+type TaxCalculator interface {
+	// Calculate returns the tax on each item sold to location. Items no rate
+	// applies to, and exempt items, have no line.
+	Calculate(location Location, items []Item) ([]*Line, error)
+}
+
+// Calculate taxes each item at the rate Lookup finds for its category,
+// rounding each line to the minor unit.
+func (table *RateTable) Calculate(location Location, items []Item) ([]*Line, error) {
+	lines := []*Line{}
+	for _, item := range items {
+		category := strings.ToLower(strings.TrimSpace(item.Category))
+		if category == "" {
+			category = CategoryStandard
+		}
+		if category == CategoryExempt {
+			continue
+		}
+		rate := table.Lookup(location, category)
+		if rate == nil {
+			continue
+		}
+		amount, err := rate.Tax(item.Amount)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, &Line{ItemID: item.ID, Rate: rate, Amount: amount})
+	}
+	return lines, nil
+}
+
+// Tax returns the tax on amount: amount times the rate, or for inclusive
+// rates the part of amount that is tax, amount * rate / (1 + rate).
+func (rate *Rate) Tax(amount money.Money) (money.Money, error) {
+	share := rate.Rate
+	if rate.Inclusive {
+		share = new(big.Rat).Quo(rate.Rate, new(big.Rat).Add(big.NewRat(1, 1), rate.Rate))
+	}
+	minor, err := money.RoundToMinor(new(big.Rat).Mul(amount.Rat(), share), amount.Currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return money.New(minor, amount.Currency), nil
+}
@@ -0,0 +1,51 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/Asif-Faizal/Minimum-Viable-Shop/money"
+)
+
+func TestCalculate(t *testing.T) {
+	table := readTestRates(t)
+	tests := []struct {
+		name     string
+		location Location
+		item     Item
+		want     int64
+		rate     string
+		noLine   bool
+	}{
+		{"inclusive rate is the tax part of the amount", Location{Country: "DE"}, Item{Amount: money.New(11900, "EUR")}, 1900, "0.19", false},
+		{"inclusive category rate", Location{Country: "DE"}, Item{Category: "reduced", Amount: money.New(10700, "EUR")}, 700, "0.07", false},
+		{"exclusive rate is added to the amount", Location{Country: "US", Region: "CA"}, Item{Amount: money.New(10000, "USD")}, 725, "0.0725", false},
+		{"rounds to the minor unit", Location{Country: "US", Region: "CA"}, Item{Amount: money.New(99, "USD")}, 7, "0.0725", false},
+		{"rounds half away from zero", Location{Country: "CA"}, Item{Amount: money.New(10, "CAD")}, 1, "0.05", false},
+		{"category is normalized", Location{Country: "DE"}, Item{Category: " Reduced ", Amount: money.New(10700, "EUR")}, 700, "0.07", false},
+		{"zero rate still has a line", Location{Country: "US", Region: "NY"}, Item{Category: "clothing", Amount: money.New(5000, "USD")}, 0, "0", false},
+		{"exempt items are not taxed", Location{Country: "DE"}, Item{Category: CategoryExempt, Amount: money.New(10000, "EUR")}, 0, "", true},
+		{"items without a rate are not taxed", Location{Country: "US", Region: "TX"}, Item{Amount: money.New(10000, "USD")}, 0, "", true},
+	}
+	for _, test := range tests {
+		test.item.ID = "item"
+		lines, err := table.Calculate(test.location, []Item{test.item})
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if test.noLine {
+			if len(lines) != 0 {
+				t.Errorf("%s: %d lines, want none", test.name, len(lines))
+			}
+			continue
+		}
+		if len(lines) != 1 {
+			t.Errorf("%s: %d lines, want 1", test.name, len(lines))
+			continue
+		}
+		line := lines[0]
+		if line.ItemID != "item" || line.Amount.Amount != test.want || line.Amount.Currency != test.item.Amount.Currency || line.Rate.Decimal() != test.rate {
+			t.Errorf("%s: tax %v at %s, want %d at %s", test.name, line.Amount, line.Rate.Decimal(), test.want, test.rate)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package tax
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CategoryStandard is the tax category of products without one, and the
+	// rate used for categories a location has no rate of its own for.
+	CategoryStandard = "standard"
+	// CategoryExempt products are never taxed.
+	CategoryExempt = "exempt"
+)
+
+var ErrInvalidLocation = errors.New("invalid tax location")
+
+// Location is where goods are taxed.
+type Location struct {
+	// Country is an ISO 3166-1 alpha-2 code such as "DE" or "US".
+	Country string `json:"country"`
+	// Region is a state or province within Country, such as "CA"; it may
+	// be empty.
+	Region string `json:"region"`
+}
+
+// ParseLocation upper-cases country and region and checks that country is a
+// two letter code. Both may be empty, for no location.
+func ParseLocation(country, region string) (Location, error) {
+	location := Location{
+		Country: strings.ToUpper(strings.TrimSpace(country)),
+		Region:  strings.ToUpper(strings.TrimSpace(region)),
+	}
+	if location.Country == "" {
+		if location.Region != "" {
+			return Location{}, fmt.Errorf("%w: region %s has no country", ErrInvalidLocation, location.Region)
+		}
+		return location, nil
+	}
+	if len(location.Country) != 2 || strings.Trim(location.Country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return Location{}, fmt.Errorf("%w: country %q is not a two letter code", ErrInvalidLocation, country)
+	}
+	return location, nil
+}
+
+// IsZero reports whether no location is set.
+func (location Location) IsZero() bool {
+	return location.Country == ""
+}
+
+// Rate is the tax on products of a category sold to a country, or to one
+// region of it.
+type Rate struct {
+	Country string `json:"country"`
+	// Region is empty for rates that apply to the whole country.
+	Region   string `json:"region"`
+	Category string `json:"category"`
+	// Rate is a fraction of the taxable amount: 0.19 is 19%.
+	Rate *big.Rat `json:"rate"`
+	// Inclusive rates are already part of prices and are not added to them.
+	Inclusive bool `json:"inclusive"`
+}
+
+func ParseRate(country, region, category, rate string, inclusive bool) (*Rate, error) {
+	location, err := ParseLocation(country, region)
+	if err != nil {
+		return nil, err
+	}
+	if location.IsZero() {
+		return nil, fmt.Errorf("%w: tax rates need a country", ErrInvalidLocation)
+	}
+	category = strings.ToLower(strings.TrimSpace(category))
+	if category == "" {
+		return nil, errors.New("tax rates need a category")
+	}
+	value, ok := new(big.Rat).SetString(strings.TrimSpace(rate))
+	if !ok || value.Sign() < 0 || value.Cmp(big.NewRat(1, 1)) > 0 {
+		return nil, fmt.Errorf("invalid tax rate %q for %s %s, expected a fraction from 0 to 1", rate, location.Country, category)
+	}
+	return &Rate{
+		Country:   location.Country,
+		Region:    location.Region,
+		Category:  category,
+		Rate:      value,
+		Inclusive: inclusive,
+	}, nil
+}
+
+func (rate *Rate) Decimal() string {
+	return FormatRate(rate.Rate)
+}
+
+// FormatRate formats a rate with up to 6 decimal places and no trailing zeros.
+func FormatRate(rate *big.Rat) string {
+	decimal := rate.FloatString(6)
+	decimal = strings.TrimRight(decimal, "0")
+	return strings.TrimSuffix(decimal, ".")
+}
+
+// RateTable holds tax rates by location and category.
+type RateTable struct {
+	rates map[string]*Rate
+}
+
+func NewRateTable(rates []*Rate) *RateTable {
+	table := &RateTable{rates: map[string]*Rate{}}
+	for _, rate := range rates {
+		table.rates[rateKey(rate.Country, rate.Region, rate.Category)] = rate
+	}
+	return table
+}
+
+// LoadRateTable reads tax rates from a CSV file with the header
+// country,region,category,rate,inclusive where region may be empty, rate is
+// a fraction such as 0.19 and inclusive is true or false. An empty path
+// yields an empty table, which taxes nothing.
+func LoadRateTable(path string) (*RateTable, error) {
+	if path == "" {
+		return NewRateTable(nil), nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadRateTable(file)
+}
+
+func ReadRateTable(reader io.Reader) (*RateTable, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comment = '#'
+	csvReader.TrimLeadingSpace = true
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	rates := []*Rate{}
+	for i, record := range records {
+		if len(record) != 5 {
+			return nil, fmt.Errorf("tax rates line %d: expected 5 fields, got %d", i+1, len(record))
+		}
+		if i == 0 && strings.EqualFold(record[0], "country") {
+			continue
+		}
+		inclusive, err := strconv.ParseBool(strings.TrimSpace(record[4]))
+		if err != nil {
+			return nil, fmt.Errorf("tax rates line %d: invalid inclusive %q", i+1, record[4])
+		}
+		rate, err := ParseRate(record[0], record[1], record[2], record[3], inclusive)
+		if err != nil {
+			return nil, fmt.Errorf("tax rates line %d: %w", i+1, err)
+		}
+		rates = append(rates, rate)
+	}
+	return NewRateTable(rates), nil
+}
+
+// Lookup returns the rate for category at location: the category's own rate
+// if the location has one, otherwise its standard rate, with rates of the
+// region taking precedence over those of the whole country. It returns nil
+// if none applies.
+func (table *RateTable) Lookup(location Location, category string) *Rate {
+	categories := []string{category}
+	if category != CategoryStandard {
+		categories = append(categories, CategoryStandard)
+	}
+	for _, category := range categories {
+		if location.Region != "" {
+			if rate, ok := table.rates[rateKey(location.Country, location.Region, category)]; ok {
+				return rate
+			}
+		}
+		if rate, ok := table.rates[rateKey(location.Country, "", category)]; ok {
+			return rate
+		}
+	}
+	return nil
+}
+
+func rateKey(country, region, category string) string {
+	return country + "/" + region + "/" + category
+}
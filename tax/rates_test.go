@@ -0,0 +1,117 @@
+package tax
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const testRates = `country,region,category,rate,inclusive
+# Germany taxes books and food at a reduced rate
+DE,,standard,0.19,true
+DE,,reduced,0.07,true
+US,CA,standard,0.0725,false
+US,NY,standard,0.04,false
+US,NY,clothing,0,false
+CA,,standard,0.05,false
+CA,,reduced,0.02,false
+CA,QC,standard,0.14975,false
+`
+
+func readTestRates(t *testing.T) *RateTable {
+	t.Helper()
+	table, err := ReadRateTable(strings.NewReader(testRates))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return table
+}
+
+func TestLookup(t *testing.T) {
+	table := readTestRates(t)
+	tests := []struct {
+		name     string
+		location Location
+		category string
+		want     string
+		region   string
+	}{
+		{"country standard rate", Location{Country: "DE"}, CategoryStandard, "0.19", ""},
+		{"country category rate", Location{Country: "DE"}, "reduced", "0.07", ""},
+		{"category without a rate falls back to standard", Location{Country: "DE"}, "books", "0.19", ""},
+		{"region without rates falls back to the country", Location{Country: "DE", Region: "BY"}, "reduced", "0.07", ""},
+		{"region rate", Location{Country: "US", Region: "CA"}, CategoryStandard, "0.0725", "CA"},
+		{"region category rate", Location{Country: "US", Region: "NY"}, "clothing", "0", "NY"},
+		{"region standard rate for another category", Location{Country: "US", Region: "NY"}, "books", "0.04", "NY"},
+		{"region rate over the country's", Location{Country: "CA", Region: "QC"}, CategoryStandard, "0.14975", "QC"},
+		{"country category rate over the region's standard rate", Location{Country: "CA", Region: "QC"}, "reduced", "0.02", ""},
+		{"other region uses the country's", Location{Country: "CA", Region: "ON"}, CategoryStandard, "0.05", ""},
+	}
+	for _, test := range tests {
+		rate := table.Lookup(test.location, test.category)
+		if rate == nil {
+			t.Errorf("%s: no rate, want %s", test.name, test.want)
+			continue
+		}
+		if rate.Decimal() != test.want || rate.Region != test.region {
+			t.Errorf("%s: rate %s in region %q, want %s in region %q", test.name, rate.Decimal(), rate.Region, test.want, test.region)
+		}
+	}
+}
+
+func TestLookupWithoutRate(t *testing.T) {
+	table := readTestRates(t)
+	tests := []struct {
+		location Location
+		category string
+	}{
+		{Location{Country: "US"}, CategoryStandard},
+		{Location{Country: "US", Region: "TX"}, CategoryStandard},
+		{Location{Country: "FR"}, "reduced"},
+	}
+	for _, test := range tests {
+		if rate := table.Lookup(test.location, test.category); rate != nil {
+			t.Errorf("Lookup(%v, %s) = %s, want no rate", test.location, test.category, rate.Decimal())
+		}
+	}
+}
+
+func TestReadRateTableRejectsInvalidRates(t *testing.T) {
+	tests := []string{
+		"DE,,standard,0.19\n",
+		"DE,,standard,1.5,true\n",
+		"DE,,standard,-0.1,true\n",
+		"DE,,standard,abc,true\n",
+		"DE,,standard,0.19,maybe\n",
+		"DEU,,standard,0.19,true\n",
+		",CA,standard,0.19,true\n",
+		"DE,,,0.19,true\n",
+	}
+	for _, rates := range tests {
+		if _, err := ReadRateTable(strings.NewReader(rates)); err == nil {
+			t.Errorf("ReadRateTable(%q) succeeded, want an error", rates)
+		}
+	}
+}
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		country string
+		region  string
+		want    Location
+		err     error
+	}{
+		{" de ", "", Location{Country: "DE"}, nil},
+		{"us", "ca", Location{Country: "US", Region: "CA"}, nil},
+		{"", "", Location{}, nil},
+		{"", "CA", Location{}, ErrInvalidLocation},
+		{"USA", "", Location{}, ErrInvalidLocation},
+		{"U1", "", Location{}, ErrInvalidLocation},
+	}
+	for _, test := range tests {
+		got, err := ParseLocation(test.country, test.region)
+		if !errors.Is(err, test.err) || got != test.want {
+			t.Errorf("ParseLocation(%q, %q) = %v, %v, want %v, %v", test.country, test.region, got, err, test.want, test.err)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package util
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	UserTypeSuperAdmin = "super_admin"
+	UserTypeAdmin      = "admin"
+	UserTypeMerchant   = "merchant"
+	UserTypeCustomer   = "customer"
+	// UserTypeService identifies internal services calling each other. No
+	// account has this type; tokens for it are minted with ServiceContext.
+	UserTypeService = "service"
+)
+
+const authorizationMetadataKey = "authorization"
+
+type contextKey string
+
+const (
+	accessTokenContextKey contextKey = "access_token"
+	claimsContextKey      contextKey = "claims"
+)
+
+// IsAdmin reports whether the claims belong to an admin or super admin.
+func (claims *JWTClaims) IsAdmin() bool {
+	return claims.UserType == UserTypeAdmin || claims.UserType == UserTypeSuperAdmin
+}
+
+func ContextWithAccessToken(ctx context.Context, accessToken string) context.Context {
+	return context.WithValue(ctx, accessTokenContextKey, accessToken)
+}
+
+func AccessTokenFromContext(ctx context.Context) string {
+	accessToken, _ := ctx.Value(accessTokenContextKey).(string)
+	return accessToken
+}
+
+// ServiceContext returns ctx carrying a short-lived access token for the named
+// internal service, for calls made on behalf of the service itself.
+func ServiceContext(ctx context.Context, service string, secret string) (context.Context, error) {
+	accessToken, err := GenerateToken(service, UserTypeService, "", secret, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	return ContextWithAccessToken(ctx, accessToken), nil
+}
+
+func ContextWithClaims(ctx context.Context, claims *JWTClaims) context.Context {
+	return context.WithValue(ctx, claimsContextKey, claims)
+}
+
+// ClaimsFromContext returns the validated claims of the caller, if any.
+func ClaimsFromContext(ctx context.Context) (*JWTClaims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*JWTClaims)
+	return claims, ok && claims != nil
+}
+
+// AccessTokenMiddleware stores the bearer token of the request, if present, in
+// the request context so gRPC clients can forward it. Validation is left to
+// the services receiving the token.
+func AccessTokenMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if accessToken, ok := strings.CutPrefix(authHeader, "Bearer "); ok && accessToken != "" {
+			r = r.WithContext(ContextWithAccessToken(r.Context(), accessToken))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AccessTokenClientInterceptor forwards the access token found in the context
+// as gRPC metadata.
+func AccessTokenClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if accessToken := AccessTokenFromContext(ctx); accessToken != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, authorizationMetadataKey, "Bearer "+accessToken)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// AccessTokenStreamClientInterceptor is the streaming counterpart of
+// AccessTokenClientInterceptor.
+func AccessTokenStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		if accessToken := AccessTokenFromContext(ctx); accessToken != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, authorizationMetadataKey, "Bearer "+accessToken)
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// AuthUnaryServerInterceptor validates the bearer token sent as gRPC metadata
+// and stores its claims in the context. Requests without a token pass through
+// anonymously; requests with an invalid token are rejected.
+func AuthUnaryServerInterceptor(secret string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx, err := authenticate(ctx, secret)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamServerInterceptor is the streaming counterpart of
+// AuthUnaryServerInterceptor.
+func AuthStreamServerInterceptor(secret string) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := authenticate(stream.Context(), secret)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream *authenticatedStream) Context() context.Context {
+	return stream.ctx
+}
+
+func authenticate(ctx context.Context, secret string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 {
+		return ctx, nil
+	}
+	accessToken, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "malformed authorization metadata")
+	}
+	claims, err := ValidateToken(accessToken, secret)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired access token")
+	}
+	return ContextWithAccessToken(ContextWithClaims(ctx, claims), accessToken), nil
+}
@@ -74,3 +74,34 @@ func UnaryServerInterceptor(logger Logger) grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+// StreamServerInterceptor returns a new stream server interceptor that logs gRPC streams
+func StreamServerInterceptor(logger Logger) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		duration := time.Since(start)
+
+		st, _ := status.FromError(err)
+
+		var logEvent *zerolog.Event
+		if err != nil {
+			logEvent = logger.Transport().Error().Err(err)
+		} else {
+			logEvent = logger.Transport().Info()
+		}
+
+		logEvent.
+			Str("method", info.FullMethod).
+			Str("duration", duration.String()).
+			Str("code", st.Code().String()).
+			Msg("gRPC Stream")
+
+		return err
+	}
+}